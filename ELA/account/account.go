@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"github.com/elastos/Elastos.ELA/common"
 	"github.com/elastos/Elastos.ELA/core/contract"
+	"github.com/elastos/Elastos.ELA/core/types"
 	"github.com/elastos/Elastos.ELA/crypto"
 )
 
@@ -25,6 +26,8 @@ type Account struct {
 	ProgramHash  common.Uint168
 	RedeemScript []byte
 	Address      string
+
+	signer Signer
 }
 
 // String format of Account
@@ -59,6 +62,17 @@ func NewAccount() (*Account, error) {
 	}, nil
 }
 
+// NewAccountWithSigner creates a standard account of the public key, which
+// is signed by the external signer.
+func NewAccountWithSigner(pubKey *crypto.PublicKey, signer Signer) (*Account, error) {
+	ac, err := NewAccountWithPublicKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	ac.signer = signer
+	return ac, nil
+}
+
 func NewAccountWithPrivateKey(privateKey []byte) (*Account, error) {
 	pubKey := crypto.NewPubKey(privateKey)
 	signatureContract, err := contract.CreateStandardContract(pubKey)
@@ -79,6 +93,27 @@ func NewAccountWithPrivateKey(privateKey []byte) (*Account, error) {
 	}, nil
 }
 
+// NewAccountWithPublicKey creates a watch-only standard account of the public
+// key, which holds no private key.
+func NewAccountWithPublicKey(pubKey *crypto.PublicKey) (*Account, error) {
+	signatureContract, err := contract.CreateStandardContract(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	programHash := signatureContract.ToProgramHash()
+	address, err := programHash.ToAddress()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		PrivateKey:   nil,
+		PublicKey:    pubKey,
+		ProgramHash:  *programHash,
+		RedeemScript: signatureContract.Code,
+		Address:      address,
+	}, nil
+}
+
 func NewMultiSigAccount(m int, pubKeys []*crypto.PublicKey) (*Account, error) {
 	multiSigContract, err := contract.CreateMultiSigContract(m, pubKeys)
 	if err != nil {
@@ -110,8 +145,33 @@ func (ac *Account) PubKey() *crypto.PublicKey {
 	return ac.PublicKey
 }
 
+// CanSign returns if the account can sign by private key or external signer
+func (ac *Account) CanSign() bool {
+	return ac.PrivateKey != nil || ac.signer != nil
+}
+
 // Sign data with account
 func (ac *Account) Sign(data []byte) ([]byte, error) {
+	if ac.PrivateKey == nil {
+		if ac.signer != nil {
+			return ac.signBySigner(data)
+		}
+		return nil, ErrWatchOnly
+	}
+	return crypto.Sign(ac.PrivateKey, data)
+}
+
+// SignTransaction signs the unsigned data of a transaction with account, the
+// referenced transactions of the inputs are passed to the external signer so
+// it can verify the amount spent by the transaction.
+func (ac *Account) SignTransaction(data []byte,
+	prevTxs []*types.Transaction) ([]byte, error) {
+	if ac.PrivateKey == nil {
+		if ac.signer != nil {
+			return ac.signTransactionBySigner(data, prevTxs)
+		}
+		return nil, ErrWatchOnly
+	}
 	return crypto.Sign(ac.PrivateKey, data)
 }
 
@@ -7,14 +7,13 @@ package account
 
 import (
 	"bytes"
+	"crypto/rand"
 	"crypto/sha256"
 	"errors"
 	"fmt"
-	"math/rand"
 	"os"
 	"sort"
 	"sync"
-	"time"
 
 	"github.com/elastos/Elastos.ELA/common"
 	"github.com/elastos/Elastos.ELA/core/contract"
@@ -65,17 +64,35 @@ func createClient(path string, password []byte,
 	if err != nil {
 		return nil, err
 	}
-	client.mainAccount = account.ProgramHash.ToCodeHash()
+	if account.CanSign() {
+		client.mainAccount = account.ProgramHash.ToCodeHash()
+	}
 
 	return client, nil
 }
 
 func Add(path string, password []byte) (*Client, error) {
+	return AddHD(path, password, ExternalChain)
+}
+
+// AddHD adds an account to wallet, the account is derived from the HD seed
+// in the chain if the wallet is a HD wallet.
+func AddHD(path string, password []byte, chain uint32) (*Client, error) {
 	client := NewClient(path, password, false)
 	if client == nil {
 		return nil, errors.New("add account failed")
 	}
-	_, err := client.CreateAccount()
+	hd, err := client.IsHDWallet()
+	if err != nil {
+		return nil, err
+	}
+	if hd {
+		_, err = client.CreateHDAccount(chain)
+	} else if chain == ExternalChain {
+		_, err = client.CreateAccount()
+	} else {
+		err = errors.New("change address is only available in HD wallet")
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +123,14 @@ func Open(path string, password []byte) (*Client, error) {
 }
 
 func (cl *Client) Sign(txn *types.Transaction) (*types.Transaction, error) {
+	return cl.SignWithPreviousTxs(txn, nil)
+}
+
+// SignWithPreviousTxs signs the transaction like Sign, the referenced
+// transactions of the inputs are passed to the external signers, so they can
+// verify the amount spent and show the fee before signing.
+func (cl *Client) SignWithPreviousTxs(txn *types.Transaction,
+	prevTxs []*types.Transaction) (*types.Transaction, error) {
 	var signedPrograms []*pg.Program
 	for _, program := range txn.Programs {
 		// Get sign type
@@ -116,14 +141,16 @@ func (cl *Client) Sign(txn *types.Transaction) (*types.Transaction, error) {
 		// Look up transaction type
 		if signType == vm.CHECKSIG {
 			// Sign single transaction
-			signedProgram, err := SignStandardTransaction(txn, program, cl.accounts)
+			signedProgram, err := signStandardTransaction(txn, program,
+				cl.accounts, prevTxs)
 			if err != nil {
 				return nil, err
 			}
 			signedPrograms = append(signedPrograms, signedProgram)
 		} else if signType == vm.CHECKMULTISIG {
 			// Sign multi sign transaction
-			signedProgram, err := SignMultiSignTransaction(txn, program, cl.accounts)
+			signedProgram, err := signMultiSignTransaction(txn, program,
+				cl.accounts, prevTxs)
 			if err != nil {
 				return nil, err
 			}
@@ -163,71 +190,45 @@ func NewClient(path string, password []byte, create bool) *Client {
 		FileStore: FileStore{path: path},
 	}
 
-	passwordKey := crypto.ToAesKey(password)
 	if create {
 		//create new client
 		client.iv = make([]byte, 16)
 		client.masterKey = make([]byte, 32)
 
 		//generate random number for iv/masterkey
-		r := rand.New(rand.NewSource(time.Now().UnixNano()))
-		for i := 0; i < 16; i++ {
-			client.iv[i] = byte(r.Intn(256))
+		if _, err := rand.Read(client.iv); err != nil {
+			fmt.Println("error: failed to generate iv")
+			return nil
 		}
-		for i := 0; i < 32; i++ {
-			client.masterKey[i] = byte(r.Intn(256))
+		if _, err := rand.Read(client.masterKey); err != nil {
+			fmt.Println("error: failed to generate master key")
+			return nil
 		}
 
 		//new client store (build DB)
 		client.BuildDatabase(path)
 
-		if err := client.SaveStoredData("Version", []byte(KeystoreVersion)); err != nil {
-			fmt.Println("error: failed to save Version")
-			return nil
-		}
-
-		pwdhash := sha256.Sum256(passwordKey)
-		if err := client.SaveStoredData("PasswordHash", pwdhash[:]); err != nil {
-			fmt.Println("error: failed to save PasswordHash")
-			return nil
-		}
 		if err := client.SaveStoredData("IV", client.iv[:]); err != nil {
 			fmt.Println("error: failed to save IV")
 			return nil
 		}
-
-		aesmk, err := crypto.AesEncrypt(client.masterKey[:], passwordKey, client.iv)
-		if err != nil {
-			fmt.Println("error: failed to encrypt the passoword", err.Error())
-			return nil
-		}
-		if err := client.SaveStoredData("MasterKey", aesmk); err != nil {
-			fmt.Println("error: failed to save MasterKey")
+		if err := client.saveMasterKey(password); err != nil {
+			fmt.Println("error: failed to save MasterKey,", err)
 			return nil
 		}
 
 	} else {
-		if ok := client.verifyPasswordKey(passwordKey); !ok {
-			return nil
-		}
 		var err error
 		client.iv, err = client.LoadStoredData("IV")
 		if err != nil {
 			fmt.Println("error: failed to load iv")
 			return nil
 		}
-		encryptedMasterKey, err := client.LoadStoredData("MasterKey")
-		if err != nil {
-			fmt.Println("error: failed to load master key")
-			return nil
-		}
-		client.masterKey, err = crypto.AesDecrypt(encryptedMasterKey, passwordKey, client.iv)
-		if err != nil {
-			fmt.Println("error: failed to decrypt master key")
+		if err := client.loadMasterKey(password); err != nil {
+			fmt.Println("error: failed to load master key,", err)
 			return nil
 		}
 	}
-	common.ClearBytes(passwordKey)
 
 	return client
 }
@@ -259,6 +260,10 @@ func (cl *Client) CreateMultiSigAccount(m int, pubKeys []*crypto.PublicKey) (*Ac
 
 // SaveAccount saves a Account to memory and db
 func (cl *Client) SaveAccount(ac *Account) error {
+	return cl.saveAccount(ac, "")
+}
+
+func (cl *Client) saveAccount(ac *Account, hdPath string) error {
 	cl.mu.Lock()
 	defer cl.mu.Unlock()
 
@@ -283,7 +288,8 @@ func (cl *Client) SaveAccount(ac *Account) error {
 	common.ClearBytes(decryptedPrivateKey)
 
 	// save Account keys to db
-	err = cl.SaveAccountData(&ac.ProgramHash, ac.RedeemScript, encryptedPrivateKey)
+	err = cl.SaveHDAccountData(&ac.ProgramHash, ac.RedeemScript,
+		encryptedPrivateKey, hdPath)
 	if err != nil {
 		return err
 	}
@@ -337,9 +343,16 @@ func (cl *Client) LoadAccounts() error {
 				accounts[programHash.ToCodeHash()] = ac
 			} else {
 				rs, _ := common.HexStringToBytes(a.RedeemScript)
+				var pubKey *crypto.PublicKey
+				if len(rs) == crypto.PublicKeyScriptLength {
+					pubKey, err = crypto.DecodePoint(rs[1 : len(rs)-1])
+					if err != nil {
+						return err
+					}
+				}
 				ac := &Account{
 					PrivateKey:   nil,
-					PublicKey:    nil,
+					PublicKey:    pubKey,
 					ProgramHash:  *programHash,
 					RedeemScript: rs,
 					Address:      a.Address,
@@ -358,7 +371,7 @@ func (cl *Client) LoadAccounts() error {
 			accounts[programHash.ToCodeHash()] = ac
 		}
 
-		if a.Type == MAINACCOUNT {
+		if isMainAccount(&a) {
 			cl.mainAccount = programHash.ToCodeHash()
 		}
 	}
@@ -422,27 +435,41 @@ func (cl *Client) HandleInterrupt() {
 }
 
 func SignBySigner(txn *types.Transaction, acc *Account) ([]byte, error) {
+	return signTransactionBySigner(txn, acc, nil)
+}
+
+func signTransactionBySigner(txn *types.Transaction, acc *Account,
+	prevTxs []*types.Transaction) ([]byte, error) {
 	buf := new(bytes.Buffer)
 	if err := txn.SerializeUnsigned(buf); err != nil {
 		return nil, err
 	}
-	signature, err := crypto.Sign(acc.PrivKey(), buf.Bytes())
+	signature, err := acc.SignTransaction(buf.Bytes(), prevTxs)
 	if err != nil {
-		return nil, errors.New("[Signature],SignBySigner failed")
+		return nil, errors.New("[Signature],SignBySigner failed: " + err.Error())
 	}
 	return signature, nil
 }
 
 func SignStandardTransaction(txn *types.Transaction, program *pg.Program,
 	accounts map[common.Uint160]*Account) (*pg.Program, error) {
+	return signStandardTransaction(txn, program, accounts, nil)
+}
+
+func signStandardTransaction(txn *types.Transaction, program *pg.Program,
+	accounts map[common.Uint160]*Account,
+	prevTxs []*types.Transaction) (*pg.Program, error) {
 	code := program.Code
 	acct, ok := accounts[*common.ToCodeHash(code)]
 	if !ok {
 		return nil, errors.New("no available account in wallet to do single-sign")
 	}
+	if !acct.CanSign() {
+		return nil, errors.New(acct.Address + ": " + ErrWatchOnly.Error())
+	}
 
 	// Sign transaction
-	signature, err := SignBySigner(txn, acct)
+	signature, err := signTransactionBySigner(txn, acct, prevTxs)
 	if err != nil {
 		return nil, err
 	}
@@ -461,6 +488,12 @@ func SignStandardTransaction(txn *types.Transaction, program *pg.Program,
 
 func SignMultiSignTransaction(txn *types.Transaction, program *pg.Program,
 	accounts map[common.Uint160]*Account) (*pg.Program, error) {
+	return signMultiSignTransaction(txn, program, accounts, nil)
+}
+
+func signMultiSignTransaction(txn *types.Transaction, program *pg.Program,
+	accounts map[common.Uint160]*Account,
+	prevTxs []*types.Transaction) (*pg.Program, error) {
 	code := program.Code
 	param := program.Parameter
 	// Check if current user is a valid signer
@@ -470,19 +503,27 @@ func SignMultiSignTransaction(txn *types.Transaction, program *pg.Program,
 	}
 	var signerIndex = -1
 	var acc *Account
+	var watchOnly bool
 	for i, hash := range codeHashes {
 		var ok bool
 		acc, ok = accounts[*hash]
+		if ok && !acc.CanSign() {
+			watchOnly = true
+			continue
+		}
 		if ok {
 			signerIndex = i
 			break
 		}
 	}
+	if signerIndex == -1 && watchOnly {
+		return nil, ErrWatchOnly
+	}
 	if signerIndex == -1 {
 		return nil, errors.New("no available account detected")
 	}
 	// Sign transaction
-	signature, err := SignBySigner(txn, acc)
+	signature, err := signTransactionBySigner(txn, acc, prevTxs)
 	if err != nil {
 		return nil, err
 	}
@@ -17,7 +17,8 @@ const (
 	MAINACCOUNT      = "main-account"
 	SUBACCOUNT       = "sub-account"
 	KeystoreFileName = "keystore.dat"
-	KeystoreVersion  = "1.0.0"
+	KeystoreVersion  = "2.0.0"
+	KeystoreVersion1 = "1.0.0"
 
 	MaxSignalQueueLen = 5
 )
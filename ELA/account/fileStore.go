@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/elastos/Elastos.ELA/common"
@@ -22,13 +23,18 @@ type AccountData struct {
 	RedeemScript        string
 	PrivateKeyEncrypted string
 	Type                string
+	HDPath              string `json:",omitempty"`
+	WatchOnly           bool   `json:",omitempty"`
 }
 
 type FileData struct {
 	Version      string
-	PasswordHash string
+	PasswordHash string `json:",omitempty"`
 	IV           string
 	MasterKey    string
+	KDF          *KDFParams `json:",omitempty"`
+	HDSeed       string     `json:",omitempty"`
+	XPub         string     `json:",omitempty"`
 	Account      []AccountData
 }
 
@@ -64,23 +70,63 @@ func (cs *FileStore) readDB() ([]byte, error) {
 	}
 }
 
-// Caller holds the lock and writes bytes to DB, then close the DB and release the lock
+// Caller holds the lock and writes bytes to DB, then close the DB and release the lock.
+// The DB is written by WriteFileAtomically so it's never left partially
+// written.
 func (cs *FileStore) writeDB(data []byte) error {
 	cs.Lock()
 	defer cs.Unlock()
-	defer cs.closeDB()
 
-	var err error
-	cs.file, err = os.OpenFile(cs.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	return WriteFileAtomically(cs.path, data)
+}
+
+// WriteFileAtomically writes data to a temporary file in the same directory
+// of path first, and renames the temporary file to path after synced, so the
+// file is never left partially written if interrupted. The file is only
+// readable and writable by the owner.
+func WriteFileAtomically(path string, data []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
 	if err != nil {
 		return err
 	}
+	tmpPath := file.Name()
+	defer func() {
+		file.Close()
+		os.Remove(tmpPath)
+	}()
 
-	if cs.file != nil {
-		cs.file.Write(data)
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
 	}
 
-	return nil
+	return os.Rename(tmpPath, path)
+}
+
+// backupDB copies the DB to the path, the backup is never overwritten.
+func (cs *FileStore) backupDB(path string) error {
+	data, err := cs.readDB()
+	if err != nil {
+		return err
+	}
+
+	cs.Lock()
+	defer cs.Unlock()
+	defer cs.closeDB()
+
+	cs.file, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := cs.file.Write(data); err != nil {
+		return err
+	}
+	return cs.file.Sync()
 }
 
 func (cs *FileStore) closeDB() {
@@ -100,11 +146,32 @@ func (cs *FileStore) BuildDatabase(path string) {
 		fmt.Println("Build DataBase Error")
 		os.Exit(1)
 	}
-	cs.writeDB(jsonBlob)
+	if err := cs.writeDB(jsonBlob); err != nil {
+		fmt.Println("Build DataBase Error,", err)
+		os.Exit(1)
+	}
 }
 
 func (cs *FileStore) SaveAccountData(programHash *common.Uint168, redeemScript []byte,
 	encryptedPrivateKey []byte) error {
+	return cs.saveAccountData(programHash, redeemScript, encryptedPrivateKey, "", false)
+}
+
+// SaveHDAccountData saves the account derived from the HD seed with the path.
+func (cs *FileStore) SaveHDAccountData(programHash *common.Uint168, redeemScript []byte,
+	encryptedPrivateKey []byte, path string) error {
+	return cs.saveAccountData(programHash, redeemScript, encryptedPrivateKey, path, false)
+}
+
+// SaveWatchOnlyAccountData saves the account without private key, the redeem
+// script is empty if the account is watched by address.
+func (cs *FileStore) SaveWatchOnlyAccountData(programHash *common.Uint168,
+	redeemScript []byte, path string) error {
+	return cs.saveAccountData(programHash, redeemScript, nil, path, true)
+}
+
+func (cs *FileStore) saveAccountData(programHash *common.Uint168, redeemScript []byte,
+	encryptedPrivateKey []byte, path string, watchOnly bool) error {
 	JSONData, err := cs.readDB()
 	if err != nil {
 		return errors.New("error: reading db")
@@ -113,11 +180,11 @@ func (cs *FileStore) SaveAccountData(programHash *common.Uint168, redeemScript [
 		return errors.New("error: unmarshal db")
 	}
 
-	var accountType string
-	if len(cs.data.Account) == 0 {
+	// the first account holding a private key is the main account, a
+	// watch-only or multi-signature account is never the main account.
+	accountType := SUBACCOUNT
+	if len(encryptedPrivateKey) > 0 && !hasMainAccount(cs.data.Account) {
 		accountType = MAINACCOUNT
-	} else {
-		accountType = SUBACCOUNT
 	}
 
 	addr, err := programHash.ToAddress()
@@ -130,6 +197,8 @@ func (cs *FileStore) SaveAccountData(programHash *common.Uint168, redeemScript [
 		RedeemScript:        common.BytesToHexString(redeemScript),
 		PrivateKeyEncrypted: common.BytesToHexString(encryptedPrivateKey),
 		Type:                accountType,
+		HDPath:              path,
+		WatchOnly:           watchOnly,
 	}
 
 	for _, v := range cs.data.Account {
@@ -143,9 +212,7 @@ func (cs *FileStore) SaveAccountData(programHash *common.Uint168, redeemScript [
 	if err != nil {
 		return errors.New("error: marshal db")
 	}
-	cs.writeDB(JSONBlob)
-
-	return nil
+	return cs.writeDB(JSONBlob)
 }
 
 func (cs *FileStore) DeleteAccountData(address string) error {
@@ -159,7 +226,7 @@ func (cs *FileStore) DeleteAccountData(address string) error {
 
 	for i, v := range cs.data.Account {
 		if address == v.Address {
-			if v.Type == MAINACCOUNT {
+			if isMainAccount(&v) {
 				return errors.New("can't remove main account")
 			}
 			cs.data.Account = append(cs.data.Account[:i], cs.data.Account[i+1:]...)
@@ -170,9 +237,7 @@ func (cs *FileStore) DeleteAccountData(address string) error {
 	if err != nil {
 		return errors.New("error: marshal db")
 	}
-	cs.writeDB(JSONBlob)
-
-	return nil
+	return cs.writeDB(JSONBlob)
 }
 
 func (cs *FileStore) LoadAccountData() ([]AccountData, error) {
@@ -205,15 +270,16 @@ func (cs *FileStore) SaveStoredData(name string, value []byte) error {
 		cs.data.MasterKey = hexValue
 	case "PasswordHash":
 		cs.data.PasswordHash = hexValue
-
+	case "HDSeed":
+		cs.data.HDSeed = hexValue
+	case "XPub":
+		cs.data.XPub = string(value)
 	}
 	JSONBlob, err := json.Marshal(cs.data)
 	if err != nil {
 		return errors.New("error: marshal db")
 	}
-	cs.writeDB(JSONBlob)
-
-	return nil
+	return cs.writeDB(JSONBlob)
 }
 
 func (cs *FileStore) LoadStoredData(name string) ([]byte, error) {
@@ -233,11 +299,53 @@ func (cs *FileStore) LoadStoredData(name string) ([]byte, error) {
 		return common.HexStringToBytes(cs.data.MasterKey)
 	case "PasswordHash":
 		return common.HexStringToBytes(cs.data.PasswordHash)
+	case "HDSeed":
+		return common.HexStringToBytes(cs.data.HDSeed)
+	case "XPub":
+		return []byte(cs.data.XPub), nil
 	}
 
 	return nil, errors.New("can't find the key: " + name)
 }
 
+// SaveMasterKeyData saves the encrypted master key with the keystore version
+// and KDF parameters at once, the password hash of version 1 is removed.
+func (cs *FileStore) SaveMasterKeyData(version string, kdf *KDFParams,
+	masterKey []byte) error {
+	JSONData, err := cs.readDB()
+	if err != nil {
+		return errors.New("error: reading db")
+	}
+	if err := json.Unmarshal(JSONData, &cs.data); err != nil {
+		return errors.New("error: unmarshal db")
+	}
+
+	cs.data.Version = version
+	cs.data.PasswordHash = ""
+	cs.data.KDF = kdf
+	cs.data.MasterKey = common.BytesToHexString(masterKey)
+
+	JSONBlob, err := json.Marshal(cs.data)
+	if err != nil {
+		return errors.New("error: marshal db")
+	}
+	return cs.writeDB(JSONBlob)
+}
+
+func (cs *FileStore) LoadKDFParams() (*KDFParams, error) {
+	JSONData, err := cs.readDB()
+	if err != nil {
+		return nil, errors.New("error: reading db")
+	}
+	if err := json.Unmarshal(JSONData, &cs.data); err != nil {
+		return nil, errors.New("error: unmarshal db")
+	}
+	if cs.data.KDF == nil {
+		return nil, errors.New("KDF parameters not found")
+	}
+	return cs.data.KDF, nil
+}
+
 func (cs *FileStore) SetPath(path string) {
 	cs.Lock()
 	defer cs.Unlock()
@@ -262,10 +370,25 @@ func GetWalletMainAccountData(walletPath string) (*AccountData, error) {
 		return nil, err
 	}
 	for _, a := range storeAccounts {
-		if a.Type == MAINACCOUNT {
+		if isMainAccount(&a) {
 			return &a, nil
 		}
 	}
 
 	return nil, errors.New("no main account found")
 }
+
+// isMainAccount returns if the account is the main account, the accounts
+// without private key saved as main account by early versions are not.
+func isMainAccount(a *AccountData) bool {
+	return a.Type == MAINACCOUNT && a.PrivateKeyEncrypted != ""
+}
+
+func hasMainAccount(accounts []AccountData) bool {
+	for i := range accounts {
+		if isMainAccount(&accounts[i]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/crypto"
+
+	"github.com/itchyny/base58-go"
+)
+
+const (
+	// HardenedKeyStart is the index of the first hardened child key.
+	HardenedKeyStart = 0x80000000
+
+	// ExternalChain is the chain of receive addresses.
+	ExternalChain = 0
+
+	// InternalChain is the chain of change addresses.
+	InternalChain = 1
+
+	// DefaultGapLimit is the default count of continuous unused addresses to
+	// stop the address discovery.
+	DefaultGapLimit = 20
+
+	// hdPurpose and hdCoinType are the purpose and registered coin type of
+	// ELA in BIP44 path.
+	hdPurpose  = 44
+	hdCoinType = 2305
+
+	// xpubVersion is the version bytes of serialized extended public key,
+	// the keys are on the P-256 curve so the version differs from "xpub" of
+	// Bitcoin, which makes the serialized keys start with "epub" and be
+	// rejected by secp256k1 tools instead of deriving wrong addresses.
+	xpubVersion = 0x031273b7
+
+	// legacyXPubVersion is the Bitcoin "xpub" version bytes used by the
+	// watch-only wallets created by earlier versions, which is only accepted
+	// when reading the keystore.
+	legacyXPubVersion = 0x0488b21e
+
+	// xpubLength is the length of serialized extended public key without
+	// checksum.
+	xpubLength = 78
+)
+
+// masterKeySeed is the HMAC key to generate master key from seed.
+var masterKeySeed = []byte("Bitcoin seed")
+
+// ErrInvalidChild indicates the child key of the index is invalid and the
+// next index should be used instead, which happens with a probability lower
+// than 1 in 2^127.
+var ErrInvalidChild = errors.New("invalid child key, use the next index")
+
+// ExtendedKey is a private key with its chain code, which can derive child
+// keys deterministically.
+type ExtendedKey struct {
+	PrivateKey []byte
+	ChainCode  []byte
+}
+
+// NewMasterKey creates the master extended key from seed.
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	mac := hmac.New(sha512.New, masterKeySeed)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	k := new(big.Int).SetBytes(sum[:32])
+	if k.Sign() == 0 || k.Cmp(crypto.DefaultParams.N) >= 0 {
+		return nil, errors.New("invalid seed")
+	}
+
+	return &ExtendedKey{PrivateKey: sum[:32], ChainCode: sum[32:]}, nil
+}
+
+// Child derives the child extended key of the index, indexes not lower than
+// HardenedKeyStart derive hardened child keys.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	var data []byte
+	if index >= HardenedKeyStart {
+		data = append([]byte{0x00}, k.PrivateKey...)
+	} else {
+		publicKey, err := crypto.NewPubKey(k.PrivateKey).EncodePoint(true)
+		if err != nil {
+			return nil, err
+		}
+		data = publicKey
+	}
+	var indexBytes [4]byte
+	binary.BigEndian.PutUint32(indexBytes[:], index)
+	data = append(data, indexBytes[:]...)
+
+	mac := hmac.New(sha512.New, k.ChainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.DefaultParams.N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, ErrInvalidChild
+	}
+	child := il.Add(il, new(big.Int).SetBytes(k.PrivateKey))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+
+	privateKey := make([]byte, 32)
+	childBytes := child.Bytes()
+	copy(privateKey[32-len(childBytes):], childBytes)
+
+	return &ExtendedKey{PrivateKey: privateKey, ChainCode: sum[32:]}, nil
+}
+
+// Derive derives the extended key along the path from this key.
+func (k *ExtendedKey) Derive(path []uint32) (*ExtendedKey, error) {
+	key := k
+	for _, index := range path {
+		var err error
+		key, err = key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// Public returns the extended public key of this key, depth, parent
+// fingerprint and child number are the position of this key in the tree.
+func (k *ExtendedKey) Public(depth uint8, parentFingerprint,
+	childNumber uint32) (*ExtendedPublicKey, error) {
+	publicKey, err := crypto.NewPubKey(k.PrivateKey).EncodePoint(true)
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedPublicKey{
+		PublicKey:         publicKey,
+		ChainCode:         k.ChainCode,
+		Depth:             depth,
+		ParentFingerprint: parentFingerprint,
+		ChildNumber:       childNumber,
+	}, nil
+}
+
+// ExtendedPublicKey is a compressed public key with its chain code, which can
+// derive the non-hardened child public keys.
+type ExtendedPublicKey struct {
+	PublicKey         []byte
+	ChainCode         []byte
+	Depth             uint8
+	ParentFingerprint uint32
+	ChildNumber       uint32
+}
+
+// Child derives the non-hardened child extended public key of the index.
+func (k *ExtendedPublicKey) Child(index uint32) (*ExtendedPublicKey, error) {
+	if index >= HardenedKeyStart {
+		return nil, errors.New("can not derive hardened child from public key")
+	}
+	parent, err := crypto.DecodePoint(k.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	var indexBytes [4]byte
+	binary.BigEndian.PutUint32(indexBytes[:], index)
+	data := append(append([]byte{}, k.PublicKey...), indexBytes[:]...)
+
+	mac := hmac.New(sha512.New, k.ChainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(crypto.DefaultParams.N) >= 0 {
+		return nil, ErrInvalidChild
+	}
+	x, y := crypto.DefaultCurve.ScalarBaseMult(sum[:32])
+	x, y = crypto.DefaultCurve.Add(x, y, parent.X, parent.Y)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+	publicKey, err := (&crypto.PublicKey{X: x, Y: y}).EncodePoint(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExtendedPublicKey{
+		PublicKey:         publicKey,
+		ChainCode:         sum[32:],
+		Depth:             k.Depth + 1,
+		ParentFingerprint: k.Fingerprint(),
+		ChildNumber:       index,
+	}, nil
+}
+
+// Fingerprint returns the first 4 bytes of the public key hash.
+func (k *ExtendedPublicKey) Fingerprint() uint32 {
+	return binary.BigEndian.Uint32(common.ToCodeHash(k.PublicKey)[:4])
+}
+
+// String returns the base58 format of the extended public key.
+func (k *ExtendedPublicKey) String() string {
+	data := make([]byte, 0, xpubLength+4)
+	data = append(data, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[0:4], xpubVersion)
+	data = append(data, k.Depth)
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], k.ParentFingerprint)
+	data = append(data, buf[:]...)
+	binary.BigEndian.PutUint32(buf[:], k.ChildNumber)
+	data = append(data, buf[:]...)
+	data = append(data, k.ChainCode...)
+	data = append(data, k.PublicKey...)
+	checksum := common.Sha256D(data)
+	data = append(data, checksum[:4]...)
+
+	bi := new(big.Int).SetBytes(data).String()
+	encoded, _ := base58.BitcoinEncoding.Encode([]byte(bi))
+	return string(encoded)
+}
+
+// ParseExtendedPublicKey parses the base58 format of extended public key.
+func ParseExtendedPublicKey(s string) (*ExtendedPublicKey, error) {
+	return parseExtendedPublicKey(s, false)
+}
+
+// parseExtendedPublicKey parses the base58 format of extended public key,
+// the legacy version is accepted if legacy is true.
+func parseExtendedPublicKey(s string, legacy bool) (*ExtendedPublicKey, error) {
+	decoded, err := base58.BitcoinEncoding.Decode([]byte(s))
+	if err != nil {
+		return nil, errors.New("invalid extended public key")
+	}
+	bi, ok := new(big.Int).SetString(string(decoded), 10)
+	if !ok {
+		return nil, errors.New("invalid extended public key")
+	}
+	data := bi.Bytes()
+	if len(data) != xpubLength+4 {
+		return nil, errors.New("invalid extended public key length")
+	}
+	checksum := common.Sha256D(data[:xpubLength])
+	if !bytes.Equal(checksum[:4], data[xpubLength:]) {
+		return nil, errors.New("invalid extended public key checksum")
+	}
+	version := binary.BigEndian.Uint32(data[0:4])
+	if version != xpubVersion && !(legacy && version == legacyXPubVersion) {
+		return nil, errors.New("unsupported extended public key version")
+	}
+	if _, err := crypto.DecodePoint(data[45:78]); err != nil {
+		return nil, err
+	}
+
+	return &ExtendedPublicKey{
+		Depth:             data[4],
+		ParentFingerprint: binary.BigEndian.Uint32(data[5:9]),
+		ChildNumber:       binary.BigEndian.Uint32(data[9:13]),
+		ChainCode:         data[13:45],
+		PublicKey:         data[45:78],
+	}, nil
+}
+
+// HDPath returns the BIP44 path of the address in the chain with the index,
+// which is m/44'/2305'/0'/chain/index.
+func HDPath(chain, index uint32) []uint32 {
+	return []uint32{hdPurpose + HardenedKeyStart, hdCoinType + HardenedKeyStart,
+		HardenedKeyStart, chain, index}
+}
+
+// hdAccountPath returns the BIP44 path of the account, m/44'/2305'/0'.
+func hdAccountPath() []uint32 {
+	return HDPath(0, 0)[:3]
+}
+
+// FormatHDPath returns the string format of the path like m/44'/2305'/0'/0/1.
+func FormatHDPath(path []uint32) string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range path {
+		if index >= HardenedKeyStart {
+			fmt.Fprintf(&b, "/%d'", index-HardenedKeyStart)
+		} else {
+			fmt.Fprintf(&b, "/%d", index)
+		}
+	}
+	return b.String()
+}
+
+// ParseHDPath parses the string format of path like m/44'/2305'/0'/0/1.
+func ParseHDPath(path string) ([]uint32, error) {
+	elements := strings.Split(path, "/")
+	if len(elements) == 0 || elements[0] != "m" {
+		return nil, errors.New("invalid HD path " + path)
+	}
+	indexes := make([]uint32, 0, len(elements)-1)
+	for _, e := range elements[1:] {
+		var offset uint32
+		if strings.HasSuffix(e, "'") {
+			offset = HardenedKeyStart
+			e = strings.TrimSuffix(e, "'")
+		}
+		index, err := strconv.ParseUint(e, 10, 32)
+		if err != nil || uint32(index) >= HardenedKeyStart {
+			return nil, errors.New("invalid HD path " + path)
+		}
+		indexes = append(indexes, uint32(index)+offset)
+	}
+	return indexes, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/crypto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExtendedKey checks keys with the test vectors of BIP32.  ELA keys are on
+// the secp256r1 curve instead of secp256k1, so only the master keys and the
+// hardened children, which do not depend on public keys, are the same as the
+// vectors.
+func TestExtendedKey(t *testing.T) {
+	vectors := []struct {
+		seed       string
+		path       []uint32
+		privateKey string
+		chainCode  string
+	}{
+		{
+			"000102030405060708090a0b0c0d0e0f",
+			nil,
+			"e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+			"873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508",
+		},
+		{
+			"000102030405060708090a0b0c0d0e0f",
+			[]uint32{HardenedKeyStart},
+			"edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+			"47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141",
+		},
+		{
+			"fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542",
+			nil,
+			"4b03d6fc340455b363f51020ad3ecca4f0850280cf436c70c727923f6db46c3e",
+			"60499f801b896d83179a4374aeb7822aaeaceaa0db1f85ee3e904c4defbd9689",
+		},
+		// the leading zero of private key is retained
+		{
+			"4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be",
+			nil,
+			"00ddb80b067e0d4993197fe10f2657a844a384589847602d56f0c629c81aae32",
+			"01d28a3e53cffa419ec122c968b3259e16b65076495494d97cae10bbfec3c36f",
+		},
+		{
+			"4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be",
+			[]uint32{HardenedKeyStart},
+			"491f7a2eebc7b57028e0d3faa0acda02e75c33b03c48fb288c41e2ea44e1daef",
+			"e5fea12a97b927fc9dc3d2cb0d1ea1cf50aa5a1fdc1f933e8906bb38df3377bd",
+		},
+	}
+	for _, v := range vectors {
+		seed, err := common.HexStringToBytes(v.seed)
+		assert.NoError(t, err)
+		master, err := NewMasterKey(seed)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		key, err := master.Derive(v.path)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Equal(t, v.privateKey, common.BytesToHexString(key.PrivateKey))
+		assert.Equal(t, v.chainCode, common.BytesToHexString(key.ChainCode))
+	}
+}
+
+func TestExtendedKey_Child(t *testing.T) {
+	seed, err := common.HexStringToBytes("000102030405060708090a0b0c0d0e0f")
+	assert.NoError(t, err)
+	master, err := NewMasterKey(seed)
+	assert.NoError(t, err)
+	account, err := master.Derive(hdAccountPath())
+	assert.NoError(t, err)
+	accountPublic, err := account.Public(3, 0, hdAccountPath()[2])
+	assert.NoError(t, err)
+
+	// non-hardened children derived from the private key and the public key
+	// are the same
+	for _, index := range []uint32{0, 1, HardenedKeyStart - 1} {
+		child, err := account.Child(index)
+		assert.NoError(t, err)
+		publicKey, err := crypto.NewPubKey(child.PrivateKey).EncodePoint(true)
+		assert.NoError(t, err)
+
+		publicChild, err := accountPublic.Child(index)
+		assert.NoError(t, err)
+		assert.Equal(t, publicKey, publicChild.PublicKey)
+		assert.Equal(t, child.ChainCode, publicChild.ChainCode)
+		assert.Equal(t, uint8(4), publicChild.Depth)
+		assert.Equal(t, accountPublic.Fingerprint(), publicChild.ParentFingerprint)
+		assert.Equal(t, index, publicChild.ChildNumber)
+	}
+
+	_, err = accountPublic.Child(HardenedKeyStart)
+	assert.Error(t, err)
+
+	hardened, err := account.Child(HardenedKeyStart)
+	assert.NoError(t, err)
+	child, err := account.Child(0)
+	assert.NoError(t, err)
+	assert.NotEqual(t, child.PrivateKey, hardened.PrivateKey)
+}
+
+func TestHDPath(t *testing.T) {
+	path := HDPath(InternalChain, 5)
+	assert.Equal(t, "m/44'/2305'/0'/1/5", FormatHDPath(path))
+	assert.Equal(t, "m/44'/2305'/0'", FormatHDPath(hdAccountPath()))
+	assert.Equal(t, "m", FormatHDPath(nil))
+
+	for _, s := range []string{
+		"m",
+		"m/0",
+		"m/0'",
+		"m/44'/2305'/0'/0/1",
+		"m/2147483647'/2147483647",
+	} {
+		parsed, err := ParseHDPath(s)
+		if assert.NoError(t, err, s) {
+			assert.Equal(t, s, FormatHDPath(parsed))
+		}
+	}
+	parsed, err := ParseHDPath("m/44'/2305'/0'/1/5")
+	assert.NoError(t, err)
+	assert.Equal(t, path, parsed)
+
+	for _, s := range []string{
+		"",
+		"44'/2305'",
+		"M/0",
+		"m/",
+		"m//0",
+		"m/x",
+		"m/-1",
+		"m/0''",
+		"m/2147483648",
+		"m/2147483648'",
+		"m/4294967296",
+	} {
+		_, err := ParseHDPath(s)
+		assert.EqualError(t, err, "invalid HD path "+s, s)
+	}
+}
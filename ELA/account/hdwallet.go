@@ -0,0 +1,312 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"errors"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/crypto"
+)
+
+// CreateFromMnemonic creates a HD wallet with the seed generated from the
+// mnemonic and passphrase, the main account is the first receive address.
+func CreateFromMnemonic(path string, password []byte, mnemonic,
+	passphrase string) (*Client, error) {
+	seed, err := MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer common.ClearBytes(seed)
+
+	return createClient(path, password, func(cl *Client) (*Account, error) {
+		if err := cl.saveHDSeed(seed); err != nil {
+			return nil, err
+		}
+		return cl.CreateHDAccount(ExternalChain)
+	})
+}
+
+// HasHDSeed returns if the wallet is a HD wallet.
+func (cl *Client) HasHDSeed() (bool, error) {
+	encryptedSeed, err := cl.LoadStoredData("HDSeed")
+	if err != nil {
+		return false, err
+	}
+	return len(encryptedSeed) > 0, nil
+}
+
+// CreateFromXPub creates a watch-only HD wallet with the extended public key
+// of account m/44'/2305'/0', the first receive address is derived but the
+// wallet has no main account as it can not sign.
+func CreateFromXPub(path string, password []byte, xpub string) (*Client, error) {
+	accountKey, err := ParseExtendedPublicKey(xpub)
+	if err != nil {
+		return nil, err
+	}
+	if accountKey.Depth != uint8(len(hdAccountPath())) {
+		return nil, errors.New("extended public key of account " +
+			FormatHDPath(hdAccountPath()) + " expected")
+	}
+
+	return createClient(path, password, func(cl *Client) (*Account, error) {
+		if err := cl.SaveStoredData("XPub", []byte(xpub)); err != nil {
+			return nil, err
+		}
+		return cl.CreateHDAccount(ExternalChain)
+	})
+}
+
+// IsHDWallet returns if the wallet is a HD wallet or a watch-only HD wallet.
+func (cl *Client) IsHDWallet() (bool, error) {
+	hd, err := cl.HasHDSeed()
+	if err != nil || hd {
+		return hd, err
+	}
+	xpub, err := cl.LoadStoredData("XPub")
+	if err != nil {
+		return false, err
+	}
+	return len(xpub) > 0, nil
+}
+
+// AccountXPub returns the extended public key of account m/44'/2305'/0',
+// which derives the same addresses as the wallet without private keys.
+func (cl *Client) AccountXPub() (*ExtendedPublicKey, error) {
+	xpub, err := cl.LoadStoredData("XPub")
+	if err != nil {
+		return nil, err
+	}
+	if len(xpub) > 0 {
+		return parseExtendedPublicKey(string(xpub), true)
+	}
+
+	master, err := cl.hdMasterKey()
+	if err != nil {
+		return nil, err
+	}
+	path := hdAccountPath()
+	parent, err := master.Derive(path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	parentPublicKey, err := parent.Public(0, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	accountKey, err := parent.Child(path[len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	return accountKey.Public(uint8(len(path)), parentPublicKey.Fingerprint(),
+		path[len(path)-1])
+}
+
+// CreateHDAccount derives the next account in the chain from the HD seed
+// then save it, the account is watch-only in a watch-only HD wallet.
+func (cl *Client) CreateHDAccount(chain uint32) (*Account, error) {
+	derive, err := cl.hdAccountDeriver()
+	if err != nil {
+		return nil, err
+	}
+	index, err := cl.nextHDIndex(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	for ; index < HardenedKeyStart; index++ {
+		path := HDPath(chain, index)
+		ac, err := derive(path)
+		if err == ErrInvalidChild {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := cl.saveHDAccount(ac, FormatHDPath(path)); err != nil {
+			return nil, err
+		}
+		return ac, nil
+	}
+
+	return nil, errors.New("no more addresses in the chain")
+}
+
+// DiscoverHDAccounts derives the accounts from the HD seed in both receive
+// and change chain, and saves the used ones which are not in wallet yet.
+// The discovery of a chain stops after gapLimit continuous unused addresses.
+func (cl *Client) DiscoverHDAccounts(gapLimit uint32,
+	used func(address string) (bool, error)) ([]*Account, error) {
+	derive, err := cl.hdAccountDeriver()
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []*Account
+	for _, chain := range []uint32{ExternalChain, InternalChain} {
+		var gap uint32
+		for index := uint32(0); gap < gapLimit && index < HardenedKeyStart; index++ {
+			path := HDPath(chain, index)
+			ac, err := derive(path)
+			if err == ErrInvalidChild {
+				continue
+			}
+			if err != nil {
+				return accounts, err
+			}
+
+			ok, err := used(ac.Address)
+			if err != nil {
+				return accounts, err
+			}
+			if !ok {
+				gap++
+				continue
+			}
+			gap = 0
+
+			if cl.GetAccountByCodeHash(ac.ProgramHash.ToCodeHash()) != nil {
+				continue
+			}
+			if err := cl.saveHDAccount(ac, FormatHDPath(path)); err != nil {
+				return accounts, err
+			}
+			accounts = append(accounts, ac)
+		}
+	}
+
+	return accounts, nil
+}
+
+// hdAccountDeriver returns the function to derive the account of a HD path,
+// from the HD seed, or from the extended public key of a watch-only wallet.
+func (cl *Client) hdAccountDeriver() (func(path []uint32) (*Account, error), error) {
+	hd, err := cl.HasHDSeed()
+	if err != nil {
+		return nil, err
+	}
+	if hd {
+		master, err := cl.hdMasterKey()
+		if err != nil {
+			return nil, err
+		}
+		return func(path []uint32) (*Account, error) {
+			return deriveHDAccount(master, path)
+		}, nil
+	}
+
+	xpub, err := cl.LoadStoredData("XPub")
+	if err != nil {
+		return nil, err
+	}
+	if len(xpub) == 0 {
+		return nil, errors.New("not a HD wallet")
+	}
+	accountKey, err := parseExtendedPublicKey(string(xpub), true)
+	if err != nil {
+		return nil, err
+	}
+	return func(path []uint32) (*Account, error) {
+		return deriveWatchOnlyHDAccount(accountKey, path)
+	}, nil
+}
+
+func (cl *Client) saveHDAccount(ac *Account, hdPath string) error {
+	if ac.PrivateKey == nil {
+		return cl.saveWatchOnlyAccount(ac, hdPath)
+	}
+	return cl.saveAccount(ac, hdPath)
+}
+
+func (cl *Client) saveHDSeed(seed []byte) error {
+	encryptedSeed, err := crypto.AesEncrypt(seed, cl.masterKey, cl.iv)
+	if err != nil {
+		return err
+	}
+	return cl.SaveStoredData("HDSeed", encryptedSeed)
+}
+
+func (cl *Client) hdMasterKey() (*ExtendedKey, error) {
+	encryptedSeed, err := cl.LoadStoredData("HDSeed")
+	if err != nil {
+		return nil, err
+	}
+	if len(encryptedSeed) == 0 {
+		return nil, errors.New("not a HD wallet")
+	}
+	seed, err := crypto.AesDecrypt(encryptedSeed, cl.masterKey, cl.iv)
+	if err != nil {
+		return nil, err
+	}
+	defer common.ClearBytes(seed)
+
+	return NewMasterKey(seed)
+}
+
+// nextHDIndex returns the index next to the last derived account in chain.
+func (cl *Client) nextHDIndex(chain uint32) (uint32, error) {
+	storeAccounts, err := cl.LoadAccountData()
+	if err != nil {
+		return 0, err
+	}
+
+	var next uint32
+	for _, a := range storeAccounts {
+		if a.HDPath == "" {
+			continue
+		}
+		path, err := ParseHDPath(a.HDPath)
+		if err != nil {
+			return 0, err
+		}
+		if len(path) != 5 || path[3] != chain {
+			continue
+		}
+		if path[4] >= next {
+			next = path[4] + 1
+		}
+	}
+
+	return next, nil
+}
+
+func deriveHDAccount(master *ExtendedKey, path []uint32) (*Account, error) {
+	key, err := master.Derive(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccountWithPrivateKey(key.PrivateKey)
+}
+
+// deriveWatchOnlyHDAccount derives the watch-only account of the path from the
+// extended public key of account m/44'/2305'/0'.
+func deriveWatchOnlyHDAccount(accountKey *ExtendedPublicKey,
+	path []uint32) (*Account, error) {
+	prefix := hdAccountPath()
+	if len(path) != len(prefix)+2 {
+		return nil, errors.New("invalid HD path " + FormatHDPath(path))
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return nil, errors.New("invalid HD path " + FormatHDPath(path))
+		}
+	}
+
+	key, err := accountKey.Child(path[len(prefix)])
+	if err != nil {
+		return nil, err
+	}
+	key, err = key.Child(path[len(prefix)+1])
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := crypto.DecodePoint(key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccountWithPublicKey(publicKey)
+}
@@ -0,0 +1,336 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/crypto"
+)
+
+// The methods of JSON signer protocol.
+const (
+	SignerMethodPublicKeys = "publickeys"
+	SignerMethodSign       = "sign"
+)
+
+// The prefixes of signer address.
+const (
+	signerUnixPrefix   = "unix:"
+	signerExecPrefix   = "exec:"
+	signerPKCS11Prefix = "pkcs11:"
+)
+
+// SignerRequest is a request of JSON signer protocol, the requests and
+// responses are JSON objects sent one by one through a stream.
+type SignerRequest struct {
+	ID     uint64            `json:"id"`
+	Method string            `json:"method"`
+	Params *SignerSignParams `json:"params,omitempty"`
+}
+
+// SignerSignParams is the params of sign request, the public key and data
+// are in hex string format. The referenced transactions of the inputs are
+// sent by order in hex string format when the data is a transaction, so the
+// signer can verify the amount spent and the fee.
+type SignerSignParams struct {
+	PublicKey   string   `json:"publickey"`
+	Data        string   `json:"data"`
+	PreviousTxs []string `json:"previoustxs,omitempty"`
+}
+
+// SignerResponse is a response of JSON signer protocol, the result is a list
+// of public keys in hex string format for publickeys request and a signature
+// in hex string format for sign request.
+type SignerResponse struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// JSONSigner is a Signer speaking JSON signer protocol to an external signer
+// process through a stream, such as stdin and stdout of the process or a
+// socket.
+type JSONSigner struct {
+	mu      sync.Mutex
+	conn    io.ReadWriteCloser
+	encoder *json.Encoder
+	decoder *json.Decoder
+	nextID  uint64
+}
+
+// NewJSONSigner creates a JSONSigner speaking through the connection.
+func NewJSONSigner(conn io.ReadWriteCloser) *JSONSigner {
+	return &JSONSigner{
+		conn:    conn,
+		encoder: json.NewEncoder(conn),
+		decoder: json.NewDecoder(conn),
+	}
+}
+
+// DialSigner connects to the external signer of the address, which is in
+// format of "unix:<path>" to connect a unix socket, "exec:<command>" to start
+// the command and speak through its stdin and stdout, or
+// "pkcs11:<module>?id=<key id>" to sign by a PKCS#11 token. The signer is not
+// authenticated by the protocol, so it is never connected through a network
+// socket, use exec with ssh or a forwarded unix socket for remote signers.
+func DialSigner(address string) (SignerCloser, error) {
+	switch {
+	case strings.HasPrefix(address, signerUnixPrefix):
+		conn, err := net.Dial("unix", strings.TrimPrefix(address, signerUnixPrefix))
+		if err != nil {
+			return nil, err
+		}
+		return NewJSONSigner(conn), nil
+
+	case strings.HasPrefix(address, signerExecPrefix):
+		args := strings.Fields(strings.TrimPrefix(address, signerExecPrefix))
+		if len(args) == 0 {
+			return nil, errors.New("no signer command specified")
+		}
+		conn, err := startSignerProcess(args[0], args[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		return NewJSONSigner(conn), nil
+
+	case strings.HasPrefix(address, signerPKCS11Prefix):
+		return ParsePKCS11Signer(strings.TrimPrefix(address, signerPKCS11Prefix))
+	}
+
+	return nil, errors.New("invalid signer address: " + address)
+}
+
+// PublicKeys returns the public keys of which the signer holds the private
+// keys.
+func (s *JSONSigner) PublicKeys() ([]*crypto.PublicKey, error) {
+	var keys []string
+	if err := s.call(SignerMethodPublicKeys, nil, &keys); err != nil {
+		return nil, err
+	}
+
+	pubKeys := make([]*crypto.PublicKey, 0, len(keys))
+	for _, key := range keys {
+		keyBytes, err := common.HexStringToBytes(key)
+		if err != nil {
+			return nil, errors.New("invalid public key " + key)
+		}
+		pubKey, err := crypto.DecodePoint(keyBytes)
+		if err != nil {
+			return nil, errors.New("invalid public key " + key)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return pubKeys, nil
+}
+
+// Sign signs the data by the private key of the public key.
+func (s *JSONSigner) Sign(pubKey *crypto.PublicKey, data []byte) ([]byte, error) {
+	return s.SignTransaction(pubKey, data, nil)
+}
+
+// SignTransaction signs the unsigned data of a transaction by the private key
+// of the public key, the referenced transactions of the inputs are sent with
+// the data.
+func (s *JSONSigner) SignTransaction(pubKey *crypto.PublicKey, data []byte,
+	prevTxs []*types.Transaction) ([]byte, error) {
+	key, err := pubKey.EncodePoint(true)
+	if err != nil {
+		return nil, err
+	}
+	params := &SignerSignParams{
+		PublicKey: common.BytesToHexString(key),
+		Data:      common.BytesToHexString(data),
+	}
+	for _, prevTx := range prevTxs {
+		buf := new(bytes.Buffer)
+		if err := prevTx.Serialize(buf); err != nil {
+			return nil, err
+		}
+		params.PreviousTxs = append(params.PreviousTxs,
+			common.BytesToHexString(buf.Bytes()))
+	}
+	var signature string
+	if err := s.call(SignerMethodSign, params, &signature); err != nil {
+		return nil, err
+	}
+	return common.HexStringToBytes(signature)
+}
+
+// Close closes the connection to the signer.
+func (s *JSONSigner) Close() error {
+	return s.conn.Close()
+}
+
+func (s *JSONSigner) call(method string, params *SignerSignParams,
+	result interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	req := &SignerRequest{ID: s.nextID, Method: method, Params: params}
+	if err := s.encoder.Encode(req); err != nil {
+		return err
+	}
+	var resp SignerResponse
+	if err := s.decoder.Decode(&resp); err != nil {
+		return err
+	}
+	if resp.ID != req.ID {
+		return fmt.Errorf("unexpected response id %d, expect %d", resp.ID, req.ID)
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// signerProcess is the connection to the stdin and stdout of a signer
+// process.
+type signerProcess struct {
+	io.WriteCloser
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func startSignerProcess(name string, args ...string) (*signerProcess, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &signerProcess{WriteCloser: stdin, ReadCloser: stdout, cmd: cmd}, nil
+}
+
+// Close closes stdin of the process and waits for it to exit, the process is
+// waited even if stdin fails to close, so it is never left as a zombie.
+func (p *signerProcess) Close() error {
+	closeErr := p.WriteCloser.Close()
+	waitErr := p.cmd.Wait()
+	if closeErr != nil {
+		return closeErr
+	}
+	return waitErr
+}
+
+// ServeSigner serves the requests of JSON signer protocol from the connection
+// by the private keys of the accounts until the connection is closed, which
+// is a local stand-in of an external signer. The data and the referenced
+// transactions of the inputs if any are passed to the callback before
+// signing, which should show them to the user and return an error if the
+// user refuses to sign, then the request is refused.
+func ServeSigner(conn io.ReadWriter, accounts []*Account,
+	onSign func(acc *Account, data []byte, prevTxs []*types.Transaction) error) error {
+	if onSign == nil {
+		return errors.New("no sign confirmation specified")
+	}
+
+	keys := make(map[string]*Account)
+	for _, acc := range accounts {
+		if acc.PrivateKey == nil || acc.PublicKey == nil {
+			continue
+		}
+		key, err := acc.PublicKey.EncodePoint(true)
+		if err != nil {
+			return err
+		}
+		keys[common.BytesToHexString(key)] = acc
+	}
+
+	encoder := json.NewEncoder(conn)
+	decoder := json.NewDecoder(conn)
+	for {
+		var req SignerRequest
+		if err := decoder.Decode(&req); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		result, err := serveSignerRequest(&req, keys, onSign)
+		resp := &SignerResponse{ID: req.ID}
+		if err != nil {
+			resp.Error = err.Error()
+		} else {
+			resp.Result, err = json.Marshal(result)
+			if err != nil {
+				return err
+			}
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func serveSignerRequest(req *SignerRequest, keys map[string]*Account,
+	onSign func(acc *Account, data []byte, prevTxs []*types.Transaction) error) (interface{}, error) {
+	switch req.Method {
+	case SignerMethodPublicKeys:
+		pubKeys := make([]string, 0, len(keys))
+		for key := range keys {
+			pubKeys = append(pubKeys, key)
+		}
+		sort.Strings(pubKeys)
+		return pubKeys, nil
+
+	case SignerMethodSign:
+		if req.Params == nil {
+			return nil, errors.New("missing params")
+		}
+		acc, ok := keys[strings.ToLower(req.Params.PublicKey)]
+		if !ok {
+			return nil, errors.New("unknown public key " + req.Params.PublicKey)
+		}
+		data, err := common.HexStringToBytes(req.Params.Data)
+		if err != nil {
+			return nil, errors.New("invalid data")
+		}
+		var prevTxs []*types.Transaction
+		for _, prevTxHex := range req.Params.PreviousTxs {
+			prevTxData, err := common.HexStringToBytes(prevTxHex)
+			if err != nil {
+				return nil, errors.New("invalid previous transaction")
+			}
+			var prevTx types.Transaction
+			if err := prevTx.Deserialize(bytes.NewReader(prevTxData)); err != nil {
+				return nil, errors.New("invalid previous transaction")
+			}
+			prevTxs = append(prevTxs, &prevTx)
+		}
+		if err := onSign(acc, data, prevTxs); err != nil {
+			return nil, err
+		}
+		signature, err := acc.Sign(data)
+		if err != nil {
+			return nil, err
+		}
+		return common.BytesToHexString(signature), nil
+	}
+
+	return nil, errors.New("unknown method " + req.Method)
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/outputpayload"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/crypto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAccount(t *testing.T) *Account {
+	acc, err := NewAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return acc
+}
+
+func encodeTestPublicKey(t *testing.T, pubKey *crypto.PublicKey) string {
+	key, err := pubKey.EncodePoint(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return common.BytesToHexString(key)
+}
+
+// TestHelperSigner is not a real test, it serves the account of the private
+// key in environment as a signer through stdin and stdout, when the test
+// binary is started as a signer command by the tests.
+func TestHelperSigner(t *testing.T) {
+	privateKey := os.Getenv("ELA_TEST_SIGNER_KEY")
+	if privateKey == "" {
+		return
+	}
+	keyBytes, _ := common.HexStringToBytes(privateKey)
+	acc, err := NewAccountWithPrivateKey(keyBytes)
+	if err != nil {
+		os.Exit(1)
+	}
+	err = ServeSigner(testStdio{os.Stdin, os.Stdout}, []*Account{acc},
+		func(*Account, []byte, []*types.Transaction) error { return nil })
+	if err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// testStdio is the connection through stdin and stdout.
+type testStdio struct {
+	io.Reader
+	io.Writer
+}
+
+func TestServeSigner(t *testing.T) {
+	acc1 := newTestAccount(t)
+	acc2 := newTestAccount(t)
+	watchOnly, err := NewAccountWithPublicKey(newTestPublicKey(t))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var signed []*Account
+	var received []*types.Transaction
+	onSign := func(acc *Account, data []byte, prevTxs []*types.Transaction) error {
+		if string(data) == "refuse" {
+			return errors.New("signing refused by user")
+		}
+		signed = append(signed, acc)
+		received = prevTxs
+		return nil
+	}
+	assert.EqualError(t, ServeSigner(nil, nil, nil),
+		"no sign confirmation specified")
+
+	serverConn, clientConn := net.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- ServeSigner(serverConn,
+			[]*Account{acc1, acc2, watchOnly}, onSign)
+	}()
+	signer := NewJSONSigner(clientConn)
+
+	// the watch-only account is not served
+	pubKeys, err := signer.PublicKeys()
+	if assert.NoError(t, err) {
+		var keys []string
+		for _, pubKey := range pubKeys {
+			keys = append(keys, encodeTestPublicKey(t, pubKey))
+		}
+		assert.ElementsMatch(t, []string{encodeTestPublicKey(t, acc1.PublicKey),
+			encodeTestPublicKey(t, acc2.PublicKey)}, keys)
+	}
+
+	data := []byte("data to sign")
+	signature, err := signer.Sign(acc2.PublicKey, data)
+	if assert.NoError(t, err) {
+		assert.NoError(t, crypto.Verify(*acc2.PublicKey, data, signature))
+	}
+	assert.Equal(t, []*Account{acc2}, signed)
+
+	_, err = signer.Sign(acc1.PublicKey, []byte("refuse"))
+	assert.EqualError(t, err, "signing refused by user")
+	_, err = signer.Sign(watchOnly.PublicKey, data)
+	assert.EqualError(t, err, "unknown public key "+
+		encodeTestPublicKey(t, watchOnly.PublicKey))
+	assert.Equal(t, []*Account{acc2}, signed)
+
+	// the account of signer signs transactions through the connection
+	acc, err := NewAccountWithSigner(acc1.PublicKey, signer)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, acc.CanSign())
+	txn := &types.Transaction{
+		Version: types.TxVersion09,
+		TxType:  types.TransferAsset,
+		Payload: &payload.TransferAsset{},
+	}
+	buf := new(bytes.Buffer)
+	assert.NoError(t, txn.SerializeUnsigned(buf))
+	signature, err = SignBySigner(txn, acc)
+	if assert.NoError(t, err) {
+		assert.NoError(t, crypto.Verify(*acc1.PublicKey, buf.Bytes(),
+			signature))
+	}
+	assert.Equal(t, []*Account{acc2, acc1}, signed)
+	assert.Empty(t, received)
+
+	// the referenced transactions of the inputs are sent with the
+	// transaction
+	prevTx := &types.Transaction{
+		Version: types.TxVersion09,
+		TxType:  types.TransferAsset,
+		Payload: &payload.TransferAsset{},
+		Outputs: []*types.Output{{
+			AssetID:     *SystemAssetID,
+			Value:       10,
+			ProgramHash: acc1.ProgramHash,
+			Payload:     &outputpayload.DefaultOutput{},
+		}},
+	}
+	txn.Inputs = []*types.Input{{
+		Previous: types.OutPoint{TxID: prevTx.Hash(), Index: 0},
+	}}
+	_, err = signTransactionBySigner(txn, acc, []*types.Transaction{prevTx})
+	if assert.NoError(t, err) && assert.Len(t, received, 1) {
+		assert.Equal(t, prevTx.Hash(), received[0].Hash())
+	}
+
+	// the request with invalid referenced transaction is refused
+	var result string
+	err = signer.call(SignerMethodSign, &SignerSignParams{
+		PublicKey:   encodeTestPublicKey(t, acc1.PublicKey),
+		Data:        common.BytesToHexString(data),
+		PreviousTxs: []string{"00"},
+	}, &result)
+	assert.EqualError(t, err, "invalid previous transaction")
+
+	assert.NoError(t, signer.Close())
+	assert.NoError(t, <-served)
+}
+
+// testSigner is a Signer returning the signature or error specified.
+type testSigner struct {
+	signature []byte
+	err       error
+}
+
+func (s *testSigner) PublicKeys() ([]*crypto.PublicKey, error) {
+	return nil, nil
+}
+
+func (s *testSigner) Sign(*crypto.PublicKey, []byte) ([]byte, error) {
+	return s.signature, s.err
+}
+
+func TestAccount_SignBySigner(t *testing.T) {
+	acc1 := newTestAccount(t)
+	acc2 := newTestAccount(t)
+	data := []byte("data to sign")
+	signBySigner := func(signer Signer) ([]byte, error) {
+		acc, err := NewAccountWithSigner(acc1.PublicKey, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return acc.Sign(data)
+	}
+
+	signature, err := crypto.Sign(acc1.PrivateKey, data)
+	if !assert.NoError(t, err) {
+		return
+	}
+	result, err := signBySigner(&testSigner{signature: signature})
+	assert.NoError(t, err)
+	assert.Equal(t, signature, result)
+
+	// the signature by another key, of other data, or malformed is rejected
+	otherKey, err := crypto.Sign(acc2.PrivateKey, data)
+	assert.NoError(t, err)
+	otherData, err := crypto.Sign(acc1.PrivateKey, []byte("other data"))
+	assert.NoError(t, err)
+	for _, signature := range [][]byte{
+		otherKey,
+		otherData,
+		signature[:crypto.SignatureLength-1],
+		nil,
+	} {
+		_, err = signBySigner(&testSigner{signature: signature})
+		assert.EqualError(t, err, acc1.Address+
+			": signer returned invalid signature")
+	}
+
+	_, err = signBySigner(&testSigner{err: errors.New("device locked")})
+	assert.EqualError(t, err, acc1.Address+": signer: device locked")
+}
+
+func TestDialSigner(t *testing.T) {
+	for _, test := range []struct {
+		address string
+		err     string
+	}{
+		{"", "invalid signer address: "},
+		{"tcp:127.0.0.1:20339", "invalid signer address: tcp:127.0.0.1:20339"},
+		{"exec:", "no signer command specified"},
+		{"exec: ", "no signer command specified"},
+		{"pkcs11:", "no PKCS#11 module specified"},
+	} {
+		_, err := DialSigner(test.address)
+		assert.EqualError(t, err, test.err, test.address)
+	}
+
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	socketPath := filepath.Join(dir, "signer.sock")
+	_, err := DialSigner("unix:" + socketPath)
+	assert.Error(t, err)
+
+	acc := newTestAccount(t)
+	key := encodeTestPublicKey(t, acc.PublicKey)
+	checkSigner := func(signer SignerCloser) {
+		pubKeys, err := signer.PublicKeys()
+		if assert.NoError(t, err) && assert.Len(t, pubKeys, 1) {
+			assert.Equal(t, key, encodeTestPublicKey(t, pubKeys[0]))
+		}
+		data := []byte("data to sign")
+		signature, err := signer.Sign(acc.PublicKey, data)
+		if assert.NoError(t, err) {
+			assert.NoError(t, crypto.Verify(*acc.PublicKey, data, signature))
+		}
+		assert.NoError(t, signer.Close())
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		ServeSigner(conn, []*Account{acc},
+			func(*Account, []byte, []*types.Transaction) error { return nil })
+	}()
+	signer, err := DialSigner("unix:" + socketPath)
+	if assert.NoError(t, err) {
+		checkSigner(signer)
+	}
+
+	// the test binary is started as the signer command
+	os.Setenv("ELA_TEST_SIGNER_KEY", common.BytesToHexString(acc.PrivateKey))
+	defer os.Unsetenv("ELA_TEST_SIGNER_KEY")
+	signer, err = DialSigner("exec:" + os.Args[0] + " -test.run=^TestHelperSigner$")
+	if assert.NoError(t, err) {
+		checkSigner(signer)
+	}
+
+	// the process is waited even if stdin fails to close
+	process, err := startSignerProcess(os.Args[0],
+		"-test.run=^TestHelperSigner$")
+	if assert.NoError(t, err) {
+		assert.NoError(t, process.WriteCloser.Close())
+		assert.Error(t, process.Close())
+		assert.NotNil(t, process.cmd.ProcessState)
+	}
+}
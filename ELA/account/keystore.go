@@ -0,0 +1,301 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/crypto"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// KDFScrypt is the name of scrypt key derivation function.
+	KDFScrypt = "scrypt"
+
+	// scryptN, scryptR and scryptP are the scrypt parameters of new keystore,
+	// which takes about 256MB memory to derive a key.
+	scryptN = 1 << 18
+	scryptR = 8
+	scryptP = 1
+
+	// kdfKeyLength is the length of key derived from password.
+	kdfKeyLength = 32
+
+	// kdfSaltLength is the length of the per-file random salt.
+	kdfSaltLength = 32
+
+	// maxScryptN, maxScryptR and maxScryptP are the max scrypt parameters
+	// accepted from keystore, and maxScryptMemory is the max memory in bytes
+	// scrypt can take, which is 128 * N * R.
+	maxScryptN      = 1 << 20
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxScryptMemory = 1 << 30
+
+	// v1BackupSuffix is the suffix of the backup file of keystore version 1
+	// made before migrated to the current version.
+	v1BackupSuffix = ".v1.bak"
+)
+
+// ErrPasswordWrong indicates the password can not decrypt the master key.
+var ErrPasswordWrong = errors.New("password wrong")
+
+// KDFParams is the parameters of the key derivation function to derive the
+// key from password to encrypt master key.
+type KDFParams struct {
+	Name   string
+	Salt   string
+	N      int
+	R      int
+	P      int
+	KeyLen int
+}
+
+// newKDFParams creates the default KDF parameters with a random salt.
+func newKDFParams() (*KDFParams, error) {
+	salt := make([]byte, kdfSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	return &KDFParams{
+		Name:   KDFScrypt,
+		Salt:   common.BytesToHexString(salt),
+		N:      scryptN,
+		R:      scryptR,
+		P:      scryptP,
+		KeyLen: kdfKeyLength,
+	}, nil
+}
+
+// validateScrypt checks the scrypt parameters are in range, so a keystore can
+// not make scrypt take unbounded memory or time.
+func (p *KDFParams) validateScrypt() error {
+	if p.N <= 1 || p.N > maxScryptN || p.N&(p.N-1) != 0 {
+		return fmt.Errorf("invalid scrypt N %d, should be a power of 2 "+
+			"between 2 and %d", p.N, maxScryptN)
+	}
+	if p.R < 1 || p.R > maxScryptR {
+		return fmt.Errorf("invalid scrypt r %d, should be between 1 and %d",
+			p.R, maxScryptR)
+	}
+	if p.P < 1 || p.P > maxScryptP {
+		return fmt.Errorf("invalid scrypt p %d, should be between 1 and %d",
+			p.P, maxScryptP)
+	}
+	if 128*p.N*p.R > maxScryptMemory {
+		return fmt.Errorf("scrypt N %d and r %d take more than %d bytes "+
+			"memory", p.N, p.R, maxScryptMemory)
+	}
+	return nil
+}
+
+// DeriveKey derives the key from password with the parameters.
+func (p *KDFParams) DeriveKey(password []byte) ([]byte, error) {
+	salt, err := common.HexStringToBytes(p.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(salt) == 0 {
+		return nil, errors.New("KDF salt is empty")
+	}
+	if p.KeyLen != kdfKeyLength {
+		return nil, fmt.Errorf("invalid KDF key length %d, should be %d",
+			p.KeyLen, kdfKeyLength)
+	}
+
+	switch p.Name {
+	case KDFScrypt:
+		if err := p.validateScrypt(); err != nil {
+			return nil, err
+		}
+		return scrypt.Key(password, salt, p.N, p.R, p.P, p.KeyLen)
+	default:
+		return nil, errors.New("unsupported KDF " + p.Name)
+	}
+}
+
+// sealMasterKey encrypts the master key with AES-GCM by the key derived from
+// password, the nonce is prefixed to the sealed master key.
+func sealMasterKey(masterKey []byte, params *KDFParams,
+	password []byte) ([]byte, error) {
+	aead, err := newMasterKeyAEAD(params, password)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, masterKey, nil), nil
+}
+
+// openMasterKey decrypts and authenticates the master key sealed by
+// sealMasterKey.
+func openMasterKey(sealed []byte, params *KDFParams,
+	password []byte) ([]byte, error) {
+	aead, err := newMasterKeyAEAD(params, password)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, errors.New("invalid master key length")
+	}
+
+	nonce := sealed[:aead.NonceSize()]
+	masterKey, err := aead.Open(nil, nonce, sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrPasswordWrong
+	}
+	return masterKey, nil
+}
+
+func newMasterKeyAEAD(params *KDFParams, password []byte) (cipher.AEAD, error) {
+	key, err := params.DeriveKey(password)
+	if err != nil {
+		return nil, err
+	}
+	defer common.ClearBytes(key)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// saveMasterKey encrypts the master key with password by a new salt, and
+// saves it as the current keystore version.
+func (cl *Client) saveMasterKey(password []byte) error {
+	params, err := newKDFParams()
+	if err != nil {
+		return err
+	}
+	sealed, err := sealMasterKey(cl.masterKey, params, password)
+	if err != nil {
+		return err
+	}
+
+	return cl.SaveMasterKeyData(KeystoreVersion, params, sealed)
+}
+
+// loadMasterKey decrypts the master key with password by the keystore
+// version, keystore of version 1 will be migrated to the current version
+// after backed up to the file with v1BackupSuffix, and the backup is removed
+// after the migrated keystore is verified.
+func (cl *Client) loadMasterKey(password []byte) error {
+	version, err := cl.LoadStoredData("Version")
+	if err != nil {
+		return err
+	}
+	encryptedMasterKey, err := cl.LoadStoredData("MasterKey")
+	if err != nil {
+		return err
+	}
+
+	switch string(version) {
+	case KeystoreVersion1:
+		passwordKey := crypto.ToAesKey(password)
+		defer common.ClearBytes(passwordKey)
+		if ok := cl.verifyPasswordKey(passwordKey); !ok {
+			return ErrPasswordWrong
+		}
+		cl.masterKey, err = crypto.AesDecrypt(encryptedMasterKey, passwordKey, cl.iv)
+		if err != nil {
+			return err
+		}
+		backupPath := cl.path + v1BackupSuffix
+		if err := cl.backupDB(backupPath); err != nil {
+			return errors.New("backup keystore before migration failed, " +
+				err.Error())
+		}
+		if err := cl.saveMasterKey(password); err != nil {
+			return errors.New("migrate keystore failed, " + err.Error() +
+				", keystore of version 1 is kept in " + backupPath)
+		}
+		// the backup holds the master key protected by the weak password
+		// hash of version 1, so it's removed once the migrated keystore is
+		// verified
+		if err := cl.verifyMasterKey(password); err != nil {
+			return errors.New("verify migrated keystore failed, " +
+				err.Error() + ", keystore of version 1 is kept in " + backupPath)
+		}
+		if err := os.Remove(backupPath); err != nil {
+			return errors.New("remove keystore backup failed, " +
+				err.Error() + ", delete " + backupPath + " manually")
+		}
+
+	case KeystoreVersion:
+		params, err := cl.LoadKDFParams()
+		if err != nil {
+			return err
+		}
+		cl.masterKey, err = openMasterKey(encryptedMasterKey, params, password)
+		if err != nil {
+			return err
+		}
+
+	default:
+		return errors.New("unsupported keystore version " + string(version))
+	}
+
+	return nil
+}
+
+// verifyMasterKey checks the keystore file is of the current version and
+// its master key decrypted by password is the master key in use.
+func (cl *Client) verifyMasterKey(password []byte) error {
+	version, err := cl.LoadStoredData("Version")
+	if err != nil {
+		return err
+	}
+	if string(version) != KeystoreVersion {
+		return errors.New("unexpected keystore version " + string(version))
+	}
+	params, err := cl.LoadKDFParams()
+	if err != nil {
+		return err
+	}
+	encryptedMasterKey, err := cl.LoadStoredData("MasterKey")
+	if err != nil {
+		return err
+	}
+	masterKey, err := openMasterKey(encryptedMasterKey, params, password)
+	if err != nil {
+		return err
+	}
+	defer common.ClearBytes(masterKey)
+	if !bytes.Equal(masterKey, cl.masterKey) {
+		return errors.New("master key mismatch")
+	}
+	return nil
+}
+
+// ChangePassword encrypts the master key with the new password, the
+// encrypted private keys are not changed as the master key is kept.
+func (cl *Client) ChangePassword(password []byte) error {
+	return cl.saveMasterKey(password)
+}
+
+// ChangePassword changes the password of the wallet file.
+func ChangePassword(path string, oldPassword, newPassword []byte) error {
+	client := NewClient(path, oldPassword, false)
+	if client == nil {
+		return errors.New("open wallet failed")
+	}
+
+	return client.ChangePassword(newPassword)
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/crypto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testPassword      = []byte("password")
+	testWrongPassword = []byte("wrong")
+)
+
+func newTestDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func readFileData(t *testing.T, path string) *FileData {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fileData FileData
+	if err := json.Unmarshal(data, &fileData); err != nil {
+		t.Fatal(err)
+	}
+	return &fileData
+}
+
+func TestKeystore(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "keystore.dat")
+
+	client, err := Create(path, testPassword)
+	if !assert.NoError(t, err) {
+		return
+	}
+	main := client.GetMainAccount()
+	fileData := readFileData(t, path)
+	assert.Equal(t, KeystoreVersion, fileData.Version)
+	assert.Empty(t, fileData.PasswordHash)
+	if assert.NotNil(t, fileData.KDF) {
+		assert.Equal(t, KDFScrypt, fileData.KDF.Name)
+		assert.Equal(t, scryptN, fileData.KDF.N)
+	}
+
+	opened, err := Open(path, testPassword)
+	if assert.NoError(t, err) {
+		assert.Equal(t, client.masterKey, opened.masterKey)
+		assert.Equal(t, main.PrivateKey, opened.GetMainAccount().PrivateKey)
+	}
+	_, err = Open(path, testWrongPassword)
+	assert.Error(t, err)
+
+	// the master key is encrypted by the new password with a new salt
+	assert.NoError(t, ChangePassword(path, testPassword, testWrongPassword))
+	assert.NotEqual(t, fileData.KDF.Salt, readFileData(t, path).KDF.Salt)
+	_, err = Open(path, testPassword)
+	assert.Error(t, err)
+	opened, err = Open(path, testWrongPassword)
+	if assert.NoError(t, err) {
+		assert.Equal(t, main.PrivateKey, opened.GetMainAccount().PrivateKey)
+	}
+
+	// no temporary file is left
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+// writeV1Keystore rewrites the master key of the wallet in keystore version 1.
+func writeV1Keystore(t *testing.T, client *Client, password []byte) []byte {
+	fileData := readFileData(t, client.path)
+	passwordKey := crypto.ToAesKey(password)
+	passwordHash := sha256.Sum256(passwordKey)
+	masterKey, err := crypto.AesEncrypt(client.masterKey, passwordKey, client.iv)
+	assert.NoError(t, err)
+	fileData.Version = KeystoreVersion1
+	fileData.KDF = nil
+	fileData.PasswordHash = common.BytesToHexString(passwordHash[:])
+	fileData.MasterKey = common.BytesToHexString(masterKey)
+
+	data, err := json.Marshal(fileData)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(client.path, data, 0600))
+	return data
+}
+
+func TestKeystore_Migration(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "keystore.dat")
+	backupPath := path + v1BackupSuffix
+
+	client, err := Create(path, testPassword)
+	if !assert.NoError(t, err) {
+		return
+	}
+	main := client.GetMainAccount()
+	v1Data := writeV1Keystore(t, client, testPassword)
+
+	// the keystore is not changed by a wrong password
+	_, err = Open(path, testWrongPassword)
+	assert.Error(t, err)
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, v1Data, data)
+	_, err = os.Stat(backupPath)
+	assert.True(t, os.IsNotExist(err))
+
+	// the keystore is migrated after backed up, and the backup holding the
+	// weak password hash is removed after the migration is verified
+	opened, err := Open(path, testPassword)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, main.PrivateKey, opened.GetMainAccount().PrivateKey)
+	_, err = os.Stat(backupPath)
+	assert.True(t, os.IsNotExist(err))
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	fileData := readFileData(t, path)
+	assert.Equal(t, KeystoreVersion, fileData.Version)
+	assert.Empty(t, fileData.PasswordHash)
+	assert.NotNil(t, fileData.KDF)
+
+	// the migrated keystore is opened by the password only
+	opened, err = Open(path, testPassword)
+	if assert.NoError(t, err) {
+		assert.Equal(t, main.PrivateKey, opened.GetMainAccount().PrivateKey)
+	}
+	_, err = Open(path, testWrongPassword)
+	assert.Error(t, err)
+
+	// the backup left by a failed migration is never overwritten
+	assert.NoError(t, ioutil.WriteFile(backupPath, []byte("backup"), 0600))
+	v1Data = writeV1Keystore(t, client, testPassword)
+	_, err = Open(path, testPassword)
+	assert.Error(t, err)
+	data, err = ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, v1Data, data)
+	backup, err := ioutil.ReadFile(backupPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("backup"), backup)
+}
+
+func TestKDFParams_DeriveKey(t *testing.T) {
+	valid := KDFParams{
+		Name:   KDFScrypt,
+		Salt:   "0102",
+		N:      1 << 10,
+		R:      8,
+		P:      1,
+		KeyLen: kdfKeyLength,
+	}
+	key, err := valid.DeriveKey(testPassword)
+	assert.NoError(t, err)
+	assert.Len(t, key, kdfKeyLength)
+
+	for _, modify := range []func(p *KDFParams){
+		func(p *KDFParams) { p.N = 0 },
+		func(p *KDFParams) { p.N = 1 },
+		func(p *KDFParams) { p.N = 1000 },
+		func(p *KDFParams) { p.N = maxScryptN << 1 },
+		func(p *KDFParams) { p.N = -1 << 10 },
+		func(p *KDFParams) { p.R = 0 },
+		func(p *KDFParams) { p.R = maxScryptR + 1 },
+		func(p *KDFParams) { p.P = 0 },
+		func(p *KDFParams) { p.P = maxScryptP + 1 },
+		func(p *KDFParams) { p.N, p.R = maxScryptN, maxScryptR },
+		func(p *KDFParams) { p.KeyLen = 16 },
+		func(p *KDFParams) { p.Salt = "" },
+		func(p *KDFParams) { p.Name = "pbkdf2" },
+	} {
+		params := valid
+		modify(&params)
+		_, err := params.DeriveKey(testPassword)
+		assert.Error(t, err, params)
+	}
+}
+
+func TestWriteFileAtomically(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "file")
+
+	assert.NoError(t, WriteFileAtomically(path, []byte("first")))
+	assert.NoError(t, WriteFileAtomically(path, []byte("second")))
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("second"), data)
+	info, err := os.Stat(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// the file is not replaced if the temporary file can not be renamed to
+	// it, and no temporary file is left
+	dirPath := filepath.Join(dir, "dir")
+	assert.NoError(t, os.MkdirAll(filepath.Join(dirPath, "child"), 0700))
+	assert.Error(t, WriteFileAtomically(dirPath, []byte("third")))
+	info, err = os.Stat(dirPath)
+	if assert.NoError(t, err) {
+		assert.True(t, info.IsDir())
+	}
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+}
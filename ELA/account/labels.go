@@ -0,0 +1,85 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+)
+
+// labelsFileSuffix is the suffix appended to the keystore file path to get
+// the path of the labels file.
+const labelsFileSuffix = ".labels"
+
+// Labels is the user defined labels of addresses and transactions, which is
+// saved in a side file of the keystore file, so it can be read and written
+// without password.
+type Labels struct {
+	Addresses    map[string]string
+	Transactions map[string]string
+}
+
+// LabelsPath returns the path of the labels file of the keystore file.
+func LabelsPath(walletPath string) string {
+	return walletPath + labelsFileSuffix
+}
+
+// LoadLabels loads the labels of the keystore file, empty labels are returned
+// if the labels file does not exist.
+func LoadLabels(walletPath string) (*Labels, error) {
+	labels := &Labels{
+		Addresses:    make(map[string]string),
+		Transactions: make(map[string]string),
+	}
+	data, err := ioutil.ReadFile(LabelsPath(walletPath))
+	if os.IsNotExist(err) {
+		return labels, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, labels); err != nil {
+		return nil, errors.New("invalid labels file")
+	}
+	if labels.Addresses == nil {
+		labels.Addresses = make(map[string]string)
+	}
+	if labels.Transactions == nil {
+		labels.Transactions = make(map[string]string)
+	}
+
+	return labels, nil
+}
+
+// Save saves the labels to the labels file of the keystore file.
+func (l *Labels) Save(walletPath string) error {
+	data, err := json.MarshalIndent(l, "", "\t")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomically(LabelsPath(walletPath), data)
+}
+
+// SetAddress sets the label of address, the label is removed if it's empty.
+func (l *Labels) SetAddress(address, label string) {
+	if label == "" {
+		delete(l.Addresses, address)
+		return
+	}
+	l.Addresses[address] = label
+}
+
+// SetTransaction sets the label of transaction, the label is removed if it's
+// empty.
+func (l *Labels) SetTransaction(txID, label string) {
+	if label == "" {
+		delete(l.Transactions, txID)
+		return
+	}
+	l.Transactions[txID] = label
+}
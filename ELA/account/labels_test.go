@@ -0,0 +1,80 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabels(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	walletPath := filepath.Join(dir, "keystore.dat")
+	assert.Equal(t, walletPath+".labels", LabelsPath(walletPath))
+
+	// empty labels are returned before the labels file is created
+	labels, err := LoadLabels(walletPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, labels.Addresses)
+	assert.Empty(t, labels.Transactions)
+
+	const (
+		address = "EQ4QhsYRwuBbNBXc8BPW972xA9ANByKt6U"
+		txID    = "4cd1e5d1b5c1d8b6ba0ec6db0bb4a1e42c5a5a2c0e4e7e4b3e1c5f8bd4e3e6a7"
+	)
+	// the labels are escaped in the file
+	addressLabel := "savings, \"cold\"\n冷钱包 <main> & \\backup"
+	labels.SetAddress(address, addressLabel)
+	labels.SetTransaction(txID, "rent")
+	if !assert.NoError(t, labels.Save(walletPath)) {
+		return
+	}
+	info, err := os.Stat(LabelsPath(walletPath))
+	if assert.NoError(t, err) {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+	// the labels file is written atomically without temporary file left
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	loaded, err := LoadLabels(walletPath)
+	if assert.NoError(t, err) {
+		assert.Equal(t, labels, loaded)
+		assert.Equal(t, addressLabel, loaded.Addresses[address])
+	}
+
+	// an empty label removes the label
+	loaded.SetAddress(address, "")
+	loaded.SetTransaction(txID, "")
+	loaded.SetTransaction("unknown", "")
+	assert.Empty(t, loaded.Addresses)
+	assert.Empty(t, loaded.Transactions)
+	assert.NoError(t, loaded.Save(walletPath))
+	loaded, err = LoadLabels(walletPath)
+	if assert.NoError(t, err) {
+		assert.Empty(t, loaded.Addresses)
+		assert.Empty(t, loaded.Transactions)
+	}
+
+	// the maps are created if they are missing in the file
+	assert.NoError(t, ioutil.WriteFile(LabelsPath(walletPath), []byte("{}"), 0600))
+	loaded, err = LoadLabels(walletPath)
+	if assert.NoError(t, err) {
+		loaded.SetAddress(address, "label")
+		loaded.SetTransaction(txID, "label")
+	}
+
+	assert.NoError(t, ioutil.WriteFile(LabelsPath(walletPath), []byte("invalid"), 0600))
+	_, err = LoadLabels(walletPath)
+	assert.EqualError(t, err, "invalid labels file")
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// DefaultMnemonicWords is the default words count of a new mnemonic.
+	DefaultMnemonicWords = 12
+
+	// seedIterations is the PBKDF2 iterations to generate seed from mnemonic.
+	seedIterations = 2048
+
+	// SeedLength is the byte length of the seed generated from mnemonic.
+	SeedLength = 64
+)
+
+// wordIndexes is the reversed index of the english word list.
+var wordIndexes = func() map[string]int {
+	indexes := make(map[string]int, len(englishWords))
+	for i, w := range englishWords {
+		indexes[w] = i
+	}
+	return indexes
+}()
+
+// NewMnemonic creates a random mnemonic with the given words count, the words
+// count should be one of 12, 15, 18, 21 and 24.
+func NewMnemonic(words int) (string, error) {
+	if words < 12 || words > 24 || words%3 != 0 {
+		return "", errors.New("mnemonic words count should be one of 12, 15, 18, 21 and 24")
+	}
+
+	entropy := make([]byte, words/3*4)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+
+	return entropyToMnemonic(entropy), nil
+}
+
+// ValidateMnemonic checks the words and the checksum of the mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return errors.New("invalid mnemonic words count")
+	}
+
+	// Convert the words into entropy with checksum appended, each word
+	// represents 11 bits.
+	bits := make([]byte, (len(words)*11+7)/8)
+	for i, w := range words {
+		index, ok := wordIndexes[w]
+		if !ok {
+			return errors.New("invalid mnemonic word " + w)
+		}
+		for j := 0; j < 11; j++ {
+			if index&(1<<uint(10-j)) != 0 {
+				pos := i*11 + j
+				bits[pos/8] |= 1 << uint(7-pos%8)
+			}
+		}
+	}
+
+	entropyLen := len(words) / 3 * 4
+	if entropyToMnemonic(bits[:entropyLen]) != strings.Join(words, " ") {
+		return errors.New("invalid mnemonic checksum")
+	}
+
+	return nil
+}
+
+// MnemonicToSeed validates the mnemonic and generates the seed from it with
+// the passphrase.
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+passphrase),
+		seedIterations, SeedLength, sha512.New), nil
+}
+
+// entropyToMnemonic appends the checksum to entropy and converts each 11 bits
+// of it into a word.
+func entropyToMnemonic(entropy []byte) string {
+	hash := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), hash[0])
+
+	count := (len(entropy)*8 + len(entropy)/4) / 11
+	words := make([]string, count)
+	for i := 0; i < count; i++ {
+		index := 0
+		for j := 0; j < 11; j++ {
+			pos := i*11 + j
+			index <<= 1
+			if data[pos/8]&(1<<uint(7-pos%8)) != 0 {
+				index |= 1
+			}
+		}
+		words[i] = englishWords[index]
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMnemonicToSeed(t *testing.T) {
+	// test vectors of BIP39 with the passphrase "TREZOR"
+	vectors := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			"00000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			"80808080808080808080808080808080",
+			"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+			"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			"bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+		},
+	}
+	for _, v := range vectors {
+		entropy, err := common.HexStringToBytes(v.entropy)
+		assert.NoError(t, err)
+		assert.Equal(t, v.mnemonic, entropyToMnemonic(entropy))
+
+		seed, err := MnemonicToSeed(v.mnemonic, "TREZOR")
+		assert.NoError(t, err)
+		assert.Equal(t, v.seed, common.BytesToHexString(seed))
+
+		// extra spaces between words are ignored
+		seed, err = MnemonicToSeed("  "+strings.Replace(v.mnemonic, " ",
+			"   ", -1)+"\n", "TREZOR")
+		assert.NoError(t, err)
+		assert.Equal(t, v.seed, common.BytesToHexString(seed))
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		mnemonic, err := NewMnemonic(words)
+		assert.NoError(t, err)
+		assert.Len(t, strings.Fields(mnemonic), words)
+		assert.NoError(t, ValidateMnemonic(mnemonic))
+	}
+	for _, words := range []int{0, 11, 13, 27} {
+		_, err := NewMnemonic(words)
+		assert.Error(t, err)
+	}
+
+	abandon := strings.Repeat("abandon ", 11)
+	assert.EqualError(t, ValidateMnemonic(abandon+"abandon"),
+		"invalid mnemonic checksum")
+	assert.EqualError(t, ValidateMnemonic(abandon+"elastos"),
+		"invalid mnemonic word elastos")
+	assert.EqualError(t, ValidateMnemonic(abandon),
+		"invalid mnemonic words count")
+	assert.EqualError(t, ValidateMnemonic(abandon+"about about"),
+		"invalid mnemonic words count")
+	_, err := MnemonicToSeed(abandon+"abandon", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/crypto"
+)
+
+// DefaultPKCS11Tool is the command to use PKCS#11 tokens, which is the
+// pkcs11-tool of OpenSC.
+const DefaultPKCS11Tool = "pkcs11-tool"
+
+// PKCS11Signer is a Signer of the P-256 keys in a PKCS#11 token, such as a
+// hardware security module or a smart card. The token is used through the
+// pkcs11-tool command, so no PKCS#11 binding is linked into the wallet, and
+// the user PIN is asked by the command on the terminal for each signing.
+type PKCS11Signer struct {
+	// Command is the pkcs11-tool command and its leading arguments.
+	Command []string
+
+	// Module is the path of the PKCS#11 module of the token.
+	Module string
+
+	// Slot is the ID of the token slot, the first slot with a token is used
+	// if it's empty.
+	Slot string
+
+	// KeyIDs are the IDs of the key objects in hex string format.
+	KeyIDs []string
+
+	mu   sync.Mutex
+	keys map[string]string
+}
+
+// ParsePKCS11Signer creates a PKCS11Signer from the address in format of
+// "<module>?id=<key id>[&id=<key id>][&slot=<slot id>]".
+func ParsePKCS11Signer(address string) (*PKCS11Signer, error) {
+	parts := strings.SplitN(address, "?", 2)
+	if parts[0] == "" {
+		return nil, errors.New("no PKCS#11 module specified")
+	}
+	var query url.Values
+	if len(parts) == 2 {
+		var err error
+		query, err = url.ParseQuery(parts[1])
+		if err != nil {
+			return nil, errors.New("invalid PKCS#11 signer address: " + address)
+		}
+	}
+	keyIDs := query["id"]
+	if len(keyIDs) == 0 {
+		return nil, errors.New("no PKCS#11 key id specified")
+	}
+	for _, id := range keyIDs {
+		if _, err := common.HexStringToBytes(id); err != nil || id == "" {
+			return nil, errors.New("invalid PKCS#11 key id " + id)
+		}
+	}
+
+	return &PKCS11Signer{
+		Command: []string{DefaultPKCS11Tool},
+		Module:  parts[0],
+		Slot:    query.Get("slot"),
+		KeyIDs:  keyIDs,
+	}, nil
+}
+
+// PublicKeys returns the public keys of the key objects.
+func (s *PKCS11Signer) PublicKeys() ([]*crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.publicKeys()
+}
+
+func (s *PKCS11Signer) publicKeys() ([]*crypto.PublicKey, error) {
+	keys := make(map[string]string)
+	pubKeys := make([]*crypto.PublicKey, 0, len(s.KeyIDs))
+	for _, id := range s.KeyIDs {
+		keyData, err := s.run(nil, "--read-object", "--type", "pubkey",
+			"--id", id)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := parsePKCS11PublicKey(keyData)
+		if err != nil {
+			return nil, fmt.Errorf("PKCS#11 key %s: %s", id, err)
+		}
+		key, err := pubKey.EncodePoint(true)
+		if err != nil {
+			return nil, err
+		}
+		keys[common.BytesToHexString(key)] = id
+		pubKeys = append(pubKeys, pubKey)
+	}
+	s.keys = keys
+	return pubKeys, nil
+}
+
+// Sign signs the SHA-256 digest of data by the ECDSA mechanism of the token.
+func (s *PKCS11Signer) Sign(pubKey *crypto.PublicKey, data []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys == nil {
+		if _, err := s.publicKeys(); err != nil {
+			return nil, err
+		}
+	}
+	key, err := pubKey.EncodePoint(true)
+	if err != nil {
+		return nil, err
+	}
+	id, ok := s.keys[common.BytesToHexString(key)]
+	if !ok {
+		return nil, errors.New("unknown public key " +
+			common.BytesToHexString(key))
+	}
+
+	digest := sha256.Sum256(data)
+	signature, err := s.run(digest[:], "--sign", "--login",
+		"--mechanism", "ECDSA", "--signature-format", "rs", "--id", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("unexpected signature length %d", len(signature))
+	}
+	return signature, nil
+}
+
+// Close does nothing, the command exits after each operation.
+func (s *PKCS11Signer) Close() error {
+	return nil
+}
+
+// run runs the pkcs11-tool command with the input and returns the output of
+// it, which are passed by temporary files so stdin and stdout are left for
+// the PIN prompt.
+func (s *PKCS11Signer) run(input []byte, args ...string) ([]byte, error) {
+	if len(s.Command) == 0 {
+		return nil, errors.New("no PKCS#11 tool specified")
+	}
+	dir, err := ioutil.TempDir("", "ela-pkcs11")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	outputPath := filepath.Join(dir, "output")
+	cmdArgs := append(s.Command[1:len(s.Command):len(s.Command)],
+		"--module", s.Module)
+	if s.Slot != "" {
+		cmdArgs = append(cmdArgs, "--slot", s.Slot)
+	}
+	cmdArgs = append(cmdArgs, args...)
+	if input != nil {
+		inputPath := filepath.Join(dir, "input")
+		if err := ioutil.WriteFile(inputPath, input, 0600); err != nil {
+			return nil, err
+		}
+		cmdArgs = append(cmdArgs, "--input-file", inputPath)
+	}
+	cmdArgs = append(cmdArgs, "--output-file", outputPath)
+
+	cmd := exec.Command(s.Command[0], cmdArgs...)
+	cmd.Stdin = os.Stdin
+	// stdout of the wallet may carry the result, so the messages of the
+	// command are printed to stderr
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %s", s.Command[0], err)
+	}
+	return ioutil.ReadFile(outputPath)
+}
+
+// parsePKCS11PublicKey parses the public key read from a token, which is a
+// DER encoded SubjectPublicKeyInfo, or the CKA_EC_POINT attribute of the
+// key object by older versions of pkcs11-tool.
+func parsePKCS11PublicKey(data []byte) (*crypto.PublicKey, error) {
+	if key, err := x509.ParsePKIXPublicKey(data); err == nil {
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || ecKey.Curve != crypto.DefaultCurve {
+			return nil, errors.New("not a P-256 public key")
+		}
+		return &crypto.PublicKey{X: ecKey.X, Y: ecKey.Y}, nil
+	}
+
+	// CKA_EC_POINT is the encoded point wrapped in a DER octet string
+	var point []byte
+	if rest, err := asn1.Unmarshal(data, &point); err != nil || len(rest) != 0 {
+		point = data
+	}
+	pubKey, err := crypto.DecodePoint(point)
+	if err != nil {
+		return nil, errors.New("invalid public key")
+	}
+	if !crypto.DefaultCurve.IsOnCurve(pubKey.X, pubKey.Y) {
+		return nil, errors.New("not a P-256 public key")
+	}
+	return pubKey, nil
+}
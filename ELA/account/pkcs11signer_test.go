@@ -0,0 +1,245 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/crypto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPKCS11Module = "/usr/lib/test-pkcs11.so"
+
+// TestHelperPKCS11Tool is not a real test, it acts as pkcs11-tool with the
+// keys in environment, when the test binary is started as the command by the
+// tests. The public key of ID 01 is read as SubjectPublicKeyInfo, and the
+// others are read as CKA_EC_POINT.
+func TestHelperPKCS11Tool(t *testing.T) {
+	keys := os.Getenv("ELA_TEST_PKCS11_KEYS")
+	if keys == "" {
+		return
+	}
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	options := make(map[string]string)
+	for i := 1; i < len(args); i++ {
+		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+			options[args[i]] = args[i+1]
+			i++
+		} else {
+			options[args[i]] = ""
+		}
+	}
+	fail := func(msg string) {
+		os.Stderr.WriteString("error: " + msg + "\n")
+		os.Exit(1)
+	}
+	if options["--module"] != testPKCS11Module {
+		fail("invalid module")
+	}
+
+	var privateKey *ecdsa.PrivateKey
+	for _, key := range strings.Split(keys, ",") {
+		parts := strings.SplitN(key, "=", 2)
+		if parts[0] == options["--id"] {
+			keyBytes, _ := common.HexStringToBytes(parts[1])
+			privateKey = new(ecdsa.PrivateKey)
+			privateKey.Curve = crypto.DefaultCurve
+			privateKey.D = new(big.Int).SetBytes(keyBytes)
+			privateKey.X, privateKey.Y = crypto.DefaultCurve.ScalarBaseMult(keyBytes)
+		}
+	}
+	if privateKey == nil {
+		fail("object not found")
+	}
+
+	var output []byte
+	switch {
+	case options["--type"] == "pubkey":
+		if _, ok := options["--read-object"]; !ok {
+			fail("invalid operation")
+		}
+		var err error
+		if options["--id"] == "01" {
+			output, err = x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		} else {
+			pubKey := &crypto.PublicKey{X: privateKey.X, Y: privateKey.Y}
+			point, _ := pubKey.EncodePoint(false)
+			output, err = asn1.Marshal(point)
+		}
+		if err != nil {
+			fail(err.Error())
+		}
+
+	case options["--mechanism"] == "ECDSA":
+		_, sign := options["--sign"]
+		_, login := options["--login"]
+		if !sign || !login || options["--signature-format"] != "rs" {
+			fail("invalid operation")
+		}
+		digest, err := ioutil.ReadFile(options["--input-file"])
+		if err != nil || len(digest) != 32 {
+			fail("invalid input")
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest)
+		if err != nil {
+			fail(err.Error())
+		}
+		output = make([]byte, crypto.SignatureLength)
+		rBytes, sBytes := r.Bytes(), s.Bytes()
+		copy(output[32-len(rBytes):], rBytes)
+		copy(output[64-len(sBytes):], sBytes)
+
+	default:
+		fail("invalid operation")
+	}
+
+	if err := ioutil.WriteFile(options["--output-file"], output, 0600); err != nil {
+		fail(err.Error())
+	}
+	os.Exit(0)
+}
+
+func TestParsePKCS11Signer(t *testing.T) {
+	signer, err := ParsePKCS11Signer(testPKCS11Module + "?id=01&id=a0b1&slot=2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{DefaultPKCS11Tool}, signer.Command)
+		assert.Equal(t, testPKCS11Module, signer.Module)
+		assert.Equal(t, "2", signer.Slot)
+		assert.Equal(t, []string{"01", "a0b1"}, signer.KeyIDs)
+	}
+	signer, err = ParsePKCS11Signer(testPKCS11Module + "?id=01")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "", signer.Slot)
+	}
+
+	for _, test := range []struct {
+		address string
+		err     string
+	}{
+		{"", "no PKCS#11 module specified"},
+		{"?id=01", "no PKCS#11 module specified"},
+		{testPKCS11Module, "no PKCS#11 key id specified"},
+		{testPKCS11Module + "?slot=1", "no PKCS#11 key id specified"},
+		{testPKCS11Module + "?id=", "invalid PKCS#11 key id "},
+		{testPKCS11Module + "?id=xyz", "invalid PKCS#11 key id xyz"},
+		{testPKCS11Module + "?id=%zz", "invalid PKCS#11 signer address: " +
+			testPKCS11Module + "?id=%zz"},
+	} {
+		_, err := ParsePKCS11Signer(test.address)
+		assert.EqualError(t, err, test.err, test.address)
+	}
+}
+
+func TestPKCS11Signer(t *testing.T) {
+	acc1 := newTestAccount(t)
+	acc2 := newTestAccount(t)
+	os.Setenv("ELA_TEST_PKCS11_KEYS",
+		"01="+common.BytesToHexString(acc1.PrivateKey)+
+			",02="+common.BytesToHexString(acc2.PrivateKey))
+	defer os.Unsetenv("ELA_TEST_PKCS11_KEYS")
+
+	// the test binary is started as pkcs11-tool
+	newSigner := func(keyIDs ...string) *PKCS11Signer {
+		return &PKCS11Signer{
+			Command: []string{os.Args[0], "-test.run=^TestHelperPKCS11Tool$", "--"},
+			Module:  testPKCS11Module,
+			KeyIDs:  keyIDs,
+		}
+	}
+
+	signer := newSigner("01", "02")
+	pubKeys, err := signer.PublicKeys()
+	if assert.NoError(t, err) && assert.Len(t, pubKeys, 2) {
+		assert.Equal(t, encodeTestPublicKey(t, acc1.PublicKey),
+			encodeTestPublicKey(t, pubKeys[0]))
+		assert.Equal(t, encodeTestPublicKey(t, acc2.PublicKey),
+			encodeTestPublicKey(t, pubKeys[1]))
+	}
+
+	// the public keys are read before the first signing
+	data := []byte("data to sign")
+	for _, signer := range []*PKCS11Signer{signer, newSigner("01", "02")} {
+		for _, acc := range []*Account{acc1, acc2} {
+			signature, err := signer.Sign(acc.PublicKey, data)
+			if assert.NoError(t, err) {
+				assert.NoError(t, crypto.Verify(*acc.PublicKey, data, signature))
+			}
+		}
+	}
+
+	// the accounts of the token sign through the client
+	acc, err := NewAccountWithSigner(acc2.PublicKey, signer)
+	if assert.NoError(t, err) {
+		signature, err := acc.Sign(data)
+		if assert.NoError(t, err) {
+			assert.NoError(t, crypto.Verify(*acc2.PublicKey, data, signature))
+		}
+	}
+	assert.NoError(t, signer.Close())
+
+	other := newTestAccount(t)
+	_, err = signer.Sign(other.PublicKey, data)
+	assert.EqualError(t, err, "unknown public key "+
+		encodeTestPublicKey(t, other.PublicKey))
+	_, err = newSigner("03").PublicKeys()
+	assert.EqualError(t, err, os.Args[0]+": exit status 1")
+	signer = newSigner("01")
+	signer.Module = "/usr/lib/other.so"
+	_, err = signer.PublicKeys()
+	assert.EqualError(t, err, os.Args[0]+": exit status 1")
+	_, err = (&PKCS11Signer{Module: testPKCS11Module, KeyIDs: []string{"01"}}).
+		PublicKeys()
+	assert.EqualError(t, err, "no PKCS#11 tool specified")
+}
+
+func TestParsePKCS11PublicKey(t *testing.T) {
+	acc := newTestAccount(t)
+	key := encodeTestPublicKey(t, acc.PublicKey)
+	ecKey := &ecdsa.PublicKey{Curve: crypto.DefaultCurve, X: acc.PublicKey.X,
+		Y: acc.PublicKey.Y}
+	spki, err := x509.MarshalPKIXPublicKey(ecKey)
+	assert.NoError(t, err)
+	point, err := acc.PublicKey.EncodePoint(false)
+	assert.NoError(t, err)
+	ecPoint, err := asn1.Marshal(point)
+	assert.NoError(t, err)
+
+	// SubjectPublicKeyInfo, CKA_EC_POINT and the raw point are accepted
+	for _, data := range [][]byte{spki, ecPoint, point} {
+		pubKey, err := parsePKCS11PublicKey(data)
+		if assert.NoError(t, err) {
+			assert.Equal(t, key, encodeTestPublicKey(t, pubKey))
+		}
+	}
+
+	// other curves and points not on curve are rejected
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	spki, err = x509.MarshalPKIXPublicKey(&p384.PublicKey)
+	assert.NoError(t, err)
+	_, err = parsePKCS11PublicKey(spki)
+	assert.EqualError(t, err, "not a P-256 public key")
+	point[64] ^= 1
+	_, err = parsePKCS11PublicKey(point)
+	assert.EqualError(t, err, "not a P-256 public key")
+	_, err = parsePKCS11PublicKey([]byte("invalid"))
+	assert.EqualError(t, err, "invalid public key")
+}
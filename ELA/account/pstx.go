@@ -0,0 +1,509 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/contract"
+	pg "github.com/elastos/Elastos.ELA/core/contract/program"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/crypto"
+	"github.com/elastos/Elastos.ELA/vm"
+)
+
+// PSTxVersion is the version of the partially signed transaction format.
+const PSTxVersion = 1
+
+// PartiallySignedTx is a container of a transaction which is signed by
+// signers on different machines.  It carries everything a signer needs to
+// check and sign the transaction offline, and collects the signatures of each
+// program until the transaction can be finalized.
+type PartiallySignedTx struct {
+	Version int
+
+	// Transaction is the hex string of the transaction without signatures.
+	Transaction string
+
+	// Inputs contains the referenced transaction of each input in the
+	// transaction by order.
+	Inputs []*PSTxInput
+
+	// Programs contains the redeem script and signatures of each program in
+	// the transaction by order.
+	Programs []*PSTxProgram
+}
+
+// PSTxInput carries the transaction referenced by an input, so the signers
+// can verify the amount spent by the input.
+type PSTxInput struct {
+	PreviousTx string
+}
+
+// PSTxProgram carries the redeem script, derivation hints of the signers and
+// the collected signatures of a program.  Keys of HDPaths and Signatures are
+// the hex string of compressed public keys.
+type PSTxProgram struct {
+	Code       string
+	HDPaths    map[string]string `json:",omitempty"`
+	Signatures map[string]string `json:",omitempty"`
+}
+
+// NewPartiallySignedTx creates a partially signed transaction from the
+// transaction and the referenced transactions of its inputs.  Signatures
+// already in the programs of the transaction are collected.
+func NewPartiallySignedTx(txn *types.Transaction,
+	prevTxs []*types.Transaction) (*PartiallySignedTx, error) {
+	if len(txn.Programs) == 0 {
+		return nil, errors.New("no program found in transaction")
+	}
+	if len(prevTxs) != len(txn.Inputs) {
+		return nil, errors.New("referenced transactions count not match inputs")
+	}
+
+	p := &PartiallySignedTx{Version: PSTxVersion}
+	for i, prevTx := range prevTxs {
+		if prevTx.Hash() != txn.Inputs[i].Previous.TxID {
+			return nil, fmt.Errorf("referenced transaction of input %d not match", i)
+		}
+		buf := new(bytes.Buffer)
+		if err := prevTx.Serialize(buf); err != nil {
+			return nil, err
+		}
+		p.Inputs = append(p.Inputs, &PSTxInput{
+			PreviousTx: common.BytesToHexString(buf.Bytes()),
+		})
+	}
+
+	data, err := getUnsignedData(txn)
+	if err != nil {
+		return nil, err
+	}
+	programs := txn.Programs
+	txn.Programs = make([]*pg.Program, 0, len(programs))
+	for _, program := range programs {
+		txn.Programs = append(txn.Programs, &pg.Program{Code: program.Code})
+		psp := &PSTxProgram{
+			Code:       common.BytesToHexString(program.Code),
+			HDPaths:    make(map[string]string),
+			Signatures: make(map[string]string),
+		}
+		if err := psp.collectSignatures(program.Parameter, data); err != nil {
+			return nil, err
+		}
+		p.Programs = append(p.Programs, psp)
+	}
+
+	buf := new(bytes.Buffer)
+	err = txn.Serialize(buf)
+	txn.Programs = programs
+	if err != nil {
+		return nil, err
+	}
+	p.Transaction = common.BytesToHexString(buf.Bytes())
+
+	return p, nil
+}
+
+// Tx returns the transaction without signatures.
+func (p *PartiallySignedTx) Tx() (*types.Transaction, error) {
+	data, err := common.HexStringToBytes(p.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	var txn types.Transaction
+	if err := txn.Deserialize(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return &txn, nil
+}
+
+// PreviousTxs returns the referenced transactions of the inputs in the
+// transaction by order.
+func (p *PartiallySignedTx) PreviousTxs() ([]*types.Transaction, error) {
+	prevTxs := make([]*types.Transaction, 0, len(p.Inputs))
+	for _, input := range p.Inputs {
+		data, err := common.HexStringToBytes(input.PreviousTx)
+		if err != nil {
+			return nil, err
+		}
+		var prevTx types.Transaction
+		if err := prevTx.Deserialize(bytes.NewReader(data)); err != nil {
+			return nil, err
+		}
+		prevTxs = append(prevTxs, &prevTx)
+	}
+	return prevTxs, nil
+}
+
+// ReferencedOutputs returns the outputs referenced by the inputs of the
+// transaction by order, the referenced transactions are checked by hash.
+func (p *PartiallySignedTx) ReferencedOutputs() ([]*types.Output, error) {
+	txn, err := p.Tx()
+	if err != nil {
+		return nil, err
+	}
+	prevTxs, err := p.PreviousTxs()
+	if err != nil {
+		return nil, err
+	}
+	return ReferencedOutputs(txn, prevTxs)
+}
+
+// ReferencedOutputs returns the outputs referenced by the inputs of the
+// transaction by order from the referenced transactions of the inputs, which
+// are checked by hash, so the amount spent by each input can be trusted.
+func ReferencedOutputs(txn *types.Transaction,
+	prevTxs []*types.Transaction) ([]*types.Output, error) {
+	if len(prevTxs) != len(txn.Inputs) {
+		return nil, errors.New("referenced transactions count not match inputs")
+	}
+
+	outputs := make([]*types.Output, 0, len(txn.Inputs))
+	for i, input := range txn.Inputs {
+		prevTx := prevTxs[i]
+		if prevTx.Hash() != input.Previous.TxID {
+			return nil, fmt.Errorf("referenced transaction of input %d not match", i)
+		}
+		if int(input.Previous.Index) >= len(prevTx.Outputs) {
+			return nil, fmt.Errorf("referenced output of input %d not found", i)
+		}
+		outputs = append(outputs, prevTx.Outputs[input.Previous.Index])
+	}
+
+	return outputs, nil
+}
+
+// Verify checks the programs match the transaction and all signatures
+// collected are valid.
+func (p *PartiallySignedTx) Verify() error {
+	txn, err := p.Tx()
+	if err != nil {
+		return err
+	}
+	if len(p.Programs) != len(txn.Programs) {
+		return errors.New("programs count not match transaction")
+	}
+	data, err := getUnsignedData(txn)
+	if err != nil {
+		return err
+	}
+	for i, psp := range p.Programs {
+		code, err := common.HexStringToBytes(psp.Code)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(code, txn.Programs[i].Code) {
+			return fmt.Errorf("program %d not match transaction", i)
+		}
+		for publicKey, signature := range psp.Signatures {
+			if err := psp.verifySignature(publicKey, signature, data); err != nil {
+				return fmt.Errorf("program %d: %s", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// SignStatus returns the count of signatures collected and needed by the
+// program of the index.
+func (p *PartiallySignedTx) SignStatus(index int) (int, int, error) {
+	psp := p.Programs[index]
+	code, err := common.HexStringToBytes(psp.Code)
+	if err != nil {
+		return 0, 0, err
+	}
+	signType, err := crypto.GetScriptType(code)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	switch signType {
+	case vm.CHECKSIG:
+		return len(psp.Signatures), 1, nil
+	case vm.CHECKMULTISIG:
+		m, err := crypto.GetM(code)
+		if err != nil {
+			return 0, 0, err
+		}
+		return len(psp.Signatures), int(m), nil
+	default:
+		return 0, 0, errors.New("unsupported program type")
+	}
+}
+
+// Combine merges the signatures and derivation hints of another partially
+// signed transaction of the same transaction.
+func (p *PartiallySignedTx) Combine(other *PartiallySignedTx) error {
+	if p.Transaction != other.Transaction {
+		return errors.New("can not combine different transactions")
+	}
+	if len(p.Programs) != len(other.Programs) {
+		return errors.New("programs count not match")
+	}
+	if err := other.Verify(); err != nil {
+		return err
+	}
+
+	for i, psp := range p.Programs {
+		for publicKey, path := range other.Programs[i].HDPaths {
+			if psp.HDPaths == nil {
+				psp.HDPaths = make(map[string]string)
+			}
+			psp.HDPaths[publicKey] = path
+		}
+		for publicKey, signature := range other.Programs[i].Signatures {
+			if psp.Signatures == nil {
+				psp.Signatures = make(map[string]string)
+			}
+			psp.Signatures[publicKey] = signature
+		}
+	}
+	return nil
+}
+
+// Finalize puts the collected signatures into the programs and returns the
+// transaction ready to send.
+func (p *PartiallySignedTx) Finalize() (*types.Transaction, error) {
+	if err := p.Verify(); err != nil {
+		return nil, err
+	}
+	txn, err := p.Tx()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, psp := range p.Programs {
+		haveSign, needSign, err := p.SignStatus(i)
+		if err != nil {
+			return nil, err
+		}
+		if haveSign < needSign {
+			return nil, fmt.Errorf("program %d needs %d signatures, got %d",
+				i, needSign, haveSign)
+		}
+
+		publicKeys, err := psp.PublicKeys()
+		if err != nil {
+			return nil, err
+		}
+		buf := new(bytes.Buffer)
+		count := 0
+		for _, publicKey := range publicKeys {
+			signature, ok := psp.Signatures[common.BytesToHexString(publicKey)]
+			if !ok || count == needSign {
+				continue
+			}
+			sig, err := common.HexStringToBytes(signature)
+			if err != nil {
+				return nil, err
+			}
+			buf.WriteByte(byte(len(sig)))
+			buf.Write(sig)
+			count++
+		}
+		txn.Programs[i].Parameter = buf.Bytes()
+	}
+
+	return txn, nil
+}
+
+// AddHDPaths adds the derivation paths of the HD accounts in wallet as hints
+// for the signers.
+func (p *PartiallySignedTx) AddHDPaths(storeAccounts []AccountData) error {
+	for _, a := range storeAccounts {
+		if a.HDPath == "" {
+			continue
+		}
+		code, err := common.HexStringToBytes(a.RedeemScript)
+		if err != nil {
+			return err
+		}
+		if len(code) != crypto.PublicKeyScriptLength {
+			continue
+		}
+		publicKey := code[1 : len(code)-1]
+		for _, psp := range p.Programs {
+			publicKeys, err := psp.PublicKeys()
+			if err != nil {
+				return err
+			}
+			for _, pk := range publicKeys {
+				if !bytes.Equal(pk, publicKey) {
+					continue
+				}
+				if psp.HDPaths == nil {
+					psp.HDPaths = make(map[string]string)
+				}
+				psp.HDPaths[common.BytesToHexString(pk)] = a.HDPath
+			}
+		}
+	}
+	return nil
+}
+
+// SignPSTx adds the signatures of accounts in wallet to the partially signed
+// transaction, returns the count of signatures added.
+func (cl *Client) SignPSTx(p *PartiallySignedTx) (int, error) {
+	if err := p.Verify(); err != nil {
+		return 0, err
+	}
+	txn, err := p.Tx()
+	if err != nil {
+		return 0, err
+	}
+	data, err := getUnsignedData(txn)
+	if err != nil {
+		return 0, err
+	}
+	// the referenced transactions are sent to the external signers, so they
+	// can show the amount spent and the fee before signing
+	prevTxs, err := p.PreviousTxs()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := ReferencedOutputs(txn, prevTxs); err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, psp := range p.Programs {
+		publicKeys, err := psp.PublicKeys()
+		if err != nil {
+			return count, err
+		}
+		for _, publicKey := range publicKeys {
+			key := common.BytesToHexString(publicKey)
+			if _, ok := psp.Signatures[key]; ok {
+				continue
+			}
+			codeHash, err := contract.PublicKeyToStandardCodeHash(publicKey)
+			if err != nil {
+				return count, err
+			}
+			acc := cl.GetAccountByCodeHash(*codeHash)
+			if acc == nil || !acc.CanSign() {
+				continue
+			}
+			signature, err := acc.SignTransaction(data, prevTxs)
+			if err != nil {
+				return count, err
+			}
+			if psp.Signatures == nil {
+				psp.Signatures = make(map[string]string)
+			}
+			psp.Signatures[key] = common.BytesToHexString(signature)
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// PublicKeys returns the compressed public keys of the signers of program.
+func (psp *PSTxProgram) PublicKeys() ([][]byte, error) {
+	code, err := common.HexStringToBytes(psp.Code)
+	if err != nil {
+		return nil, err
+	}
+	signType, err := crypto.GetScriptType(code)
+	if err != nil {
+		return nil, err
+	}
+
+	switch signType {
+	case vm.CHECKSIG:
+		if len(code) != crypto.PublicKeyScriptLength {
+			return nil, errors.New("invalid standard redeem script")
+		}
+		return [][]byte{code[1 : len(code)-1]}, nil
+	case vm.CHECKMULTISIG:
+		scripts, err := crypto.ParseMultisigScript(code)
+		if err != nil {
+			return nil, err
+		}
+		publicKeys := make([][]byte, 0, len(scripts))
+		for _, script := range scripts {
+			publicKeys = append(publicKeys, script[1:])
+		}
+		return publicKeys, nil
+	default:
+		return nil, errors.New("unsupported program type")
+	}
+}
+
+// collectSignatures matches the signatures in parameter with the public keys
+// of program.
+func (psp *PSTxProgram) collectSignatures(parameter, data []byte) error {
+	if len(parameter)%crypto.SignatureScriptLength != 0 {
+		return errors.New("invalid signatures length in program")
+	}
+	publicKeys, err := psp.PublicKeys()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(parameter); i += crypto.SignatureScriptLength {
+		signature := parameter[i+1 : i+crypto.SignatureScriptLength]
+		for _, publicKey := range publicKeys {
+			pubKey, err := crypto.DecodePoint(publicKey)
+			if err != nil {
+				return err
+			}
+			if crypto.Verify(*pubKey, data, signature) == nil {
+				psp.Signatures[common.BytesToHexString(publicKey)] =
+					common.BytesToHexString(signature)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (psp *PSTxProgram) verifySignature(publicKey, signature string,
+	data []byte) error {
+	publicKeys, err := psp.PublicKeys()
+	if err != nil {
+		return err
+	}
+	pk, err := common.HexStringToBytes(publicKey)
+	if err != nil {
+		return err
+	}
+	var found bool
+	for _, key := range publicKeys {
+		if bytes.Equal(key, pk) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errors.New("public key " + publicKey + " is not a signer")
+	}
+
+	pubKey, err := crypto.DecodePoint(pk)
+	if err != nil {
+		return err
+	}
+	sig, err := common.HexStringToBytes(signature)
+	if err != nil {
+		return err
+	}
+	if err := crypto.Verify(*pubKey, data, sig); err != nil {
+		return errors.New("invalid signature of " + publicKey)
+	}
+	return nil
+}
+
+func getUnsignedData(txn *types.Transaction) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := txn.SerializeUnsigned(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/account"
+	"github.com/elastos/Elastos.ELA/blockchain"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/config"
+	pg "github.com/elastos/Elastos.ELA/core/contract/program"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/outputpayload"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/crypto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTransferTx returns a transfer transaction paying the value to the
+// program hash from the outpoints.
+func newTransferTx(programHash common.Uint168, value common.Fixed64,
+	outPoints ...types.OutPoint) *types.Transaction {
+	txn := &types.Transaction{
+		Version: types.TxVersion09,
+		TxType:  types.TransferAsset,
+		Payload: &payload.TransferAsset{},
+		Attributes: []*types.Attribute{{
+			Usage: types.Nonce,
+			Data:  []byte(strconv.Itoa(len(outPoints))),
+		}},
+		Outputs: []*types.Output{{
+			AssetID:     config.ELAAssetID,
+			Value:       value,
+			ProgramHash: programHash,
+			Type:        types.OTNone,
+			Payload:     &outputpayload.DefaultOutput{},
+		}},
+	}
+	for _, outPoint := range outPoints {
+		txn.Inputs = append(txn.Inputs, &types.Input{Previous: outPoint})
+	}
+	return txn
+}
+
+// copyPSTx returns a copy of the partially signed transaction through its
+// file format.
+func copyPSTx(t *testing.T, p *account.PartiallySignedTx) *account.PartiallySignedTx {
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pstx account.PartiallySignedTx
+	if err := json.Unmarshal(data, &pstx); err != nil {
+		t.Fatal(err)
+	}
+	return &pstx
+}
+
+func TestPartiallySignedTx(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pstx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// three signers of a 2 of 3 multi-signature account on different wallets
+	var signers []*account.Client
+	var publicKeys []*crypto.PublicKey
+	for i := 0; i < 3; i++ {
+		client, err := account.Create(filepath.Join(dir,
+			"keystore"+strconv.Itoa(i)+".dat"), []byte("password"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		signers = append(signers, client)
+		publicKeys = append(publicKeys, client.GetMainAccount().PublicKey)
+	}
+	multiSig, err := account.NewMultiSigAccount(2, publicKeys)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	prevTx := newTransferTx(multiSig.ProgramHash, 10*1e8)
+	txn := newTransferTx(signers[0].GetMainAccount().ProgramHash, 9*1e8,
+		types.OutPoint{TxID: prevTx.Hash(), Index: 0})
+	txn.Programs = []*pg.Program{{Code: multiSig.RedeemScript}}
+
+	_, err = account.NewPartiallySignedTx(txn, nil)
+	assert.Error(t, err)
+	_, err = account.NewPartiallySignedTx(txn, []*types.Transaction{txn})
+	assert.Error(t, err)
+	pstx, err := account.NewPartiallySignedTx(txn, []*types.Transaction{prevTx})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, account.PSTxVersion, pstx.Version)
+	data, err := json.Marshal(pstx)
+	assert.NoError(t, err)
+	copied, err := json.Marshal(copyPSTx(t, pstx))
+	assert.NoError(t, err)
+	assert.Equal(t, data, copied)
+	unsigned, err := pstx.Tx()
+	assert.NoError(t, err)
+	assert.Equal(t, txn.Hash(), unsigned.Hash())
+	outputs, err := pstx.ReferencedOutputs()
+	if assert.NoError(t, err) && assert.Len(t, outputs, 1) {
+		assert.Equal(t, prevTx.Outputs[0].Value, outputs[0].Value)
+		assert.Equal(t, multiSig.ProgramHash, outputs[0].ProgramHash)
+	}
+
+	// the signers sign copies of the transaction separately
+	var signed []*account.PartiallySignedTx
+	for _, signer := range signers {
+		p := copyPSTx(t, pstx)
+		count, err := signer.SignPSTx(p)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		haveSign, needSign, err := p.SignStatus(0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, haveSign)
+		assert.Equal(t, 2, needSign)
+		signed = append(signed, copyPSTx(t, p))
+	}
+	_, err = signed[0].Finalize()
+	assert.EqualError(t, err, "program 0 needs 2 signatures, got 1")
+
+	combined := copyPSTx(t, signed[0])
+	assert.NoError(t, combined.Combine(signed[2]))
+	haveSign, _, err := combined.SignStatus(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, haveSign)
+
+	// the finalized transaction is verified by blockchain
+	final, err := combined.Finalize()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, txn.Hash(), final.Hash())
+	buf := new(bytes.Buffer)
+	assert.NoError(t, final.SerializeUnsigned(buf))
+	assert.NoError(t, blockchain.RunPrograms(buf.Bytes(),
+		[]common.Uint168{multiSig.ProgramHash}, final.Programs))
+
+	// extra signatures are not put into the program
+	assert.NoError(t, combined.Combine(signed[1]))
+	haveSign, _, err = combined.SignStatus(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, haveSign)
+	final, err = combined.Finalize()
+	if assert.NoError(t, err) {
+		assert.Len(t, final.Programs[0].Parameter, 2*crypto.SignatureScriptLength)
+		assert.NoError(t, blockchain.RunPrograms(buf.Bytes(),
+			[]common.Uint168{multiSig.ProgramHash}, final.Programs))
+	}
+
+	// signatures in the transaction are collected
+	collected, err := account.NewPartiallySignedTx(final,
+		[]*types.Transaction{prevTx})
+	if assert.NoError(t, err) {
+		haveSign, _, err = collected.SignStatus(0)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, haveSign)
+		assert.Equal(t, pstx.Transaction, collected.Transaction)
+	}
+
+	// a signature of another signer is rejected
+	key0, err := signers[0].GetMainAccount().PublicKey.EncodePoint(true)
+	assert.NoError(t, err)
+	key1, err := signers[1].GetMainAccount().PublicKey.EncodePoint(true)
+	assert.NoError(t, err)
+	forged := copyPSTx(t, signed[0])
+	forged.Programs[0].Signatures[common.BytesToHexString(key0)] =
+		signed[1].Programs[0].Signatures[common.BytesToHexString(key1)]
+	assert.Error(t, combined.Combine(forged))
+	_, err = forged.Finalize()
+	assert.Error(t, err)
+
+	// a different transaction can not be combined
+	other := newTransferTx(multiSig.ProgramHash, 8*1e8,
+		types.OutPoint{TxID: prevTx.Hash(), Index: 0})
+	other.Programs = []*pg.Program{{Code: multiSig.RedeemScript}}
+	otherPSTx, err := account.NewPartiallySignedTx(other,
+		[]*types.Transaction{prevTx})
+	assert.NoError(t, err)
+	assert.EqualError(t, combined.Combine(otherPSTx),
+		"can not combine different transactions")
+
+	// the referenced transactions are checked before signing
+	tampered := copyPSTx(t, pstx)
+	tampered.Inputs[0].PreviousTx = otherPSTx.Transaction
+	_, err = signers[0].SignPSTx(tampered)
+	assert.EqualError(t, err, "referenced transaction of input 0 not match")
+}
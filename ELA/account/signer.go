@@ -0,0 +1,116 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"errors"
+
+	"github.com/elastos/Elastos.ELA/core/contract"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/crypto"
+)
+
+// Signer signs data by private keys kept outside of the keystore, such as in
+// another process, on another machine or in a hardware security module, so
+// the private keys never touch the disk of the wallet host.
+type Signer interface {
+	// PublicKeys returns the public keys of which the signer holds the
+	// private keys.
+	PublicKeys() ([]*crypto.PublicKey, error)
+
+	// Sign signs the data by the private key of the public key.
+	Sign(pubKey *crypto.PublicKey, data []byte) ([]byte, error)
+}
+
+// TransactionSigner is a Signer which also receives the referenced
+// transactions of the inputs when signing a transaction, so it can verify
+// the amount spent and show the fee to the user before signing.
+type TransactionSigner interface {
+	Signer
+
+	// SignTransaction signs the unsigned data of a transaction by the
+	// private key of the public key, prevTxs are the referenced transactions
+	// of the inputs by order.
+	SignTransaction(pubKey *crypto.PublicKey, data []byte,
+		prevTxs []*types.Transaction) ([]byte, error)
+}
+
+// SignerCloser is a Signer holding a connection or process to be closed
+// after use.
+type SignerCloser interface {
+	Signer
+
+	// Close releases the connection or process of the signer.
+	Close() error
+}
+
+// SetSigner adds the keys of the signer to the client, so the transactions
+// and payloads are signed by the signer for them. The accounts of the keys
+// are kept in memory only, and the accounts already having private keys in
+// keystore are still signed by the keystore.
+func (cl *Client) SetSigner(signer Signer) error {
+	pubKeys, err := signer.PublicKeys()
+	if err != nil {
+		return err
+	}
+	if len(pubKeys) == 0 {
+		return errors.New("no public key found in signer")
+	}
+
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	for _, pubKey := range pubKeys {
+		signatureContract, err := contract.CreateStandardContract(pubKey)
+		if err != nil {
+			return err
+		}
+		codeHash := *signatureContract.ToCodeHash()
+		if acc, ok := cl.accounts[codeHash]; ok {
+			if acc.PrivateKey == nil {
+				acc.signer = signer
+			}
+			continue
+		}
+		acc, err := NewAccountWithSigner(pubKey, signer)
+		if err != nil {
+			return err
+		}
+		cl.accounts[codeHash] = acc
+	}
+
+	return nil
+}
+
+// signBySigner signs the data by the external signer of account, and verifies
+// the signature so a faulty signer is detected before the signature is used.
+func (ac *Account) signBySigner(data []byte) ([]byte, error) {
+	signature, err := ac.signer.Sign(ac.PublicKey, data)
+	return ac.verifySignerSignature(data, signature, err)
+}
+
+// signTransactionBySigner signs the unsigned data of a transaction by the
+// external signer of account, with the referenced transactions of the inputs
+// if the signer accepts them.
+func (ac *Account) signTransactionBySigner(data []byte,
+	prevTxs []*types.Transaction) ([]byte, error) {
+	signer, ok := ac.signer.(TransactionSigner)
+	if !ok {
+		return ac.signBySigner(data)
+	}
+	signature, err := signer.SignTransaction(ac.PublicKey, data, prevTxs)
+	return ac.verifySignerSignature(data, signature, err)
+}
+
+func (ac *Account) verifySignerSignature(data, signature []byte,
+	err error) ([]byte, error) {
+	if err != nil {
+		return nil, errors.New(ac.Address + ": signer: " + err.Error())
+	}
+	if err := crypto.Verify(*ac.PublicKey, data, signature); err != nil {
+		return nil, errors.New(ac.Address + ": signer returned invalid signature")
+	}
+	return signature, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"errors"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/contract"
+	"github.com/elastos/Elastos.ELA/crypto"
+	"github.com/elastos/Elastos.ELA/utils"
+	"github.com/elastos/Elastos.ELA/vm"
+)
+
+// ErrWatchOnly indicates the account holds no private key to sign.
+var ErrWatchOnly = errors.New("watch-only account can not sign")
+
+// NewWatchOnlyAccount creates a watch-only account by an address, or a public
+// key or redeem script in hex string. The account watched by address has no
+// redeem script, so it can not be spent by the transactions built in wallet.
+func NewWatchOnlyAccount(s string) (*Account, error) {
+	if programHash, err := common.Uint168FromAddress(s); err == nil {
+		switch contract.GetPrefixType(*programHash) {
+		case contract.PrefixStandard, contract.PrefixMultiSig:
+		default:
+			return nil, errors.New("standard or multi-signature address expected")
+		}
+		return &Account{
+			ProgramHash: *programHash,
+			Address:     s,
+		}, nil
+	}
+
+	data, err := common.HexStringToBytes(s)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("address, public key or redeem script expected")
+	}
+	if len(data) == crypto.COMPRESSEDLEN || len(data) == crypto.NOCOMPRESSEDLEN {
+		publicKey, err := crypto.DecodePoint(data)
+		if err != nil {
+			return nil, err
+		}
+		return NewAccountWithPublicKey(publicKey)
+	}
+	return newAccountWithRedeemScript(data)
+}
+
+// newAccountWithRedeemScript creates a watch-only account of the standard or
+// multi-signature redeem script.
+func newAccountWithRedeemScript(code []byte) (*Account, error) {
+	signType, err := crypto.GetScriptType(code)
+	if err != nil {
+		return nil, err
+	}
+
+	switch signType {
+	case vm.CHECKSIG:
+		if len(code) != crypto.PublicKeyScriptLength {
+			return nil, errors.New("invalid standard redeem script")
+		}
+		publicKey, err := crypto.DecodePoint(code[1 : len(code)-1])
+		if err != nil {
+			return nil, err
+		}
+		return NewAccountWithPublicKey(publicKey)
+
+	case vm.CHECKMULTISIG:
+		if _, err := crypto.ParseMultisigScript(code); err != nil {
+			return nil, err
+		}
+		programHash := common.ToProgramHash(byte(contract.PrefixMultiSig), code)
+		address, err := programHash.ToAddress()
+		if err != nil {
+			return nil, err
+		}
+		return &Account{
+			ProgramHash:  *programHash,
+			RedeemScript: code,
+			Address:      address,
+		}, nil
+
+	default:
+		return nil, errors.New("standard or multi-signature redeem script expected")
+	}
+}
+
+// AddWatchOnly adds a watch-only account to wallet, the wallet is created if
+// it does not exist.
+func AddWatchOnly(path string, password []byte, ac *Account) (*Client, error) {
+	exist := utils.FileExisted(path)
+	client := NewClient(path, password, !exist)
+	if client == nil {
+		return nil, errors.New("add watch-only account failed")
+	}
+	if err := client.SaveWatchOnlyAccount(ac); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// SaveWatchOnlyAccount saves a watch-only account to memory and db.
+func (cl *Client) SaveWatchOnlyAccount(ac *Account) error {
+	return cl.saveWatchOnlyAccount(ac, "")
+}
+
+func (cl *Client) saveWatchOnlyAccount(ac *Account, hdPath string) error {
+	if ac.PrivateKey != nil {
+		return errors.New("watch-only account should not hold private key")
+	}
+
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	cl.accounts[ac.ProgramHash.ToCodeHash()] = ac
+	return cl.SaveWatchOnlyAccountData(&ac.ProgramHash, ac.RedeemScript, hdPath)
+}
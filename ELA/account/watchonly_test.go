@@ -0,0 +1,390 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package account
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/crypto"
+
+	"github.com/itchyny/base58-go"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon " +
+	"abandon abandon abandon abandon abandon about"
+
+func newTestPublicKey(t *testing.T) *crypto.PublicKey {
+	_, publicKey, err := crypto.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return publicKey
+}
+
+func writeFileData(t *testing.T, path string, fileData *FileData) {
+	data, err := json.Marshal(fileData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtendedPublicKey(t *testing.T) {
+	// the serialization and fingerprint follow the test vector 1 of BIP32
+	// except the version bytes, the public keys of the vector are on the
+	// secp256k1 curve so children derived from them differ from the vector.
+	masterPublicKey, err := common.HexStringToBytes(
+		"0339a36013301597daef41fbe593a02cc513d0b55527ec2df1050e2e8ff49c85c2")
+	assert.NoError(t, err)
+	master := &ExtendedPublicKey{PublicKey: masterPublicKey}
+	assert.Equal(t, uint32(0x3442193e), master.Fingerprint())
+
+	publicKey, err := common.HexStringToBytes(
+		"035a784662a4a20a65bf6aab9ae98a6c068a81c52e4b032c0fb5400c706cfccc56")
+	assert.NoError(t, err)
+	chainCode, err := common.HexStringToBytes(
+		"47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141")
+	assert.NoError(t, err)
+	child := &ExtendedPublicKey{
+		PublicKey:         publicKey,
+		ChainCode:         chainCode,
+		Depth:             1,
+		ParentFingerprint: master.Fingerprint(),
+		ChildNumber:       HardenedKeyStart,
+	}
+	assert.Equal(t, "epub8aziDyfVYR5Kn9AbR1iXTtJH5emHXYJ9og9jww4YSEoED4Wf"+
+		"YWmwxEqzHSNWjMSiPxP5zadHa84zAqiivnzSt6oxnbhVtJMG7qLwqrCrMTw",
+		child.String())
+
+	// the Bitcoin xpub of the vector is rejected, so the P-256 keys are never
+	// mixed up with secp256k1 keys, but it's still accepted from the
+	// keystores of earlier versions
+	legacy := "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1" +
+		"WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"
+	_, err = ParseExtendedPublicKey(legacy)
+	assert.EqualError(t, err, "unsupported extended public key version")
+	parsedLegacy, err := parseExtendedPublicKey(legacy, true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, child, parsedLegacy)
+	}
+
+	// round trip of the account key derived from the same seed
+	seed, err := common.HexStringToBytes("000102030405060708090a0b0c0d0e0f")
+	assert.NoError(t, err)
+	masterKey, err := NewMasterKey(seed)
+	assert.NoError(t, err)
+	accountKey, err := masterKey.Derive(hdAccountPath())
+	assert.NoError(t, err)
+	accountPublic, err := accountKey.Public(3, 0x01020304, hdAccountPath()[2])
+	assert.NoError(t, err)
+	xpub := accountPublic.String()
+	parsed, err := ParseExtendedPublicKey(xpub)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, accountPublic, parsed)
+	assert.Equal(t, xpub, parsed.String())
+
+	// the parsed key derives the same children as the private key
+	for _, index := range []uint32{0, 1} {
+		childKey, err := accountKey.Child(index)
+		assert.NoError(t, err)
+		childPublicKey, err := crypto.NewPubKey(childKey.PrivateKey).EncodePoint(true)
+		assert.NoError(t, err)
+		publicChild, err := parsed.Child(index)
+		if assert.NoError(t, err) {
+			assert.Equal(t, childPublicKey, publicChild.PublicKey)
+			assert.Equal(t, childKey.ChainCode, publicChild.ChainCode)
+		}
+	}
+
+	corrupted := []byte(xpub)
+	if corrupted[len(corrupted)-1] == 'a' {
+		corrupted[len(corrupted)-1] = 'b'
+	} else {
+		corrupted[len(corrupted)-1] = 'a'
+	}
+	// x = 1 is not on the curve
+	notOnCurve := make([]byte, 33)
+	notOnCurve[0], notOnCurve[32] = 0x02, 0x01
+	for _, s := range []string{
+		"",
+		"invalid0",
+		xpub[:len(xpub)-1],
+		string(corrupted),
+		(&ExtendedPublicKey{PublicKey: notOnCurve, ChainCode: chainCode}).String(),
+	} {
+		_, err := ParseExtendedPublicKey(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestCreateFromXPub(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	hdPath := filepath.Join(dir, "hd.dat")
+	watchPath := filepath.Join(dir, "watch.dat")
+
+	hd, err := CreateFromMnemonic(hdPath, testPassword, testMnemonic, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	xpub, err := hd.AccountXPub()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(3), xpub.Depth)
+
+	watch, err := CreateFromXPub(watchPath, testPassword, xpub.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	hdWallet, err := watch.IsHDWallet()
+	assert.NoError(t, err)
+	assert.True(t, hdWallet)
+	watchXPub, err := watch.AccountXPub()
+	assert.NoError(t, err)
+	assert.Equal(t, xpub, watchXPub)
+
+	// the watch-only wallet derives the same addresses as the HD wallet but
+	// has no main account
+	main := hd.GetMainAccount()
+	assert.Nil(t, watch.GetMainAccount())
+	accounts := watch.GetAccounts()
+	if assert.Len(t, accounts, 1) {
+		assert.Equal(t, main.Address, accounts[0].Address)
+		assert.Equal(t, main.PublicKey, accounts[0].PublicKey)
+		assert.Nil(t, accounts[0].PrivateKey)
+		assert.False(t, accounts[0].CanSign())
+	}
+	for _, chain := range []uint32{ExternalChain, InternalChain} {
+		expected, err := hd.CreateHDAccount(chain)
+		assert.NoError(t, err)
+		derived, err := watch.CreateHDAccount(chain)
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected.Address, derived.Address)
+			assert.False(t, derived.CanSign())
+		}
+	}
+	_, err = GetWalletMainAccountData(watchPath)
+	assert.EqualError(t, err, "no main account found")
+	opened, err := Open(watchPath, testPassword)
+	if assert.NoError(t, err) {
+		assert.Nil(t, opened.GetMainAccount())
+		assert.Len(t, opened.GetAccounts(), 3)
+	}
+
+	// only the extended public key of account m/44'/2305'/0' is accepted
+	master, err := hd.hdMasterKey()
+	assert.NoError(t, err)
+	masterPublic, err := master.Public(0, 0, 0)
+	assert.NoError(t, err)
+	_, err = CreateFromXPub(filepath.Join(dir, "master.dat"), testPassword,
+		masterPublic.String())
+	assert.EqualError(t, err,
+		"extended public key of account m/44'/2305'/0' expected")
+	_, err = CreateFromXPub(filepath.Join(dir, "invalid.dat"), testPassword,
+		"invalid0")
+	assert.Error(t, err)
+}
+
+func TestCreateFromXPub_Legacy(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	hdPath := filepath.Join(dir, "hd.dat")
+	watchPath := filepath.Join(dir, "watch.dat")
+
+	hd, err := CreateFromMnemonic(hdPath, testPassword, testMnemonic, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	xpub, err := hd.AccountXPub()
+	assert.NoError(t, err)
+	watch, err := CreateFromXPub(watchPath, testPassword, xpub.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// watch-only wallets of earlier versions stored the key with the
+	// Bitcoin version bytes
+	data := make([]byte, 0, xpubLength+4)
+	data = append(data, 0x04, 0x88, 0xb2, 0x1e, xpub.Depth)
+	data = append(data, byte(xpub.ParentFingerprint>>24),
+		byte(xpub.ParentFingerprint>>16), byte(xpub.ParentFingerprint>>8),
+		byte(xpub.ParentFingerprint))
+	data = append(data, byte(xpub.ChildNumber>>24), byte(xpub.ChildNumber>>16),
+		byte(xpub.ChildNumber>>8), byte(xpub.ChildNumber))
+	data = append(data, xpub.ChainCode...)
+	data = append(data, xpub.PublicKey...)
+	checksum := common.Sha256D(data)
+	data = append(data, checksum[:4]...)
+	encoded, err := base58.BitcoinEncoding.Encode(
+		[]byte(new(big.Int).SetBytes(data).String()))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(encoded), "xpub"))
+	assert.NoError(t, watch.SaveStoredData("XPub", encoded))
+
+	opened, err := Open(watchPath, testPassword)
+	if !assert.NoError(t, err) {
+		return
+	}
+	// the key is exported with the current version bytes
+	openedXPub, err := opened.AccountXPub()
+	if assert.NoError(t, err) {
+		assert.Equal(t, xpub, openedXPub)
+		assert.True(t, strings.HasPrefix(openedXPub.String(), "epub"))
+	}
+	derived, err := opened.CreateHDAccount(InternalChain)
+	expected, _ := hd.CreateHDAccount(InternalChain)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected.Address, derived.Address)
+	}
+}
+
+func TestAddWatchOnly(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "keystore.dat")
+
+	publicKey := newTestPublicKey(t)
+	watched, err := NewAccountWithPublicKey(publicKey)
+	assert.NoError(t, err)
+	client, err := AddWatchOnly(path, testPassword, watched)
+	if !assert.NoError(t, err) {
+		return
+	}
+	// the wallet created by a watch-only account has no main account
+	assert.Nil(t, client.GetMainAccount())
+	_, err = GetWalletMainAccountData(path)
+	assert.Error(t, err)
+	_, err = AddWatchOnly(path, testPassword, watched)
+	assert.EqualError(t, err, "account already exists")
+
+	// the first account can sign becomes the main account
+	_, err = Add(path, testPassword)
+	assert.NoError(t, err)
+	client, err = Open(path, testPassword)
+	if !assert.NoError(t, err) {
+		return
+	}
+	main := client.GetMainAccount()
+	if !assert.NotNil(t, main) {
+		return
+	}
+	assert.True(t, main.CanSign())
+
+	// adding watch-only accounts keeps the main account
+	byAddress, err := NewWatchOnlyAccount(watched.Address)
+	assert.NoError(t, err)
+	assert.Nil(t, byAddress.PublicKey)
+	_, err = AddWatchOnly(path, testPassword, byAddress)
+	assert.EqualError(t, err, "account already exists")
+	otherKey := newTestPublicKey(t)
+	other, err := NewAccountWithPublicKey(otherKey)
+	assert.NoError(t, err)
+	byAddress, err = NewWatchOnlyAccount(other.Address)
+	assert.NoError(t, err)
+	_, err = AddWatchOnly(path, testPassword, byAddress)
+	assert.NoError(t, err)
+	mainData, err := GetWalletMainAccountData(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, main.Address, mainData.Address)
+	}
+
+	withKey, err := NewAccount()
+	assert.NoError(t, err)
+	_, err = AddWatchOnly(path, testPassword, withKey)
+	assert.EqualError(t, err, "watch-only account should not hold private key")
+}
+
+func TestLoadAccounts(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "keystore.dat")
+
+	// a multi-signature account is never the main account
+	publicKeys := []*crypto.PublicKey{newTestPublicKey(t), newTestPublicKey(t),
+		newTestPublicKey(t)}
+	multiSig, err := AddMultiSig(path, testPassword, 2, publicKeys)
+	if !assert.NoError(t, err) {
+		return
+	}
+	client, err := Add(path, testPassword)
+	if !assert.NoError(t, err) {
+		return
+	}
+	sub, err := client.CreateAccount()
+	assert.NoError(t, err)
+	byPublicKey, err := NewAccountWithPublicKey(newTestPublicKey(t))
+	assert.NoError(t, err)
+	assert.NoError(t, client.SaveWatchOnlyAccount(byPublicKey))
+	other, err := NewAccountWithPublicKey(newTestPublicKey(t))
+	assert.NoError(t, err)
+	byAddress, err := NewWatchOnlyAccount(other.Address)
+	assert.NoError(t, err)
+	assert.NoError(t, client.SaveWatchOnlyAccount(byAddress))
+	mainData, err := GetWalletMainAccountData(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	client, err = Open(path, testPassword)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, client.GetAccounts(), 5)
+	main := client.GetMainAccount()
+	if assert.NotNil(t, main) {
+		assert.Equal(t, mainData.Address, main.Address)
+		assert.True(t, main.CanSign())
+	}
+	if acc := client.GetAccountByCodeHash(sub.ProgramHash.ToCodeHash()); assert.NotNil(t, acc) {
+		assert.Equal(t, sub.PrivateKey, acc.PrivateKey)
+		assert.Equal(t, sub.PublicKey, acc.PublicKey)
+	}
+	if acc := client.GetAccountByCodeHash(byPublicKey.ProgramHash.ToCodeHash()); assert.NotNil(t, acc) {
+		assert.Equal(t, byPublicKey.PublicKey, acc.PublicKey)
+		assert.Equal(t, byPublicKey.RedeemScript, acc.RedeemScript)
+		assert.False(t, acc.CanSign())
+	}
+	if acc := client.GetAccountByCodeHash(byAddress.ProgramHash.ToCodeHash()); assert.NotNil(t, acc) {
+		assert.Equal(t, byAddress.Address, acc.Address)
+		assert.Nil(t, acc.PublicKey)
+		assert.Empty(t, acc.RedeemScript)
+		assert.False(t, acc.CanSign())
+	}
+	if acc := client.GetAccountByCodeHash(multiSig.ProgramHash.ToCodeHash()); assert.NotNil(t, acc) {
+		assert.Equal(t, multiSig.RedeemScript, acc.RedeemScript)
+		assert.False(t, acc.CanSign())
+	}
+
+	// the accounts without private key saved as main account by early
+	// versions are not loaded as the main account
+	fileData := readFileData(t, path)
+	for i := range fileData.Account {
+		if fileData.Account[i].PrivateKeyEncrypted == "" {
+			fileData.Account[i].Type = MAINACCOUNT
+			fileData.Account[i].WatchOnly = false
+		} else {
+			fileData.Account[i].Type = SUBACCOUNT
+		}
+	}
+	writeFileData(t, path, fileData)
+	client, err = Open(path, testPassword)
+	if assert.NoError(t, err) {
+		assert.Nil(t, client.GetMainAccount())
+		assert.Len(t, client.GetAccounts(), 5)
+	}
+	_, err = GetWalletMainAccountData(path)
+	assert.Error(t, err)
+}
@@ -378,6 +378,25 @@ func (c *ChainStoreFFLDB) GetUTXO(programHash *Uint168) ([]*UTXO, error) {
 	return c.indexManager.FetchUTXO(programHash)
 }
 
+func (c *ChainStoreFFLDB) ForEachUTXO(
+	fn func(*indexers.UTXOEntry) error) (*Uint256, uint32, error) {
+	return c.indexManager.ForEachUTXO(fn)
+}
+
+func (c *ChainStoreFFLDB) GetUTXOSetInfo() (*indexers.UTXOSetInfo, error) {
+	return c.indexManager.FetchUTXOSetInfo()
+}
+
+func (c *ChainStoreFFLDB) GetAddressTransactions(programHash *Uint168,
+	skip, limit uint32) ([]*indexers.AddressTx, uint32, error) {
+	return c.indexManager.FetchAddressTransactions(programHash, skip, limit)
+}
+
+func (c *ChainStoreFFLDB) GetSpendingInfo(
+	outPoint *OutPoint) (*indexers.SpendingInfo, error) {
+	return c.indexManager.FetchSpendingInfo(outPoint)
+}
+
 func (c *ChainStoreFFLDB) IsTx3Exist(txHash *Uint256) bool {
 	return c.indexManager.IsTx3Exist(txHash)
 }
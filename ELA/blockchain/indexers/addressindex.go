@@ -0,0 +1,279 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package indexers
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/database"
+)
+
+const (
+	// addressIndexName is the human-readable name for the index.
+	addressIndexName = "address transaction index"
+
+	// addrTxKeySize is the size of an address transaction entry key, which
+	// is the block height followed by the transaction position in block.
+	addrTxKeySize = 4 + 4
+
+	// addrTxValueSize is the size of an address transaction entry value,
+	// which is the transaction hash followed by the net delta.
+	addrTxValueSize = common.UINT256SIZE + 8
+)
+
+var (
+	// addressIndexKey is the key of the address index and the db bucket used
+	// to house it.
+	addressIndexKey = []byte("addrtxidx")
+
+	// ErrAddressIndexDisabled indicates the address index is not enabled.
+	ErrAddressIndexDisabled = errors.New("address index is not enabled")
+)
+
+// AddressTx is a transaction which credited or debited an address.
+type AddressTx struct {
+	// TxID is the hash of the transaction.
+	TxID common.Uint256
+
+	// Height is the height of the block the transaction located.
+	Height uint32
+
+	// Delta is the net value change of the address made by the transaction,
+	// which is the sum of outputs paid to the address minus the sum of
+	// outputs of the address spent by the transaction.
+	Delta common.Fixed64
+}
+
+// -----------------------------------------------------------------------------
+// The address index consists of a bucket for each program hash, each entry of
+// the bucket is a transaction which credited or debited the address.
+//
+// The serialized key format is:
+//
+//   <block height><tx position>
+//
+//   Field           Type             Size
+//   block height    uint32           4 bytes (big endian)
+//   tx position     uint32           4 bytes (big endian)
+//
+// Keys are big endian so entries are iterated in the order of the chain.
+//
+// The serialized value format is:
+//
+//   <tx hash><delta>
+//
+//   Field           Type             Size
+//   tx hash         common.Uint256   common.UINT256SIZE
+//   delta           int64            8 bytes
+// -----------------------------------------------------------------------------
+
+func addrTxKey(height uint32, position uint32) []byte {
+	key := make([]byte, addrTxKeySize)
+	binary.BigEndian.PutUint32(key[:4], height)
+	binary.BigEndian.PutUint32(key[4:], position)
+	return key
+}
+
+// dbPutAddressIndexEntry uses an existing database transaction to add an
+// address transaction entry of the given program hash.
+func dbPutAddressIndexEntry(dbTx database.Tx, programHash *common.Uint168,
+	height uint32, position uint32, txID *common.Uint256,
+	delta common.Fixed64) error {
+	addrIndex := dbTx.Metadata().Bucket(addressIndexKey)
+	programHashIndex, err := addrIndex.CreateBucketIfNotExists(programHash.Bytes())
+	if err != nil {
+		return err
+	}
+	value := make([]byte, addrTxValueSize)
+	copy(value, txID[:])
+	byteOrder.PutUint64(value[common.UINT256SIZE:], uint64(delta))
+	return programHashIndex.Put(addrTxKey(height, position), value)
+}
+
+// dbRemoveAddressIndexEntry uses an existing database transaction to remove
+// an address transaction entry of the given program hash.
+func dbRemoveAddressIndexEntry(dbTx database.Tx, programHash *common.Uint168,
+	height uint32, position uint32) error {
+	programHashIndex := dbTx.Metadata().Bucket(addressIndexKey).
+		Bucket(programHash.Bytes())
+	if programHashIndex == nil {
+		return nil
+	}
+	return programHashIndex.Delete(addrTxKey(height, position))
+}
+
+// dbFetchAddressIndexEntries uses an existing database transaction to fetch
+// transactions of the given program hash, from the latest to the earliest.
+// The first skip transactions are skipped and at most limit transactions are
+// returned, along with the count of all transactions of the address.
+func dbFetchAddressIndexEntries(dbTx database.Tx, programHash *common.Uint168,
+	skip, limit uint32) ([]*AddressTx, uint32, error) {
+	programHashIndex := dbTx.Metadata().Bucket(addressIndexKey).
+		Bucket(programHash.Bytes())
+	if programHashIndex == nil {
+		return nil, 0, nil
+	}
+
+	var total uint32
+	txs := make([]*AddressTx, 0)
+	cursor := programHashIndex.Cursor()
+	for ok := cursor.Last(); ok; ok = cursor.Prev() {
+		key, value := cursor.Key(), cursor.Value()
+		if len(key) != addrTxKeySize || len(value) != addrTxValueSize {
+			return nil, 0, database.Error{
+				ErrorCode:   database.ErrCorruption,
+				Description: "corrupt address index entry",
+			}
+		}
+		total++
+		if total <= skip || uint32(len(txs)) >= limit {
+			continue
+		}
+
+		var tx AddressTx
+		copy(tx.TxID[:], value[:common.UINT256SIZE])
+		tx.Height = binary.BigEndian.Uint32(key[:4])
+		tx.Delta = common.Fixed64(byteOrder.Uint64(value[common.UINT256SIZE:]))
+		txs = append(txs, &tx)
+	}
+
+	return txs, total, nil
+}
+
+// AddressIndex implements an address to transactions index.
+type AddressIndex struct {
+	db      database.DB
+	txStore ITxStore
+}
+
+// Init initializes the address index. This is part of the Indexer interface.
+func (idx *AddressIndex) Init() error {
+	return nil // Nothing to do.
+}
+
+// Key returns the database key to use for the index as a byte slice.
+//
+// This is part of the Indexer interface.
+func (idx *AddressIndex) Key() []byte {
+	return addressIndexKey
+}
+
+// Name returns the human-readable name of the index.
+//
+// This is part of the Indexer interface.
+func (idx *AddressIndex) Name() string {
+	return addressIndexName
+}
+
+// Create is invoked when the indexer manager determines the index needs
+// to be created for the first time.  It creates the buckets for the address
+// index.
+//
+// This is part of the Indexer interface.
+func (idx *AddressIndex) Create(dbTx database.Tx) error {
+	meta := dbTx.Metadata()
+	_, err := meta.CreateBucket(addressIndexKey)
+	return err
+}
+
+// ConnectBlock is invoked by the index manager when a new block has been
+// connected to the main chain.  This indexer adds an entry for every address
+// credited or debited by each transaction in the passed block.
+//
+// This is part of the Indexer interface.
+func (idx *AddressIndex) ConnectBlock(dbTx database.Tx, block *types.Block) error {
+	deltas, err := idx.blockDeltas(block)
+	if err != nil {
+		return err
+	}
+	for position, txDeltas := range deltas {
+		txID := block.Transactions[position].Hash()
+		for programHash, delta := range txDeltas {
+			err := dbPutAddressIndexEntry(dbTx, &programHash, block.Height,
+				uint32(position), &txID, delta)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DisconnectBlock is invoked by the index manager when a block has been
+// disconnected from the main chain.  This indexer removes the entries added
+// for each transaction in the block.
+//
+// This is part of the Indexer interface.
+func (idx *AddressIndex) DisconnectBlock(dbTx database.Tx, block *types.Block) error {
+	deltas, err := idx.blockDeltas(block)
+	if err != nil {
+		return err
+	}
+	for position, txDeltas := range deltas {
+		for programHash := range txDeltas {
+			err := dbRemoveAddressIndexEntry(dbTx, &programHash, block.Height,
+				uint32(position))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// blockDeltas returns the net value change of each address made by each
+// transaction of the block.
+func (idx *AddressIndex) blockDeltas(block *types.Block) (
+	[]map[common.Uint168]common.Fixed64, error) {
+	// Transactions may spend outputs of previous transactions in the same
+	// block, resolve them from the block first.
+	blockTxs := make(map[common.Uint256]*types.Transaction,
+		len(block.Transactions))
+	for _, txn := range block.Transactions {
+		blockTxs[txn.Hash()] = txn
+	}
+
+	deltas := make([]map[common.Uint168]common.Fixed64, len(block.Transactions))
+	for i, txn := range block.Transactions {
+		txDeltas := make(map[common.Uint168]common.Fixed64)
+		for _, output := range txn.Outputs {
+			txDeltas[output.ProgramHash] += output.Value
+		}
+		if !txn.IsCoinBaseTx() {
+			for _, input := range txn.Inputs {
+				referTx, ok := blockTxs[input.Previous.TxID]
+				if !ok {
+					var err error
+					referTx, _, err = idx.txStore.FetchTx(input.Previous.TxID)
+					if err != nil {
+						return nil, err
+					}
+				}
+				if int(input.Previous.Index) >= len(referTx.Outputs) {
+					return nil, AssertError("input refers to nonexistent output")
+				}
+				referOutput := referTx.Outputs[input.Previous.Index]
+				txDeltas[referOutput.ProgramHash] -= referOutput.Value
+			}
+		}
+		deltas[i] = txDeltas
+	}
+	return deltas, nil
+}
+
+// NewAddressIndex returns a new instance of an indexer that is used to create
+// a mapping of the program hashes of all addresses be used in the blockchain
+// to the transactions which credited or debited them.
+//
+// It implements the Indexer interface which plugs into the IndexManager that in
+// turn is used by the blockchain package.  This allows the index to be
+// seamlessly maintained along with the chain.
+func NewAddressIndex(db database.DB, store ITxStore) *AddressIndex {
+	return &AddressIndex{db, store}
+}
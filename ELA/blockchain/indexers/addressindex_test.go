@@ -0,0 +1,205 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package indexers
+
+import (
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/log"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/database"
+	"github.com/elastos/Elastos.ELA/utils/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// testAddressIndexTx1 spends outputs of testUtxoIndexReferTx.
+	testAddressIndexTx1 = &types.Transaction{
+		TxType:  types.TransferAsset,
+		Payload: &payload.TransferAsset{},
+		Inputs: []*types.Input{
+			{
+				Previous: types.OutPoint{
+					Index: 1,
+					TxID:  testUtxoIndexReferTx.Hash(),
+				},
+			},
+			{
+				Previous: types.OutPoint{
+					Index: 2,
+					TxID:  testUtxoIndexReferTx.Hash(),
+				},
+			},
+		},
+		Outputs: []*types.Output{
+			{
+				Value:       250,
+				ProgramHash: *recipient1,
+			},
+			{
+				Value:       40,
+				ProgramHash: *referRecipient2,
+			},
+		},
+	}
+
+	// testAddressIndexTx2 spends an output of testAddressIndexTx1 in the
+	// same block.
+	testAddressIndexTx2 = &types.Transaction{
+		TxType:  types.TransferAsset,
+		Payload: &payload.TransferAsset{},
+		Inputs: []*types.Input{
+			{
+				Previous: types.OutPoint{
+					Index: 0,
+					TxID:  testAddressIndexTx1.Hash(),
+				},
+			},
+		},
+		Outputs: []*types.Output{
+			{
+				Value:       240,
+				ProgramHash: *recipient2,
+			},
+		},
+	}
+
+	testAddressIndexBlock = &types.Block{
+		Header: types.Header{
+			Height: 300,
+		},
+		Transactions: []*types.Transaction{
+			testAddressIndexTx1,
+			testAddressIndexTx2,
+		},
+	}
+
+	testAddressIndex *AddressIndex
+	addressIndexDB   database.DB
+)
+
+func TestAddressIndexInit(t *testing.T) {
+	log.NewDefault(test.NodeLogPath, 0, 0, 0)
+
+	var err error
+	addressIndexDB, err = LoadBlockDB(test.DataPath)
+	assert.NoError(t, err)
+	txStore := NewTestTxStore()
+	txStore.SetTx(testUtxoIndexReferTx, referHeight)
+
+	testAddressIndex = NewAddressIndex(addressIndexDB, txStore)
+	assert.Equal(t, addressIndexKey, testAddressIndex.Key())
+	assert.Equal(t, addressIndexName, testAddressIndex.Name())
+	assert.NoError(t, testAddressIndex.Init())
+
+	_ = addressIndexDB.Update(func(dbTx database.Tx) error {
+		err := testAddressIndex.Create(dbTx)
+		assert.NoError(t, err)
+
+		// the reference transaction paid referRecipient1 and referRecipient2
+		referTxID := testUtxoIndexReferTx.Hash()
+		assert.NoError(t, dbPutAddressIndexEntry(dbTx, referRecipient1,
+			referHeight, 1, &referTxID, 100))
+		assert.NoError(t, dbPutAddressIndexEntry(dbTx, referRecipient2,
+			referHeight, 1, &referTxID, 500))
+		return nil
+	})
+}
+
+func TestAddressIndex_ConnectBlock(t *testing.T) {
+	_ = addressIndexDB.Update(func(dbTx database.Tx) error {
+		err := testAddressIndex.ConnectBlock(dbTx, testAddressIndexBlock)
+		assert.NoError(t, err)
+		return nil
+	})
+
+	referTxID := testUtxoIndexReferTx.Hash()
+	_ = addressIndexDB.View(func(dbTx database.Tx) error {
+		txs, total, err := dbFetchAddressIndexEntries(dbTx, referRecipient1, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), total)
+		assert.Equal(t, []*AddressTx{
+			{TxID: testAddressIndexTx1.Hash(), Height: 300, Delta: -100},
+			{TxID: referTxID, Height: referHeight, Delta: 100},
+		}, txs)
+
+		txs, total, err = dbFetchAddressIndexEntries(dbTx, referRecipient2, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), total)
+		assert.Equal(t, []*AddressTx{
+			{TxID: testAddressIndexTx1.Hash(), Height: 300, Delta: -160},
+			{TxID: referTxID, Height: referHeight, Delta: 500},
+		}, txs)
+
+		// spending an output of the same block
+		txs, total, err = dbFetchAddressIndexEntries(dbTx, recipient1, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), total)
+		assert.Equal(t, []*AddressTx{
+			{TxID: testAddressIndexTx2.Hash(), Height: 300, Delta: -250},
+			{TxID: testAddressIndexTx1.Hash(), Height: 300, Delta: 250},
+		}, txs)
+
+		// paging
+		txs, total, err = dbFetchAddressIndexEntries(dbTx, recipient1, 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), total)
+		assert.Equal(t, []*AddressTx{
+			{TxID: testAddressIndexTx1.Hash(), Height: 300, Delta: 250},
+		}, txs)
+		txs, total, err = dbFetchAddressIndexEntries(dbTx, recipient1, 0, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), total)
+		assert.Equal(t, []*AddressTx{
+			{TxID: testAddressIndexTx2.Hash(), Height: 300, Delta: -250},
+		}, txs)
+
+		// unknown address
+		txs, total, err = dbFetchAddressIndexEntries(dbTx,
+			&common.Uint168{}, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(0), total)
+		assert.Equal(t, 0, len(txs))
+
+		return nil
+	})
+}
+
+func TestAddressIndex_DisconnectBlock(t *testing.T) {
+	_ = addressIndexDB.Update(func(dbTx database.Tx) error {
+		err := testAddressIndex.DisconnectBlock(dbTx, testAddressIndexBlock)
+		assert.NoError(t, err)
+
+		txs, total, err := dbFetchAddressIndexEntries(dbTx, referRecipient1, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), total)
+		assert.Equal(t, []*AddressTx{
+			{TxID: testUtxoIndexReferTx.Hash(), Height: referHeight, Delta: 100},
+		}, txs)
+
+		_, total, err = dbFetchAddressIndexEntries(dbTx, recipient1, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(0), total)
+		_, total, err = dbFetchAddressIndexEntries(dbTx, recipient2, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(0), total)
+
+		return nil
+	})
+}
+
+func TestAddressIndexEnd(t *testing.T) {
+	_ = addressIndexDB.Update(func(dbTx database.Tx) error {
+		meta := dbTx.Metadata()
+		err := meta.DeleteBucket(addressIndexKey)
+		assert.NoError(t, err)
+		return nil
+	})
+	addressIndexDB.Close()
+}
@@ -67,6 +67,24 @@ type IndexManager interface {
 	// FetchUTXO retrieval the utxo set of a account address
 	FetchUTXO(programHash *common.Uint168) ([]*types.UTXO, error)
 
+	// ForEachUTXO walks all utxos at the tip of the utxo index in a
+	// deterministic order, and returns the hash and height of the tip
+	ForEachUTXO(fn func(*UTXOEntry) error) (*common.Uint256, uint32, error)
+
+	// FetchUTXOSetInfo retrieval the statistics of the utxo set at the tip
+	// of the utxo index
+	FetchUTXOSetInfo() (*UTXOSetInfo, error)
+
+	// FetchAddressTransactions retrieval transactions credited or debited an
+	// account address from the latest to the earliest, and the count of all
+	// the transactions
+	FetchAddressTransactions(programHash *common.Uint168, skip,
+		limit uint32) ([]*AddressTx, uint32, error)
+
+	// FetchSpendingInfo retrieval the input which spent an outpoint, nil will
+	// be returned if the outpoint is not spent
+	FetchSpendingInfo(outPoint *types.OutPoint) (*SpendingInfo, error)
+
 	// IsTx3Exist use to find if tx3 exist in db
 	IsTx3Exist(txHash *common.Uint256) bool
 }
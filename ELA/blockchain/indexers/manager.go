@@ -11,6 +11,7 @@ package indexers
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 
 	"github.com/elastos/Elastos.ELA/common"
@@ -137,6 +138,8 @@ type Manager struct {
 	db             database.DB
 	enabledIndexes []Indexer
 	txStore        ITxStore
+	addressIndex   *AddressIndex
+	spentIndex     *SpentIndex
 }
 
 // Ensure the Manager type implements the blockchain.IndexManager interface.
@@ -504,6 +507,96 @@ func (m *Manager) FetchUTXO(programHash *common.Uint168) ([]*types.UTXO, error)
 	return utxos, nil
 }
 
+func (m *Manager) ForEachUTXO(fn func(*UTXOEntry) error) (
+	*common.Uint256, uint32, error) {
+	var tipHash *common.Uint256
+	var tipHeight int32
+	err := m.db.View(func(dbTx database.Tx) error {
+		var err error
+		tipHash, tipHeight, err = dbFetchIndexerTip(dbTx, utxoIndexKey)
+		if err != nil {
+			return err
+		}
+		return dbForEachUtxoIndexEntry(dbTx, fn)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tipHash, uint32(tipHeight), nil
+}
+
+func (m *Manager) FetchUTXOSetInfo() (*UTXOSetInfo, error) {
+	var info UTXOSetInfo
+	var lastProgramHash *common.Uint168
+	hasher := sha256.New()
+	tipHash, tipHeight, err := m.ForEachUTXO(func(entry *UTXOEntry) error {
+		if lastProgramHash == nil || !lastProgramHash.IsEqual(entry.ProgramHash) {
+			info.Addresses++
+			programHash := entry.ProgramHash
+			lastProgramHash = &programHash
+		}
+		info.UTXOs++
+		info.TotalAmount += entry.Value
+
+		if err := entry.ProgramHash.Serialize(hasher); err != nil {
+			return err
+		}
+		if err := common.WriteUint32(hasher, entry.Height); err != nil {
+			return err
+		}
+		return entry.UTXO.Serialize(hasher)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info.Height = tipHeight
+	info.BestBlock = *tipHash
+	info.Hash = sha256.Sum256(hasher.Sum(nil))
+	return &info, nil
+}
+
+func (m *Manager) FetchAddressTransactions(programHash *common.Uint168,
+	skip, limit uint32) ([]*AddressTx, uint32, error) {
+	if m.addressIndex == nil {
+		return nil, 0, ErrAddressIndexDisabled
+	}
+
+	var txs []*AddressTx
+	var total uint32
+	err := m.db.View(func(dbTx database.Tx) error {
+		var err error
+		txs, total, err = dbFetchAddressIndexEntries(dbTx, programHash,
+			skip, limit)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return txs, total, nil
+}
+
+func (m *Manager) FetchSpendingInfo(outPoint *types.OutPoint) (
+	*SpendingInfo, error) {
+	if m.spentIndex == nil {
+		return nil, ErrSpentIndexDisabled
+	}
+
+	var info *SpendingInfo
+	err := m.db.View(func(dbTx database.Tx) error {
+		var err error
+		info, err = dbFetchSpentIndexEntry(dbTx, outPoint)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
 func (m *Manager) IsTx3Exist(txHash *common.Uint256) bool {
 	exist := false
 	_ = m.db.View(func(dbTx database.Tx) error {
@@ -525,10 +618,22 @@ func NewManager(db database.DB, params *config.Params) *Manager {
 	tx3Index := NewTx3Index(db)
 	var enabledIndexes []Indexer
 	enabledIndexes = append(enabledIndexes, txIndex, unspentIndex, utxoIndex, tx3Index)
+	var addressIndex *AddressIndex
+	if params.EnableAddressIndex {
+		addressIndex = NewAddressIndex(db, unspentIndex)
+		enabledIndexes = append(enabledIndexes, addressIndex)
+	}
+	var spentIndex *SpentIndex
+	if params.EnableSpentIndex {
+		spentIndex = NewSpentIndex(db)
+		enabledIndexes = append(enabledIndexes, spentIndex)
+	}
 	return &Manager{
 		db:             db,
 		enabledIndexes: enabledIndexes,
 		txStore:        unspentIndex,
+		addressIndex:   addressIndex,
+		spentIndex:     spentIndex,
 	}
 }
 
@@ -0,0 +1,190 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package indexers
+
+import (
+	"errors"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/database"
+)
+
+const (
+	// spentIndexName is the human-readable name for the index.
+	spentIndexName = "spent by index"
+
+	// spentIndexValueSize is the size of a spent index entry value.
+	spentIndexValueSize = common.UINT256SIZE + 2 + 4
+)
+
+var (
+	// spentIndexKey is the key of the spent index and the db bucket used
+	// to house it.
+	spentIndexKey = []byte("spentbyidx")
+
+	// ErrSpentIndexDisabled indicates the spent index is not enabled.
+	ErrSpentIndexDisabled = errors.New("spent index is not enabled")
+)
+
+// SpendingInfo is the input which spent an outpoint.
+type SpendingInfo struct {
+	// TxID is the hash of the spending transaction.
+	TxID common.Uint256
+
+	// Index is the index of the input in the spending transaction.
+	Index uint16
+
+	// Height is the height of the block the spending transaction located.
+	Height uint32
+}
+
+// -----------------------------------------------------------------------------
+// The spent index consists of an entry for every spent outpoint, the key is
+// the serialized outpoint.
+//
+// The serialized value format is:
+//
+//   <tx hash><input index><block height>
+//
+//   Field           Type             Size
+//   tx hash         common.Uint256   common.UINT256SIZE
+//   input index     uint16           2 bytes
+//   block height    uint32           4 bytes
+// -----------------------------------------------------------------------------
+
+// dbPutSpentIndexEntry uses an existing database transaction to update the
+// spending input of the given outpoint.
+func dbPutSpentIndexEntry(dbTx database.Tx, outPoint *types.OutPoint,
+	info *SpendingInfo) error {
+	value := make([]byte, spentIndexValueSize)
+	copy(value, info.TxID[:])
+	byteOrder.PutUint16(value[common.UINT256SIZE:], info.Index)
+	byteOrder.PutUint32(value[common.UINT256SIZE+2:], info.Height)
+
+	spentIndex := dbTx.Metadata().Bucket(spentIndexKey)
+	return spentIndex.Put(outPoint.Bytes(), value)
+}
+
+// dbRemoveSpentIndexEntry uses an existing database transaction to remove the
+// spending input of the given outpoint.
+func dbRemoveSpentIndexEntry(dbTx database.Tx, outPoint *types.OutPoint) error {
+	spentIndex := dbTx.Metadata().Bucket(spentIndexKey)
+	return spentIndex.Delete(outPoint.Bytes())
+}
+
+// dbFetchSpentIndexEntry uses an existing database transaction to fetch the
+// spending input of the given outpoint. When the outpoint is not spent, nil
+// will be returned for the both the info and the error.
+func dbFetchSpentIndexEntry(dbTx database.Tx, outPoint *types.OutPoint) (
+	*SpendingInfo, error) {
+	spentIndex := dbTx.Metadata().Bucket(spentIndexKey)
+	value := spentIndex.Get(outPoint.Bytes())
+	if value == nil {
+		return nil, nil
+	}
+	if len(value) != spentIndexValueSize {
+		return nil, database.Error{
+			ErrorCode:   database.ErrCorruption,
+			Description: "corrupt spent index entry",
+		}
+	}
+
+	var info SpendingInfo
+	copy(info.TxID[:], value[:common.UINT256SIZE])
+	info.Index = byteOrder.Uint16(value[common.UINT256SIZE:])
+	info.Height = byteOrder.Uint32(value[common.UINT256SIZE+2:])
+	return &info, nil
+}
+
+// SpentIndex implements an outpoint to spending input index.
+type SpentIndex struct {
+	db database.DB
+}
+
+// Init initializes the spent index. This is part of the Indexer interface.
+func (idx *SpentIndex) Init() error {
+	return nil // Nothing to do.
+}
+
+// Key returns the database key to use for the index as a byte slice.
+//
+// This is part of the Indexer interface.
+func (idx *SpentIndex) Key() []byte {
+	return spentIndexKey
+}
+
+// Name returns the human-readable name of the index.
+//
+// This is part of the Indexer interface.
+func (idx *SpentIndex) Name() string {
+	return spentIndexName
+}
+
+// Create is invoked when the indexer manager determines the index needs
+// to be created for the first time.  It creates the buckets for the spent
+// index.
+//
+// This is part of the Indexer interface.
+func (idx *SpentIndex) Create(dbTx database.Tx) error {
+	meta := dbTx.Metadata()
+	_, err := meta.CreateBucket(spentIndexKey)
+	return err
+}
+
+// ConnectBlock is invoked by the index manager when a new block has been
+// connected to the main chain.  This indexer adds an entry for every outpoint
+// spent by the transactions in the passed block.
+//
+// This is part of the Indexer interface.
+func (idx *SpentIndex) ConnectBlock(dbTx database.Tx, block *types.Block) error {
+	for _, txn := range block.Transactions {
+		if txn.IsCoinBaseTx() {
+			continue
+		}
+		txID := txn.Hash()
+		for i, input := range txn.Inputs {
+			err := dbPutSpentIndexEntry(dbTx, &input.Previous, &SpendingInfo{
+				TxID:   txID,
+				Index:  uint16(i),
+				Height: block.Height,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DisconnectBlock is invoked by the index manager when a block has been
+// disconnected from the main chain.  This indexer removes the entries of
+// outpoints spent by the transactions in the block.
+//
+// This is part of the Indexer interface.
+func (idx *SpentIndex) DisconnectBlock(dbTx database.Tx, block *types.Block) error {
+	for _, txn := range block.Transactions {
+		if txn.IsCoinBaseTx() {
+			continue
+		}
+		for _, input := range txn.Inputs {
+			if err := dbRemoveSpentIndexEntry(dbTx, &input.Previous); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NewSpentIndex returns a new instance of an indexer that is used to create a
+// mapping of all spent outpoints to the inputs which spent them.
+//
+// It implements the Indexer interface which plugs into the IndexManager that in
+// turn is used by the blockchain package.  This allows the index to be
+// seamlessly maintained along with the chain.
+func NewSpentIndex(db database.DB) *SpentIndex {
+	return &SpentIndex{db}
+}
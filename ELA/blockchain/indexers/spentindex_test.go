@@ -0,0 +1,104 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package indexers
+
+import (
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common/log"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/database"
+	"github.com/elastos/Elastos.ELA/utils/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testSpentIndex *SpentIndex
+	spentIndexDB   database.DB
+)
+
+func TestSpentIndexInit(t *testing.T) {
+	log.NewDefault(test.NodeLogPath, 0, 0, 0)
+
+	var err error
+	spentIndexDB, err = LoadBlockDB(test.DataPath)
+	assert.NoError(t, err)
+
+	testSpentIndex = NewSpentIndex(spentIndexDB)
+	assert.Equal(t, spentIndexKey, testSpentIndex.Key())
+	assert.Equal(t, spentIndexName, testSpentIndex.Name())
+	assert.NoError(t, testSpentIndex.Init())
+
+	_ = spentIndexDB.Update(func(dbTx database.Tx) error {
+		err := testSpentIndex.Create(dbTx)
+		assert.NoError(t, err)
+		return err
+	})
+}
+
+func TestSpentIndex_ConnectBlock(t *testing.T) {
+	_ = spentIndexDB.Update(func(dbTx database.Tx) error {
+		// outpoints should not be spent
+		for _, input := range testUtxoIndexTx2.Inputs {
+			info, err := dbFetchSpentIndexEntry(dbTx, &input.Previous)
+			assert.NoError(t, err)
+			assert.Nil(t, info)
+		}
+
+		// connect the block
+		err := testSpentIndex.ConnectBlock(dbTx, testUtxoIndexBlock)
+		assert.NoError(t, err)
+
+		// outpoints should be spent by inputs of testUtxoIndexTx2
+		for i, input := range testUtxoIndexTx2.Inputs {
+			info, err := dbFetchSpentIndexEntry(dbTx, &input.Previous)
+			assert.NoError(t, err)
+			assert.Equal(t, &SpendingInfo{
+				TxID:   testUtxoIndexTx2.Hash(),
+				Index:  uint16(i),
+				Height: testUtxoIndexBlock.Height,
+			}, info)
+		}
+
+		// outputs of the block should not be spent
+		info, err := dbFetchSpentIndexEntry(dbTx, &types.OutPoint{
+			TxID:  testUtxoIndexTx1.Hash(),
+			Index: 0,
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, info)
+
+		return nil
+	})
+}
+
+func TestSpentIndex_DisconnectBlock(t *testing.T) {
+	_ = spentIndexDB.Update(func(dbTx database.Tx) error {
+		// disconnect the block
+		err := testSpentIndex.DisconnectBlock(dbTx, testUtxoIndexBlock)
+		assert.NoError(t, err)
+
+		// outpoints should be unspent again
+		for _, input := range testUtxoIndexTx2.Inputs {
+			info, err := dbFetchSpentIndexEntry(dbTx, &input.Previous)
+			assert.NoError(t, err)
+			assert.Nil(t, info)
+		}
+
+		return nil
+	})
+}
+
+func TestSpentIndexEnd(t *testing.T) {
+	_ = spentIndexDB.Update(func(dbTx database.Tx) error {
+		meta := dbTx.Metadata()
+		err := meta.DeleteBucket(spentIndexKey)
+		assert.NoError(t, err)
+		return nil
+	})
+	spentIndexDB.Close()
+}
@@ -7,6 +7,8 @@ package indexers
 
 import (
 	"bytes"
+	"sort"
+
 	"github.com/elastos/Elastos.ELA/common"
 	"github.com/elastos/Elastos.ELA/core/types"
 	"github.com/elastos/Elastos.ELA/database"
@@ -123,6 +125,113 @@ func dbFetchUtxoIndexEntryByHeight(dbTx database.Tx, programHash *common.Uint168
 	return utxos, nil
 }
 
+// UTXOEntry is an unspent output in the utxo index.
+type UTXOEntry struct {
+	types.UTXO
+
+	// ProgramHash is the program hash of the output.
+	ProgramHash common.Uint168
+
+	// Height is the height of the block the output located.
+	Height uint32
+}
+
+// UTXOSetInfo is the statistics of the utxo set at the tip of the utxo index.
+type UTXOSetInfo struct {
+	// Height is the height of the tip of the utxo index.
+	Height uint32
+
+	// BestBlock is the hash of the tip of the utxo index.
+	BestBlock common.Uint256
+
+	// UTXOs is the count of utxos.
+	UTXOs uint64
+
+	// Addresses is the count of program hashes which own utxos.
+	Addresses uint64
+
+	// TotalAmount is the total value of utxos.
+	TotalAmount common.Fixed64
+
+	// Hash is the double sha256 hash of all utxos, each utxo is serialized
+	// as program hash, height, tx id, index and value, in the order walked by
+	// ForEachUTXO.
+	Hash common.Uint256
+}
+
+// dbForEachUtxoIndexEntry uses an existing database transaction to walk all
+// utxos in the index.  Utxos are walked in a deterministic order, by program
+// hash, height, tx id and index, so the result does not depend on the order
+// utxos were added or removed.
+func dbForEachUtxoIndexEntry(dbTx database.Tx, fn func(*UTXOEntry) error) error {
+	utxoIndex := dbTx.Metadata().Bucket(utxoIndexKey)
+	return utxoIndex.ForEachBucket(func(k []byte) error {
+		programHash, err := common.Uint168FromBytes(k)
+		if err != nil {
+			return err
+		}
+		programHashIndex := utxoIndex.Bucket(k)
+
+		// Heights are stored in little endian, so sort entries by height.
+		type entry struct {
+			height uint32
+			utxos  []*types.UTXO
+		}
+		var entries []entry
+		err = programHashIndex.ForEach(func(key, serializedData []byte) error {
+			if len(serializedData) == 0 {
+				return nil
+			}
+			height, err := common.ReadUint32(bytes.NewReader(key))
+			if err != nil {
+				return err
+			}
+			r := bytes.NewReader(serializedData)
+			count, err := common.ReadVarUint(r, 0)
+			if err != nil {
+				return err
+			}
+			utxos := make([]*types.UTXO, 0, count)
+			for i := 0; i < int(count); i++ {
+				var utxo types.UTXO
+				if err := utxo.Deserialize(r); err != nil {
+					return err
+				}
+				utxos = append(utxos, &utxo)
+			}
+			entries = append(entries, entry{height: height, utxos: utxos})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].height < entries[j].height
+		})
+
+		for _, e := range entries {
+			utxos := e.utxos
+			sort.Slice(utxos, func(i, j int) bool {
+				if utxos[i].TxID != utxos[j].TxID {
+					return utxos[i].TxID.Compare(utxos[j].TxID) < 0
+				}
+				return utxos[i].Index < utxos[j].Index
+			})
+			for _, utxo := range utxos {
+				err := fn(&UTXOEntry{
+					UTXO:        *utxo,
+					ProgramHash: *programHash,
+					Height:      e.height,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
 // UtxoIndex implements a utxo by tx hash index.
 type UtxoIndex struct {
 	db      database.DB
@@ -350,6 +350,41 @@ func TestUtxoIndex_DisconnectBlock(t *testing.T) {
 	})
 }
 
+func TestUtxoIndex_ForEach(t *testing.T) {
+	_ = utxoIndexDB.View(func(dbTx database.Tx) error {
+		var entries []*UTXOEntry
+		err := dbForEachUtxoIndexEntry(dbTx, func(entry *UTXOEntry) error {
+			entries = append(entries, entry)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(entries))
+
+		// utxos of an address should be walked in order of tx id and index
+		var utxos2 []types.UTXO
+		for _, entry := range entries {
+			assert.Equal(t, referHeight, entry.Height)
+			if entry.ProgramHash.IsEqual(*referRecipient2) {
+				utxos2 = append(utxos2, entry.UTXO)
+			}
+		}
+		assert.Equal(t, []types.UTXO{
+			{
+				TxID:  testUtxoIndexReferTx.Hash(),
+				Index: 2,
+				Value: 200,
+			},
+			{
+				TxID:  testUtxoIndexReferTx.Hash(),
+				Index: 3,
+				Value: 300,
+			},
+		}, utxos2)
+
+		return nil
+	})
+}
+
 func TestUtxoIndexEnd(t *testing.T) {
 	_ = utxoIndexDB.Update(func(dbTx database.Tx) error {
 		meta := dbTx.Metadata()
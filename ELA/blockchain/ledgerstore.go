@@ -82,6 +82,22 @@ type IFFLDBChainStore interface {
 	// Get utxo by program hash
 	GetUTXO(programHash *Uint168) ([]*UTXO, error)
 
+	// Walk all utxos in a deterministic order, and return the hash and
+	// height of the tip of the utxo index
+	ForEachUTXO(fn func(*indexers.UTXOEntry) error) (*Uint256, uint32, error)
+
+	// Get the statistics of the utxo set
+	GetUTXOSetInfo() (*indexers.UTXOSetInfo, error)
+
+	// Get transactions credited or debited an address by program hash, from
+	// the latest to the earliest, and the count of all the transactions
+	GetAddressTransactions(programHash *Uint168, skip,
+		limit uint32) ([]*indexers.AddressTx, uint32, error)
+
+	// Get the input which spent an outpoint, nil will be returned if the
+	// outpoint is not spent
+	GetSpendingInfo(outPoint *OutPoint) (*indexers.SpendingInfo, error)
+
 	// IsTx3Exist use to find if tx3 exist in db
 	IsTx3Exist(txHash *Uint256) bool
 }
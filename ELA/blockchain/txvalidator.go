@@ -112,7 +112,7 @@ func (b *BlockChain) CheckTransactionContext(blockHeight uint32,
 		return elaerr.Simple(elaerr.ErrTxDoubleSpend, nil)
 	}
 
-	if err := checkTransactionUTXOLock(txn, references); err != nil {
+	if err := CheckTransactionUTXOLock(txn, references); err != nil {
 		log.Warn("[CheckTransactionUTXOLock],", err)
 		return elaerr.Simple(elaerr.ErrTxUTXOLocked, err)
 	}
@@ -697,6 +697,15 @@ func (b *BlockChain) checkTransactionOutput(txn *Transaction,
 			return err
 		}
 	}
+	return CheckTransactionOutputs(txn, blockHeight,
+		b.GetHeight() >= b.chainParams.PublicDPOSHeight)
+}
+
+// CheckTransactionOutputs checks the asset ID, value, program hash and payload
+// of each output of a transaction at the block height, the special outputs
+// are no more than one if limitSpecialOutputs is true.
+func CheckTransactionOutputs(txn *Transaction, blockHeight uint32,
+	limitSpecialOutputs bool) error {
 	// check if output address is valid
 	specialOutputCount := 0
 	for _, output := range txn.Outputs {
@@ -722,7 +731,7 @@ func (b *BlockChain) checkTransactionOutput(txn *Transaction,
 			}
 		}
 	}
-	if b.GetHeight() >= b.chainParams.PublicDPOSHeight && specialOutputCount > 1 {
+	if limitSpecialOutputs && specialOutputCount > 1 {
 		return errors.New("special output count should less equal than 1")
 	}
 
@@ -794,7 +803,10 @@ func checkOutputPayload(txType TxType, output *Output) error {
 	return output.Payload.Validate()
 }
 
-func checkTransactionUTXOLock(txn *Transaction, references map[*Input]Output) error {
+// CheckTransactionUTXOLock checks the locked UTXOs referenced by the inputs
+// are spent with the lock sequence and the lock time not less than the output
+// lock of them.
+func CheckTransactionUTXOLock(txn *Transaction, references map[*Input]Output) error {
 	for input, output := range references {
 
 		if output.OutputLock == 0 {
@@ -25,6 +25,10 @@ var (
 		Name:  "password, p",
 		Usage: "wallet password",
 	}
+	AccountNewPasswordFlag = cli.StringFlag{
+		Name:  "newpassword",
+		Usage: "the new wallet password",
+	}
 	AccountMultiMFlag = cli.IntFlag{
 		Name:  "m",
 		Usage: "min signature `<number>` of multi signature address",
@@ -33,6 +37,44 @@ var (
 		Name:  "pubkeys, pks",
 		Usage: "public key list of multi signature address, separate public keys with comma `,`",
 	}
+	AccountMnemonicFlag = cli.BoolFlag{
+		Name:  "mnemonic",
+		Usage: "create or import a HD wallet with mnemonic",
+	}
+	AccountMnemonicWordsFlag = cli.IntFlag{
+		Name:  "words",
+		Usage: "words `<count>` of the new mnemonic, one of 12, 15, 18, 21 and 24",
+		Value: account.DefaultMnemonicWords,
+	}
+	AccountPassphraseFlag = cli.StringFlag{
+		Name:  "passphrase",
+		Usage: "the optional passphrase of mnemonic",
+	}
+	AccountGapLimitFlag = cli.UintFlag{
+		Name:  "gaplimit",
+		Usage: "stop address discovery after `<number>` continuous unused addresses",
+		Value: account.DefaultGapLimit,
+	}
+	AccountChangeFlag = cli.BoolFlag{
+		Name:  "change",
+		Usage: "derive a change address instead of receive address in HD wallet",
+	}
+	AccountWatchFlag = cli.BoolFlag{
+		Name:  "watch",
+		Usage: "import a watch-only account by address, public key or redeem script",
+	}
+	AccountXPubFlag = cli.BoolFlag{
+		Name:  "xpub",
+		Usage: "import or export the extended public key of HD wallet for watch-only",
+	}
+	AccountSignerFlag = cli.StringFlag{
+		Name:  "signer",
+		Usage: "sign by the external `<signer>` in format of unix:<path>, exec:<command> or pkcs11:<module>?id=<key id>",
+	}
+	SignerListenFlag = cli.StringFlag{
+		Name:  "listen",
+		Usage: "serve on the unix socket `<path>`, default is stdin and stdout",
+	}
 
 	// Transaction flags
 	TransactionFromFlag = cli.StringFlag{
@@ -57,11 +99,11 @@ var (
 	}
 	TransactionOutputLockFlag = cli.StringFlag{
 		Name:  "outputlock",
-		Usage: "the `<lock height>` to specify when the received asset can be spent",
+		Usage: "the `<lock height>` to specify when the received asset can be spent, \"+N\" means N blocks after the current height",
 	}
 	TransactionTxLockFlag = cli.StringFlag{
 		Name:  "txlock",
-		Usage: "the `<lock height>` to specify when the transaction can be packaged",
+		Usage: "the `<lock height>` to specify when the transaction can be packaged, \"+N\" means N blocks after the current height",
 	}
 	TransactionHexFlag = cli.StringFlag{
 		Name:  "hex",
@@ -84,6 +126,188 @@ var (
 		Usage: "the locked `<address>` on main chain represents one side chain",
 	}
 
+	// Vesting flags
+	TransactionVestingFlag = cli.IntFlag{
+		Name:  "vesting",
+		Usage: "split the amount into `<number>` of installments unlocked at successive heights",
+	}
+	TransactionIntervalFlag = cli.UintFlag{
+		Name:  "interval",
+		Usage: "the `<blocks>` between the lock heights of installments",
+	}
+	TransactionStartFlag = cli.StringFlag{
+		Name:  "start",
+		Usage: "the `<lock height>` of the first installment, default is one interval after the current height",
+	}
+
+	// Coin control flags
+	TransactionUTXOFlag = cli.StringFlag{
+		Name:  "utxo",
+		Usage: "the UTXOs to spend, in format of `<txid:index>` and separated by comma",
+	}
+	TransactionStrategyFlag = cli.StringFlag{
+		Name:  "strategy",
+		Usage: "the coin selection `<strategy>`: largest, smallest, bnb or privacy",
+	}
+	TransactionMaxInputsFlag = cli.IntFlag{
+		Name:  "maxinputs",
+		Usage: "the max `<count>` of inputs, use the count fits the max transaction size if not specified",
+	}
+	TransactionChangeToleranceFlag = cli.StringFlag{
+		Name:  "changetolerance",
+		Usage: "the max excess `<amount>` paid as extra fee without change by bnb strategy, default is 0.00001",
+	}
+
+	// History flags
+	HistoryRescanFlag = cli.BoolFlag{
+		Name:  "rescan",
+		Usage: "rescan the history from the genesis block",
+	}
+	HistoryFormatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: "the output `<format>`: table, csv or json",
+		Value: "table",
+	}
+	HistoryOutputFlag = cli.StringFlag{
+		Name:  "output, o",
+		Usage: "the `<file>` path to write the history, print it if not specified",
+	}
+	LabelAddressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "the `<address>` to label",
+	}
+	LabelTxIDFlag = cli.StringFlag{
+		Name:  "txid",
+		Usage: "the transaction `<id>` to label",
+	}
+
+	// Partially signed transaction flags
+	PSTxFileFlag = cli.StringFlag{
+		Name:  "pstx",
+		Usage: "the partially signed transaction `<file>`",
+		Value: "to_be_signed.pstx",
+	}
+
+	// Producer flags
+	ProducerOwnerPublicKeyFlag = cli.StringFlag{
+		Name:  "ownerpublickey",
+		Usage: "the owner public key of the producer, use the main account if not specified",
+	}
+	ProducerNodePublicKeyFlag = cli.StringFlag{
+		Name:  "nodepublickey",
+		Usage: "the node public key of the producer, use the owner public key if not specified",
+	}
+	ProducerNickNameFlag = cli.StringFlag{
+		Name:  "nickname",
+		Usage: "the nick name of the producer",
+	}
+	ProducerUrlFlag = cli.StringFlag{
+		Name:  "url",
+		Usage: "the url of the producer",
+	}
+	ProducerLocationFlag = cli.Uint64Flag{
+		Name:  "location",
+		Usage: "the location `<code>` of the producer",
+	}
+	ProducerNetAddressFlag = cli.StringFlag{
+		Name:  "netaddress",
+		Usage: "the `<ip:port>` of the producer node",
+	}
+	ProducerDepositAmountFlag = cli.StringFlag{
+		Name:  "depositamount",
+		Usage: "the deposit `<amount>` to register the producer",
+		Value: "5000",
+	}
+
+	// CR flags
+	CRPublicKeyFlag = cli.StringFlag{
+		Name:  "publickey",
+		Usage: "the public key of the CR, use the main account if not specified",
+	}
+	CRNickNameFlag = cli.StringFlag{
+		Name:  "nickname",
+		Usage: "the nick name of the CR",
+	}
+	CRUrlFlag = cli.StringFlag{
+		Name:  "url",
+		Usage: "the url of the CR",
+	}
+	CRLocationFlag = cli.Uint64Flag{
+		Name:  "location",
+		Usage: "the location `<code>` of the CR",
+	}
+	CRDepositAmountFlag = cli.StringFlag{
+		Name:  "depositamount",
+		Usage: "the deposit `<amount>` to register the CR",
+		Value: "5000",
+	}
+	CRWithoutDIDFlag = cli.BoolFlag{
+		Name:  "nodid",
+		Usage: "build the CR information without DID, which is required before DID is supported",
+	}
+
+	// Proposal flags
+	ProposalOwnerPublicKeyFlag = cli.StringFlag{
+		Name:  "ownerpublickey",
+		Usage: "the public key of the proposal owner, use the main account if not specified",
+	}
+	ProposalTypeFlag = cli.StringFlag{
+		Name:  "proposaltype",
+		Usage: "the `<type>` of the proposal, normal or elip",
+		Value: "normal",
+	}
+	ProposalCategoryFlag = cli.StringFlag{
+		Name:  "category",
+		Usage: "the category data of the proposal",
+	}
+	ProposalDraftHashFlag = cli.StringFlag{
+		Name:  "drafthash",
+		Usage: "the `<hash>` of the proposal draft",
+	}
+	ProposalBudgetsFlag = cli.StringFlag{
+		Name: "budgets",
+		Usage: "the budgets of the proposal in `<type:stage:amount>` format separated by comma, " +
+			"the type is imprest, normalpayment or finalpayment",
+	}
+	ProposalRecipientFlag = cli.StringFlag{
+		Name:  "recipient",
+		Usage: "the `<address>` to receive the proposal budgets",
+	}
+	ProposalCRMemberDIDFlag = cli.StringFlag{
+		Name:  "crmemberdid",
+		Usage: "the DID `<address>` of the CR member who sponsors the proposal",
+	}
+	ProposalVoteResultFlag = cli.StringFlag{
+		Name:  "voteresult",
+		Usage: "the review `<result>` of the proposal, approve, reject or abstain",
+	}
+	ProposalOpinionHashFlag = cli.StringFlag{
+		Name:  "opinionhash",
+		Usage: "the `<hash>` of the opinion",
+	}
+	ProposalMessageHashFlag = cli.StringFlag{
+		Name:  "messagehash",
+		Usage: "the `<hash>` of the tracking message",
+	}
+	ProposalTrackingTypeFlag = cli.StringFlag{
+		Name: "trackingtype",
+		Usage: "the `<type>` of the tracking, common, progress, rejected, " +
+			"terminated, changeowner or finalized",
+	}
+	ProposalNewOwnerPublicKeyFlag = cli.StringFlag{
+		Name:  "newownerpublickey",
+		Usage: "the public key of the new proposal owner",
+	}
+	ProposalSecretaryGeneralFlag = cli.StringFlag{
+		Name:  "secretarygeneral",
+		Usage: "the public key of the secretary general, get from the node if not specified",
+	}
+	ProposalPayloadFileFlag = cli.StringFlag{
+		Name:  "payload",
+		Usage: "the `<file>` to exchange the payload between signers",
+		Value: "payload.json",
+	}
+
 	// RPC flags
 	RPCUserFlag = cli.StringFlag{
 		Name:  "rpcuser",
@@ -15,6 +15,7 @@ import (
 	"github.com/elastos/Elastos.ELA/cmd/mine"
 	"github.com/elastos/Elastos.ELA/cmd/rollback"
 	"github.com/elastos/Elastos.ELA/cmd/script"
+	"github.com/elastos/Elastos.ELA/cmd/utxo"
 	"github.com/elastos/Elastos.ELA/cmd/wallet"
 
 	"github.com/urfave/cli"
@@ -50,6 +51,7 @@ func main() {
 		*mine.NewCommand(),
 		*script.NewCommand(),
 		*rollback.NewCommand(),
+		*utxo.NewCommand(),
 	}
 
 	//sort.Sort(cli.CommandsByName(app.Commands))
@@ -75,6 +75,19 @@ func NewCommand() *cli.Command {
 					return nil
 				},
 			},
+			{
+				Name:  "gettxoutsetinfo",
+				Usage: "Show statistics of the UTXO set",
+				Action: func(c *cli.Context) error {
+					result, err := cmdcom.RPCCall("gettxoutsetinfo", http.Params{})
+					if err != nil {
+						fmt.Println("error: get utxo set info failed,", err)
+						return err
+					}
+					printFormat(result)
+					return nil
+				},
+			},
 			{
 				Name:  "getcurrentheight",
 				Usage: "Get best block height",
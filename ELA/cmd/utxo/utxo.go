@@ -0,0 +1,128 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package utxo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/elastos/Elastos.ELA/blockchain"
+	"github.com/elastos/Elastos.ELA/blockchain/indexers"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/config/settings"
+	"github.com/elastos/Elastos.ELA/common/log"
+
+	"github.com/urfave/cli"
+)
+
+const dataPath = "data"
+
+var appSettings = settings.NewSettings()
+
+func NewCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "utxo",
+		Usage: "Export the UTXO set",
+		Description: "With ela-cli utxo command, you could export the UTXO set " +
+			"from blockchain data. The node should be stopped before exporting.",
+		ArgsUsage: "[args]",
+		Subcommands: []cli.Command{
+			{
+				Name:  "export",
+				Usage: "Export the UTXO set to a CSV file",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "the `<file>` to export the UTXO set to",
+						Value: "utxos.csv",
+					},
+					cmdcom.ConfigFileFlag,
+					cmdcom.DataDirFlag,
+					cmdcom.TestNetFlag,
+					cmdcom.RegTestFlag,
+					cmdcom.InstantBlockFlag,
+				},
+				Action: exportAction,
+			},
+		},
+	}
+}
+
+func exportAction(c *cli.Context) error {
+	appSettings.SetContext(c)
+	appSettings.SetupConfig()
+	appSettings.InitParamsValue()
+
+	output := c.String("output")
+	if output == "" {
+		return errors.New("output file not specified")
+	}
+
+	log.NewDefault("logs/node", 0, 0, 0)
+	dataDir := filepath.Join(c.String("datadir"), dataPath)
+	chainStore, err := blockchain.NewChainStore(dataDir, appSettings.Params())
+	if err != nil {
+		fmt.Println("create chain store failed, ", err)
+		return err
+	}
+	defer chainStore.Close()
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	if _, err := fmt.Fprintln(w, "address,height,txid,vout,value"); err != nil {
+		return err
+	}
+	var count uint64
+	var total common.Fixed64
+	_, _, err = chainStore.GetFFLDB().ForEachUTXO(
+		func(entry *indexers.UTXOEntry) error {
+			address, err := entry.ProgramHash.ToAddress()
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(w, "%s,%d,%s,%d,%s\n", address,
+				entry.Height, reversedString(entry.TxID),
+				entry.Index, entry.Value.String())
+			count++
+			total += entry.Value
+			return err
+		})
+	if err != nil {
+		fmt.Println("export utxo set failed, ", err)
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	info, err := chainStore.GetFFLDB().GetUTXOSetInfo()
+	if err != nil {
+		fmt.Println("get utxo set info failed, ", err)
+		return err
+	}
+	fmt.Println("height:", info.Height)
+	fmt.Println("bestblock:", reversedString(info.BestBlock))
+	fmt.Println("txouts:", count)
+	fmt.Println("total:", total.String())
+	fmt.Println("hash:", reversedString(info.Hash))
+	fmt.Println("exported to", output)
+	return nil
+}
+
+// reversedString returns the hash in the reversed hex string like the RPC
+// interfaces do.
+func reversedString(hash common.Uint256) string {
+	return common.BytesToHexString(common.BytesReverse(hash[:]))
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/elastos/Elastos.ELA/core/contract"
 	"github.com/elastos/Elastos.ELA/crypto"
 	"github.com/elastos/Elastos.ELA/utils"
+	"github.com/elastos/Elastos.ELA/utils/http"
 
 	"github.com/urfave/cli"
 )
@@ -31,6 +32,9 @@ var accountCommand = []cli.Command{
 		Flags: []cli.Flag{
 			cmdcom.AccountWalletFlag,
 			cmdcom.AccountPasswordFlag,
+			cmdcom.AccountMnemonicFlag,
+			cmdcom.AccountMnemonicWordsFlag,
+			cmdcom.AccountPassphraseFlag,
 		},
 		Action: createAccount,
 	},
@@ -62,6 +66,7 @@ var accountCommand = []cli.Command{
 		Flags: []cli.Flag{
 			cmdcom.AccountWalletFlag,
 			cmdcom.AccountPasswordFlag,
+			cmdcom.AccountChangeFlag,
 		},
 		Action: addAccount,
 	},
@@ -87,20 +92,30 @@ var accountCommand = []cli.Command{
 		},
 		Action: delAccount,
 	},
-	//{
-	//	Category: "Account",
-	//	Name:     "chpwd",
-	//	Usage:    "Change wallet password",
-	//	Action:   changePassword,
-	//},
+	{
+		Category: "Account",
+		Name:     "chpwd",
+		Usage:    "Change wallet password",
+		Flags: []cli.Flag{
+			cmdcom.AccountWalletFlag,
+			cmdcom.AccountPasswordFlag,
+			cmdcom.AccountNewPasswordFlag,
+		},
+		Action: changePassword,
+	},
 	{
 		Category:  "Account",
 		Name:      "import",
-		Usage:     "Import an account by private key hex string",
+		Usage:     "Import an account by private key hex string or a HD wallet by mnemonic",
 		ArgsUsage: "[args]",
 		Flags: []cli.Flag{
 			cmdcom.AccountWalletFlag,
 			cmdcom.AccountPasswordFlag,
+			cmdcom.AccountMnemonicFlag,
+			cmdcom.AccountPassphraseFlag,
+			cmdcom.AccountGapLimitFlag,
+			cmdcom.AccountWatchFlag,
+			cmdcom.AccountXPubFlag,
 		},
 		Action: importAccount,
 	},
@@ -111,6 +126,7 @@ var accountCommand = []cli.Command{
 		Flags: []cli.Flag{
 			cmdcom.AccountWalletFlag,
 			cmdcom.AccountPasswordFlag,
+			cmdcom.AccountXPubFlag,
 		},
 		Action: exportAccount,
 	},
@@ -149,6 +165,23 @@ func createAccount(c *cli.Context) error {
 		p = []byte(password)
 	}
 
+	if c.Bool("mnemonic") {
+		mnemonic, err := account.NewMnemonic(c.Int("words"))
+		if err != nil {
+			return err
+		}
+		client, err := account.CreateFromMnemonic(walletPath, p, mnemonic,
+			c.String("passphrase"))
+		if err != nil {
+			return err
+		}
+		fmt.Println("Please write down the mnemonic and keep it safe, " +
+			"it is the only way to restore the wallet:")
+		fmt.Println(mnemonic)
+		fmt.Println()
+		return ShowAccountInfo(client)
+	}
+
 	client, err := account.Create(walletPath, p)
 	if err != nil {
 		return err
@@ -199,7 +232,11 @@ func addAccount(c *cli.Context) error {
 		return err
 	}
 
-	client, err := account.Add(walletPath, password)
+	chain := uint32(account.ExternalChain)
+	if c.Bool("change") {
+		chain = account.InternalChain
+	}
+	client, err := account.AddHD(walletPath, password, chain)
 	if err != nil {
 		return err
 	}
@@ -271,10 +308,47 @@ func delAccount(c *cli.Context) error {
 	return ShowAccountInfo(client)
 }
 
+func changePassword(c *cli.Context) error {
+	walletPath := c.String("wallet")
+	if exist := utils.FileExisted(walletPath); !exist {
+		fmt.Println(fmt.Sprintf("error: %s is not found.", walletPath))
+		cli.ShowCommandHelpAndExit(c, "chpwd", 1)
+	}
+	password, err := cmdcom.GetFlagPassword(c)
+	if err != nil {
+		return err
+	}
+
+	newPassword := []byte(c.String("newpassword"))
+	if len(newPassword) == 0 {
+		fmt.Println("Please input the new password.")
+		newPassword, err = utils.GetConfirmedPassword()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := account.ChangePassword(walletPath, password, newPassword); err != nil {
+		return err
+	}
+	fmt.Println("password changed")
+	return nil
+}
+
 func importAccount(c *cli.Context) error {
 	walletPath := c.String("wallet")
 	pwdHex := c.String("password")
 
+	if c.Bool("mnemonic") {
+		return importMnemonic(c)
+	}
+	if c.Bool("watch") {
+		return importWatchOnly(c)
+	}
+	if c.Bool("xpub") {
+		return importXPub(c)
+	}
+
 	if c.NArg() < 1 {
 		cmdcom.PrintErrorMsg("Missing argument. PrivateKey hex expected.")
 		cli.ShowCommandHelpAndExit(c, "import", 1)
@@ -325,6 +399,140 @@ func importAccount(c *cli.Context) error {
 	return ShowAccountInfo(client)
 }
 
+func importMnemonic(c *cli.Context) error {
+	walletPath := c.String("wallet")
+	if c.NArg() > 0 {
+		return errors.New("the mnemonic should not be given as arguments, " +
+			"enter it when prompted")
+	}
+	if exist := utils.FileExisted(walletPath); exist {
+		return errors.New(walletPath + " already exists, " +
+			"a HD wallet can only be imported to a new wallet file")
+	}
+	mnemonic, err := utils.GetMnemonic()
+	if err != nil {
+		return err
+	}
+	if err := account.ValidateMnemonic(mnemonic); err != nil {
+		return err
+	}
+
+	password := []byte(c.String("password"))
+	if len(password) == 0 {
+		password, err = utils.GetConfirmedPassword()
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := account.CreateFromMnemonic(walletPath, password, mnemonic,
+		c.String("passphrase"))
+	if err != nil {
+		return err
+	}
+
+	discoverHDAccounts(c, client)
+
+	return ShowAccountInfo(client)
+}
+
+func importWatchOnly(c *cli.Context) error {
+	walletPath := c.String("wallet")
+	if c.NArg() < 1 {
+		cmdcom.PrintErrorMsg("Missing argument. Address, public key or redeem script expected.")
+		cli.ShowCommandHelpAndExit(c, "import", 1)
+	}
+	acc, err := account.NewWatchOnlyAccount(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	password := []byte(c.String("password"))
+	if len(password) == 0 {
+		var err error
+		if utils.FileExisted(walletPath) {
+			password, err = utils.GetPassword()
+		} else {
+			password, err = utils.GetConfirmedPassword()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := account.AddWatchOnly(walletPath, password, acc); err != nil {
+		return err
+	}
+	client, err := account.Open(walletPath, password)
+	if err != nil {
+		return err
+	}
+
+	return ShowAccountInfo(client)
+}
+
+func importXPub(c *cli.Context) error {
+	walletPath := c.String("wallet")
+	if c.NArg() < 1 {
+		cmdcom.PrintErrorMsg("Missing argument. Extended public key expected.")
+		cli.ShowCommandHelpAndExit(c, "import", 1)
+	}
+	if exist := utils.FileExisted(walletPath); exist {
+		return errors.New(walletPath + " already exists, " +
+			"an extended public key can only be imported to a new wallet file")
+	}
+
+	password := []byte(c.String("password"))
+	if len(password) == 0 {
+		var err error
+		password, err = utils.GetConfirmedPassword()
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := account.CreateFromXPub(walletPath, password, c.Args().First())
+	if err != nil {
+		return err
+	}
+	discoverHDAccounts(c, client)
+
+	return ShowAccountInfo(client)
+}
+
+// discoverHDAccounts discovers the used addresses of the HD wallet from node.
+// An address is used if it has any transaction, which is queried from the
+// address index of node, or if it has UTXOs when the address index is not
+// available.
+func discoverHDAccounts(c *cli.Context, client *account.Client) {
+	useUTXOs := false
+	accounts, err := client.DiscoverHDAccounts(uint32(c.Uint("gaplimit")),
+		func(address string) (bool, error) {
+			if !useUTXOs {
+				count, err := getAddressTransactionCount(address)
+				if err == nil {
+					return count > 0, nil
+				}
+				if _, ok := err.(*http.Error); !ok {
+					return false, err
+				}
+				fmt.Println("warning: address index not available,", err)
+				fmt.Println("warning: addresses with all their coins spent " +
+					"are treated as unused")
+				useUTXOs = true
+			}
+			available, locked, err := getAddressUTXOs(address)
+			if err != nil {
+				return false, err
+			}
+			return len(available) > 0 || len(locked) > 0, nil
+		})
+	if err != nil {
+		fmt.Println("warning: address discovery failed,", err)
+	}
+	fmt.Println("discovered", len(accounts), "used addresses")
+}
+
 func exportAccount(c *cli.Context) error {
 	walletPath := c.String("wallet")
 	password, err := cmdcom.GetFlagPassword(c)
@@ -337,6 +545,15 @@ func exportAccount(c *cli.Context) error {
 		return err
 	}
 
+	if c.Bool("xpub") {
+		xpub, err := client.AccountXPub()
+		if err != nil {
+			return err
+		}
+		fmt.Println(xpub.String())
+		return nil
+	}
+
 	accounts := client.GetAccounts()
 
 	fmt.Printf("%-34s %-66s\n", "ADDRESS", "PRIVATE KEY")
@@ -344,7 +561,11 @@ func exportAccount(c *cli.Context) error {
 	for _, account := range accounts {
 		prefixType := contract.GetPrefixType(account.ProgramHash)
 		if prefixType == contract.PrefixStandard {
-			fmt.Printf("%-34s %-66s\n", account.Address, hex.EncodeToString(account.PrivKey()))
+			privateKey := hex.EncodeToString(account.PrivKey())
+			if account.PrivKey() == nil {
+				privateKey = "watch-only"
+			}
+			fmt.Printf("%-34s %-66s\n", account.Address, privateKey)
 			fmt.Println(strings.Repeat("-", 34), strings.Repeat("-", 66))
 		}
 	}
@@ -385,9 +606,7 @@ func generateDepositAddress(c *cli.Context) error {
 		return errors.New("standard address expected")
 	}
 
-	codeHash := programHash.ToCodeHash()
-	depositHash := common.Uint168FromCodeHash(byte(contract.PrefixDeposit), codeHash)
-	address, err := depositHash.ToAddress()
+	address, err := toDepositAddress(programHash)
 	if err != nil {
 		return err
 	}
@@ -396,6 +615,13 @@ func generateDepositAddress(c *cli.Context) error {
 	return nil
 }
 
+// toDepositAddress returns the deposit address of the standard program hash.
+func toDepositAddress(programHash *common.Uint168) (string, error) {
+	codeHash := programHash.ToCodeHash()
+	depositHash := common.Uint168FromCodeHash(byte(contract.PrefixDeposit), codeHash)
+	return depositHash.ToAddress()
+}
+
 func getCode(publicKey string) []byte {
 	pkBytes, _ := common.HexStringToBytes(publicKey)
 	pk, _ := crypto.DecodePoint(pkBytes)
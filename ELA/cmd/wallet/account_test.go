@@ -0,0 +1,153 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon " +
+	"abandon abandon abandon abandon abandon about"
+
+// newTestHDWallet creates a HD wallet of the test mnemonic in a temporary
+// directory, the directory is removed by the returned function.
+func newTestHDWallet(t *testing.T, name string) (*account.Client, func()) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := account.CreateFromMnemonic(filepath.Join(dir, name),
+		[]byte(testPassword), testMnemonic, "")
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return client, func() { os.RemoveAll(dir) }
+}
+
+// testReceiveAddresses returns the first count receive addresses of the HD
+// wallet of the test mnemonic.
+func testReceiveAddresses(t *testing.T, count int) []string {
+	client, cleanup := newTestHDWallet(t, "addresses.dat")
+	defer cleanup()
+	addresses := []string{client.GetMainAccount().Address}
+	for len(addresses) < count {
+		ac, err := client.CreateHDAccount(account.ExternalChain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addresses = append(addresses, ac.Address)
+	}
+	return addresses
+}
+
+func TestDiscoverHDAccounts(t *testing.T) {
+	addresses := testReceiveAddresses(t, 4)
+	// the second address spent all its coins, and the fourth one has coins
+	txCounts := map[string]int{addresses[1]: 2, addresses[3]: 1}
+	getAddressTransactions := func(params map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"transactions": []interface{}{},
+			"totalcount":   txCounts[params["address"].(string)],
+		}
+	}
+	listUnspent := func(params map[string]interface{}) interface{} {
+		address := params["addresses"].([]interface{})[0].(string)
+		if address != addresses[3] {
+			return []interface{}{}
+		}
+		return testListUnspent("1")(params)
+	}
+	discover := func() []string {
+		client, cleanup := newTestHDWallet(t, "keystore.dat")
+		defer cleanup()
+		discoverHDAccounts(newTestContext(t,
+			[]cli.Flag{cmdcom.AccountGapLimitFlag},
+			map[string]string{"gaplimit": "2"}), client)
+		var discovered []string
+		for _, ac := range client.GetAccounts() {
+			discovered = append(discovered, ac.Address)
+		}
+		return discovered
+	}
+
+	// the address with spent coins is used by its transactions, so the
+	// discovery goes on to the fourth address
+	stop := startTestRPC(t, map[string]func(map[string]interface{}) interface{}{
+		"getaddresstransactions": getAddressTransactions,
+		"listunspent":            listUnspent,
+	})
+	assert.ElementsMatch(t, []string{addresses[0], addresses[1], addresses[3]},
+		discover())
+	stop()
+
+	// only addresses with UTXOs are used if the address index is disabled,
+	// so the discovery stops before the fourth address
+	stop = startTestRPC(t, map[string]func(map[string]interface{}) interface{}{
+		"listunspent": listUnspent,
+	})
+	assert.Equal(t, []string{addresses[0]}, discover())
+	stop()
+}
+
+func TestImportMnemonic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer startTestRPC(t, map[string]func(map[string]interface{}) interface{}{
+		"getaddresstransactions": func(map[string]interface{}) interface{} {
+			return map[string]interface{}{"totalcount": 0}
+		},
+	})()
+
+	flags := []cli.Flag{cmdcom.AccountWalletFlag, cmdcom.AccountPasswordFlag,
+		cmdcom.AccountPassphraseFlag, cmdcom.AccountGapLimitFlag}
+	values := map[string]string{
+		"wallet":   filepath.Join(dir, "keystore.dat"),
+		"password": testPassword,
+	}
+
+	// the mnemonic is not accepted from arguments
+	err = importMnemonic(newTestContext(t, flags, values,
+		"abandon", "abandon", "about"))
+	assert.EqualError(t, err, "the mnemonic should not be given as "+
+		"arguments, enter it when prompted")
+
+	// the mnemonic is read from the prompt
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+	go func() {
+		w.Write([]byte(" abandon abandon abandon abandon  abandon abandon " +
+			"abandon abandon abandon abandon abandon about\n"))
+		w.Close()
+	}()
+	assert.NoError(t, importMnemonic(newTestContext(t, flags, values)))
+	r.Close()
+
+	expected, cleanup := newTestHDWallet(t, "expected.dat")
+	defer cleanup()
+	client, err := account.Open(values["wallet"], []byte(testPassword))
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected.GetMainAccount().Address,
+			client.GetMainAccount().Address)
+	}
+}
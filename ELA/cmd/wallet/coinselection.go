@@ -0,0 +1,413 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastos/Elastos.ELA/account"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/outputpayload"
+
+	"github.com/urfave/cli"
+)
+
+// Coin selection strategies.
+const (
+	// StrategyLargestFirst selects the largest UTXOs first, which uses the
+	// fewest inputs.
+	StrategyLargestFirst = "largest"
+
+	// StrategySmallestFirst selects the smallest UTXOs first, which spends
+	// the dust UTXOs.
+	StrategySmallestFirst = "smallest"
+
+	// StrategyBranchAndBound searches the UTXOs which cover the amount
+	// without change, and falls back to largest first if not found.
+	StrategyBranchAndBound = "bnb"
+
+	// StrategyPrivacy selects a single UTXO covers the amount if exists,
+	// otherwise selects UTXOs randomly, to avoid revealing the UTXOs of
+	// wallet unnecessarily.
+	StrategyPrivacy = "privacy"
+)
+
+const (
+	// maxTransactionSize is the max size of transaction built by wallet. A
+	// node rejects transactions larger than its block context size, which is
+	// MaxBlockSize in config.json, 2000000 on main net if not configured and
+	// pact.MaxBlockContextSize 8000000 on the other nets. The configured value
+	// of node is unknown to wallet, so the smallest default is taken.
+	maxTransactionSize = 2000000
+
+	// inputSize is the serialized size of an input, which contains the
+	// previous transaction hash, previous output index and sequence.
+	inputSize = 32 + 2 + 4
+
+	// txReservedSize is the size reserved for the parts of a transaction
+	// other than inputs.
+	txReservedSize = 100000
+
+	// DefaultMaxInputs is the max count of inputs in a transaction, so that
+	// the transaction does not exceed maxTransactionSize.
+	DefaultMaxInputs = (maxTransactionSize - txReservedSize) / inputSize
+
+	// bnbMaxTries is the max count of tries of branch and bound selection.
+	bnbMaxTries = 100000
+
+	// DefaultChangeTolerance is the default cost of change, the max excess
+	// paid as extra fee instead of creating a change output by branch and
+	// bound selection.  A change output smaller than it is not worth to be
+	// created and spent later.
+	DefaultChangeTolerance = common.Fixed64(1000)
+)
+
+// CoinSelection is the options to select UTXOs as inputs of a transaction.
+type CoinSelection struct {
+	// OutPoints is the UTXOs specified to spend, Strategy is ignored if it's
+	// not empty.
+	OutPoints []*types.OutPoint
+
+	// Strategy is the strategy to select UTXOs, UTXOs are selected by the
+	// node if it's empty.
+	Strategy string
+
+	// MaxInputs is the max count of inputs selected.
+	MaxInputs int
+
+	// ChangeTolerance is the max excess paid as extra fee instead of creating
+	// a change output by branch and bound selection, it's independent of the
+	// fee so the fee paid is never more than fee plus ChangeTolerance.
+	ChangeTolerance common.Fixed64
+}
+
+// coin is a UTXO can be spent by the transaction.
+type coin struct {
+	input      *types.Input
+	amount     common.Fixed64
+	outputLock uint32
+}
+
+// getCoinSelection parses the coin control flags.
+func getCoinSelection(c *cli.Context) (*CoinSelection, error) {
+	cs := &CoinSelection{
+		Strategy:        c.String("strategy"),
+		MaxInputs:       c.Int("maxinputs"),
+		ChangeTolerance: DefaultChangeTolerance,
+	}
+	if cs.MaxInputs <= 0 {
+		cs.MaxInputs = DefaultMaxInputs
+	}
+	if toleranceStr := c.String("changetolerance"); toleranceStr != "" {
+		tolerance, err := common.StringToFixed64(toleranceStr)
+		if err != nil || *tolerance < 0 {
+			return nil, errors.New("invalid change tolerance")
+		}
+		cs.ChangeTolerance = *tolerance
+	}
+	switch cs.Strategy {
+	case "", StrategyLargestFirst, StrategySmallestFirst,
+		StrategyBranchAndBound, StrategyPrivacy:
+	default:
+		return nil, errors.New("invalid coin selection strategy " + cs.Strategy)
+	}
+
+	utxos := strings.TrimSpace(strings.Trim(c.String("utxo"), ","))
+	if utxos == "" {
+		return cs, nil
+	}
+	for _, utxo := range strings.Split(utxos, ",") {
+		outPoint, err := parseOutPoint(strings.TrimSpace(utxo))
+		if err != nil {
+			return nil, err
+		}
+		cs.OutPoints = append(cs.OutPoints, outPoint)
+	}
+	if cs.Strategy != "" {
+		return nil, errors.New("'--strategy' cannot be specified when specify '--utxo' option")
+	}
+
+	return cs, nil
+}
+
+// parseOutPoint parses the UTXO in format of txid:index.
+func parseOutPoint(s string) (*types.OutPoint, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return nil, errors.New("invalid utxo " + s + ", txid:index expected")
+	}
+	txIDReverse, err := hex.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.New("invalid utxo txid " + parts[0])
+	}
+	txID, err := common.Uint256FromBytes(common.BytesReverse(txIDReverse))
+	if err != nil {
+		return nil, errors.New("invalid utxo txid " + parts[0])
+	}
+	index, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return nil, errors.New("invalid utxo index " + parts[1])
+	}
+	return types.NewOutPoint(*txID, uint16(index)), nil
+}
+
+// listCoins returns the UTXOs of address which can be spent.
+func listCoins(address string) ([]*coin, error) {
+	availableUTXOs, _, err := getAddressUTXOs(address)
+	if err != nil {
+		return nil, err
+	}
+
+	coins := make([]*coin, 0, len(availableUTXOs))
+	for _, utxo := range availableUTXOs {
+		txIDReverse, err := hex.DecodeString(utxo.TxID)
+		if err != nil {
+			return nil, err
+		}
+		txID, err := common.Uint256FromBytes(common.BytesReverse(txIDReverse))
+		if err != nil {
+			return nil, err
+		}
+		amount, err := common.StringToFixed64(utxo.Amount)
+		if err != nil {
+			return nil, err
+		}
+		sequence := math.MaxUint32
+		if utxo.OutputLock > 0 {
+			sequence = math.MaxUint32 - 1
+		}
+		coins = append(coins, &coin{
+			input: &types.Input{
+				Previous: *types.NewOutPoint(*txID, utxo.VOut),
+				Sequence: uint32(sequence),
+			},
+			amount:     *amount,
+			outputLock: utxo.OutputLock,
+		})
+	}
+
+	return coins, nil
+}
+
+// coinInputs returns the inputs spending the coins.
+func coinInputs(coins []*coin) []*types.Input {
+	inputs := make([]*types.Input, 0, len(coins))
+	for _, c := range coins {
+		inputs = append(inputs, c.input)
+	}
+	return inputs
+}
+
+// selectInputs selects the UTXOs of address to cover the total amount, and
+// creates the change output if needed.
+func selectInputs(fromAddr string, totalAmount common.Fixed64,
+	cs *CoinSelection) ([]*coin, []*types.Output, error) {
+	if len(cs.OutPoints) == 0 && cs.Strategy == "" {
+		coins, changes, err := createCoins(fromAddr, totalAmount)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(coins) > cs.MaxInputs {
+			return nil, nil, fmt.Errorf("%d inputs exceed the max inputs %d, "+
+				"use --strategy or consolidate the UTXOs first", len(coins), cs.MaxInputs)
+		}
+		return coins, changes, nil
+	}
+
+	coins, err := listCoins(fromAddr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var selected []*coin
+	tolerance := common.Fixed64(0)
+	switch {
+	case len(cs.OutPoints) > 0:
+		selected, err = selectOutPoints(coins, cs.OutPoints)
+	case cs.Strategy == StrategyLargestFirst:
+		selected, err = selectLargestFirst(coins, totalAmount, cs.MaxInputs)
+	case cs.Strategy == StrategySmallestFirst:
+		selected, err = selectSmallestFirst(coins, totalAmount, cs.MaxInputs)
+	case cs.Strategy == StrategyBranchAndBound:
+		selected = selectBranchAndBound(coins, totalAmount, cs.ChangeTolerance,
+			cs.MaxInputs)
+		if selected != nil {
+			tolerance = cs.ChangeTolerance
+		} else {
+			selected, err = selectLargestFirst(coins, totalAmount, cs.MaxInputs)
+		}
+	case cs.Strategy == StrategyPrivacy:
+		selected, err = selectPrivacy(coins, totalAmount, cs.MaxInputs)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(selected) > cs.MaxInputs {
+		return nil, nil, fmt.Errorf("%d inputs exceed the max inputs %d",
+			len(selected), cs.MaxInputs)
+	}
+
+	var amount common.Fixed64
+	for _, c := range selected {
+		amount += c.amount
+	}
+	if amount < totalAmount {
+		return nil, nil, errors.New("[Wallet], Available token is not enough")
+	}
+
+	var changeOutputs []*types.Output
+	if excess := amount - totalAmount; excess > 0 && excess <= tolerance {
+		fmt.Println("no change output, the excess", excess.String(),
+			"is paid as extra fee")
+	} else if excess > tolerance {
+		programHash, err := common.Uint168FromAddress(fromAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+		changeOutputs = append(changeOutputs, &types.Output{
+			AssetID:     *account.SystemAssetID,
+			Value:       amount - totalAmount,
+			OutputLock:  uint32(0),
+			ProgramHash: *programHash,
+			Type:        types.OTNone,
+			Payload:     &outputpayload.DefaultOutput{},
+		})
+	}
+
+	return selected, changeOutputs, nil
+}
+
+func selectOutPoints(coins []*coin, outPoints []*types.OutPoint) ([]*coin, error) {
+	available := make(map[types.OutPoint]*coin, len(coins))
+	for _, c := range coins {
+		available[c.input.Previous] = c
+	}
+
+	selected := make([]*coin, 0, len(outPoints))
+	for _, op := range outPoints {
+		c, ok := available[*op]
+		if !ok {
+			return nil, fmt.Errorf("utxo %s:%d is not available",
+				common.BytesToHexString(common.BytesReverse(op.TxID.Bytes())), op.Index)
+		}
+		delete(available, *op)
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
+
+// accumulate selects coins by order until the amount is covered.
+func accumulate(coins []*coin, amount common.Fixed64,
+	maxInputs int) ([]*coin, error) {
+	var total common.Fixed64
+	for i, c := range coins {
+		if i == maxInputs {
+			return nil, fmt.Errorf("amount can not be covered by %d inputs, "+
+				"consolidate the UTXOs first", maxInputs)
+		}
+		total += c.amount
+		if total >= amount {
+			return coins[:i+1], nil
+		}
+	}
+	return nil, errors.New("[Wallet], Available token is not enough")
+}
+
+func selectLargestFirst(coins []*coin, amount common.Fixed64,
+	maxInputs int) ([]*coin, error) {
+	sort.SliceStable(coins, func(i, j int) bool {
+		return coins[i].amount > coins[j].amount
+	})
+	return accumulate(coins, amount, maxInputs)
+}
+
+func selectSmallestFirst(coins []*coin, amount common.Fixed64,
+	maxInputs int) ([]*coin, error) {
+	sort.SliceStable(coins, func(i, j int) bool {
+		return coins[i].amount < coins[j].amount
+	})
+	return accumulate(coins, amount, maxInputs)
+}
+
+func selectPrivacy(coins []*coin, amount common.Fixed64,
+	maxInputs int) ([]*coin, error) {
+	var single *coin
+	for _, c := range coins {
+		if c.amount >= amount && (single == nil || c.amount < single.amount) {
+			single = c
+		}
+	}
+	if single != nil {
+		return []*coin{single}, nil
+	}
+
+	rand.Shuffle(len(coins), func(i, j int) {
+		coins[i], coins[j] = coins[j], coins[i]
+	})
+	return accumulate(coins, amount, maxInputs)
+}
+
+// selectBranchAndBound searches the coins of which the total is between amount
+// and amount plus tolerance with the least excess, returns nil if not found.
+func selectBranchAndBound(coins []*coin, amount, tolerance common.Fixed64,
+	maxInputs int) []*coin {
+	sort.SliceStable(coins, func(i, j int) bool {
+		return coins[i].amount > coins[j].amount
+	})
+	// remaining[i] is the total of coins from index i.
+	remaining := make([]common.Fixed64, len(coins)+1)
+	for i := len(coins) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + coins[i].amount
+	}
+
+	var best, selected []int
+	var bestExcess common.Fixed64
+	var tries int
+	var search func(index int, total common.Fixed64)
+	search = func(index int, total common.Fixed64) {
+		if tries >= bnbMaxTries || (best != nil && bestExcess == 0) {
+			return
+		}
+		tries++
+		if total > amount+tolerance {
+			return
+		}
+		if total >= amount {
+			if best == nil || total-amount < bestExcess {
+				best = append([]int{}, selected...)
+				bestExcess = total - amount
+			}
+			return
+		}
+		if index == len(coins) || total+remaining[index] < amount ||
+			len(selected) == maxInputs {
+			return
+		}
+
+		selected = append(selected, index)
+		search(index+1, total+coins[index].amount)
+		selected = selected[:len(selected)-1]
+		search(index+1, total)
+	}
+	search(0, 0)
+
+	if best == nil {
+		return nil
+	}
+	result := make([]*coin, 0, len(best))
+	for _, i := range best {
+		result = append(result, coins[i])
+	}
+	return result
+}
@@ -0,0 +1,353 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/outputpayload"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+// newCoins returns coins of the amounts, the index of outpoint is the index
+// of the amount.
+func newCoins(amounts ...common.Fixed64) []*coin {
+	coins := make([]*coin, 0, len(amounts))
+	for i, amount := range amounts {
+		coins = append(coins, &coin{
+			input: &types.Input{
+				Previous: *types.NewOutPoint(common.Uint256{1}, uint16(i)),
+			},
+			amount: amount,
+		})
+	}
+	return coins
+}
+
+// coinAmounts returns the amounts of coins by order.
+func coinAmounts(coins []*coin) []common.Fixed64 {
+	amounts := make([]common.Fixed64, 0, len(coins))
+	for _, c := range coins {
+		amounts = append(amounts, c.amount)
+	}
+	return amounts
+}
+
+// testListUnspent returns a handler of listunspent answering UTXOs of the
+// amounts.
+func testListUnspent(amounts ...string) func(map[string]interface{}) interface{} {
+	return func(params map[string]interface{}) interface{} {
+		address := params["addresses"].([]interface{})[0]
+		utxos := make([]map[string]interface{}, 0, len(amounts))
+		for i, amount := range amounts {
+			utxos = append(utxos, map[string]interface{}{
+				"txid":          common.BytesToHexString(make([]byte, 32)),
+				"vout":          i,
+				"address":       address,
+				"amount":        amount,
+				"confirmations": 200,
+			})
+		}
+		return utxos
+	}
+}
+
+func TestSelectAccumulate(t *testing.T) {
+	tests := []struct {
+		name      string
+		selection func([]*coin, common.Fixed64, int) ([]*coin, error)
+		amounts   []common.Fixed64
+		amount    common.Fixed64
+		maxInputs int
+		expected  []common.Fixed64
+		err       string
+	}{
+		{"largest", selectLargestFirst, []common.Fixed64{1, 5, 3, 4}, 8,
+			DefaultMaxInputs, []common.Fixed64{5, 4}, ""},
+		{"largest exact", selectLargestFirst, []common.Fixed64{1, 5, 3}, 5,
+			DefaultMaxInputs, []common.Fixed64{5}, ""},
+		{"largest all", selectLargestFirst, []common.Fixed64{1, 5, 3}, 9,
+			DefaultMaxInputs, []common.Fixed64{5, 3, 1}, ""},
+		{"largest not enough", selectLargestFirst, []common.Fixed64{1, 5, 3}, 10,
+			DefaultMaxInputs, nil, "[Wallet], Available token is not enough"},
+		{"largest max inputs", selectLargestFirst, []common.Fixed64{1, 5, 3}, 9,
+			2, nil, "amount can not be covered by 2 inputs, consolidate the UTXOs first"},
+		{"smallest", selectSmallestFirst, []common.Fixed64{1, 5, 3, 4}, 8,
+			DefaultMaxInputs, []common.Fixed64{1, 3, 4}, ""},
+		{"smallest max inputs", selectSmallestFirst, []common.Fixed64{1, 5, 3, 4}, 8,
+			2, nil, "amount can not be covered by 2 inputs, consolidate the UTXOs first"},
+		{"smallest not enough", selectSmallestFirst, nil, 1,
+			DefaultMaxInputs, nil, "[Wallet], Available token is not enough"},
+		{"privacy single", selectPrivacy, []common.Fixed64{9, 5, 7, 3}, 6,
+			DefaultMaxInputs, []common.Fixed64{7}, ""},
+		{"privacy exact", selectPrivacy, []common.Fixed64{9, 5, 7, 3}, 5,
+			DefaultMaxInputs, []common.Fixed64{5}, ""},
+		{"privacy all", selectPrivacy, []common.Fixed64{9, 5, 7, 3}, 24,
+			DefaultMaxInputs, nil, ""},
+		{"privacy not enough", selectPrivacy, []common.Fixed64{9, 5, 7, 3}, 25,
+			DefaultMaxInputs, nil, "[Wallet], Available token is not enough"},
+		{"privacy max inputs", selectPrivacy, []common.Fixed64{9, 5, 7, 3}, 24,
+			3, nil, "amount can not be covered by 3 inputs, consolidate the UTXOs first"},
+	}
+	for _, test := range tests {
+		selected, err := test.selection(newCoins(test.amounts...), test.amount,
+			test.maxInputs)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.name)
+			continue
+		}
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+		if test.expected != nil {
+			assert.Equal(t, test.expected, coinAmounts(selected), test.name)
+		}
+		var total common.Fixed64
+		for _, c := range selected {
+			total += c.amount
+		}
+		assert.True(t, total >= test.amount, test.name)
+	}
+}
+
+func TestSelectPrivacy_Random(t *testing.T) {
+	// the coins are selected randomly if no single coin covers the amount
+	orders := make(map[common.Fixed64]struct{})
+	for i := 0; i < 100; i++ {
+		selected, err := selectPrivacy(newCoins(1, 2, 3, 4, 5, 6), 7,
+			DefaultMaxInputs)
+		assert.NoError(t, err)
+		orders[selected[0].amount] = struct{}{}
+	}
+	assert.True(t, len(orders) > 1)
+}
+
+func TestSelectBranchAndBound(t *testing.T) {
+	tests := []struct {
+		name      string
+		amounts   []common.Fixed64
+		amount    common.Fixed64
+		tolerance common.Fixed64
+		maxInputs int
+		expected  []common.Fixed64
+	}{
+		{"exact single", []common.Fixed64{10, 7, 3}, 7, 0, DefaultMaxInputs,
+			[]common.Fixed64{7}},
+		{"exact many", []common.Fixed64{10, 6, 5, 2}, 13, 0, DefaultMaxInputs,
+			[]common.Fixed64{6, 5, 2}},
+		{"least excess", []common.Fixed64{10, 6, 5}, 12, 4, DefaultMaxInputs,
+			[]common.Fixed64{10, 5}},
+		{"excess over tolerance", []common.Fixed64{10, 6, 5}, 12, 0,
+			DefaultMaxInputs, nil},
+		{"not enough", []common.Fixed64{10, 6, 5}, 22, 100, DefaultMaxInputs,
+			nil},
+		{"max inputs", []common.Fixed64{10, 6, 5, 2}, 13, 0, 2, nil},
+		{"no coins", nil, 1, 100, DefaultMaxInputs, nil},
+	}
+	for _, test := range tests {
+		selected := selectBranchAndBound(newCoins(test.amounts...), test.amount,
+			test.tolerance, test.maxInputs)
+		if test.expected == nil {
+			assert.Nil(t, selected, test.name)
+			continue
+		}
+		assert.Equal(t, test.expected, coinAmounts(selected), test.name)
+	}
+}
+
+func TestGetCoinSelection(t *testing.T) {
+	flags := []cli.Flag{
+		cmdcom.TransactionUTXOFlag,
+		cmdcom.TransactionStrategyFlag,
+		cmdcom.TransactionMaxInputsFlag,
+		cmdcom.TransactionChangeToleranceFlag,
+	}
+	cs, err := getCoinSelection(newTestContext(t, flags, nil))
+	if assert.NoError(t, err) {
+		assert.Equal(t, DefaultMaxInputs, cs.MaxInputs)
+		assert.Equal(t, DefaultChangeTolerance, cs.ChangeTolerance)
+	}
+
+	// the change tolerance is not related to the fee
+	cs, err = getCoinSelection(newTestContext(t, flags, map[string]string{
+		"strategy":        "bnb",
+		"maxinputs":       "10",
+		"changetolerance": "0.001",
+	}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, StrategyBranchAndBound, cs.Strategy)
+		assert.Equal(t, 10, cs.MaxInputs)
+		assert.Equal(t, common.Fixed64(1e5), cs.ChangeTolerance)
+	}
+
+	for _, values := range []map[string]string{
+		{"changetolerance": "-1"},
+		{"changetolerance": "invalid"},
+		{"strategy": "unknown"},
+		{"strategy": "bnb", "utxo": common.BytesToHexString(make([]byte, 32)) + ":0"},
+		{"utxo": "invalid"},
+	} {
+		_, err := getCoinSelection(newTestContext(t, flags, values))
+		assert.Error(t, err, values)
+	}
+}
+
+func TestSelectInputs_BranchAndBound(t *testing.T) {
+	stop := startTestRPC(t, map[string]func(map[string]interface{}) interface{}{
+		"listunspent": testListUnspent("1", "2.00001", "5"),
+	})
+	defer stop()
+	address := "EJbTbWd8a9rdutUfvBxhcrvEeNy21tW1Ee"
+	cs := &CoinSelection{
+		Strategy:        StrategyBranchAndBound,
+		MaxInputs:       DefaultMaxInputs,
+		ChangeTolerance: DefaultChangeTolerance,
+	}
+
+	// the excess within the change tolerance is paid as extra fee
+	inputs, changes, err := selectInputs(address, 3*1e8, cs)
+	assert.NoError(t, err)
+	assert.Len(t, inputs, 2)
+	assert.Empty(t, changes)
+
+	// the change is created if the excess exceeds the change tolerance, no
+	// matter how large the fee is
+	cs.ChangeTolerance = 0
+	inputs, changes, err = selectInputs(address, 3*1e8, cs)
+	assert.NoError(t, err)
+	assert.Len(t, inputs, 1)
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, common.Fixed64(2*1e8), changes[0].Value)
+	}
+}
+
+func TestDefaultMaxInputs(t *testing.T) {
+	walletPath, _, cleanup := newTestWallet(t)
+	defer cleanup()
+	sender, err := getSender(walletPath, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	programHash, err := common.Uint168FromAddress(sender.Address)
+	assert.NoError(t, err)
+
+	stop := startTestRPC(t, map[string]func(map[string]interface{}) interface{}{
+		"getcurrentheight": testCurrentHeight(1000000),
+	})
+	defer stop()
+
+	// the transaction with the max inputs fits the max transaction size
+	coins := newCoins(make([]common.Fixed64, DefaultMaxInputs)...)
+	txn, err := newTransferTransaction(sender, coins, []*types.Output{{
+		AssetID:     *account.SystemAssetID,
+		Value:       1,
+		ProgramHash: *programHash,
+		Type:        types.OTNone,
+		Payload:     &outputpayload.DefaultOutput{},
+	}}, 0)
+	if assert.NoError(t, err) {
+		assert.True(t, txn.GetSize() <= maxTransactionSize)
+	}
+}
+
+func TestCreateConsolidateTransaction(t *testing.T) {
+	walletPath, client, cleanup := newTestWallet(t)
+	defer cleanup()
+	main := client.GetMainAccount()
+	handlers := map[string]func(map[string]interface{}) interface{}{
+		"listunspent":      testListUnspent("5", "1", "3", "2", "4"),
+		"getcurrentheight": testCurrentHeight(1000000),
+	}
+	stop := startTestRPC(t, handlers)
+	defer stop()
+
+	// the transaction file is written to the working directory
+	dir, err := ioutil.TempDir("", "consolidate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	defer os.Chdir(wd)
+
+	flags := []cli.Flag{
+		cmdcom.TransactionFromFlag,
+		cmdcom.TransactionToFlag,
+		cmdcom.TransactionFeeFlag,
+		cmdcom.TransactionMaxInputsFlag,
+		cmdcom.AccountWalletFlag,
+	}
+	values := map[string]string{
+		"wallet":    walletPath,
+		"fee":       "0.1",
+		"maxinputs": "3",
+	}
+	if !assert.NoError(t, CreateConsolidateTransaction(
+		newTestContext(t, flags, values))) {
+		return
+	}
+	data, err := ioutil.ReadFile("to_be_signed.txn")
+	assert.NoError(t, err)
+	raw, err := common.HexStringToBytes(string(data))
+	assert.NoError(t, err)
+	var txn types.Transaction
+	if !assert.NoError(t, txn.Deserialize(bytes.NewReader(raw))) {
+		return
+	}
+
+	// the smallest UTXOs are consolidated to the from address
+	if assert.Len(t, txn.Inputs, 3) {
+		assert.Equal(t, uint16(1), txn.Inputs[0].Previous.Index)
+		assert.Equal(t, uint16(3), txn.Inputs[1].Previous.Index)
+		assert.Equal(t, uint16(2), txn.Inputs[2].Previous.Index)
+	}
+	if assert.Len(t, txn.Outputs, 1) {
+		assert.Equal(t, main.ProgramHash, txn.Outputs[0].ProgramHash)
+		assert.Equal(t, common.Fixed64(6*1e8-1e7), txn.Outputs[0].Value)
+	}
+	assert.Equal(t, uint32(0), txn.LockTime)
+
+	// the locked UTXO is spent after the output lock of it
+	handlers["listunspent"] = func(params map[string]interface{}) interface{} {
+		utxos := testListUnspent("5", "1", "3", "2", "4")(params).([]map[string]interface{})
+		utxos[3]["outputlock"] = 1000
+		return utxos
+	}
+	if !assert.NoError(t, CreateConsolidateTransaction(
+		newTestContext(t, flags, values))) {
+		return
+	}
+	data, err = ioutil.ReadFile("to_be_signed.txn")
+	assert.NoError(t, err)
+	raw, err = common.HexStringToBytes(string(data))
+	assert.NoError(t, err)
+	txn = types.Transaction{}
+	if assert.NoError(t, txn.Deserialize(bytes.NewReader(raw))) &&
+		assert.Len(t, txn.Inputs, 3) {
+		assert.Equal(t, uint32(1000), txn.LockTime)
+		assert.Equal(t, uint32(math.MaxUint32-1), txn.Inputs[1].Sequence)
+		assert.Equal(t, uint32(math.MaxUint32), txn.Inputs[0].Sequence)
+	}
+
+	values["fee"] = "6"
+	assert.EqualError(t, CreateConsolidateTransaction(
+		newTestContext(t, flags, values)),
+		"the amount of UTXOs is not enough to pay the fee")
+	values["fee"] = "0.1"
+	values["maxinputs"] = "1"
+	assert.EqualError(t, CreateConsolidateTransaction(
+		newTestContext(t, flags, values)), "no enough UTXOs to consolidate")
+}
@@ -61,8 +61,15 @@ func ShowAccountInfo(client *account.Client) error {
 			return err
 		}
 		prefixType := contract.GetPrefixType(acc.ProgramHash)
-		if prefixType == contract.PrefixStandard {
-			fmt.Printf("%-34s %-66s\n", addr, hex.EncodeToString(publicKey))
+		if len(acc.RedeemScript) == 0 {
+			fmt.Printf("%-34s %-66s\n", addr, "watch-only")
+		} else if prefixType == contract.PrefixStandard {
+			if acc.PrivateKey == nil {
+				fmt.Printf("%-34s %-66s %s\n", addr, hex.EncodeToString(publicKey),
+					"watch-only")
+			} else {
+				fmt.Printf("%-34s %-66s\n", addr, hex.EncodeToString(publicKey))
+			}
 		} else if prefixType == contract.PrefixMultiSig {
 			publicKeys, err := crypto.ParseMultisigScript(acc.RedeemScript)
 			if err != nil {
@@ -105,6 +112,18 @@ func ShowAccountBalance(walletPath string) error {
 	return nil
 }
 
+func getCurrentHeight() (uint32, error) {
+	result, err := cmdcom.RPCCall("getcurrentheight", http.Params{})
+	if err != nil {
+		return 0, err
+	}
+	height, ok := result.(float64)
+	if !ok {
+		return 0, errors.New("invalid current height")
+	}
+	return uint32(height), nil
+}
+
 func getUTXOsByAmount(address string, amount common.Fixed64) ([]servers.UTXOInfo, error) {
 	result, err := cmdcom.RPCCall("getutxosbyamount", http.Params{
 		"address": address,
@@ -150,6 +169,27 @@ func getAddressUTXOs(address string) ([]servers.UTXOInfo, []servers.UTXOInfo, er
 	return availableUTXOs, lockedUTXOs, nil
 }
 
+// getAddressTransactionCount returns the count of transactions credited or
+// debited the address, which needs the address index of the node.
+func getAddressTransactionCount(address string) (uint32, error) {
+	result, err := cmdcom.RPCCall("getaddresstransactions", http.Params{
+		"address": address,
+		"limit":   1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return 0, err
+	}
+	var info servers.AddressTransactionsInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return 0, err
+	}
+	return info.TotalCount, nil
+}
+
 func getAddressBalance(address string) (common.Fixed64, common.Fixed64, error) {
 	availableUTXOs, lockedUTXOs, err := getAddressUTXOs(address)
 	if err != nil {
@@ -227,7 +267,7 @@ func OutputTx(haveSign, needSign int, txn *types.Transaction) error {
 	return nil
 }
 
-func parseMultiOutput(path string) ([]*OutputInfo, error) {
+func parseMultiOutput(path string, heights *lockHeights) ([]*OutputInfo, error) {
 	if _, err := os.Stat(path); err != nil {
 		return nil, errors.New("invalid multi output file path")
 	}
@@ -238,6 +278,7 @@ func parseMultiOutput(path string) ([]*OutputInfo, error) {
 
 	var multiOutput []*OutputInfo
 	r := csv.NewReader(file)
+	r.FieldsPerRecord = -1
 	for {
 		record, err := r.Read()
 		if err == io.EOF {
@@ -246,6 +287,9 @@ func parseMultiOutput(path string) ([]*OutputInfo, error) {
 		if err != nil {
 			return nil, errors.New(fmt.Sprint("invalid multi output data:", err.Error()))
 		}
+		if len(record) < 2 || len(record) > 3 {
+			return nil, errors.New(fmt.Sprint("invalid multi output data:", record))
+		}
 
 		amountStr := strings.TrimSpace(record[1])
 		amount, err := common.StringToFixed64(amountStr)
@@ -253,8 +297,20 @@ func parseMultiOutput(path string) ([]*OutputInfo, error) {
 			return nil, errors.New("invalid multi output transaction amount: " + amountStr)
 		}
 		address := strings.TrimSpace(record[0])
-		multiOutput = append(multiOutput, &OutputInfo{address, amount})
-		fmt.Println("Multi output address:", address, ", amount:", amountStr)
+		var outputLock uint32
+		if len(record) > 2 {
+			outputLock, err = heights.parse(record[2])
+			if err != nil {
+				return nil, errors.New("invalid multi output lock height: " + record[2])
+			}
+		}
+		multiOutput = append(multiOutput, &OutputInfo{address, amount, outputLock})
+		if outputLock > 0 {
+			fmt.Println("Multi output address:", address, ", amount:", amountStr,
+				", lock height:", outputLock)
+		} else {
+			fmt.Println("Multi output address:", address, ", amount:", amountStr)
+		}
 	}
 
 	return multiOutput, nil
@@ -0,0 +1,340 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/contract"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+
+	"github.com/urfave/cli"
+)
+
+var crCommand = []cli.Command{
+	{
+		Category: "CR",
+		Name:     "cr",
+		Usage:    "Build transactions to manage a CR candidate",
+		Description: "With ela-cli wallet cr, you could register, update " +
+			"and unregister a CR candidate, and return the deposit after unregistered.",
+		ArgsUsage: "[args]",
+		Subcommands: []cli.Command{
+			{
+				Name:  "register",
+				Usage: "Build a tx to register CR candidate",
+				Flags: []cli.Flag{
+					cmdcom.CRPublicKeyFlag,
+					cmdcom.CRNickNameFlag,
+					cmdcom.CRUrlFlag,
+					cmdcom.CRLocationFlag,
+					cmdcom.CRDepositAmountFlag,
+					cmdcom.CRWithoutDIDFlag,
+					cmdcom.TransactionFromFlag,
+					cmdcom.TransactionFeeFlag,
+					cmdcom.AccountWalletFlag,
+					cmdcom.AccountPasswordFlag,
+					cmdcom.AccountSignerFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateRegisterCRTransaction(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "update",
+				Usage: "Build a tx to update CR candidate information",
+				Flags: []cli.Flag{
+					cmdcom.CRPublicKeyFlag,
+					cmdcom.CRNickNameFlag,
+					cmdcom.CRUrlFlag,
+					cmdcom.CRLocationFlag,
+					cmdcom.CRWithoutDIDFlag,
+					cmdcom.TransactionFromFlag,
+					cmdcom.TransactionFeeFlag,
+					cmdcom.AccountWalletFlag,
+					cmdcom.AccountPasswordFlag,
+					cmdcom.AccountSignerFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateUpdateCRTransaction(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "unregister",
+				Usage: "Build a tx to unregister CR candidate",
+				Flags: []cli.Flag{
+					cmdcom.CRPublicKeyFlag,
+					cmdcom.TransactionFromFlag,
+					cmdcom.TransactionFeeFlag,
+					cmdcom.AccountWalletFlag,
+					cmdcom.AccountPasswordFlag,
+					cmdcom.AccountSignerFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateUnregisterCRTransaction(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "returndeposit",
+				Usage: "Build a tx to return deposit coin of CR",
+				Flags: []cli.Flag{
+					cmdcom.CRPublicKeyFlag,
+					cmdcom.TransactionToFlag,
+					cmdcom.TransactionAmountFlag,
+					cmdcom.TransactionFeeFlag,
+					cmdcom.AccountWalletFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateReturnCRDepositCoinTransaction(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+		},
+	},
+}
+
+func CreateRegisterCRTransaction(c *cli.Context) error {
+	fee, err := getFlagFee(c)
+	if err != nil {
+		return err
+	}
+
+	depositAmount, err := common.StringToFixed64(c.String("depositamount"))
+	if err != nil {
+		return errors.New("invalid deposit amount")
+	}
+
+	info, version, err := createCRInfo(c)
+	if err != nil {
+		return err
+	}
+
+	depositContract, err := contract.CreateDepositContractByCode(info.Code)
+	if err != nil {
+		return err
+	}
+	depositAddress, err := depositContract.ToProgramHash().ToAddress()
+	if err != nil {
+		return err
+	}
+
+	txn, err := createPayloadTransaction(c.String("wallet"), c.String("from"),
+		*fee, types.RegisterCR, info, &OutputInfo{
+			Recipient: depositAddress,
+			Amount:    depositAmount,
+		})
+	if err != nil {
+		return errors.New("create transaction failed: " + err.Error())
+	}
+	txn.PayloadVersion = version
+
+	OutputTx(0, 1, txn)
+
+	return nil
+}
+
+func CreateUpdateCRTransaction(c *cli.Context) error {
+	fee, err := getFlagFee(c)
+	if err != nil {
+		return err
+	}
+
+	info, version, err := createCRInfo(c)
+	if err != nil {
+		return err
+	}
+
+	txn, err := createPayloadTransaction(c.String("wallet"), c.String("from"),
+		*fee, types.UpdateCR, info)
+	if err != nil {
+		return errors.New("create transaction failed: " + err.Error())
+	}
+	txn.PayloadVersion = version
+
+	OutputTx(0, 1, txn)
+
+	return nil
+}
+
+func CreateUnregisterCRTransaction(c *cli.Context) error {
+	fee, err := getFlagFee(c)
+	if err != nil {
+		return err
+	}
+
+	walletPath := c.String("wallet")
+	password, err := cmdcom.GetFlagPassword(c)
+	if err != nil {
+		return err
+	}
+
+	client, err := account.Open(walletPath, password)
+	if err != nil {
+		return err
+	}
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return err
+	}
+	defer closeSigner()
+
+	acc, _, err := getStandardAccount(client, c.String("publickey"))
+	if err != nil {
+		return err
+	}
+	cidContract, err := contract.CreateCRIDContractByCode(acc.RedeemScript)
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	urPayload := &payload.UnregisterCR{
+		CID: *cidContract.ToProgramHash(),
+	}
+	if err = urPayload.SerializeUnsigned(buf, payload.UnregisterCRVersion); err != nil {
+		return err
+	}
+	signature, err := acc.Sign(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	urPayload.Signature = signature
+
+	txn, err := createPayloadTransaction(walletPath, c.String("from"), *fee,
+		types.UnregisterCR, urPayload)
+	if err != nil {
+		return errors.New("create transaction failed: " + err.Error())
+	}
+
+	OutputTx(0, 1, txn)
+
+	return nil
+}
+
+func CreateReturnCRDepositCoinTransaction(c *cli.Context) error {
+	txn, err := createReturnDepositTransaction(c, types.ReturnCRDepositCoin,
+		c.String("publickey"), "getcrdepositcoin", "publickey")
+	if err != nil {
+		return errors.New("create transaction failed: " + err.Error())
+	}
+
+	OutputTx(0, 1, txn)
+
+	return nil
+}
+
+// createCRInfo creates the CR information from flags and signs it with the
+// CR account in wallet, returns the information and the payload version.
+func createCRInfo(c *cli.Context) (*payload.CRInfo, byte, error) {
+	nickName := c.String("nickname")
+	if nickName == "" {
+		return nil, 0, errors.New("use --nickname to specify CR nick name")
+	}
+
+	walletPath := c.String("wallet")
+	password, err := cmdcom.GetFlagPassword(c)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	client, err := account.Open(walletPath, password)
+	if err != nil {
+		return nil, 0, err
+	}
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer closeSigner()
+
+	acc, _, err := getStandardAccount(client, c.String("publickey"))
+	if err != nil {
+		return nil, 0, err
+	}
+	cidContract, err := contract.CreateCRIDContractByCode(acc.RedeemScript)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	info := &payload.CRInfo{
+		Code:     acc.RedeemScript,
+		CID:      *cidContract.ToProgramHash(),
+		NickName: nickName,
+		Url:      c.String("url"),
+		Location: c.Uint64("location"),
+	}
+	version := payload.CRInfoVersion
+	if !c.Bool("nodid") {
+		did, err := getDIDByCode(acc.RedeemScript)
+		if err != nil {
+			return nil, 0, err
+		}
+		info.DID = *did
+		version = payload.CRInfoDIDVersion
+	}
+
+	buf := new(bytes.Buffer)
+	if err = info.SerializeUnsigned(buf, version); err != nil {
+		return nil, 0, err
+	}
+	signature, err := acc.Sign(buf.Bytes())
+	if err != nil {
+		return nil, 0, err
+	}
+	info.Signature = signature
+
+	return info, version, nil
+}
+
+// getDIDByCode returns the DID of the CR by the code of the CR.
+func getDIDByCode(code []byte) (*common.Uint168, error) {
+	didCode := make([]byte, len(code))
+	copy(didCode, code)
+	didCode = append(didCode[:len(code)-1], common.DID)
+	ct, err := contract.CreateCRIDContractByCode(didCode)
+	if err != nil {
+		return nil, err
+	}
+	return ct.ToProgramHash(), nil
+}
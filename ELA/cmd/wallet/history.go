@@ -0,0 +1,581 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/contract"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/servers"
+	"github.com/elastos/Elastos.ELA/utils/http"
+
+	"github.com/urfave/cli"
+)
+
+// Events of the transactions in history.
+const (
+	eventReceive       = "receive"
+	eventSend          = "send"
+	eventSelf          = "self"
+	eventVote          = "vote"
+	eventDeposit       = "deposit"
+	eventReturnDeposit = "return deposit"
+	eventCRPayout      = "cr payout"
+	eventCoinbase      = "coinbase"
+)
+
+// Formats of the history output.
+const (
+	historyFormatTable = "table"
+	historyFormatCSV   = "csv"
+	historyFormatJSON  = "json"
+)
+
+const (
+	// historyFileSuffix is the suffix appended to the keystore file path to
+	// get the path of the history cache file.
+	historyFileSuffix = ".history"
+
+	// historySaveInterval is the count of blocks scanned between saving the
+	// history cache, so an interrupted rescan continues from there.
+	historySaveInterval = 1000
+)
+
+var historyCommand = []cli.Command{
+	{
+		Category: "Account",
+		Name:     "history",
+		Usage:    "Show the transaction history of the wallet",
+		Description: "With ela-cli wallet history, you could list the transactions " +
+			"of the addresses in wallet, which are scanned from the blocks of node " +
+			"and cached in a side file of the keystore file.",
+		Flags: []cli.Flag{
+			cmdcom.AccountWalletFlag,
+			cmdcom.HistoryRescanFlag,
+			cmdcom.HistoryFormatFlag,
+			cmdcom.HistoryOutputFlag,
+		},
+		Action: func(c *cli.Context) error {
+			if err := ShowHistory(c); err != nil {
+				fmt.Println("error:", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	},
+	{
+		Category:  "Account",
+		Name:      "label",
+		Usage:     "Set the label of an address or transaction, or list the labels",
+		ArgsUsage: "[<label>]",
+		Flags: []cli.Flag{
+			cmdcom.AccountWalletFlag,
+			cmdcom.LabelAddressFlag,
+			cmdcom.LabelTxIDFlag,
+		},
+		Action: func(c *cli.Context) error {
+			if err := SetLabel(c); err != nil {
+				fmt.Println("error:", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	},
+}
+
+// historyUTXO is an output to the addresses in wallet, which is used to get
+// the amount sent by the wallet when it's spent.
+type historyUTXO struct {
+	Address string
+	Value   common.Fixed64
+}
+
+// HistoryRecord is a transaction related to the addresses in wallet.
+type HistoryRecord struct {
+	TxID      string
+	Height    uint32
+	Time      uint32
+	TxType    string
+	Event     string
+	Received  common.Fixed64
+	Sent      common.Fixed64
+	Fee       common.Fixed64
+	Addresses []string
+}
+
+// historyCache is the scanned history saved in the side file of keystore.
+type historyCache struct {
+	Height    uint32
+	BlockHash string
+	Addresses []string
+	UTXOs     map[string]*historyUTXO
+	Records   []*HistoryRecord
+}
+
+// historyBlock is the part of block returned by getblockbyheight used to
+// scan the history.
+type historyBlock struct {
+	Hash   string      `json:"hash"`
+	Height uint32      `json:"height"`
+	Time   uint32      `json:"time"`
+	Tx     []historyTx `json:"tx"`
+}
+
+type historyTx struct {
+	TxID    string               `json:"txid"`
+	TxType  types.TxType         `json:"type"`
+	Inputs  []servers.InputInfo  `json:"vin"`
+	Outputs []servers.OutputInfo `json:"vout"`
+}
+
+// historyEntry is the history record with labels for output.
+type historyEntry struct {
+	TxID      string   `json:"txid"`
+	Height    uint32   `json:"height"`
+	Time      string   `json:"time"`
+	TxType    string   `json:"txtype"`
+	Event     string   `json:"event"`
+	Received  string   `json:"received"`
+	Sent      string   `json:"sent"`
+	Fee       string   `json:"fee"`
+	Amount    string   `json:"amount"`
+	Addresses []string `json:"addresses"`
+	Label     string   `json:"label,omitempty"`
+}
+
+func ShowHistory(c *cli.Context) error {
+	walletPath := c.String("wallet")
+	format := c.String("format")
+	switch format {
+	case historyFormatTable, historyFormatCSV, historyFormatJSON:
+	default:
+		return errors.New("invalid format " + format + ", table, csv or json expected")
+	}
+
+	addresses, err := getHistoryAddresses(walletPath)
+	if err != nil {
+		return err
+	}
+	cache, err := loadHistoryCache(walletPath)
+	if err != nil {
+		return err
+	}
+	if c.Bool("rescan") || !cache.hasAddresses(addresses) {
+		cache = newHistoryCache(addresses)
+	}
+	if err := cache.scan(walletPath); err != nil {
+		return err
+	}
+
+	labels, err := account.LoadLabels(walletPath)
+	if err != nil {
+		return err
+	}
+	entries := make([]*historyEntry, 0, len(cache.Records))
+	for _, r := range cache.Records {
+		entries = append(entries, newHistoryEntry(r, labels))
+	}
+
+	w := io.Writer(os.Stdout)
+	if output := c.String("output"); output != "" {
+		file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	switch format {
+	case historyFormatCSV:
+		return writeHistoryCSV(w, entries)
+	case historyFormatJSON:
+		data, err := json.MarshalIndent(entries, "", "\t")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	default:
+		writeHistoryTable(w, entries)
+		return nil
+	}
+}
+
+func SetLabel(c *cli.Context) error {
+	walletPath := c.String("wallet")
+	labels, err := account.LoadLabels(walletPath)
+	if err != nil {
+		return err
+	}
+
+	address := c.String("address")
+	txID := c.String("txid")
+	if c.NArg() == 0 {
+		if address != "" || txID != "" {
+			return errors.New("missing argument, label expected")
+		}
+		printLabels(labels)
+		return nil
+	}
+	label := c.Args().First()
+
+	switch {
+	case address != "" && txID != "":
+		return errors.New("'--address' cannot be specified when specify '--txid' option")
+	case address != "":
+		if _, err := common.Uint168FromAddress(address); err != nil {
+			return errors.New("invalid address " + address)
+		}
+		labels.SetAddress(address, label)
+	case txID != "":
+		if _, err := servers.FromReversedString(txID); err != nil ||
+			len(txID) != common.UINT256SIZE*2 {
+			return errors.New("invalid transaction id " + txID)
+		}
+		labels.SetTransaction(txID, label)
+	default:
+		return errors.New("use --address or --txid to specify the labeled object")
+	}
+
+	return labels.Save(walletPath)
+}
+
+func printLabels(labels *account.Labels) {
+	addresses := make([]string, 0, len(labels.Addresses))
+	for address := range labels.Addresses {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	txIDs := make([]string, 0, len(labels.Transactions))
+	for txID := range labels.Transactions {
+		txIDs = append(txIDs, txID)
+	}
+	sort.Strings(txIDs)
+
+	fmt.Printf("%-64s %s\n", "ADDRESS / TXID", "LABEL")
+	fmt.Println(strings.Repeat("-", 64), strings.Repeat("-", 20))
+	for _, address := range addresses {
+		fmt.Printf("%-64s %s\n", address, labels.Addresses[address])
+	}
+	for _, txID := range txIDs {
+		fmt.Printf("%-64s %s\n", txID, labels.Transactions[txID])
+	}
+}
+
+// getHistoryAddresses returns the addresses in wallet and the deposit
+// addresses of the standard accounts.
+func getHistoryAddresses(walletPath string) ([]string, error) {
+	storeAccounts, err := account.GetWalletAccountData(walletPath)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(storeAccounts)*2)
+	for _, a := range storeAccounts {
+		addresses = append(addresses, a.Address)
+		programHash, err := common.Uint168FromAddress(a.Address)
+		if err != nil {
+			return nil, err
+		}
+		if contract.GetPrefixType(*programHash) != contract.PrefixStandard {
+			continue
+		}
+		depositAddress, err := toDepositAddress(programHash)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, depositAddress)
+	}
+	sort.Strings(addresses)
+
+	return addresses, nil
+}
+
+func newHistoryCache(addresses []string) *historyCache {
+	return &historyCache{
+		Addresses: addresses,
+		UTXOs:     make(map[string]*historyUTXO),
+	}
+}
+
+func historyPath(walletPath string) string {
+	return walletPath + historyFileSuffix
+}
+
+func loadHistoryCache(walletPath string) (*historyCache, error) {
+	data, err := ioutil.ReadFile(historyPath(walletPath))
+	if os.IsNotExist(err) {
+		return newHistoryCache(nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cache historyCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, errors.New("invalid history file, use --rescan to rebuild it")
+	}
+	if cache.UTXOs == nil {
+		cache.UTXOs = make(map[string]*historyUTXO)
+	}
+	return &cache, nil
+}
+
+func (h *historyCache) save(walletPath string) error {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	// a rescan may take hours, so the cache is never left truncated if the
+	// wallet is interrupted while saving it
+	return account.WriteFileAtomically(historyPath(walletPath), data)
+}
+
+// hasAddresses returns if the cache is scanned with the addresses, the
+// history should be rescanned if the addresses are changed.
+func (h *historyCache) hasAddresses(addresses []string) bool {
+	if len(h.Addresses) != len(addresses) {
+		return false
+	}
+	for i := range addresses {
+		if h.Addresses[i] != addresses[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// scan scans the blocks after the cached height to the current height, the
+// history is rescanned if the cached block is not in the best chain anymore.
+func (h *historyCache) scan(walletPath string) error {
+	bestHeight, err := getCurrentHeight()
+	if err != nil {
+		return err
+	}
+
+	if h.BlockHash != "" {
+		block, err := getHistoryBlock(h.Height)
+		if err != nil {
+			return err
+		}
+		if block.Hash != h.BlockHash {
+			fmt.Println("block", h.Height, "is not in the best chain, rescan the history")
+			*h = *newHistoryCache(h.Addresses)
+		}
+	}
+
+	addresses := make(map[string]struct{}, len(h.Addresses))
+	for _, address := range h.Addresses {
+		addresses[address] = struct{}{}
+	}
+	start := h.Height + 1
+	if h.BlockHash == "" {
+		start = 0
+	}
+	var progress bool
+	for height := start; height <= bestHeight; height++ {
+		block, err := getHistoryBlock(height)
+		if err != nil {
+			return err
+		}
+		for _, tx := range block.Tx {
+			if err := h.processTx(block, &tx, addresses); err != nil {
+				return err
+			}
+		}
+		h.Height = height
+		h.BlockHash = block.Hash
+
+		if height > start && height%historySaveInterval == 0 {
+			progress = true
+			fmt.Fprintf(os.Stderr, "scanned %d / %d\r", height, bestHeight)
+			if err := h.save(walletPath); err != nil {
+				return err
+			}
+		}
+	}
+	if progress {
+		fmt.Fprintln(os.Stderr)
+	}
+
+	return h.save(walletPath)
+}
+
+// processTx records the transaction if it spends or receives the coins of
+// the addresses.
+func (h *historyCache) processTx(block *historyBlock, tx *historyTx,
+	addresses map[string]struct{}) error {
+	var sent, received, outputAmount common.Fixed64
+	var ownInputs int
+	related := make(map[string]struct{})
+	for _, input := range tx.Inputs {
+		key := input.TxID + ":" + strconv.Itoa(int(input.VOut))
+		utxo, ok := h.UTXOs[key]
+		if !ok {
+			continue
+		}
+		sent += utxo.Value
+		ownInputs++
+		related[utxo.Address] = struct{}{}
+		delete(h.UTXOs, key)
+	}
+
+	var ownOutputs int
+	var vote bool
+	for _, output := range tx.Outputs {
+		value, err := common.StringToFixed64(output.Value)
+		if err != nil {
+			return err
+		}
+		outputAmount += *value
+		if _, ok := addresses[output.Address]; !ok {
+			continue
+		}
+		received += *value
+		ownOutputs++
+		related[output.Address] = struct{}{}
+		if output.OutputType == uint32(types.OTVote) {
+			vote = true
+		}
+		h.UTXOs[tx.TxID+":"+strconv.Itoa(int(output.Index))] = &historyUTXO{
+			Address: output.Address,
+			Value:   *value,
+		}
+	}
+	if ownInputs == 0 && ownOutputs == 0 {
+		return nil
+	}
+
+	var fee common.Fixed64
+	if ownInputs == len(tx.Inputs) && sent > outputAmount {
+		fee = sent - outputAmount
+	}
+	relatedAddresses := make([]string, 0, len(related))
+	for address := range related {
+		relatedAddresses = append(relatedAddresses, address)
+	}
+	sort.Strings(relatedAddresses)
+
+	h.Records = append(h.Records, &HistoryRecord{
+		TxID:      tx.TxID,
+		Height:    block.Height,
+		Time:      block.Time,
+		TxType:    tx.TxType.Name(),
+		Event:     historyEvent(tx, ownInputs, ownOutputs, vote),
+		Received:  received,
+		Sent:      sent,
+		Fee:       fee,
+		Addresses: relatedAddresses,
+	})
+	return nil
+}
+
+// historyEvent returns the event of transaction to the wallet.
+func historyEvent(tx *historyTx, ownInputs, ownOutputs int, vote bool) string {
+	switch tx.TxType {
+	case types.CoinBase:
+		return eventCoinbase
+	case types.RegisterProducer, types.RegisterCR:
+		return eventDeposit
+	case types.ReturnDepositCoin, types.ReturnCRDepositCoin:
+		return eventReturnDeposit
+	case types.CRCProposalWithdraw:
+		return eventCRPayout
+	}
+	switch {
+	case vote:
+		return eventVote
+	case ownInputs == 0:
+		return eventReceive
+	case ownOutputs == len(tx.Outputs):
+		return eventSelf
+	default:
+		return eventSend
+	}
+}
+
+func getHistoryBlock(height uint32) (*historyBlock, error) {
+	result, err := cmdcom.RPCCall("getblockbyheight", http.Params{
+		"height": height,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var block historyBlock
+	if err := json.Unmarshal(data, &block); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+func newHistoryEntry(r *HistoryRecord, labels *account.Labels) *historyEntry {
+	label := labels.Transactions[r.TxID]
+	if label == "" {
+		var addressLabels []string
+		for _, address := range r.Addresses {
+			if l, ok := labels.Addresses[address]; ok {
+				addressLabels = append(addressLabels, l)
+			}
+		}
+		label = strings.Join(addressLabels, ", ")
+	}
+
+	return &historyEntry{
+		TxID:      r.TxID,
+		Height:    r.Height,
+		Time:      time.Unix(int64(r.Time), 0).UTC().Format("2006-01-02 15:04:05"),
+		TxType:    r.TxType,
+		Event:     r.Event,
+		Received:  r.Received.String(),
+		Sent:      r.Sent.String(),
+		Fee:       r.Fee.String(),
+		Amount:    (r.Received - r.Sent).String(),
+		Addresses: r.Addresses,
+		Label:     label,
+	}
+}
+
+func writeHistoryCSV(w io.Writer, entries []*historyEntry) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"txid", "height", "time", "txtype", "event", "received",
+		"sent", "fee", "amount", "addresses", "label"})
+	for _, e := range entries {
+		cw.Write([]string{e.TxID, strconv.FormatUint(uint64(e.Height), 10),
+			e.Time, e.TxType, e.Event, e.Received, e.Sent, e.Fee, e.Amount,
+			strings.Join(e.Addresses, ";"), e.Label})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeHistoryTable(w io.Writer, entries []*historyEntry) {
+	fmt.Fprintf(w, "%8s %-19s %-64s %-14s %20s %12s %s\n", "HEIGHT", "TIME",
+		"TXID", "EVENT", "AMOUNT", "FEE", "LABEL")
+	fmt.Fprintln(w, strings.Repeat("-", 8), strings.Repeat("-", 19),
+		strings.Repeat("-", 64), strings.Repeat("-", 14), strings.Repeat("-", 20),
+		strings.Repeat("-", 12), strings.Repeat("-", 20))
+	for _, e := range entries {
+		fmt.Fprintf(w, "%8d %-19s %-64s %-14s %20s %12s %s\n", e.Height, e.Time,
+			e.TxID, e.Event, e.Amount, e.Fee, e.Label)
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/servers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+const (
+	testOwnAddress      = "EQ4QhsYRwuBbNBXc8BPW972xA9ANByKt6U"
+	testOwnAddress2     = "ETfFNJcYMqKfCsRcTS8GwcnXmuRPF6PNHX"
+	testExternalAddress = "EPdjf5jb2jvTNVsAsbUAGbpTKqb7z8NLLt"
+)
+
+// testTxID returns a transaction id filled by the byte.
+func testTxID(b byte) string {
+	return strings.Repeat(fmt.Sprintf("%02x", b), common.UINT256SIZE)
+}
+
+func testInput(txID string, vout uint16) servers.InputInfo {
+	return servers.InputInfo{TxID: txID, VOut: vout}
+}
+
+func testOutput(index uint32, address, value string) servers.OutputInfo {
+	return servers.OutputInfo{Index: index, Address: address, Value: value}
+}
+
+func TestHistoryCache_ProcessTx(t *testing.T) {
+	cache := newHistoryCache([]string{testOwnAddress, testOwnAddress2})
+	addresses := map[string]struct{}{
+		testOwnAddress:  {},
+		testOwnAddress2: {},
+	}
+	block := &historyBlock{Hash: "hash", Height: 10, Time: 1600000000}
+	vote := testOutput(0, testOwnAddress, "1")
+	vote.OutputType = uint32(types.OTVote)
+	txs := []historyTx{
+		// receive from others, the fee is paid by others
+		{
+			TxID:   testTxID(1),
+			TxType: types.TransferAsset,
+			Inputs: []servers.InputInfo{testInput(testTxID(0), 0)},
+			Outputs: []servers.OutputInfo{
+				testOutput(0, testOwnAddress, "10"),
+				testOutput(1, testExternalAddress, "5"),
+			},
+		},
+		// send to others with change
+		{
+			TxID:   testTxID(2),
+			TxType: types.TransferAsset,
+			Inputs: []servers.InputInfo{testInput(testTxID(1), 0)},
+			Outputs: []servers.OutputInfo{
+				testOutput(0, testExternalAddress, "3"),
+				testOutput(1, testOwnAddress, "6.9999"),
+			},
+		},
+		// send to self
+		{
+			TxID:    testTxID(3),
+			TxType:  types.TransferAsset,
+			Inputs:  []servers.InputInfo{testInput(testTxID(2), 1)},
+			Outputs: []servers.OutputInfo{testOutput(0, testOwnAddress2, "6.9998")},
+		},
+		// the fee is unknown if others spend inputs together
+		{
+			TxID:   testTxID(4),
+			TxType: types.TransferAsset,
+			Inputs: []servers.InputInfo{
+				testInput(testTxID(3), 0),
+				testInput(testTxID(0), 1),
+			},
+			Outputs: []servers.OutputInfo{testOutput(0, testExternalAddress, "20")},
+		},
+		// not related to wallet
+		{
+			TxID:    testTxID(5),
+			TxType:  types.TransferAsset,
+			Inputs:  []servers.InputInfo{testInput(testTxID(0), 2)},
+			Outputs: []servers.OutputInfo{testOutput(0, testExternalAddress, "1")},
+		},
+		{
+			TxID:    testTxID(6),
+			TxType:  types.TransferAsset,
+			Inputs:  []servers.InputInfo{testInput(testTxID(0), 3)},
+			Outputs: []servers.OutputInfo{vote},
+		},
+		{
+			TxID:    testTxID(7),
+			TxType:  types.CoinBase,
+			Outputs: []servers.OutputInfo{testOutput(0, testOwnAddress2, "2")},
+		},
+	}
+	for i := range txs {
+		assert.NoError(t, cache.processTx(block, &txs[i], addresses))
+	}
+
+	expected := []HistoryRecord{
+		{TxID: testTxID(1), Event: eventReceive, Received: 10e8,
+			Addresses: []string{testOwnAddress}},
+		{TxID: testTxID(2), Event: eventSend, Received: 6.9999e8, Sent: 10e8,
+			Fee: 0.0001e8, Addresses: []string{testOwnAddress}},
+		{TxID: testTxID(3), Event: eventSelf, Received: 6.9998e8,
+			Sent: 6.9999e8, Fee: 0.0001e8,
+			Addresses: []string{testOwnAddress, testOwnAddress2}},
+		{TxID: testTxID(4), Event: eventSend, Sent: 6.9998e8,
+			Addresses: []string{testOwnAddress2}},
+		{TxID: testTxID(6), Event: eventVote, Received: 1e8,
+			Addresses: []string{testOwnAddress}},
+		{TxID: testTxID(7), TxType: types.CoinBase.Name(), Event: eventCoinbase,
+			Received: 2e8, Addresses: []string{testOwnAddress2}},
+	}
+	if !assert.Len(t, cache.Records, len(expected)) {
+		return
+	}
+	for i, r := range cache.Records {
+		e := expected[i]
+		e.Height, e.Time = block.Height, block.Time
+		if e.TxType == "" {
+			e.TxType = types.TransferAsset.Name()
+		}
+		assert.Equal(t, e, *r, e.TxID)
+	}
+
+	// spent outputs are removed from the UTXOs of wallet
+	assert.Len(t, cache.UTXOs, 2)
+	assert.Equal(t, &historyUTXO{Address: testOwnAddress, Value: 1e8},
+		cache.UTXOs[testTxID(6)+":0"])
+	assert.Equal(t, &historyUTXO{Address: testOwnAddress2, Value: 2e8},
+		cache.UTXOs[testTxID(7)+":0"])
+
+	invalid := historyTx{
+		TxID:    testTxID(8),
+		Outputs: []servers.OutputInfo{testOutput(0, testOwnAddress, "invalid")},
+	}
+	assert.Error(t, cache.processTx(block, &invalid, addresses))
+}
+
+func TestHistoryEvent(t *testing.T) {
+	tests := []struct {
+		txType     types.TxType
+		outputs    int
+		ownInputs  int
+		ownOutputs int
+		vote       bool
+		event      string
+	}{
+		{types.TransferAsset, 2, 0, 1, false, eventReceive},
+		{types.TransferAsset, 2, 1, 1, false, eventSend},
+		{types.TransferAsset, 1, 1, 0, false, eventSend},
+		{types.TransferAsset, 2, 1, 2, false, eventSelf},
+		{types.TransferAsset, 2, 1, 2, true, eventVote},
+		{types.TransferAsset, 1, 0, 1, true, eventVote},
+		{types.CoinBase, 2, 0, 1, false, eventCoinbase},
+		{types.RegisterProducer, 2, 1, 2, false, eventDeposit},
+		{types.RegisterCR, 2, 1, 1, false, eventDeposit},
+		{types.ReturnDepositCoin, 1, 1, 1, false, eventReturnDeposit},
+		{types.ReturnCRDepositCoin, 1, 1, 1, false, eventReturnDeposit},
+		{types.CRCProposalWithdraw, 2, 0, 1, false, eventCRPayout},
+	}
+	for _, test := range tests {
+		tx := &historyTx{
+			TxType:  test.txType,
+			Outputs: make([]servers.OutputInfo, test.outputs),
+		}
+		assert.Equal(t, test.event, historyEvent(tx, test.ownInputs,
+			test.ownOutputs, test.vote), "%s %+v", test.txType.Name(), test)
+	}
+}
+
+func TestNewHistoryEntry(t *testing.T) {
+	labels := &account.Labels{
+		Addresses: map[string]string{
+			testOwnAddress:  "main",
+			testOwnAddress2: "savings",
+		},
+		Transactions: map[string]string{testTxID(2): "rent"},
+	}
+	record := &HistoryRecord{
+		TxID:      testTxID(1),
+		Height:    10,
+		Time:      1600000000,
+		TxType:    types.TransferAsset.Name(),
+		Event:     eventSend,
+		Received:  6.9999e8,
+		Sent:      10e8,
+		Fee:       0.0001e8,
+		Addresses: []string{testOwnAddress, testOwnAddress2},
+	}
+	assert.Equal(t, &historyEntry{
+		TxID:      testTxID(1),
+		Height:    10,
+		Time:      "2020-09-13 12:26:40",
+		TxType:    types.TransferAsset.Name(),
+		Event:     eventSend,
+		Received:  "6.99990000",
+		Sent:      "10",
+		Fee:       "0.00010000",
+		Amount:    "-3.00010000",
+		Addresses: []string{testOwnAddress, testOwnAddress2},
+		Label:     "main, savings",
+	}, newHistoryEntry(record, labels))
+
+	// the label of transaction is used before the labels of addresses
+	record.TxID = testTxID(2)
+	assert.Equal(t, "rent", newHistoryEntry(record, labels).Label)
+	record.Addresses = []string{testExternalAddress}
+	record.TxID = testTxID(3)
+	assert.Empty(t, newHistoryEntry(record, labels).Label)
+}
+
+func TestWriteHistoryCSV(t *testing.T) {
+	entries := []*historyEntry{
+		{
+			TxID:      testTxID(1),
+			Height:    10,
+			Time:      "2020-09-13 12:26:40",
+			TxType:    types.TransferAsset.Name(),
+			Event:     eventSend,
+			Received:  "6.99990000",
+			Sent:      "10",
+			Fee:       "0.00010000",
+			Amount:    "-3.00010000",
+			Addresses: []string{testOwnAddress, testOwnAddress2},
+			Label:     "rent, \"March\"\nsecond line",
+		},
+		{
+			TxID:      testTxID(2),
+			Event:     eventReceive,
+			Addresses: []string{testOwnAddress},
+		},
+	}
+	buf := new(bytes.Buffer)
+	assert.NoError(t, writeHistoryCSV(buf, entries))
+	// the label is quoted with the quotes doubled
+	assert.Contains(t, buf.String(), "\"rent, \"\"March\"\"\nsecond line\"")
+
+	records, err := csv.NewReader(buf).ReadAll()
+	if !assert.NoError(t, err) || !assert.Len(t, records, 3) {
+		return
+	}
+	assert.Equal(t, []string{"txid", "height", "time", "txtype", "event",
+		"received", "sent", "fee", "amount", "addresses", "label"}, records[0])
+	assert.Equal(t, []string{testTxID(1), "10", "2020-09-13 12:26:40",
+		types.TransferAsset.Name(), eventSend, "6.99990000", "10", "0.00010000",
+		"-3.00010000", testOwnAddress + ";" + testOwnAddress2,
+		"rent, \"March\"\nsecond line"}, records[1])
+	assert.Equal(t, testOwnAddress, records[2][9])
+	assert.Empty(t, records[2][10])
+}
+
+func TestShowHistory(t *testing.T) {
+	walletPath, client, cleanup := newTestWallet(t)
+	defer cleanup()
+	main := client.GetMainAccount()
+	depositAddress, err := toDepositAddress(&main.ProgramHash)
+	assert.NoError(t, err)
+
+	addresses, err := getHistoryAddresses(walletPath)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{main.Address, depositAddress}, addresses)
+
+	blocks := []*historyBlock{
+		{Hash: "block0", Height: 0, Time: 1600000000, Tx: []historyTx{{
+			TxID:    testTxID(1),
+			TxType:  types.CoinBase,
+			Outputs: []servers.OutputInfo{testOutput(0, main.Address, "10")},
+		}}},
+		{Hash: "block1", Height: 1, Time: 1600000120, Tx: []historyTx{{
+			TxID:   testTxID(2),
+			TxType: types.RegisterProducer,
+			Inputs: []servers.InputInfo{testInput(testTxID(1), 0)},
+			Outputs: []servers.OutputInfo{
+				testOutput(0, depositAddress, "5"),
+				testOutput(1, main.Address, "4.9999"),
+			},
+		}}},
+	}
+	var scanned []uint32
+	stop := startTestRPC(t, map[string]func(map[string]interface{}) interface{}{
+		"getcurrentheight": func(map[string]interface{}) interface{} {
+			return len(blocks) - 1
+		},
+		"getblockbyheight": func(params map[string]interface{}) interface{} {
+			height := uint32(params["height"].(float64))
+			scanned = append(scanned, height)
+			return blocks[height]
+		},
+	})
+	defer stop()
+
+	// labels are set to the transaction and address
+	label := "deposit, \"producer\"\n<node> & 节点"
+	labelFlags := []cli.Flag{
+		cmdcom.AccountWalletFlag,
+		cmdcom.LabelAddressFlag,
+		cmdcom.LabelTxIDFlag,
+	}
+	assert.NoError(t, SetLabel(newTestContext(t, labelFlags,
+		map[string]string{"wallet": walletPath, "txid": testTxID(2)}, label)))
+	assert.NoError(t, SetLabel(newTestContext(t, labelFlags,
+		map[string]string{"wallet": walletPath, "address": main.Address}, "main")))
+	labels, err := account.LoadLabels(walletPath)
+	if assert.NoError(t, err) {
+		assert.Equal(t, label, labels.Transactions[testTxID(2)])
+		assert.Equal(t, "main", labels.Addresses[main.Address])
+	}
+
+	outputPath := filepath.Join(filepath.Dir(walletPath), "history")
+	historyFlags := []cli.Flag{
+		cmdcom.AccountWalletFlag,
+		cmdcom.HistoryRescanFlag,
+		cmdcom.HistoryFormatFlag,
+		cmdcom.HistoryOutputFlag,
+	}
+	showHistory := func(values map[string]string) []*historyEntry {
+		values["wallet"] = walletPath
+		values["format"] = historyFormatJSON
+		values["output"] = outputPath
+		if !assert.NoError(t, ShowHistory(newTestContext(t, historyFlags,
+			values))) {
+			t.FailNow()
+		}
+		data, err := ioutil.ReadFile(outputPath)
+		assert.NoError(t, err)
+		var entries []*historyEntry
+		assert.NoError(t, json.Unmarshal(data, &entries))
+		return entries
+	}
+	entries := showHistory(map[string]string{})
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, eventCoinbase, entries[0].Event)
+		assert.Equal(t, "10", entries[0].Amount)
+		assert.Equal(t, "main", entries[0].Label)
+		assert.Equal(t, eventDeposit, entries[1].Event)
+		assert.Equal(t, "-0.00010000", entries[1].Amount)
+		assert.Equal(t, "0.00010000", entries[1].Fee)
+		assert.ElementsMatch(t, []string{main.Address, depositAddress},
+			entries[1].Addresses)
+		assert.Equal(t, label, entries[1].Label)
+	}
+	assert.Equal(t, []uint32{0, 1}, scanned)
+
+	// the cache is saved atomically without temporary file left
+	files, err := ioutil.ReadDir(filepath.Dir(walletPath))
+	assert.NoError(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.ElementsMatch(t, []string{"keystore.dat", "keystore.dat.labels",
+		"keystore.dat.history", "history"}, names)
+
+	// only the cached block is checked if no new block
+	scanned = nil
+	entries = showHistory(map[string]string{})
+	assert.Len(t, entries, 2)
+	assert.Equal(t, []uint32{1}, scanned)
+
+	// the history is rescanned if the cached block is not in best chain
+	scanned = nil
+	blocks[1].Hash = "fork"
+	entries = showHistory(map[string]string{})
+	assert.Len(t, entries, 2)
+	assert.Equal(t, []uint32{1, 0, 1}, scanned)
+
+	scanned = nil
+	entries = showHistory(map[string]string{"rescan": "true"})
+	assert.Len(t, entries, 2)
+	assert.Equal(t, []uint32{0, 1}, scanned)
+
+	assert.EqualError(t, ShowHistory(newTestContext(t, historyFlags,
+		map[string]string{"wallet": walletPath, "format": "xml"})),
+		"invalid format xml, table, csv or json expected")
+}
+
+func TestSetLabel(t *testing.T) {
+	walletPath, client, cleanup := newTestWallet(t)
+	defer cleanup()
+	address := client.GetMainAccount().Address
+	flags := []cli.Flag{
+		cmdcom.AccountWalletFlag,
+		cmdcom.LabelAddressFlag,
+		cmdcom.LabelTxIDFlag,
+	}
+	setLabel := func(values map[string]string, args ...string) error {
+		values["wallet"] = walletPath
+		return SetLabel(newTestContext(t, flags, values, args...))
+	}
+
+	assert.NoError(t, setLabel(map[string]string{"address": address},
+		"label"))
+	assert.EqualError(t, setLabel(map[string]string{"address": "invalid"},
+		"label"), "invalid address invalid")
+	assert.EqualError(t, setLabel(map[string]string{"txid": testTxID(1)[2:]},
+		"label"), "invalid transaction id "+testTxID(1)[2:])
+	assert.EqualError(t, setLabel(map[string]string{"txid": "invalid"},
+		"label"), "invalid transaction id invalid")
+	assert.EqualError(t, setLabel(map[string]string{
+		"address": address,
+		"txid":    testTxID(1),
+	}, "label"), "'--address' cannot be specified when specify '--txid' option")
+	assert.EqualError(t, setLabel(map[string]string{}, "label"),
+		"use --address or --txid to specify the labeled object")
+	assert.EqualError(t, setLabel(map[string]string{"address": address}),
+		"missing argument, label expected")
+
+	// an empty label removes the label
+	labels, err := account.LoadLabels(walletPath)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{address: "label"}, labels.Addresses)
+	assert.NoError(t, setLabel(map[string]string{"address": address}, ""))
+	labels, err = account.LoadLabels(walletPath)
+	assert.NoError(t, err)
+	assert.Empty(t, labels.Addresses)
+	assert.Empty(t, labels.Transactions)
+}
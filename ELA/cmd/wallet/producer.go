@@ -0,0 +1,584 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/contract"
+	pg "github.com/elastos/Elastos.ELA/core/contract/program"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/crypto"
+	"github.com/elastos/Elastos.ELA/utils/http"
+
+	"github.com/urfave/cli"
+)
+
+var producerCommand = []cli.Command{
+	{
+		Category: "Producer",
+		Name:     "producer",
+		Usage:    "Build transactions to manage a producer",
+		Description: "With ela-cli wallet producer, you could register, update " +
+			"and cancel a producer, and return the deposit after canceled.",
+		ArgsUsage: "[args]",
+		Subcommands: []cli.Command{
+			{
+				Name:  "register",
+				Usage: "Build a tx to register producer",
+				Flags: []cli.Flag{
+					cmdcom.ProducerOwnerPublicKeyFlag,
+					cmdcom.ProducerNodePublicKeyFlag,
+					cmdcom.ProducerNickNameFlag,
+					cmdcom.ProducerUrlFlag,
+					cmdcom.ProducerLocationFlag,
+					cmdcom.ProducerNetAddressFlag,
+					cmdcom.ProducerDepositAmountFlag,
+					cmdcom.TransactionFromFlag,
+					cmdcom.TransactionFeeFlag,
+					cmdcom.AccountWalletFlag,
+					cmdcom.AccountPasswordFlag,
+					cmdcom.AccountSignerFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateRegisterProducerTransaction(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "update",
+				Usage: "Build a tx to update producer information",
+				Flags: []cli.Flag{
+					cmdcom.ProducerOwnerPublicKeyFlag,
+					cmdcom.ProducerNodePublicKeyFlag,
+					cmdcom.ProducerNickNameFlag,
+					cmdcom.ProducerUrlFlag,
+					cmdcom.ProducerLocationFlag,
+					cmdcom.ProducerNetAddressFlag,
+					cmdcom.TransactionFromFlag,
+					cmdcom.TransactionFeeFlag,
+					cmdcom.AccountWalletFlag,
+					cmdcom.AccountPasswordFlag,
+					cmdcom.AccountSignerFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateUpdateProducerTransaction(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "cancel",
+				Usage: "Build a tx to cancel producer",
+				Flags: []cli.Flag{
+					cmdcom.ProducerOwnerPublicKeyFlag,
+					cmdcom.TransactionFromFlag,
+					cmdcom.TransactionFeeFlag,
+					cmdcom.AccountWalletFlag,
+					cmdcom.AccountPasswordFlag,
+					cmdcom.AccountSignerFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateCancelProducerTransaction(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "returndeposit",
+				Usage: "Build a tx to return deposit coin of canceled producer",
+				Flags: []cli.Flag{
+					cmdcom.ProducerOwnerPublicKeyFlag,
+					cmdcom.TransactionToFlag,
+					cmdcom.TransactionAmountFlag,
+					cmdcom.TransactionFeeFlag,
+					cmdcom.AccountWalletFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateReturnDepositCoinTransaction(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+		},
+	},
+}
+
+func CreateRegisterProducerTransaction(c *cli.Context) error {
+	fee, err := getFlagFee(c)
+	if err != nil {
+		return err
+	}
+
+	depositAmount, err := common.StringToFixed64(c.String("depositamount"))
+	if err != nil {
+		return errors.New("invalid deposit amount")
+	}
+
+	info, err := createProducerInfo(c)
+	if err != nil {
+		return err
+	}
+
+	depositHash, err := contract.PublicKeyToDepositProgramHash(info.OwnerPublicKey)
+	if err != nil {
+		return err
+	}
+	depositAddress, err := depositHash.ToAddress()
+	if err != nil {
+		return err
+	}
+
+	txn, err := createPayloadTransaction(c.String("wallet"), c.String("from"),
+		*fee, types.RegisterProducer, info, &OutputInfo{
+			Recipient: depositAddress,
+			Amount:    depositAmount,
+		})
+	if err != nil {
+		return errors.New("create transaction failed: " + err.Error())
+	}
+
+	OutputTx(0, 1, txn)
+
+	return nil
+}
+
+func CreateUpdateProducerTransaction(c *cli.Context) error {
+	fee, err := getFlagFee(c)
+	if err != nil {
+		return err
+	}
+
+	info, err := createProducerInfo(c)
+	if err != nil {
+		return err
+	}
+
+	txn, err := createPayloadTransaction(c.String("wallet"), c.String("from"),
+		*fee, types.UpdateProducer, info)
+	if err != nil {
+		return errors.New("create transaction failed: " + err.Error())
+	}
+
+	OutputTx(0, 1, txn)
+
+	return nil
+}
+
+func CreateCancelProducerTransaction(c *cli.Context) error {
+	fee, err := getFlagFee(c)
+	if err != nil {
+		return err
+	}
+
+	walletPath := c.String("wallet")
+	password, err := cmdcom.GetFlagPassword(c)
+	if err != nil {
+		return err
+	}
+
+	client, err := account.Open(walletPath, password)
+	if err != nil {
+		return err
+	}
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return err
+	}
+	defer closeSigner()
+
+	acc, ownerPublicKey, err := getStandardAccount(client,
+		c.String("ownerpublickey"))
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	cpPayload := &payload.ProcessProducer{
+		OwnerPublicKey: ownerPublicKey,
+	}
+	if err = cpPayload.SerializeUnsigned(buf, payload.ProcessProducerVersion); err != nil {
+		return err
+	}
+	signature, err := acc.Sign(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	cpPayload.Signature = signature
+
+	txn, err := createPayloadTransaction(walletPath, c.String("from"), *fee,
+		types.CancelProducer, cpPayload)
+	if err != nil {
+		return errors.New("create transaction failed: " + err.Error())
+	}
+
+	OutputTx(0, 1, txn)
+
+	return nil
+}
+
+func CreateReturnDepositCoinTransaction(c *cli.Context) error {
+	txn, err := createReturnDepositTransaction(c, types.ReturnDepositCoin,
+		c.String("ownerpublickey"), "getdepositcoin", "ownerpublickey")
+	if err != nil {
+		return errors.New("create transaction failed: " + err.Error())
+	}
+
+	OutputTx(0, 1, txn)
+
+	return nil
+}
+
+// createReturnDepositTransaction creates a transaction to return the deposit
+// coin of the public key, the main account is used if the public key is not
+// specified. The available deposit coin is got by the RPC method if the amount
+// is not specified.
+func createReturnDepositTransaction(c *cli.Context, txType types.TxType,
+	publicKeyStr string, method string, param string) (*types.Transaction, error) {
+	fee, err := getFlagFee(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// the deposit address is created from the public key, so the deposit
+	// UTXOs are spent by the standard program of the public key.
+	var redeemScript []byte
+	if publicKeyStr != "" {
+		publicKeyBytes, err := common.HexStringToBytes(publicKeyStr)
+		if err != nil {
+			return nil, err
+		}
+		publicKey, err := crypto.DecodePoint(publicKeyBytes)
+		if err != nil {
+			return nil, errors.New("invalid public key")
+		}
+		redeemScript, err = contract.CreateStandardRedeemScript(publicKey)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		mainAccount, err := account.GetWalletMainAccountData(c.String("wallet"))
+		if err != nil {
+			return nil, err
+		}
+		redeemScript, err = common.HexStringToBytes(mainAccount.RedeemScript)
+		if err != nil {
+			return nil, err
+		}
+		if !contract.IsStandard(redeemScript) {
+			return nil, errors.New("main account is not a standard account")
+		}
+	}
+
+	depositContract, err := contract.CreateDepositContractByCode(redeemScript)
+	if err != nil {
+		return nil, err
+	}
+	depositAddress, err := depositContract.ToProgramHash().ToAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	// return all available deposit coin if amount not specified
+	var amount *common.Fixed64
+	if amountStr := c.String("amount"); amountStr != "" {
+		amount, err = common.StringToFixed64(amountStr)
+		if err != nil {
+			return nil, errors.New("invalid transaction amount")
+		}
+	} else {
+		available, err := getAvailableDepositCoin(method, param,
+			common.BytesToHexString(redeemScript[1:len(redeemScript)-1]))
+		if err != nil {
+			return nil, err
+		}
+		if available <= *fee {
+			return nil, errors.New("available deposit coin is not enough")
+		}
+		value := available - *fee
+		amount = &value
+	}
+
+	to := c.String("to")
+	if to == "" {
+		standardContract := &contract.Contract{
+			Code:   redeemScript,
+			Prefix: contract.PrefixStandard,
+		}
+		to, err = standardContract.ToProgramHash().ToAddress()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// create outputs
+	txOutputs, totalAmount, err := createNormalOutputs([]*OutputInfo{{
+		Recipient: to,
+		Amount:    amount,
+	}}, *fee, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// create inputs from the deposit address, the change goes back to it
+	txInputs, changeOutputs, err := createInputs(depositAddress, totalAmount)
+	if err != nil {
+		return nil, err
+	}
+	txOutputs = append(txOutputs, changeOutputs...)
+
+	// create attributes
+	txAttr := types.NewAttribute(types.Nonce, []byte(strconv.FormatInt(rand.Int63(), 10)))
+	txAttributes := make([]*types.Attribute, 0)
+	txAttributes = append(txAttributes, &txAttr)
+
+	// create program
+	var txProgram = &pg.Program{
+		Code:      redeemScript,
+		Parameter: nil,
+	}
+
+	return &types.Transaction{
+		Version:    types.TxVersion09,
+		TxType:     txType,
+		Payload:    &payload.ReturnDepositCoin{},
+		Attributes: txAttributes,
+		Inputs:     txInputs,
+		Outputs:    txOutputs,
+		Programs:   []*pg.Program{txProgram},
+		LockTime:   0,
+	}, nil
+}
+
+func getFlagFee(c *cli.Context) (*common.Fixed64, error) {
+	feeStr := c.String("fee")
+	if feeStr == "" {
+		return nil, errors.New("use --fee to specify transfer fee")
+	}
+	fee, err := common.StringToFixed64(feeStr)
+	if err != nil {
+		return nil, errors.New("invalid transaction fee")
+	}
+	return fee, nil
+}
+
+// getStandardAccount returns the standard account of the public key in
+// wallet, the main account is used if the public key is not specified.
+func getStandardAccount(client *account.Client, publicKeyStr string) (
+	*account.Account, []byte, error) {
+	var acc *account.Account
+	var publicKey []byte
+	if publicKeyStr == "" {
+		acc = client.GetMainAccount()
+		if acc == nil {
+			return nil, nil, errors.New("no main account in wallet")
+		}
+		if contract.GetPrefixType(acc.ProgramHash) != contract.PrefixStandard {
+			return nil, nil, errors.New("main account is not a standard account")
+		}
+	} else {
+		var err error
+		publicKey, err = common.HexStringToBytes(publicKeyStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		codeHash, err := contract.PublicKeyToStandardCodeHash(publicKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		acc = client.GetAccountByCodeHash(*codeHash)
+		if acc == nil {
+			return nil, nil, errors.New("no available account in wallet")
+		}
+	}
+
+	// the public key of account watched by address is unknown
+	if acc.PublicKey == nil {
+		return nil, nil, errors.New("public key of watch-only account " +
+			acc.Address + " is unknown")
+	}
+	if !acc.CanSign() {
+		return nil, nil, errors.New("account " + acc.Address +
+			" is watch-only, use --signer to sign by an external signer")
+	}
+	if publicKey == nil {
+		var err error
+		publicKey, err = acc.PublicKey.EncodePoint(true)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return acc, publicKey, nil
+}
+
+// createProducerInfo creates the producer information from flags and signs
+// it with the owner account in wallet.
+func createProducerInfo(c *cli.Context) (*payload.ProducerInfo, error) {
+	nickName := c.String("nickname")
+	if nickName == "" {
+		return nil, errors.New("use --nickname to specify producer nick name")
+	}
+
+	walletPath := c.String("wallet")
+	password, err := cmdcom.GetFlagPassword(c)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := account.Open(walletPath, password)
+	if err != nil {
+		return nil, err
+	}
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return nil, err
+	}
+	defer closeSigner()
+
+	acc, ownerPublicKey, err := getStandardAccount(client,
+		c.String("ownerpublickey"))
+	if err != nil {
+		return nil, err
+	}
+
+	nodePublicKey := ownerPublicKey
+	if nodePublicKeyStr := c.String("nodepublickey"); nodePublicKeyStr != "" {
+		nodePublicKey, err = common.HexStringToBytes(nodePublicKeyStr)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := crypto.DecodePoint(nodePublicKey); err != nil {
+			return nil, errors.New("invalid node public key")
+		}
+	}
+
+	info := &payload.ProducerInfo{
+		OwnerPublicKey: ownerPublicKey,
+		NodePublicKey:  nodePublicKey,
+		NickName:       nickName,
+		Url:            c.String("url"),
+		Location:       c.Uint64("location"),
+		NetAddress:     c.String("netaddress"),
+	}
+
+	buf := new(bytes.Buffer)
+	if err = info.SerializeUnsigned(buf, payload.ProducerInfoVersion); err != nil {
+		return nil, err
+	}
+	signature, err := acc.Sign(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	info.Signature = signature
+
+	return info, nil
+}
+
+// createPayloadTransaction creates a transaction with the given type and
+// payload, the fee and outputs are paid by the sender in wallet.
+func createPayloadTransaction(walletPath string, from string,
+	fee common.Fixed64, txType types.TxType, txPayload types.Payload,
+	outputs ...*OutputInfo) (*types.Transaction, error) {
+	// get sender in wallet by from address
+	sender, err := getSender(walletPath, from)
+	if err != nil {
+		return nil, err
+	}
+
+	// create outputs
+	txOutputs, totalAmount, err := createNormalOutputs(outputs, fee, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// create inputs
+	txInputs, changeOutputs, err := createInputs(sender.Address, totalAmount)
+	if err != nil {
+		return nil, err
+	}
+	txOutputs = append(txOutputs, changeOutputs...)
+
+	redeemScript, err := common.HexStringToBytes(sender.RedeemScript)
+	if err != nil {
+		return nil, err
+	}
+	// create attributes
+	txAttr := types.NewAttribute(types.Nonce, []byte(strconv.FormatInt(rand.Int63(), 10)))
+	txAttributes := make([]*types.Attribute, 0)
+	txAttributes = append(txAttributes, &txAttr)
+
+	// create program
+	var txProgram = &pg.Program{
+		Code:      redeemScript,
+		Parameter: nil,
+	}
+
+	return &types.Transaction{
+		Version:    types.TxVersion09,
+		TxType:     txType,
+		Payload:    txPayload,
+		Attributes: txAttributes,
+		Inputs:     txInputs,
+		Outputs:    txOutputs,
+		Programs:   []*pg.Program{txProgram},
+		LockTime:   0,
+	}, nil
+}
+
+func getAvailableDepositCoin(method string, param string,
+	publicKey string) (common.Fixed64, error) {
+	result, err := cmdcom.RPCCall(method, http.Params{param: publicKey})
+	if err != nil {
+		return 0, err
+	}
+	coin, ok := result.(map[string]interface{})
+	if !ok {
+		return 0, errors.New("invalid deposit coin response")
+	}
+	available, ok := coin["available"].(string)
+	if !ok {
+		return 0, errors.New("invalid deposit coin response")
+	}
+	amount, err := common.StringToFixed64(available)
+	if err != nil {
+		return 0, err
+	}
+	return *amount, nil
+}
@@ -0,0 +1,312 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/contract"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/crypto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+var producerFlags = []cli.Flag{
+	cmdcom.ProducerOwnerPublicKeyFlag,
+	cmdcom.ProducerNodePublicKeyFlag,
+	cmdcom.ProducerNickNameFlag,
+	cmdcom.ProducerUrlFlag,
+	cmdcom.ProducerLocationFlag,
+	cmdcom.ProducerNetAddressFlag,
+	cmdcom.ProducerDepositAmountFlag,
+	cmdcom.TransactionFromFlag,
+	cmdcom.TransactionToFlag,
+	cmdcom.TransactionAmountFlag,
+	cmdcom.TransactionFeeFlag,
+	cmdcom.AccountWalletFlag,
+	cmdcom.AccountPasswordFlag,
+	cmdcom.AccountSignerFlag,
+}
+
+// testUTXOs returns a handler of getutxosbyamount answering one UTXO of the
+// amount.
+func testUTXOs(amount string) func(map[string]interface{}) interface{} {
+	return func(params map[string]interface{}) interface{} {
+		return []map[string]interface{}{{
+			"txid":    common.BytesToHexString(make([]byte, 32)),
+			"vout":    0,
+			"address": params["address"],
+			"amount":  amount,
+		}}
+	}
+}
+
+func TestCreateProducerInfo(t *testing.T) {
+	walletPath, client, cleanup := newTestWallet(t)
+	defer cleanup()
+	main := client.GetMainAccount()
+	ownerPublicKey, err := main.PublicKey.EncodePoint(true)
+	assert.NoError(t, err)
+
+	values := map[string]string{
+		"wallet":     walletPath,
+		"password":   testPassword,
+		"nickname":   "producer",
+		"url":        "https://producer.org",
+		"location":   "86",
+		"netaddress": "127.0.0.1:20339",
+	}
+	info, err := createProducerInfo(newTestContext(t, producerFlags, values))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, ownerPublicKey, info.OwnerPublicKey)
+	assert.Equal(t, ownerPublicKey, info.NodePublicKey)
+	assert.Equal(t, "producer", info.NickName)
+	assert.Equal(t, "https://producer.org", info.Url)
+	assert.Equal(t, uint64(86), info.Location)
+	assert.Equal(t, "127.0.0.1:20339", info.NetAddress)
+
+	// the signature is checked the same way as the register producer
+	// transaction is checked by blockchain
+	buf := new(bytes.Buffer)
+	assert.NoError(t, info.SerializeUnsigned(buf, payload.ProducerInfoVersion))
+	assert.NoError(t, crypto.Verify(*main.PublicKey, buf.Bytes(), info.Signature))
+
+	// node public key
+	_, nodePublicKey, err := crypto.GenerateKeyPair()
+	assert.NoError(t, err)
+	nodeKey, err := nodePublicKey.EncodePoint(true)
+	assert.NoError(t, err)
+	values["nodepublickey"] = common.BytesToHexString(nodeKey)
+	values["ownerpublickey"] = common.BytesToHexString(ownerPublicKey)
+	info, err = createProducerInfo(newTestContext(t, producerFlags, values))
+	if assert.NoError(t, err) {
+		assert.Equal(t, nodeKey, info.NodePublicKey)
+		assert.Equal(t, ownerPublicKey, info.OwnerPublicKey)
+	}
+
+	values["nodepublickey"] = "0102"
+	_, err = createProducerInfo(newTestContext(t, producerFlags, values))
+	assert.EqualError(t, err, "invalid node public key")
+	delete(values, "nodepublickey")
+
+	// owner key not in wallet
+	values["ownerpublickey"] = common.BytesToHexString(nodeKey)
+	_, err = createProducerInfo(newTestContext(t, producerFlags, values))
+	assert.EqualError(t, err, "no available account in wallet")
+	delete(values, "ownerpublickey")
+
+	delete(values, "nickname")
+	_, err = createProducerInfo(newTestContext(t, producerFlags, values))
+	assert.Error(t, err)
+}
+
+func TestCreatePayloadTransaction(t *testing.T) {
+	walletPath, client, cleanup := newTestWallet(t)
+	defer cleanup()
+	stop := startTestRPC(t, map[string]func(map[string]interface{}) interface{}{
+		"getutxosbyamount": testUTXOs("100"),
+	})
+	defer stop()
+
+	main := client.GetMainAccount()
+	depositHash, err := contract.PublicKeyToDepositProgramHash(
+		mustEncodePoint(t, main.PublicKey))
+	assert.NoError(t, err)
+	depositAddress, err := depositHash.ToAddress()
+	assert.NoError(t, err)
+	amount := common.Fixed64(5000 * 1e8)
+
+	_, err = createPayloadTransaction(walletPath, "", common.Fixed64(1e4),
+		types.RegisterProducer, &payload.ProducerInfo{}, &OutputInfo{
+			Recipient: depositAddress,
+			Amount:    &amount,
+		})
+	assert.Error(t, err, "available token is not enough")
+
+	amount = common.Fixed64(50 * 1e8)
+	txn, err := createPayloadTransaction(walletPath, "", common.Fixed64(1e4),
+		types.RegisterProducer, &payload.ProducerInfo{}, &OutputInfo{
+			Recipient: depositAddress,
+			Amount:    &amount,
+		})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, types.RegisterProducer, txn.TxType)
+	assert.Len(t, txn.Inputs, 1)
+	if assert.Len(t, txn.Outputs, 2) {
+		assert.Equal(t, *depositHash, txn.Outputs[0].ProgramHash)
+		assert.Equal(t, amount, txn.Outputs[0].Value)
+		assert.Equal(t, main.ProgramHash, txn.Outputs[1].ProgramHash)
+		assert.Equal(t, common.Fixed64(100*1e8)-amount-1e4, txn.Outputs[1].Value)
+	}
+	if assert.Len(t, txn.Programs, 1) {
+		assert.Equal(t, main.RedeemScript, txn.Programs[0].Code)
+	}
+}
+
+func TestCreateReturnDepositTransaction(t *testing.T) {
+	walletPath, client, cleanup := newTestWallet(t)
+	defer cleanup()
+	main := client.GetMainAccount()
+	publicKey := mustEncodePoint(t, main.PublicKey)
+
+	var depositCoinParams map[string]interface{}
+	stop := startTestRPC(t, map[string]func(map[string]interface{}) interface{}{
+		"getutxosbyamount": testUTXOs("10"),
+		"getdepositcoin": func(params map[string]interface{}) interface{} {
+			depositCoinParams = params
+			return map[string]interface{}{"available": "10", "deducted": "0"}
+		},
+	})
+	defer stop()
+
+	flags := []cli.Flag{
+		cmdcom.ProducerOwnerPublicKeyFlag,
+		cmdcom.TransactionToFlag,
+		cmdcom.TransactionAmountFlag,
+		cmdcom.TransactionFeeFlag,
+		cmdcom.AccountWalletFlag,
+	}
+	check := func(values map[string]string) {
+		txn, err := createReturnDepositTransaction(newTestContext(t, flags, values),
+			types.ReturnDepositCoin, values["ownerpublickey"], "getdepositcoin",
+			"ownerpublickey")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, common.BytesToHexString(publicKey),
+			depositCoinParams["ownerpublickey"])
+		assert.Equal(t, types.ReturnDepositCoin, txn.TxType)
+		assert.IsType(t, &payload.ReturnDepositCoin{}, txn.Payload)
+		// all available deposit coin is returned to the owner
+		if assert.Len(t, txn.Outputs, 1) {
+			assert.Equal(t, main.ProgramHash, txn.Outputs[0].ProgramHash)
+			assert.Equal(t, common.Fixed64(10*1e8-1e4), txn.Outputs[0].Value)
+		}
+		// the deposit is spent by the standard program of the owner
+		if assert.Len(t, txn.Programs, 1) {
+			assert.Equal(t, main.RedeemScript, txn.Programs[0].Code)
+		}
+	}
+
+	check(map[string]string{
+		"ownerpublickey": common.BytesToHexString(publicKey),
+		"fee":            "0.0001",
+	})
+	// the main account is used by default
+	check(map[string]string{
+		"wallet": walletPath,
+		"fee":    "0.0001",
+	})
+
+	_, err := createReturnDepositTransaction(newTestContext(t, flags,
+		map[string]string{"wallet": walletPath, "fee": "10"}),
+		types.ReturnDepositCoin, "", "getdepositcoin", "ownerpublickey")
+	assert.EqualError(t, err, "available deposit coin is not enough")
+
+	_, err = createReturnDepositTransaction(newTestContext(t, flags,
+		map[string]string{"ownerpublickey": "0102", "fee": "0.0001"}),
+		types.ReturnDepositCoin, "0102", "getdepositcoin", "ownerpublickey")
+	assert.EqualError(t, err, "invalid public key")
+}
+
+func TestGetStandardAccount(t *testing.T) {
+	walletPath, client, cleanup := newTestWallet(t)
+	defer cleanup()
+	main := client.GetMainAccount()
+	publicKey := mustEncodePoint(t, main.PublicKey)
+
+	acc, key, err := getStandardAccount(client, "")
+	assert.NoError(t, err)
+	assert.Equal(t, main, acc)
+	assert.Equal(t, publicKey, key)
+
+	other, err := client.CreateAccount()
+	assert.NoError(t, err)
+	acc, key, err = getStandardAccount(client,
+		common.BytesToHexString(mustEncodePoint(t, other.PublicKey)))
+	assert.NoError(t, err)
+	assert.Equal(t, other.Address, acc.Address)
+	assert.Equal(t, mustEncodePoint(t, other.PublicKey), key)
+
+	_, _, err = getStandardAccount(client, "invalid")
+	assert.Error(t, err)
+
+	// the watch-only account signs only by an external signer
+	privateKey, publicKey2, err := crypto.GenerateKeyPair()
+	assert.NoError(t, err)
+	watched, err := account.NewAccountWithPublicKey(publicKey2)
+	assert.NoError(t, err)
+	assert.NoError(t, client.SaveWatchOnlyAccount(watched))
+	watchedKey := common.BytesToHexString(mustEncodePoint(t, publicKey2))
+	_, _, err = getStandardAccount(client, watchedKey)
+	assert.EqualError(t, err, "account "+watched.Address+
+		" is watch-only, use --signer to sign by an external signer")
+	assert.NoError(t, client.SetSigner(&testSigner{
+		privateKey: privateKey,
+		publicKey:  publicKey2,
+	}))
+	acc, key, err = getStandardAccount(client, watchedKey)
+	if assert.NoError(t, err) {
+		assert.Equal(t, watched.Address, acc.Address)
+		assert.Equal(t, mustEncodePoint(t, publicKey2), key)
+	}
+
+	// the public key of the account watched by address is unknown
+	_, publicKey3, err := crypto.GenerateKeyPair()
+	assert.NoError(t, err)
+	other, err = account.NewAccountWithPublicKey(publicKey3)
+	assert.NoError(t, err)
+	byAddress, err := account.NewWatchOnlyAccount(other.Address)
+	assert.NoError(t, err)
+	assert.NoError(t, client.SaveWatchOnlyAccount(byAddress))
+	_, _, err = getStandardAccount(client,
+		common.BytesToHexString(mustEncodePoint(t, publicKey3)))
+	assert.EqualError(t, err, "public key of watch-only account "+
+		other.Address+" is unknown")
+
+	// a watch-only wallet has no main account
+	watchClient, err := account.AddWatchOnly(
+		filepath.Join(filepath.Dir(walletPath), "watch.dat"),
+		[]byte(testPassword), watched)
+	if assert.NoError(t, err) {
+		_, _, err = getStandardAccount(watchClient, "")
+		assert.EqualError(t, err, "no main account in wallet")
+	}
+}
+
+// testSigner is an external signer holding one private key.
+type testSigner struct {
+	privateKey []byte
+	publicKey  *crypto.PublicKey
+}
+
+func (s *testSigner) PublicKeys() ([]*crypto.PublicKey, error) {
+	return []*crypto.PublicKey{s.publicKey}, nil
+}
+
+func (s *testSigner) Sign(pubKey *crypto.PublicKey, data []byte) ([]byte, error) {
+	return crypto.Sign(s.privateKey, data)
+}
+
+func mustEncodePoint(t *testing.T, publicKey *crypto.PublicKey) []byte {
+	key, err := publicKey.EncodePoint(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
@@ -0,0 +1,786 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/contract"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/utils/http"
+
+	"github.com/urfave/cli"
+)
+
+var proposalCommand = []cli.Command{
+	{
+		Category: "CR",
+		Name:     "proposal",
+		Usage:    "Build transactions of CRC proposals",
+		Description: "With ela-cli wallet proposal, you could create, review and " +
+			"track a CRC proposal. The payload of proposal and tracking is saved " +
+			"to a file, and exchanged between signers to sign it in order, then " +
+			"build the transaction with the fully signed payload.",
+		ArgsUsage: "[args]",
+		Subcommands: []cli.Command{
+			{
+				Name:  "create",
+				Usage: "Create a proposal payload signed by the proposal owner",
+				Flags: []cli.Flag{
+					cmdcom.ProposalTypeFlag,
+					cmdcom.ProposalCategoryFlag,
+					cmdcom.ProposalOwnerPublicKeyFlag,
+					cmdcom.ProposalDraftHashFlag,
+					cmdcom.ProposalBudgetsFlag,
+					cmdcom.ProposalRecipientFlag,
+					cmdcom.ProposalCRMemberDIDFlag,
+					cmdcom.ProposalPayloadFileFlag,
+					cmdcom.AccountWalletFlag,
+					cmdcom.AccountPasswordFlag,
+					cmdcom.AccountSignerFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateCRCProposalPayload(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "review",
+				Usage: "Build a tx to review proposal by CR member",
+				Flags: []cli.Flag{
+					cmdcom.CRCProposalHashFlag,
+					cmdcom.ProposalVoteResultFlag,
+					cmdcom.ProposalOpinionHashFlag,
+					cmdcom.CRPublicKeyFlag,
+					cmdcom.TransactionFromFlag,
+					cmdcom.TransactionFeeFlag,
+					cmdcom.AccountWalletFlag,
+					cmdcom.AccountPasswordFlag,
+					cmdcom.AccountSignerFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateCRCProposalReviewTransaction(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "track",
+				Usage: "Create a proposal tracking payload signed by the proposal owner",
+				Flags: []cli.Flag{
+					cmdcom.CRCProposalHashFlag,
+					cmdcom.ProposalMessageHashFlag,
+					cmdcom.CRCProposalStageFlag,
+					cmdcom.ProposalTrackingTypeFlag,
+					cmdcom.ProposalOwnerPublicKeyFlag,
+					cmdcom.ProposalNewOwnerPublicKeyFlag,
+					cmdcom.ProposalOpinionHashFlag,
+					cmdcom.ProposalPayloadFileFlag,
+					cmdcom.AccountWalletFlag,
+					cmdcom.AccountPasswordFlag,
+					cmdcom.AccountSignerFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateCRCProposalTrackingPayload(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "sign",
+				Usage: "Sign the proposal or tracking payload by the next signer",
+				Flags: []cli.Flag{
+					cmdcom.ProposalPayloadFileFlag,
+					cmdcom.ProposalTrackingTypeFlag,
+					cmdcom.ProposalOpinionHashFlag,
+					cmdcom.ProposalSecretaryGeneralFlag,
+					cmdcom.AccountWalletFlag,
+					cmdcom.AccountPasswordFlag,
+					cmdcom.AccountSignerFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := SignProposalPayload(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "buildtx",
+				Usage: "Build a tx with the fully signed proposal or tracking payload",
+				Flags: []cli.Flag{
+					cmdcom.ProposalPayloadFileFlag,
+					cmdcom.TransactionFromFlag,
+					cmdcom.TransactionFeeFlag,
+					cmdcom.AccountWalletFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreateProposalPayloadTransaction(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+		},
+	},
+}
+
+// payloadFile is the content of the file to exchange a payload which need
+// to be signed by multiple signers.
+type payloadFile struct {
+	TxType  types.TxType `json:"txtype"`
+	Payload string       `json:"payload"`
+}
+
+func CreateCRCProposalPayload(c *cli.Context) error {
+	proposalType, err := payload.ParseCRCProposalType(c.String("proposaltype"))
+	if err != nil {
+		return err
+	}
+
+	draftHashStr := c.String("drafthash")
+	if draftHashStr == "" {
+		return errors.New("use --drafthash to specify proposal draft hash")
+	}
+	draftHash, err := common.Uint256FromReversedHexString(draftHashStr)
+	if err != nil {
+		return errors.New("invalid draft hash")
+	}
+
+	budgetsStr := c.String("budgets")
+	if budgetsStr == "" {
+		return errors.New("use --budgets to specify proposal budgets")
+	}
+	budgets, err := parseBudgets(budgetsStr)
+	if err != nil {
+		return err
+	}
+
+	didStr := c.String("crmemberdid")
+	if didStr == "" {
+		return errors.New("use --crmemberdid to specify the DID of CR member")
+	}
+	did, err := common.Uint168FromAddress(didStr)
+	if err != nil {
+		return errors.New("invalid CR member DID")
+	}
+
+	walletPath := c.String("wallet")
+	password, err := cmdcom.GetFlagPassword(c)
+	if err != nil {
+		return err
+	}
+
+	client, err := account.Open(walletPath, password)
+	if err != nil {
+		return err
+	}
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return err
+	}
+	defer closeSigner()
+
+	acc, ownerPublicKey, err := getStandardAccount(client,
+		c.String("ownerpublickey"))
+	if err != nil {
+		return err
+	}
+
+	// use the address of owner as recipient if not specified
+	recipient := &acc.ProgramHash
+	if recipientStr := c.String("recipient"); recipientStr != "" {
+		recipient, err = common.Uint168FromAddress(recipientStr)
+		if err != nil {
+			return errors.New("invalid recipient address")
+		}
+	}
+
+	proposal := &payload.CRCProposal{
+		ProposalType:       proposalType,
+		CategoryData:       c.String("category"),
+		OwnerPublicKey:     ownerPublicKey,
+		DraftHash:          *draftHash,
+		Budgets:            budgets,
+		Recipient:          *recipient,
+		CRCouncilMemberDID: *did,
+	}
+
+	buf := new(bytes.Buffer)
+	if err = proposal.SerializeUnsigned(buf, payload.CRCProposalVersion); err != nil {
+		return err
+	}
+	if proposal.Signature, err = acc.Sign(buf.Bytes()); err != nil {
+		return err
+	}
+
+	return outputPayload(c.String("payload"), types.CRCProposal, proposal)
+}
+
+func CreateCRCProposalReviewTransaction(c *cli.Context) error {
+	fee, err := getFlagFee(c)
+	if err != nil {
+		return err
+	}
+
+	proposalHashStr := c.String("proposalhash")
+	if proposalHashStr == "" {
+		return errors.New("use --proposalhash to specify proposal hash")
+	}
+	proposalHash, err := common.Uint256FromReversedHexString(proposalHashStr)
+	if err != nil {
+		return errors.New("invalid proposal hash")
+	}
+
+	voteResult, err := payload.ParseVoteResult(c.String("voteresult"))
+	if err != nil {
+		return err
+	}
+
+	var opinionHash common.Uint256
+	if opinionHashStr := c.String("opinionhash"); opinionHashStr != "" {
+		hash, err := common.Uint256FromHexString(opinionHashStr)
+		if err != nil {
+			return errors.New("invalid opinion hash")
+		}
+		opinionHash = *hash
+	}
+
+	walletPath := c.String("wallet")
+	password, err := cmdcom.GetFlagPassword(c)
+	if err != nil {
+		return err
+	}
+
+	client, err := account.Open(walletPath, password)
+	if err != nil {
+		return err
+	}
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return err
+	}
+	defer closeSigner()
+
+	acc, _, err := getStandardAccount(client, c.String("publickey"))
+	if err != nil {
+		return err
+	}
+	did, err := getDIDByCode(acc.RedeemScript)
+	if err != nil {
+		return err
+	}
+
+	review := &payload.CRCProposalReview{
+		ProposalHash: *proposalHash,
+		VoteResult:   voteResult,
+		OpinionHash:  opinionHash,
+		DID:          *did,
+	}
+
+	buf := new(bytes.Buffer)
+	if err = review.SerializeUnsigned(buf, payload.CRCProposalReviewVersion); err != nil {
+		return err
+	}
+	if review.Signature, err = acc.Sign(buf.Bytes()); err != nil {
+		return err
+	}
+
+	txn, err := createPayloadTransaction(walletPath, c.String("from"), *fee,
+		types.CRCProposalReview, review)
+	if err != nil {
+		return errors.New("create transaction failed: " + err.Error())
+	}
+
+	OutputTx(0, 1, txn)
+
+	return nil
+}
+
+func CreateCRCProposalTrackingPayload(c *cli.Context) error {
+	proposalHashStr := c.String("proposalhash")
+	if proposalHashStr == "" {
+		return errors.New("use --proposalhash to specify proposal hash")
+	}
+	proposalHash, err := common.Uint256FromReversedHexString(proposalHashStr)
+	if err != nil {
+		return errors.New("invalid proposal hash")
+	}
+
+	var messageHash common.Uint256
+	if messageHashStr := c.String("messagehash"); messageHashStr != "" {
+		hash, err := common.Uint256FromHexString(messageHashStr)
+		if err != nil {
+			return errors.New("invalid message hash")
+		}
+		messageHash = *hash
+	}
+
+	var stage uint64
+	if stageStr := c.String("stage"); stageStr != "" {
+		stage, err = strconv.ParseUint(stageStr, 10, 8)
+		if err != nil {
+			return errors.New("invalid stage")
+		}
+	}
+
+	trackingType := payload.Common
+	if typeStr := c.String("trackingtype"); typeStr != "" {
+		trackingType, err = payload.ParseCRCProposalTrackingType(typeStr)
+		if err != nil {
+			return err
+		}
+	}
+
+	var newOwnerPublicKey []byte
+	if newOwnerStr := c.String("newownerpublickey"); newOwnerStr != "" {
+		newOwnerPublicKey, err = common.HexStringToBytes(newOwnerStr)
+		if err != nil {
+			return errors.New("invalid new owner public key")
+		}
+	}
+
+	var opinionHash common.Uint256
+	if opinionHashStr := c.String("opinionhash"); opinionHashStr != "" {
+		hash, err := common.Uint256FromHexString(opinionHashStr)
+		if err != nil {
+			return errors.New("invalid opinion hash")
+		}
+		opinionHash = *hash
+	}
+
+	walletPath := c.String("wallet")
+	password, err := cmdcom.GetFlagPassword(c)
+	if err != nil {
+		return err
+	}
+
+	client, err := account.Open(walletPath, password)
+	if err != nil {
+		return err
+	}
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return err
+	}
+	defer closeSigner()
+
+	acc, ownerPublicKey, err := getStandardAccount(client,
+		c.String("ownerpublickey"))
+	if err != nil {
+		return err
+	}
+
+	tracking := &payload.CRCProposalTracking{
+		ProposalHash:                *proposalHash,
+		MessageHash:                 messageHash,
+		Stage:                       uint8(stage),
+		OwnerPublicKey:              ownerPublicKey,
+		NewOwnerPublicKey:           newOwnerPublicKey,
+		ProposalTrackingType:        trackingType,
+		SecretaryGeneralOpinionHash: opinionHash,
+	}
+
+	buf := new(bytes.Buffer)
+	if err = tracking.SerializeUnsigned(buf, payload.CRCProposalTrackingVersion); err != nil {
+		return err
+	}
+	if tracking.OwnerSignature, err = acc.Sign(buf.Bytes()); err != nil {
+		return err
+	}
+
+	return outputPayload(c.String("payload"), types.CRCProposalTracking, tracking)
+}
+
+func SignProposalPayload(c *cli.Context) error {
+	path := c.String("payload")
+	txType, pld, err := readPayload(path)
+	if err != nil {
+		return err
+	}
+
+	walletPath := c.String("wallet")
+	password, err := cmdcom.GetFlagPassword(c)
+	if err != nil {
+		return err
+	}
+
+	client, err := account.Open(walletPath, password)
+	if err != nil {
+		return err
+	}
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return err
+	}
+	defer closeSigner()
+
+	var signed int
+	switch p := pld.(type) {
+	case *payload.CRCProposal:
+		signed, err = signCRCProposal(client, p)
+	case *payload.CRCProposalTracking:
+		// the tracking type and opinion are decided by secretary general
+		if len(p.SecretaryGeneralSignature) == 0 {
+			if typeStr := c.String("trackingtype"); typeStr != "" {
+				if p.ProposalTrackingType, err =
+					payload.ParseCRCProposalTrackingType(typeStr); err != nil {
+					return err
+				}
+			}
+			if opinionHashStr := c.String("opinionhash"); opinionHashStr != "" {
+				hash, err := common.Uint256FromHexString(opinionHashStr)
+				if err != nil {
+					return errors.New("invalid opinion hash")
+				}
+				p.SecretaryGeneralOpinionHash = *hash
+			}
+		}
+		signed, err = signCRCProposalTracking(client, p,
+			c.String("secretarygeneral"))
+	default:
+		return errors.New("unsupported payload type " + txType.Name())
+	}
+	if err != nil {
+		return err
+	}
+	if signed == 0 {
+		return errors.New("no account in wallet to sign the payload")
+	}
+
+	return outputPayload(path, txType, pld)
+}
+
+func CreateProposalPayloadTransaction(c *cli.Context) error {
+	fee, err := getFlagFee(c)
+	if err != nil {
+		return err
+	}
+
+	txType, pld, err := readPayload(c.String("payload"))
+	if err != nil {
+		return err
+	}
+	haveSign, needSign := getPayloadSignStatus(pld)
+	if haveSign != needSign {
+		return fmt.Errorf("payload is not fully signed [ %d / %d ]",
+			haveSign, needSign)
+	}
+
+	txn, err := createPayloadTransaction(c.String("wallet"), c.String("from"),
+		*fee, txType, pld)
+	if err != nil {
+		return errors.New("create transaction failed: " + err.Error())
+	}
+	txn.PayloadVersion = payloadVersions[txType]
+
+	OutputTx(0, 1, txn)
+
+	return nil
+}
+
+// signCRCProposal signs the proposal by the proposal owner and then the CR
+// member, returns the count of signatures signed by accounts in wallet.
+func signCRCProposal(client *account.Client,
+	p *payload.CRCProposal) (int, error) {
+	var signed int
+	buf := new(bytes.Buffer)
+	if err := p.SerializeUnsigned(buf, payload.CRCProposalVersion); err != nil {
+		return 0, err
+	}
+	if len(p.Signature) == 0 {
+		acc, err := getAccountByPublicKey(client, p.OwnerPublicKey)
+		if err != nil || acc == nil {
+			return signed, err
+		}
+		if p.Signature, err = acc.Sign(buf.Bytes()); err != nil {
+			return signed, err
+		}
+		signed++
+	}
+
+	if len(p.CRCouncilMemberSignature) == 0 {
+		if err := common.WriteVarBytes(buf, p.Signature); err != nil {
+			return signed, err
+		}
+		if err := p.CRCouncilMemberDID.Serialize(buf); err != nil {
+			return signed, err
+		}
+		acc, err := getAccountByDID(client, p.CRCouncilMemberDID)
+		if err != nil || acc == nil {
+			return signed, err
+		}
+		if p.CRCouncilMemberSignature, err = acc.Sign(buf.Bytes()); err != nil {
+			return signed, err
+		}
+		signed++
+	}
+
+	return signed, nil
+}
+
+// signCRCProposalTracking signs the proposal tracking by the proposal owner,
+// the new proposal owner if need and then the secretary general, returns the
+// count of signatures signed by accounts in wallet.
+func signCRCProposalTracking(client *account.Client,
+	p *payload.CRCProposalTracking, secretaryGeneral string) (int, error) {
+	var signed int
+	buf := new(bytes.Buffer)
+	if err := p.SerializeUnsigned(buf, payload.CRCProposalTrackingVersion); err != nil {
+		return 0, err
+	}
+	if len(p.OwnerSignature) == 0 {
+		acc, err := getAccountByPublicKey(client, p.OwnerPublicKey)
+		if err != nil || acc == nil {
+			return signed, err
+		}
+		if p.OwnerSignature, err = acc.Sign(buf.Bytes()); err != nil {
+			return signed, err
+		}
+		signed++
+	}
+	if err := common.WriteVarBytes(buf, p.OwnerSignature); err != nil {
+		return signed, err
+	}
+
+	if len(p.NewOwnerPublicKey) != 0 && len(p.NewOwnerSignature) == 0 {
+		acc, err := getAccountByPublicKey(client, p.NewOwnerPublicKey)
+		if err != nil || acc == nil {
+			return signed, err
+		}
+		if p.NewOwnerSignature, err = acc.Sign(buf.Bytes()); err != nil {
+			return signed, err
+		}
+		signed++
+	}
+	if err := common.WriteVarBytes(buf, p.NewOwnerSignature); err != nil {
+		return signed, err
+	}
+
+	if len(p.SecretaryGeneralSignature) == 0 {
+		if secretaryGeneral == "" {
+			var err error
+			if secretaryGeneral, err = getSecretaryGeneral(); err != nil {
+				// not the secretary general if signed by other signers
+				if signed > 0 {
+					return signed, nil
+				}
+				return signed, err
+			}
+		}
+		publicKey, err := common.HexStringToBytes(secretaryGeneral)
+		if err != nil {
+			return signed, errors.New("invalid secretary general public key")
+		}
+		acc, err := getAccountByPublicKey(client, publicKey)
+		if err != nil || acc == nil {
+			return signed, err
+		}
+		buf.WriteByte(byte(p.ProposalTrackingType))
+		if err := p.SecretaryGeneralOpinionHash.Serialize(buf); err != nil {
+			return signed, err
+		}
+		if p.SecretaryGeneralSignature, err = acc.Sign(buf.Bytes()); err != nil {
+			return signed, err
+		}
+		signed++
+	}
+
+	return signed, nil
+}
+
+// getPayloadSignStatus returns the count of signatures have been signed and
+// the count of signatures needed by the payload.
+func getPayloadSignStatus(pld types.Payload) (haveSign, needSign int) {
+	var signatures [][]byte
+	switch p := pld.(type) {
+	case *payload.CRCProposal:
+		signatures = [][]byte{p.Signature, p.CRCouncilMemberSignature}
+	case *payload.CRCProposalTracking:
+		signatures = [][]byte{p.OwnerSignature, p.SecretaryGeneralSignature}
+		if len(p.NewOwnerPublicKey) != 0 {
+			signatures = append(signatures, p.NewOwnerSignature)
+		}
+	}
+	for _, s := range signatures {
+		if len(s) != 0 {
+			haveSign++
+		}
+	}
+	return haveSign, len(signatures)
+}
+
+// payloadVersions is the versions of the payloads saved in payload files.
+var payloadVersions = map[types.TxType]byte{
+	types.CRCProposal:         payload.CRCProposalVersion,
+	types.CRCProposalTracking: payload.CRCProposalTrackingVersion,
+}
+
+func outputPayload(path string, txType types.TxType, pld types.Payload) error {
+	buf := new(bytes.Buffer)
+	if err := pld.Serialize(buf, payloadVersions[txType]); err != nil {
+		return err
+	}
+	data, err := json.Marshal(&payloadFile{
+		TxType:  txType,
+		Payload: common.BytesToHexString(buf.Bytes()),
+	})
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+
+	haveSign, needSign := getPayloadSignStatus(pld)
+	fmt.Println("[", haveSign, "/", needSign, "]", txType.Name(),
+		"payload was successfully signed")
+	fmt.Println("File: ", path)
+
+	return nil
+}
+
+func readPayload(path string) (types.TxType, types.Payload, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	var file payloadFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return 0, nil, errors.New("invalid payload file")
+	}
+	version, ok := payloadVersions[file.TxType]
+	if !ok {
+		return 0, nil, errors.New("unsupported payload type " +
+			file.TxType.Name())
+	}
+	pld, err := types.GetPayload(file.TxType)
+	if err != nil {
+		return 0, nil, err
+	}
+	content, err := common.HexStringToBytes(file.Payload)
+	if err != nil {
+		return 0, nil, errors.New("invalid payload content")
+	}
+	if err := pld.Deserialize(bytes.NewReader(content), version); err != nil {
+		return 0, nil, errors.New("deserialize payload failed")
+	}
+	return file.TxType, pld, nil
+}
+
+// getAccountByPublicKey returns the standard account of the public key in
+// wallet, or nil if not exist.
+func getAccountByPublicKey(client *account.Client,
+	publicKey []byte) (*account.Account, error) {
+	codeHash, err := contract.PublicKeyToStandardCodeHash(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetAccountByCodeHash(*codeHash), nil
+}
+
+// getAccountByDID returns the standard account of the DID in wallet, or nil
+// if not exist.
+func getAccountByDID(client *account.Client,
+	did common.Uint168) (*account.Account, error) {
+	for _, acc := range client.GetAccounts() {
+		if !contract.IsStandard(acc.RedeemScript) {
+			continue
+		}
+		accDID, err := getDIDByCode(acc.RedeemScript)
+		if err != nil {
+			return nil, err
+		}
+		if accDID.IsEqual(did) {
+			return acc, nil
+		}
+	}
+	return nil, nil
+}
+
+func getSecretaryGeneral() (string, error) {
+	result, err := cmdcom.RPCCall("getsecretarygeneral", http.Params{})
+	if err != nil {
+		return "", err
+	}
+	info, ok := result.(map[string]interface{})
+	if !ok {
+		return "", errors.New("invalid secretary general response")
+	}
+	secretaryGeneral, ok := info["secretarygeneral"].(string)
+	if !ok {
+		return "", errors.New("invalid secretary general response")
+	}
+	return secretaryGeneral, nil
+}
+
+func parseBudgets(s string) ([]payload.Budget, error) {
+	var budgets []payload.Budget
+	for _, b := range strings.Split(s, ",") {
+		fields := strings.Split(strings.TrimSpace(b), ":")
+		if len(fields) != 3 {
+			return nil, errors.New("invalid budget " + b)
+		}
+		budgetType, err := payload.ParseInstallmentType(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		stage, err := strconv.ParseUint(fields[1], 10, 8)
+		if err != nil {
+			return nil, errors.New("invalid budget stage " + fields[1])
+		}
+		amount, err := common.StringToFixed64(fields[2])
+		if err != nil {
+			return nil, errors.New("invalid budget amount " + fields[2])
+		}
+		budgets = append(budgets, payload.Budget{
+			Type:   budgetType,
+			Stage:  byte(stage),
+			Amount: *amount,
+		})
+	}
+	return budgets, nil
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/contract"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/crypto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+func TestCreateCRCProposalPayload(t *testing.T) {
+	walletPath, client, cleanup := newTestWallet(t)
+	defer cleanup()
+	owner := client.GetMainAccount()
+	member, err := client.CreateAccount()
+	assert.NoError(t, err)
+	memberDID, err := getDIDByCode(member.RedeemScript)
+	assert.NoError(t, err)
+	didAddress, err := memberDID.ToAddress()
+	assert.NoError(t, err)
+	draftHash := common.Uint256{1, 2, 3}
+
+	payloadPath := filepath.Join(filepath.Dir(walletPath), "proposal.json")
+	flags := []cli.Flag{
+		cmdcom.ProposalTypeFlag,
+		cmdcom.ProposalCategoryFlag,
+		cmdcom.ProposalOwnerPublicKeyFlag,
+		cmdcom.ProposalDraftHashFlag,
+		cmdcom.ProposalBudgetsFlag,
+		cmdcom.ProposalRecipientFlag,
+		cmdcom.ProposalCRMemberDIDFlag,
+		cmdcom.ProposalPayloadFileFlag,
+		cmdcom.AccountWalletFlag,
+		cmdcom.AccountPasswordFlag,
+		cmdcom.AccountSignerFlag,
+	}
+	values := map[string]string{
+		"proposaltype": "normal",
+		"category":     "category",
+		"drafthash": common.BytesToHexString(
+			common.BytesReverse(draftHash.Bytes())),
+		"budgets":     "imprest:0:1,finalpayment:1:2.5",
+		"crmemberdid": didAddress,
+		"payload":     payloadPath,
+		"wallet":      walletPath,
+		"password":    testPassword,
+	}
+	if !assert.NoError(t, CreateCRCProposalPayload(
+		newTestContext(t, flags, values))) {
+		return
+	}
+
+	txType, pld, err := readPayload(payloadPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, types.CRCProposal, txType)
+	proposal := pld.(*payload.CRCProposal)
+	assert.Equal(t, payload.Normal, proposal.ProposalType)
+	assert.Equal(t, draftHash, proposal.DraftHash)
+	assert.Equal(t, owner.ProgramHash, proposal.Recipient)
+	assert.Equal(t, []payload.Budget{
+		{Type: payload.Imprest, Stage: 0, Amount: 1e8},
+		{Type: payload.FinalPayment, Stage: 1, Amount: 2.5e8},
+	}, proposal.Budgets)
+	haveSign, needSign := getPayloadSignStatus(proposal)
+	assert.Equal(t, 1, haveSign)
+	assert.Equal(t, 2, needSign)
+
+	// the CR member signs after the owner
+	signed, err := signCRCProposal(client, proposal)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, signed)
+	haveSign, _ = getPayloadSignStatus(proposal)
+	assert.Equal(t, 2, haveSign)
+
+	// the signatures are checked the same way as the proposal transaction is
+	// checked by blockchain
+	buf := new(bytes.Buffer)
+	assert.NoError(t, proposal.SerializeUnsigned(buf, payload.CRCProposalVersion))
+	assert.NoError(t, crypto.Verify(*owner.PublicKey, buf.Bytes(),
+		proposal.Signature))
+	assert.NoError(t, common.WriteVarBytes(buf, proposal.Signature))
+	assert.NoError(t, proposal.CRCouncilMemberDID.Serialize(buf))
+	assert.NoError(t, crypto.Verify(*member.PublicKey, buf.Bytes(),
+		proposal.CRCouncilMemberSignature))
+
+	values["budgets"] = "payment:0:1"
+	assert.EqualError(t, CreateCRCProposalPayload(
+		newTestContext(t, flags, values)), "invalid budget type payment")
+	values["proposaltype"] = "unknown"
+	assert.EqualError(t, CreateCRCProposalPayload(
+		newTestContext(t, flags, values)), "invalid proposal type unknown")
+}
+
+func TestSignCRCProposalTracking(t *testing.T) {
+	_, client, cleanup := newTestWallet(t)
+	defer cleanup()
+	owner := client.GetMainAccount()
+	newOwner, err := client.CreateAccount()
+	assert.NoError(t, err)
+	secretary, err := client.CreateAccount()
+	assert.NoError(t, err)
+
+	tracking := &payload.CRCProposalTracking{
+		ProposalTrackingType:        payload.ChangeOwner,
+		ProposalHash:                common.Uint256{1},
+		MessageHash:                 common.Uint256{2},
+		OwnerPublicKey:              mustEncodePoint(t, owner.PublicKey),
+		NewOwnerPublicKey:           mustEncodePoint(t, newOwner.PublicKey),
+		SecretaryGeneralOpinionHash: common.Uint256{3},
+	}
+	signed, err := signCRCProposalTracking(client, tracking,
+		common.BytesToHexString(mustEncodePoint(t, secretary.PublicKey)))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, signed)
+	haveSign, needSign := getPayloadSignStatus(tracking)
+	assert.Equal(t, 3, haveSign)
+	assert.Equal(t, 3, needSign)
+
+	// the signatures are checked the same way as the proposal tracking
+	// transaction is checked by blockchain
+	buf := new(bytes.Buffer)
+	assert.NoError(t, tracking.SerializeUnsigned(buf,
+		payload.CRCProposalTrackingVersion))
+	assert.NoError(t, crypto.Verify(*owner.PublicKey, buf.Bytes(),
+		tracking.OwnerSignature))
+	assert.NoError(t, common.WriteVarBytes(buf, tracking.OwnerSignature))
+	assert.NoError(t, crypto.Verify(*newOwner.PublicKey, buf.Bytes(),
+		tracking.NewOwnerSignature))
+	assert.NoError(t, common.WriteVarBytes(buf, tracking.NewOwnerSignature))
+	buf.WriteByte(byte(tracking.ProposalTrackingType))
+	assert.NoError(t, tracking.SecretaryGeneralOpinionHash.Serialize(buf))
+	assert.NoError(t, crypto.Verify(*secretary.PublicKey, buf.Bytes(),
+		tracking.SecretaryGeneralSignature))
+
+	// nothing more to sign
+	signed, err = signCRCProposalTracking(client, tracking, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, signed)
+}
+
+func TestPayloadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "payload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "payload.json")
+
+	tracking := &payload.CRCProposalTracking{
+		ProposalTrackingType:      payload.Progress,
+		ProposalHash:              common.Uint256{1},
+		Stage:                     2,
+		OwnerPublicKey:            []byte{1, 2, 3},
+		NewOwnerPublicKey:         []byte{},
+		OwnerSignature:            []byte{4, 5, 6},
+		NewOwnerSignature:         []byte{},
+		SecretaryGeneralSignature: []byte{},
+	}
+	assert.NoError(t, outputPayload(path, types.CRCProposalTracking, tracking))
+
+	// the payload is saved in the version of the payload type
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	var file payloadFile
+	assert.NoError(t, json.Unmarshal(data, &file))
+	assert.Equal(t, types.CRCProposalTracking, file.TxType)
+	buf := new(bytes.Buffer)
+	assert.NoError(t, tracking.Serialize(buf, payload.CRCProposalTrackingVersion))
+	assert.Equal(t, common.BytesToHexString(buf.Bytes()), file.Payload)
+
+	txType, pld, err := readPayload(path)
+	assert.NoError(t, err)
+	assert.Equal(t, types.CRCProposalTracking, txType)
+	assert.Equal(t, tracking, pld)
+
+	data, err = json.Marshal(&payloadFile{TxType: types.TransferAsset})
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(path, data, 0600))
+	_, _, err = readPayload(path)
+	assert.EqualError(t, err, "unsupported payload type TransferAsset")
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("invalid"), 0600))
+	_, _, err = readPayload(path)
+	assert.EqualError(t, err, "invalid payload file")
+}
+
+func TestCreateCRInfo(t *testing.T) {
+	walletPath, client, cleanup := newTestWallet(t)
+	defer cleanup()
+	main := client.GetMainAccount()
+	cid, err := contract.CreateCRIDContractByCode(main.RedeemScript)
+	assert.NoError(t, err)
+	did, err := getDIDByCode(main.RedeemScript)
+	assert.NoError(t, err)
+
+	flags := []cli.Flag{
+		cmdcom.CRPublicKeyFlag,
+		cmdcom.CRNickNameFlag,
+		cmdcom.CRUrlFlag,
+		cmdcom.CRLocationFlag,
+		cmdcom.CRWithoutDIDFlag,
+		cmdcom.AccountWalletFlag,
+		cmdcom.AccountPasswordFlag,
+		cmdcom.AccountSignerFlag,
+	}
+	values := map[string]string{
+		"nickname": "cr",
+		"url":      "https://cr.org",
+		"location": "86",
+		"wallet":   walletPath,
+		"password": testPassword,
+	}
+	check := func(expectedVersion byte, expectedDID common.Uint168) {
+		info, version, err := createCRInfo(newTestContext(t, flags, values))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, expectedVersion, version)
+		assert.Equal(t, main.RedeemScript, info.Code)
+		assert.Equal(t, *cid.ToProgramHash(), info.CID)
+		assert.Equal(t, expectedDID, info.DID)
+
+		// the signature is checked the same way as the register CR
+		// transaction is checked by blockchain
+		buf := new(bytes.Buffer)
+		assert.NoError(t, info.SerializeUnsigned(buf, version))
+		assert.NoError(t, crypto.Verify(*main.PublicKey, buf.Bytes(),
+			info.Signature))
+	}
+	check(payload.CRInfoDIDVersion, *did)
+	values["nodid"] = "true"
+	check(payload.CRInfoVersion, common.Uint168{})
+}
@@ -0,0 +1,393 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/contract"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/crypto"
+	"github.com/elastos/Elastos.ELA/servers"
+	"github.com/elastos/Elastos.ELA/utils"
+	"github.com/elastos/Elastos.ELA/utils/http"
+	"github.com/elastos/Elastos.ELA/vm"
+
+	"github.com/urfave/cli"
+)
+
+var pstxCommand = []cli.Command{
+	{
+		Category: "Transaction",
+		Name:     "pstx",
+		Usage:    "Sign a transaction by multiple signers with a partially signed transaction file",
+		Description: "With ela-cli wallet pstx, you could create a partially signed " +
+			"transaction file from a built transaction, pass it to the signers to " +
+			"inspect and sign on their own machines, combine the signed files and " +
+			"finalize the transaction to send.",
+		ArgsUsage: "[args]",
+		Subcommands: []cli.Command{
+			{
+				Name:  "create",
+				Usage: "Create a partially signed transaction file from a transaction",
+				Flags: []cli.Flag{
+					cmdcom.TransactionHexFlag,
+					cmdcom.TransactionFileFlag,
+					cmdcom.PSTxFileFlag,
+					cmdcom.AccountWalletFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NumFlags() == 0 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CreatePSTx(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "inspect",
+				Usage: "Show the transaction and signatures in a partially signed transaction file",
+				Flags: []cli.Flag{
+					cmdcom.PSTxFileFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if err := InspectPSTx(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "sign",
+				Usage: "Sign a partially signed transaction file by the accounts in wallet",
+				Flags: []cli.Flag{
+					cmdcom.PSTxFileFlag,
+					cmdcom.AccountWalletFlag,
+					cmdcom.AccountPasswordFlag,
+					cmdcom.AccountSignerFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if err := SignPSTx(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:      "combine",
+				Usage:     "Combine the signatures of partially signed transaction files",
+				ArgsUsage: "<file> [<file>...]",
+				Flags: []cli.Flag{
+					cmdcom.PSTxFileFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() < 1 {
+						cli.ShowSubcommandHelp(c)
+						return nil
+					}
+					if err := CombinePSTx(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "finalize",
+				Usage: "Build the transaction ready to send from a fully signed file",
+				Flags: []cli.Flag{
+					cmdcom.PSTxFileFlag,
+				},
+				Action: func(c *cli.Context) error {
+					if err := FinalizePSTx(c); err != nil {
+						fmt.Println("error:", err)
+						os.Exit(1)
+					}
+					return nil
+				},
+			},
+		},
+	},
+}
+
+func CreatePSTx(c *cli.Context) error {
+	txHex, err := getTransactionHex(c)
+	if err != nil {
+		return err
+	}
+	rawData, err := common.HexStringToBytes(txHex)
+	if err != nil {
+		return errors.New("decode transaction content failed")
+	}
+	var txn types.Transaction
+	if err := txn.Deserialize(bytes.NewReader(rawData)); err != nil {
+		return errors.New("deserialize transaction failed")
+	}
+
+	prevTxs := make([]*types.Transaction, 0, len(txn.Inputs))
+	for _, input := range txn.Inputs {
+		prevTx, err := getRawTransaction(input.Previous.TxID)
+		if err != nil {
+			return err
+		}
+		prevTxs = append(prevTxs, prevTx)
+	}
+
+	pstx, err := account.NewPartiallySignedTx(&txn, prevTxs)
+	if err != nil {
+		return err
+	}
+
+	walletPath := c.String("wallet")
+	if utils.FileExisted(walletPath) {
+		storeAccounts, err := account.GetWalletAccountData(walletPath)
+		if err != nil {
+			return err
+		}
+		if err := pstx.AddHDPaths(storeAccounts); err != nil {
+			return err
+		}
+	}
+
+	return outputPSTx(c.String("pstx"), pstx)
+}
+
+func InspectPSTx(c *cli.Context) error {
+	pstx, err := readPSTx(c.String("pstx"))
+	if err != nil {
+		return err
+	}
+	txn, err := pstx.Tx()
+	if err != nil {
+		return err
+	}
+	referenced, err := pstx.ReferencedOutputs()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Hash:", servers.ToReversedString(txn.Hash()))
+	fmt.Println("TxType:", txn.TxType.Name())
+	fmt.Println("LockTime:", txn.LockTime)
+
+	var inputAmount, outputAmount common.Fixed64
+	fmt.Println("Inputs:")
+	for i, input := range txn.Inputs {
+		address, err := referenced[i].ProgramHash.ToAddress()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%5d %s:%d %34s %s\n", i,
+			servers.ToReversedString(input.Previous.TxID), input.Previous.Index,
+			address, referenced[i].Value.String())
+		inputAmount += referenced[i].Value
+	}
+	fmt.Println("Outputs:")
+	for i, output := range txn.Outputs {
+		address, err := output.ProgramHash.ToAddress()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%5d %34s %s\n", i, address, output.Value.String())
+		outputAmount += output.Value
+	}
+	fmt.Println("Fee:", (inputAmount - outputAmount).String())
+
+	fmt.Println("Programs:")
+	for i, psp := range pstx.Programs {
+		address, err := getProgramAddress(psp.Code)
+		if err != nil {
+			return err
+		}
+		haveSign, needSign, err := pstx.SignStatus(i)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%5d %34s [ %d / %d ]\n", i, address, haveSign, needSign)
+
+		publicKeys, err := psp.PublicKeys()
+		if err != nil {
+			return err
+		}
+		for _, publicKey := range publicKeys {
+			key := common.BytesToHexString(publicKey)
+			status := "unsigned"
+			if _, ok := psp.Signatures[key]; ok {
+				status = "signed"
+			}
+			fmt.Printf("%5s %s %-8s %s\n", "", key, status, psp.HDPaths[key])
+		}
+	}
+
+	return pstx.Verify()
+}
+
+func SignPSTx(c *cli.Context) error {
+	path := c.String("pstx")
+	pstx, err := readPSTx(path)
+	if err != nil {
+		return err
+	}
+
+	walletPath := c.String("wallet")
+	password, err := cmdcom.GetFlagPassword(c)
+	if err != nil {
+		return err
+	}
+	client, err := account.Open(walletPath, password)
+	if err != nil {
+		return err
+	}
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return err
+	}
+	defer closeSigner()
+
+	count, err := client.SignPSTx(pstx)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("no more signature can be added by the accounts in wallet")
+	}
+	storeAccounts, err := client.LoadAccountData()
+	if err != nil {
+		return err
+	}
+	if err := pstx.AddHDPaths(storeAccounts); err != nil {
+		return err
+	}
+	fmt.Println(count, "signatures added")
+
+	return outputPSTx(path, pstx)
+}
+
+func CombinePSTx(c *cli.Context) error {
+	var pstx *account.PartiallySignedTx
+	for _, path := range c.Args() {
+		other, err := readPSTx(path)
+		if err != nil {
+			return errors.New(path + ": " + err.Error())
+		}
+		if pstx == nil {
+			if err := other.Verify(); err != nil {
+				return errors.New(path + ": " + err.Error())
+			}
+			pstx = other
+			continue
+		}
+		if err := pstx.Combine(other); err != nil {
+			return errors.New(path + ": " + err.Error())
+		}
+	}
+
+	return outputPSTx(c.String("pstx"), pstx)
+}
+
+func FinalizePSTx(c *cli.Context) error {
+	pstx, err := readPSTx(c.String("pstx"))
+	if err != nil {
+		return err
+	}
+	txn, err := pstx.Finalize()
+	if err != nil {
+		return err
+	}
+
+	return OutputTx(1, 1, txn)
+}
+
+func outputPSTx(path string, pstx *account.PartiallySignedTx) error {
+	data, err := json.MarshalIndent(pstx, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+
+	for i := range pstx.Programs {
+		haveSign, needSign, err := pstx.SignStatus(i)
+		if err != nil {
+			return err
+		}
+		fmt.Println("program", i, "[", haveSign, "/", needSign, "]")
+	}
+	fmt.Println("File: ", path)
+
+	return nil
+}
+
+func readPSTx(path string) (*account.PartiallySignedTx, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pstx account.PartiallySignedTx
+	if err := json.Unmarshal(data, &pstx); err != nil {
+		return nil, errors.New("invalid partially signed transaction file")
+	}
+	if pstx.Version != account.PSTxVersion {
+		return nil, fmt.Errorf("unsupported partially signed transaction version %d",
+			pstx.Version)
+	}
+	return &pstx, nil
+}
+
+// getRawTransaction gets the transaction of the hash from node.
+func getRawTransaction(hash common.Uint256) (*types.Transaction, error) {
+	result, err := cmdcom.RPCCall("getrawtransaction", http.Params{
+		"txid": servers.ToReversedString(hash),
+	})
+	if err != nil {
+		return nil, err
+	}
+	txHex, ok := result.(string)
+	if !ok {
+		return nil, errors.New("invalid raw transaction")
+	}
+	rawData, err := common.HexStringToBytes(txHex)
+	if err != nil {
+		return nil, err
+	}
+	var txn types.Transaction
+	if err := txn.Deserialize(bytes.NewReader(rawData)); err != nil {
+		return nil, err
+	}
+	return &txn, nil
+}
+
+// getProgramAddress returns the address of the redeem script.
+func getProgramAddress(codeHex string) (string, error) {
+	code, err := common.HexStringToBytes(codeHex)
+	if err != nil {
+		return "", err
+	}
+	signType, err := crypto.GetScriptType(code)
+	if err != nil {
+		return "", err
+	}
+	prefix := contract.PrefixStandard
+	if signType == vm.CHECKMULTISIG {
+		prefix = contract.PrefixMultiSig
+	}
+	return common.ToProgramHash(byte(prefix), code).ToAddress()
+}
@@ -0,0 +1,453 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/crypto"
+	"github.com/elastos/Elastos.ELA/servers"
+	"github.com/elastos/Elastos.ELA/utils/signal"
+
+	"github.com/urfave/cli"
+)
+
+var signerCommand = []cli.Command{
+	{
+		Category: "Account",
+		Name:     "signer",
+		Usage:    "Serve the accounts in wallet as an external signer",
+		Description: "With ela-cli wallet signer, you could keep the private keys " +
+			"on another machine and sign by the --signer option of the signing " +
+			"commands, the requests are served through stdin and stdout, or the " +
+			"unix socket specified by --listen. Each transaction or payload is " +
+			"shown before signing and signed only if it is confirmed.",
+		Flags: []cli.Flag{
+			cmdcom.SignerListenFlag,
+			cmdcom.AccountWalletFlag,
+			cmdcom.AccountPasswordFlag,
+		},
+		Action: func(c *cli.Context) error {
+			if err := serveSigner(c); err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	},
+}
+
+// stdio is the connection through stdin and stdout.
+type stdio struct {
+	io.Reader
+	io.Writer
+}
+
+func serveSigner(c *cli.Context) error {
+	walletPath := c.String("wallet")
+	password, err := cmdcom.GetFlagPassword(c)
+	if err != nil {
+		return err
+	}
+	client, err := account.Open(walletPath, password)
+	if err != nil {
+		return err
+	}
+	accounts := client.GetAccounts()
+
+	// stdin and stdout are used by the protocol without --listen, so the
+	// signing is confirmed through the terminal, and the prompts are printed
+	// to stderr in both cases
+	path := c.String("listen")
+	if path == "" {
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			return errors.New("no terminal to confirm signing, " +
+				"use --listen to serve on a unix socket")
+		}
+		defer tty.Close()
+		confirmer := newSignConfirmer(tty, os.Stderr)
+		return account.ServeSigner(stdio{os.Stdin, os.Stdout}, accounts,
+			confirmer.confirm)
+	}
+	confirmer := newSignConfirmer(os.Stdin, os.Stderr)
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+	if err := os.Chmod(path, 0600); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "signer listening on", path)
+
+	// close the listener on interrupt, so the socket file is removed
+	interrupt := signal.NewInterrupt()
+	go func() {
+		<-interrupt.C
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if interrupt.Interrupted() {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			err := account.ServeSigner(conn, accounts, confirmer.confirm)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+			}
+		}()
+	}
+}
+
+// signConfirmer shows the data to sign and asks the user to confirm it, the
+// requests of concurrent connections are confirmed one by one.
+type signConfirmer struct {
+	mu     sync.Mutex
+	reader *bufio.Reader
+	writer io.Writer
+}
+
+func newSignConfirmer(r io.Reader, w io.Writer) *signConfirmer {
+	return &signConfirmer{reader: bufio.NewReader(r), writer: w}
+}
+
+// confirm returns an error unless the user answers yes, the transaction is
+// refused without asking if the amount spent by its inputs is not verified.
+func (sc *signConfirmer) confirm(acc *account.Account, data []byte,
+	prevTxs []*types.Transaction) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	fmt.Fprintln(sc.writer, "Sign by", acc.Address)
+	description, err := describeSignData(data, prevTxs)
+	if err != nil {
+		fmt.Fprintln(sc.writer, "Refused:", err)
+		return err
+	}
+	fmt.Fprint(sc.writer, description)
+	fmt.Fprint(sc.writer, "Confirm to sign? (y/N): ")
+	answer, err := sc.reader.ReadString('\n')
+	if err != nil {
+		fmt.Fprintln(sc.writer)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return errors.New("signing refused by user")
+}
+
+// describeSignData describes the data to sign, which is the unsigned
+// transaction, or the unsigned payload of the producer, CR and proposal
+// transactions. The inputs of transaction are described by the referenced
+// outputs to show the fee, so an error is returned if they are not verified.
+func describeSignData(data []byte,
+	prevTxs []*types.Transaction) (string, error) {
+	var txn types.Transaction
+	r := bytes.NewReader(data)
+	if err := txn.DeserializeUnsigned(r); err != nil || r.Len() != 0 {
+		return describeSignPayload(data), nil
+	}
+	referenced, err := account.ReferencedOutputs(&txn, prevTxs)
+	if err != nil {
+		return "", errors.New("can not verify the amount spent: " + err.Error())
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "Hash:", servers.ToReversedString(txn.Hash()))
+	fmt.Fprintln(&buf, "TxType:", txn.TxType.Name())
+	fmt.Fprintln(&buf, "LockTime:", txn.LockTime)
+	var inputAmount common.Fixed64
+	fmt.Fprintln(&buf, "Inputs:")
+	for i, input := range txn.Inputs {
+		fmt.Fprintf(&buf, "%5d %s:%d %34s %s\n", i,
+			servers.ToReversedString(input.Previous.TxID), input.Previous.Index,
+			formatProgramHash(referenced[i].ProgramHash),
+			referenced[i].Value.String())
+		inputAmount += referenced[i].Value
+	}
+	var outputAmount common.Fixed64
+	fmt.Fprintln(&buf, "Outputs:")
+	for i, output := range txn.Outputs {
+		fmt.Fprintf(&buf, "%5d %34s %s", i, formatProgramHash(output.ProgramHash),
+			output.Value.String())
+		if output.OutputLock > 0 {
+			fmt.Fprintf(&buf, " locked until %d", output.OutputLock)
+		}
+		if output.Type != types.OTNone {
+			fmt.Fprintf(&buf, " type %d", output.Type)
+		}
+		fmt.Fprintln(&buf)
+		outputAmount += output.Value
+	}
+	fmt.Fprintln(&buf, "InputAmount:", inputAmount.String())
+	fmt.Fprintln(&buf, "OutputAmount:", outputAmount.String())
+	fmt.Fprintln(&buf, "Fee:", (inputAmount - outputAmount).String())
+	return buf.String(), nil
+}
+
+// signPayloads are the payloads signed separately from the transaction, by
+// the data signed in each signing step.
+var signPayloads = []struct {
+	name     string
+	describe func(r io.Reader, w io.Writer) error
+}{
+	{"ProducerInfo of RegisterProducer or UpdateProducer", describeProducerInfo},
+	{"ProcessProducer of CancelProducer", describeProcessProducer},
+	{"ActivateProducer", describeActivateProducer},
+	{"CRInfo of RegisterCR or UpdateCR", describeCRInfo(payload.CRInfoVersion)},
+	{"CRInfo of RegisterCR or UpdateCR with DID", describeCRInfo(payload.CRInfoDIDVersion)},
+	{"UnregisterCR", describeUnregisterCR},
+	{"CRCProposal to sign by the owner", describeCRCProposal(false)},
+	{"CRCProposal to sign by the CR council member", describeCRCProposal(true)},
+	{"CRCProposalReview", describeCRCProposalReview},
+	{"CRCProposalTracking to sign by the owner", describeCRCProposalTracking(0)},
+	{"CRCProposalTracking to sign by the new owner", describeCRCProposalTracking(1)},
+	{"CRCProposalTracking to sign by the secretary general", describeCRCProposalTracking(2)},
+	{"CRCProposalWithdraw", describeCRCProposalWithdraw},
+}
+
+// describeSignPayload describes the payload data by each payload which it is
+// decoded as exactly, the payloads of the same format are all shown, and the
+// data of unknown format is shown by its hash only.
+func describeSignPayload(data []byte) string {
+	var buf bytes.Buffer
+	hash := sha256.Sum256(data)
+	fmt.Fprintf(&buf, "Payload: %d bytes, SHA-256 %s\n", len(data),
+		common.BytesToHexString(hash[:]))
+	for _, p := range signPayloads {
+		var fields bytes.Buffer
+		r := bytes.NewReader(data)
+		if err := p.describe(r, &fields); err != nil || r.Len() != 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "As %s:\n", p.name)
+		buf.Write(fields.Bytes())
+	}
+	return buf.String()
+}
+
+func describeProducerInfo(r io.Reader, w io.Writer) error {
+	var p payload.ProducerInfo
+	if err := p.DeserializeUnsigned(r, payload.ProducerInfoVersion); err != nil {
+		return err
+	}
+	writeSignField(w, "OwnerPublicKey", common.BytesToHexString(p.OwnerPublicKey))
+	writeSignField(w, "NodePublicKey", common.BytesToHexString(p.NodePublicKey))
+	writeSignField(w, "NickName", p.NickName)
+	writeSignField(w, "Url", p.Url)
+	writeSignField(w, "Location", p.Location)
+	writeSignField(w, "NetAddress", p.NetAddress)
+	return nil
+}
+
+func describeProcessProducer(r io.Reader, w io.Writer) error {
+	var p payload.ProcessProducer
+	if err := p.DeserializeUnsigned(r, payload.ProcessProducerVersion); err != nil {
+		return err
+	}
+	writeSignField(w, "OwnerPublicKey", common.BytesToHexString(p.OwnerPublicKey))
+	return nil
+}
+
+func describeActivateProducer(r io.Reader, w io.Writer) error {
+	var p payload.ActivateProducer
+	if err := p.DeserializeUnsigned(r, payload.ActivateProducerVersion); err != nil {
+		return err
+	}
+	writeSignField(w, "NodePublicKey", common.BytesToHexString(p.NodePublicKey))
+	return nil
+}
+
+func describeCRInfo(version byte) func(r io.Reader, w io.Writer) error {
+	return func(r io.Reader, w io.Writer) error {
+		var p payload.CRInfo
+		if err := p.DeserializeUnsigned(r, version); err != nil {
+			return err
+		}
+		writeSignField(w, "Code", common.BytesToHexString(p.Code))
+		writeSignField(w, "CID", formatProgramHash(p.CID))
+		if version > payload.CRInfoVersion {
+			writeSignField(w, "DID", formatProgramHash(p.DID))
+		}
+		writeSignField(w, "NickName", p.NickName)
+		writeSignField(w, "Url", p.Url)
+		writeSignField(w, "Location", p.Location)
+		return nil
+	}
+}
+
+func describeUnregisterCR(r io.Reader, w io.Writer) error {
+	var p payload.UnregisterCR
+	if err := p.DeserializeUnsigned(r, payload.UnregisterCRVersion); err != nil {
+		return err
+	}
+	writeSignField(w, "CID", formatProgramHash(p.CID))
+	return nil
+}
+
+// describeCRCProposal returns the function to describe the proposal signed
+// by the owner, or by the CR council member which also signs the signature of
+// the owner and the DID of the member.
+func describeCRCProposal(crMember bool) func(r io.Reader, w io.Writer) error {
+	return func(r io.Reader, w io.Writer) error {
+		var p payload.CRCProposal
+		if err := p.DeserializeUnSigned(r, payload.CRCProposalVersion); err != nil {
+			return err
+		}
+		if crMember {
+			var err error
+			p.Signature, err = common.ReadVarBytes(r, crypto.SignatureLength,
+				"owner signature")
+			if err != nil {
+				return err
+			}
+			if err := p.CRCouncilMemberDID.Deserialize(r); err != nil {
+				return err
+			}
+		}
+		writeSignField(w, "ProposalType", p.ProposalType.Name())
+		writeSignField(w, "CategoryData", p.CategoryData)
+		writeSignField(w, "OwnerPublicKey", common.BytesToHexString(p.OwnerPublicKey))
+		writeSignField(w, "DraftHash", servers.ToReversedString(p.DraftHash))
+		for i, budget := range p.Budgets {
+			writeSignField(w, fmt.Sprintf("Budget %d", i), fmt.Sprintf(
+				"%s stage %d %s", budget.Type.Name(), budget.Stage,
+				budget.Amount.String()))
+		}
+		writeSignField(w, "Recipient", formatProgramHash(p.Recipient))
+		if crMember {
+			writeSignField(w, "CRCouncilMemberDID",
+				formatProgramHash(p.CRCouncilMemberDID))
+		}
+		return nil
+	}
+}
+
+func describeCRCProposalReview(r io.Reader, w io.Writer) error {
+	var p payload.CRCProposalReview
+	if err := p.DeserializeUnsigned(r, payload.CRCProposalReviewVersion); err != nil {
+		return err
+	}
+	writeSignField(w, "ProposalHash", servers.ToReversedString(p.ProposalHash))
+	writeSignField(w, "VoteResult", p.VoteResult.Name())
+	writeSignField(w, "OpinionHash", p.OpinionHash.String())
+	writeSignField(w, "DID", formatProgramHash(p.DID))
+	return nil
+}
+
+// describeCRCProposalTracking returns the function to describe the proposal
+// tracking after the count of signatures signed, the new owner also signs the
+// signature of the owner, and the secretary general also signs both
+// signatures, the tracking type and the opinion hash.
+func describeCRCProposalTracking(signatures int) func(r io.Reader, w io.Writer) error {
+	return func(r io.Reader, w io.Writer) error {
+		var p payload.CRCProposalTracking
+		err := p.DeserializeUnSigned(r, payload.CRCProposalTrackingVersion)
+		if err != nil {
+			return err
+		}
+		if signatures > 0 {
+			p.OwnerSignature, err = common.ReadVarBytes(r,
+				crypto.SignatureLength, "owner signature")
+			if err != nil {
+				return err
+			}
+		}
+		if signatures > 1 {
+			p.NewOwnerSignature, err = common.ReadVarBytes(r,
+				crypto.SignatureLength, "new owner signature")
+			if err != nil {
+				return err
+			}
+			trackingType, err := common.ReadUint8(r)
+			if err != nil {
+				return err
+			}
+			p.ProposalTrackingType = payload.CRCProposalTrackingType(trackingType)
+			if err := p.SecretaryGeneralOpinionHash.Deserialize(r); err != nil {
+				return err
+			}
+		}
+		writeSignField(w, "ProposalHash", servers.ToReversedString(p.ProposalHash))
+		writeSignField(w, "MessageHash", p.MessageHash.String())
+		writeSignField(w, "Stage", p.Stage)
+		writeSignField(w, "OwnerPublicKey", common.BytesToHexString(p.OwnerPublicKey))
+		writeSignField(w, "NewOwnerPublicKey",
+			common.BytesToHexString(p.NewOwnerPublicKey))
+		if signatures > 1 {
+			writeSignField(w, "ProposalTrackingType", p.ProposalTrackingType.Name())
+			writeSignField(w, "SecretaryGeneralOpinionHash",
+				p.SecretaryGeneralOpinionHash.String())
+		}
+		return nil
+	}
+}
+
+func describeCRCProposalWithdraw(r io.Reader, w io.Writer) error {
+	var p payload.CRCProposalWithdraw
+	err := p.DeserializeUnsigned(r, payload.CRCProposalWithdrawVersion)
+	if err != nil {
+		return err
+	}
+	writeSignField(w, "ProposalHash", servers.ToReversedString(p.ProposalHash))
+	writeSignField(w, "OwnerPublicKey", common.BytesToHexString(p.OwnerPublicKey))
+	return nil
+}
+
+func writeSignField(w io.Writer, name string, value interface{}) {
+	fmt.Fprintf(w, "%4s%s: %v\n", "", name, value)
+}
+
+// formatProgramHash returns the address of the program hash, or the hex
+// string of it if it is not a valid address.
+func formatProgramHash(programHash common.Uint168) string {
+	address, err := programHash.ToAddress()
+	if err != nil {
+		return "invalid program hash " + programHash.String()
+	}
+	return address
+}
+
+// setSigner sets the external signer specified by --signer to the client,
+// the returned function closes the connection to the signer.
+func setSigner(c *cli.Context, client *account.Client) (func(), error) {
+	address := c.String("signer")
+	if address == "" {
+		return func() {}, nil
+	}
+	signer, err := account.DialSigner(address)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.SetSigner(signer); err != nil {
+		signer.Close()
+		return nil, err
+	}
+	return func() { signer.Close() }, nil
+}
@@ -0,0 +1,296 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/account"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/outputpayload"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/crypto"
+	"github.com/elastos/Elastos.ELA/servers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestSignData returns a transfer transaction paying to the accounts, the
+// referenced transactions of its inputs and the unsigned data of it.
+func newTestSignData(t *testing.T, recipient, change *account.Account) (
+	*types.Transaction, []*types.Transaction, []byte) {
+	newOutput := func(acc *account.Account, value common.Fixed64,
+		outputLock uint32) *types.Output {
+		return &types.Output{
+			AssetID:     *account.SystemAssetID,
+			Value:       value,
+			OutputLock:  outputLock,
+			ProgramHash: acc.ProgramHash,
+			Type:        types.OTNone,
+			Payload:     &outputpayload.DefaultOutput{},
+		}
+	}
+
+	prevTx := &types.Transaction{
+		Version: types.TxVersion09,
+		TxType:  types.TransferAsset,
+		Payload: &payload.TransferAsset{},
+		Outputs: []*types.Output{
+			newOutput(recipient, 1e8, 0),
+			newOutput(recipient, 2e8, 0),
+			newOutput(change, 100e8, 0),
+		},
+	}
+	txn := &types.Transaction{
+		Version:  types.TxVersion09,
+		TxType:   types.TransferAsset,
+		Payload:  &payload.TransferAsset{},
+		LockTime: 1000,
+		Inputs: []*types.Input{{
+			Previous: types.OutPoint{TxID: prevTx.Hash(), Index: 2},
+			Sequence: 0xfffffffe,
+		}},
+		Outputs: []*types.Output{
+			newOutput(recipient, 10e8, 0),
+			newOutput(change, 89.9999e8, 1000),
+		},
+	}
+	buf := new(bytes.Buffer)
+	if err := txn.SerializeUnsigned(buf); err != nil {
+		t.Fatal(err)
+	}
+	return txn, []*types.Transaction{prevTx}, buf.Bytes()
+}
+
+func newTestAccount(t *testing.T) *account.Account {
+	acc, err := account.NewAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return acc
+}
+
+func TestDescribeSignData(t *testing.T) {
+	recipient := newTestAccount(t)
+	change := newTestAccount(t)
+	txn, prevTxs, data := newTestSignData(t, recipient, change)
+	description, err := describeSignData(data, prevTxs)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hash: "+servers.ToReversedString(txn.Hash())+"\n"+
+		"TxType: TransferAsset\n"+
+		"LockTime: 1000\n"+
+		"Inputs:\n"+
+		"    0 "+servers.ToReversedString(prevTxs[0].Hash())+":2 "+
+		change.Address+" 100\n"+
+		"Outputs:\n"+
+		"    0 "+recipient.Address+" 10\n"+
+		"    1 "+change.Address+" 89.99990000 locked until 1000\n"+
+		"InputAmount: 100\n"+
+		"OutputAmount: 99.99990000\n"+
+		"Fee: 0.00010000\n", description)
+
+	// the transaction is not described if the amount spent is not verified
+	_, err = describeSignData(data, nil)
+	assert.EqualError(t, err, "can not verify the amount spent: "+
+		"referenced transactions count not match inputs")
+	_, err = describeSignData(data, []*types.Transaction{txn})
+	assert.EqualError(t, err, "can not verify the amount spent: "+
+		"referenced transaction of input 0 not match")
+
+	// the data which is not a transaction or known payload is shown by its
+	// hash
+	for _, data := range [][]byte{
+		[]byte("producer info"),
+		append(data, 0),
+		data[:len(data)-1],
+		nil,
+	} {
+		hash := sha256.Sum256(data)
+		description, err := describeSignData(data, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("Payload: %d bytes, SHA-256 %s\n",
+			len(data), common.BytesToHexString(hash[:])), description,
+			"%x", data)
+	}
+}
+
+func TestDescribeSignData_Payload(t *testing.T) {
+	owner := newTestAccount(t)
+	node := newTestAccount(t)
+	ownerKey, err := owner.PublicKey.EncodePoint(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeKey, err := node.PublicKey.EncodePoint(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	describe := func(serialize func(w io.Writer) error) string {
+		buf := new(bytes.Buffer)
+		if err := serialize(buf); err != nil {
+			t.Fatal(err)
+		}
+		description, err := describeSignData(buf.Bytes(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash := sha256.Sum256(buf.Bytes())
+		header := fmt.Sprintf("Payload: %d bytes, SHA-256 %s\n", buf.Len(),
+			common.BytesToHexString(hash[:]))
+		assert.True(t, strings.HasPrefix(description, header))
+		return strings.TrimPrefix(description, header)
+	}
+
+	info := &payload.ProducerInfo{
+		OwnerPublicKey: ownerKey,
+		NodePublicKey:  nodeKey,
+		NickName:       "producer",
+		Url:            "https://example.com",
+		Location:       86,
+		NetAddress:     "127.0.0.1:20338",
+	}
+	assert.Equal(t, "As ProducerInfo of RegisterProducer or UpdateProducer:\n"+
+		"    OwnerPublicKey: "+common.BytesToHexString(ownerKey)+"\n"+
+		"    NodePublicKey: "+common.BytesToHexString(nodeKey)+"\n"+
+		"    NickName: producer\n"+
+		"    Url: https://example.com\n"+
+		"    Location: 86\n"+
+		"    NetAddress: 127.0.0.1:20338\n",
+		describe(func(w io.Writer) error {
+			return info.SerializeUnsigned(w, payload.ProducerInfoVersion)
+		}))
+
+	// the payloads of the same format are all shown
+	cancel := &payload.ProcessProducer{OwnerPublicKey: ownerKey}
+	assert.Equal(t, "As ProcessProducer of CancelProducer:\n"+
+		"    OwnerPublicKey: "+common.BytesToHexString(ownerKey)+"\n"+
+		"As ActivateProducer:\n"+
+		"    NodePublicKey: "+common.BytesToHexString(ownerKey)+"\n",
+		describe(func(w io.Writer) error {
+			return cancel.SerializeUnsigned(w, payload.ProcessProducerVersion)
+		}))
+
+	// the proposal is described by the data of each signer
+	proposal := &payload.CRCProposal{
+		ProposalType:       payload.Normal,
+		CategoryData:       "category",
+		OwnerPublicKey:     ownerKey,
+		Budgets:            []payload.Budget{{Type: payload.Imprest, Amount: 5e8}},
+		Recipient:          owner.ProgramHash,
+		Signature:          make([]byte, crypto.SignatureLength),
+		CRCouncilMemberDID: node.ProgramHash,
+	}
+	description := describe(func(w io.Writer) error {
+		if err := proposal.SerializeUnsigned(w,
+			payload.CRCProposalVersion); err != nil {
+			return err
+		}
+		if err := common.WriteVarBytes(w, proposal.Signature); err != nil {
+			return err
+		}
+		return proposal.CRCouncilMemberDID.Serialize(w)
+	})
+	assert.Equal(t, "As CRCProposal to sign by the CR council member:\n"+
+		"    ProposalType: Normal\n"+
+		"    CategoryData: category\n"+
+		"    OwnerPublicKey: "+common.BytesToHexString(ownerKey)+"\n"+
+		"    DraftHash: "+servers.ToReversedString(common.Uint256{})+"\n"+
+		"    Budget 0: Imprest stage 0 5\n"+
+		"    Recipient: "+owner.Address+"\n"+
+		"    CRCouncilMemberDID: "+node.Address+"\n", description)
+
+	tracking := &payload.CRCProposalTracking{
+		Stage:                1,
+		OwnerPublicKey:       ownerKey,
+		OwnerSignature:       make([]byte, crypto.SignatureLength),
+		ProposalTrackingType: payload.Finalized,
+	}
+	description = describe(func(w io.Writer) error {
+		if err := tracking.SerializeUnsigned(w,
+			payload.CRCProposalTrackingVersion); err != nil {
+			return err
+		}
+		if err := common.WriteVarBytes(w, tracking.OwnerSignature); err != nil {
+			return err
+		}
+		if err := common.WriteVarBytes(w, tracking.NewOwnerSignature); err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte{byte(tracking.ProposalTrackingType)}); err != nil {
+			return err
+		}
+		return tracking.SecretaryGeneralOpinionHash.Serialize(w)
+	})
+	assert.True(t, strings.HasPrefix(description,
+		"As CRCProposalTracking to sign by the secretary general:\n"))
+	assert.Contains(t, description, "    ProposalTrackingType: Finalized\n")
+}
+
+func TestSignConfirmer(t *testing.T) {
+	acc := newTestAccount(t)
+	_, prevTxs, data := newTestSignData(t, newTestAccount(t), acc)
+	description, err := describeSignData(data, prevTxs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	confirmer := newSignConfirmer(strings.NewReader(
+		"y\nYES\n\nn\nyess\n"), out)
+	assert.NoError(t, confirmer.confirm(acc, data, prevTxs))
+	assert.Equal(t, "Sign by "+acc.Address+"\n"+description+
+		"Confirm to sign? (y/N): ", out.String())
+	assert.NoError(t, confirmer.confirm(acc, data, prevTxs))
+	for i := 0; i < 3; i++ {
+		assert.EqualError(t, confirmer.confirm(acc, data, prevTxs),
+			"signing refused by user")
+	}
+	// the signing is refused when no answer is available
+	assert.EqualError(t, confirmer.confirm(acc, data, prevTxs),
+		"signing refused by user")
+
+	// the answer without line end is accepted
+	confirmer = newSignConfirmer(strings.NewReader("y"), out)
+	assert.NoError(t, confirmer.confirm(acc, data, prevTxs))
+
+	// the transaction is refused without asking if the amount spent is not
+	// verified
+	out.Reset()
+	confirmer = newSignConfirmer(strings.NewReader("y\n"), out)
+	err = confirmer.confirm(acc, data, nil)
+	assert.EqualError(t, err, "can not verify the amount spent: "+
+		"referenced transactions count not match inputs")
+	assert.Equal(t, "Sign by "+acc.Address+"\nRefused: "+err.Error()+"\n",
+		out.String())
+
+	// the signer serves requests only if they are confirmed
+	out.Reset()
+	confirmer = newSignConfirmer(strings.NewReader("n\ny\n"), out)
+	serverConn, clientConn := net.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- account.ServeSigner(serverConn, []*account.Account{acc},
+			confirmer.confirm)
+	}()
+	signer := account.NewJSONSigner(clientConn)
+	_, err = signer.SignTransaction(acc.PublicKey, data, prevTxs)
+	assert.EqualError(t, err, "signing refused by user")
+	_, err = signer.SignTransaction(acc.PublicKey, data, prevTxs)
+	assert.NoError(t, err)
+	_, err = signer.Sign(acc.PublicKey, data)
+	assert.EqualError(t, err, "can not verify the amount spent: "+
+		"referenced transactions count not match inputs")
+	assert.Equal(t, 3, strings.Count(out.String(), "Sign by "+acc.Address))
+	assert.NoError(t, signer.Close())
+	assert.NoError(t, <-served)
+}
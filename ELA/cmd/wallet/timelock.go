@@ -0,0 +1,127 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/elastos/Elastos.ELA/common"
+
+	"github.com/urfave/cli"
+)
+
+// relativeHeightPrefix is the prefix of a lock height relative to the current
+// height, such as "+720" means 720 blocks after the current height.
+const relativeHeightPrefix = "+"
+
+// lockHeights parses the lock heights of a transaction, the current height
+// is got from node only once when a relative lock height is used.
+type lockHeights struct {
+	current *uint32
+}
+
+// parse parses an absolute lock height or a relative lock height starts
+// with "+", an empty string means no lock.
+func (h *lockHeights) parse(s string) (uint32, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	relative := strings.HasPrefix(s, relativeHeightPrefix)
+	height, err := strconv.ParseUint(strings.TrimPrefix(s, relativeHeightPrefix), 10, 32)
+	if err != nil {
+		return 0, errors.New("invalid lock height: " + s)
+	}
+	if !relative {
+		return uint32(height), nil
+	}
+
+	if h.current == nil {
+		current, err := getCurrentHeight()
+		if err != nil {
+			return 0, err
+		}
+		h.current = &current
+	}
+	if uint64(*h.current)+height > math.MaxUint32 {
+		return 0, errors.New("lock height out of range: " + s)
+	}
+	return *h.current + uint32(height), nil
+}
+
+// VestingSchedule describes a payment split into installments, each of them
+// is unlocked at Interval blocks after the previous one.
+type VestingSchedule struct {
+	Installments int
+	Start        uint32
+	Interval     uint32
+}
+
+// getVestingSchedule returns the vesting schedule specified by the vesting
+// flags, nil is returned if no vesting is specified.
+func getVestingSchedule(c *cli.Context, heights *lockHeights) (*VestingSchedule, error) {
+	installments := c.Int("vesting")
+	if installments == 0 {
+		if c.IsSet("interval") || c.IsSet("start") {
+			return nil, errors.New("use --vesting to specify the number of installments")
+		}
+		return nil, nil
+	}
+	if installments < 0 || installments > math.MaxUint16 {
+		return nil, errors.New("invalid number of installments")
+	}
+	interval := c.Uint("interval")
+	if interval == 0 || interval > math.MaxUint32 {
+		return nil, errors.New("use --interval to specify the blocks between installments")
+	}
+	startStr := c.String("start")
+	if startStr == "" {
+		startStr = relativeHeightPrefix + strconv.FormatUint(uint64(interval), 10)
+	}
+	start, err := heights.parse(startStr)
+	if err != nil {
+		return nil, err
+	}
+	if start == 0 {
+		return nil, errors.New("invalid vesting start height")
+	}
+	last := uint64(start) + uint64(installments-1)*uint64(interval)
+	if last > math.MaxUint32 {
+		return nil, errors.New("vesting schedule exceeds the max lock height")
+	}
+
+	return &VestingSchedule{
+		Installments: installments,
+		Start:        start,
+		Interval:     uint32(interval),
+	}, nil
+}
+
+// Outputs splits the amount paid to the recipient into installments, the
+// remainder of the division goes to the last installment.
+func (v *VestingSchedule) Outputs(recipient string, amount common.Fixed64) ([]*OutputInfo, error) {
+	installment := amount / common.Fixed64(v.Installments)
+	if installment <= 0 {
+		return nil, errors.New("amount is too small to split into installments")
+	}
+
+	outputs := make([]*OutputInfo, 0, v.Installments)
+	for i := 0; i < v.Installments; i++ {
+		value := installment
+		if i == v.Installments-1 {
+			value = amount - installment*common.Fixed64(v.Installments-1)
+		}
+		outputs = append(outputs, &OutputInfo{
+			Recipient:  recipient,
+			Amount:     &value,
+			OutputLock: v.Start + uint32(i)*v.Interval,
+		})
+	}
+	return outputs, nil
+}
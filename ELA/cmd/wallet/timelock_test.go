@@ -0,0 +1,247 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"math"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/outputpayload"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+func TestLockHeights_Parse(t *testing.T) {
+	var calls int
+	stop := startTestRPC(t, map[string]func(map[string]interface{}) interface{}{
+		"getcurrentheight": func(map[string]interface{}) interface{} {
+			calls++
+			return 100
+		},
+	})
+	defer stop()
+
+	heights := &lockHeights{}
+	for _, test := range []struct {
+		s      string
+		height uint32
+	}{
+		{"", 0},
+		{" ", 0},
+		{"0", 0},
+		{"500", 500},
+		{" 600 ", 600},
+		{"4294967295", math.MaxUint32},
+	} {
+		height, err := heights.parse(test.s)
+		assert.NoError(t, err, test.s)
+		assert.Equal(t, test.height, height, test.s)
+	}
+	// the current height is not needed by absolute lock heights
+	assert.Equal(t, 0, calls)
+
+	// the current height is got only once
+	height, err := heights.parse("+20")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(120), height)
+	height, err = heights.parse("+0")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(100), height)
+	height, err = heights.parse("+4294967195")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(math.MaxUint32), height)
+	assert.Equal(t, 1, calls)
+
+	_, err = heights.parse("+4294967196")
+	assert.EqualError(t, err, "lock height out of range: +4294967196")
+	for _, s := range []string{
+		"abc",
+		"-1",
+		"+",
+		"++1",
+		"+-1",
+		"1.5",
+		"4294967296",
+		"+4294967296",
+	} {
+		_, err := heights.parse(s)
+		assert.EqualError(t, err, "invalid lock height: "+s, s)
+	}
+}
+
+func TestVestingSchedule_Outputs(t *testing.T) {
+	const recipient = "EJbTbWd8a9rdutUfvBxhcrvEeNy21tW1Ee"
+	vesting := &VestingSchedule{Installments: 3, Start: 1000, Interval: 720}
+	check := func(amount common.Fixed64, expected ...common.Fixed64) {
+		outputs, err := vesting.Outputs(recipient, amount)
+		if !assert.NoError(t, err) || !assert.Len(t, outputs, len(expected)) {
+			return
+		}
+		var total common.Fixed64
+		for i, output := range outputs {
+			assert.Equal(t, recipient, output.Recipient)
+			assert.Equal(t, expected[i], *output.Amount)
+			assert.Equal(t, vesting.Start+uint32(i)*vesting.Interval,
+				output.OutputLock)
+			total += *output.Amount
+		}
+		// nothing is lost by rounding
+		assert.Equal(t, amount, total)
+	}
+
+	check(9e8, 3e8, 3e8, 3e8)
+	// the remainder goes to the last installment
+	check(10e8, 333333333, 333333333, 333333334)
+	check(5, 1, 1, 3)
+	check(3, 1, 1, 1)
+
+	_, err := vesting.Outputs(recipient, 2)
+	assert.EqualError(t, err, "amount is too small to split into installments")
+
+	vesting = &VestingSchedule{Installments: 1, Start: 10, Interval: 1}
+	check(7, 7)
+}
+
+func TestGetVestingSchedule(t *testing.T) {
+	stop := startTestRPC(t, map[string]func(map[string]interface{}) interface{}{
+		"getcurrentheight": func(map[string]interface{}) interface{} {
+			return 100
+		},
+	})
+	defer stop()
+	flags := []cli.Flag{
+		cmdcom.TransactionVestingFlag,
+		cmdcom.TransactionIntervalFlag,
+		cmdcom.TransactionStartFlag,
+	}
+	getVesting := func(values map[string]string) (*VestingSchedule, error) {
+		return getVestingSchedule(newTestContext(t, flags, values),
+			&lockHeights{})
+	}
+
+	vesting, err := getVesting(map[string]string{})
+	assert.NoError(t, err)
+	assert.Nil(t, vesting)
+
+	// the first installment is one interval after the current height
+	vesting, err = getVesting(map[string]string{"vesting": "4", "interval": "10"})
+	assert.NoError(t, err)
+	assert.Equal(t, &VestingSchedule{Installments: 4, Start: 110, Interval: 10},
+		vesting)
+	vesting, err = getVesting(map[string]string{"vesting": "4",
+		"interval": "10", "start": "+5"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(105), vesting.Start)
+	vesting, err = getVesting(map[string]string{"vesting": "2",
+		"interval": "10", "start": "4294967285"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(4294967285), vesting.Start)
+
+	for _, test := range []struct {
+		values map[string]string
+		err    string
+	}{
+		{map[string]string{"interval": "10"},
+			"use --vesting to specify the number of installments"},
+		{map[string]string{"vesting": "-1", "interval": "10"},
+			"invalid number of installments"},
+		{map[string]string{"vesting": "65536", "interval": "10"},
+			"invalid number of installments"},
+		{map[string]string{"vesting": "2"},
+			"use --interval to specify the blocks between installments"},
+		{map[string]string{"vesting": "2", "interval": "10", "start": "0"},
+			"invalid vesting start height"},
+		{map[string]string{"vesting": "2", "interval": "10", "start": "x"},
+			"invalid lock height: x"},
+		{map[string]string{"vesting": "3", "interval": "10", "start": "4294967285"},
+			"vesting schedule exceeds the max lock height"},
+	} {
+		_, err := getVesting(test.values)
+		assert.EqualError(t, err, test.err, "%v", test.values)
+	}
+}
+
+func TestNewTransferTransaction(t *testing.T) {
+	walletPath, _, cleanup := newTestWallet(t)
+	defer cleanup()
+	sender, err := getSender(walletPath, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	programHash, err := common.Uint168FromAddress(sender.Address)
+	assert.NoError(t, err)
+	height := uint32(1000000)
+	stop := startTestRPC(t, map[string]func(map[string]interface{}) interface{}{
+		"getcurrentheight": func(map[string]interface{}) interface{} {
+			return height
+		},
+	})
+	defer stop()
+	newOutput := func(programHash common.Uint168) *types.Output {
+		return &types.Output{
+			AssetID:     *account.SystemAssetID,
+			Value:       1e8,
+			ProgramHash: programHash,
+			Type:        types.OTNone,
+			Payload:     &outputpayload.DefaultOutput{},
+		}
+	}
+
+	coins := newCoins(1e8, 2e8)
+	txn, err := newTransferTransaction(sender, coins,
+		[]*types.Output{newOutput(*programHash)}, 0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, coinInputs(coins), txn.Inputs)
+	}
+
+	// the outputs are checked by the rules of blockchain
+	output := newOutput(*programHash)
+	output.AssetID = common.Uint256{1}
+	_, err = newTransferTransaction(sender, coins, []*types.Output{output}, 0)
+	assert.EqualError(t, err, "asset ID in output is invalid")
+	output = newOutput(*programHash)
+	output.Value = -1
+	_, err = newTransferTransaction(sender, coins, []*types.Output{output}, 0)
+	assert.EqualError(t, err, "Invalide transaction UTXO output.")
+	_, err = newTransferTransaction(sender, coins,
+		[]*types.Output{newOutput(common.Uint168{0x99})}, 0)
+	assert.EqualError(t, err, "invalid program hash prefix")
+
+	// the outputs are checked at the next block height of node, the program
+	// hash is not checked before CheckAddressHeight as the node does
+	height = config.DefaultParams.CheckAddressHeight - 2
+	_, err = newTransferTransaction(sender, coins,
+		[]*types.Output{newOutput(common.Uint168{0x99})}, 0)
+	assert.NoError(t, err)
+	height = config.DefaultParams.CheckAddressHeight - 1
+	_, err = newTransferTransaction(sender, coins,
+		[]*types.Output{newOutput(common.Uint168{0x99})}, 0)
+	assert.EqualError(t, err, "invalid program hash prefix")
+
+	// the locked UTXO is spent with the lock sequence and the lock time not
+	// less than the output lock of it
+	coins[1].outputLock = 1000
+	_, err = newTransferTransaction(sender, coins,
+		[]*types.Output{newOutput(*programHash)}, 1000)
+	assert.EqualError(t, err, "Invalid input sequence, the transaction lock "+
+		"height should not be less than 1000")
+	coins[1].input.Sequence = math.MaxUint32 - 1
+	_, err = newTransferTransaction(sender, coins,
+		[]*types.Output{newOutput(*programHash)}, 999)
+	assert.EqualError(t, err, "UTXO output locked, the transaction lock "+
+		"height should not be less than 1000")
+	txn, err = newTransferTransaction(sender, coins,
+		[]*types.Output{newOutput(*programHash)}, 1000)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint32(1000), txn.LockTime)
+	}
+}
@@ -36,6 +36,13 @@ var txCommand = []cli.Command{
 			cmdcom.TransactionFeeFlag,
 			cmdcom.TransactionOutputLockFlag,
 			cmdcom.TransactionTxLockFlag,
+			cmdcom.TransactionVestingFlag,
+			cmdcom.TransactionIntervalFlag,
+			cmdcom.TransactionStartFlag,
+			cmdcom.TransactionUTXOFlag,
+			cmdcom.TransactionStrategyFlag,
+			cmdcom.TransactionMaxInputsFlag,
+			cmdcom.TransactionChangeToleranceFlag,
 			cmdcom.AccountWalletFlag,
 		},
 		Subcommands: buildTxCommand,
@@ -51,6 +58,7 @@ var txCommand = []cli.Command{
 			cmdcom.TransactionFileFlag,
 			cmdcom.AccountWalletFlag,
 			cmdcom.AccountPasswordFlag,
+			cmdcom.AccountSignerFlag,
 		},
 		Action: signTx,
 	},
@@ -65,6 +73,30 @@ var txCommand = []cli.Command{
 		},
 		Action: sendTx,
 	},
+	{
+		Category:    "Transaction",
+		Name:        "consolidate",
+		Usage:       "Build a transaction to consolidate the small UTXOs",
+		Description: "use --from --fee to merge the smallest UTXOs of the address into one output",
+		Flags: []cli.Flag{
+			cmdcom.TransactionFromFlag,
+			cmdcom.TransactionToFlag,
+			cmdcom.TransactionFeeFlag,
+			cmdcom.TransactionMaxInputsFlag,
+			cmdcom.AccountWalletFlag,
+		},
+		Action: func(c *cli.Context) error {
+			if c.NumFlags() == 0 {
+				cli.ShowSubcommandHelp(c)
+				return nil
+			}
+			if err := CreateConsolidateTransaction(c); err != nil {
+				fmt.Println("error:", err)
+				os.Exit(1)
+			}
+			return nil
+		},
+	},
 	{
 		Category: "Transaction",
 		Name:     "showtx",
@@ -84,6 +116,7 @@ var buildTxCommand = []cli.Command{
 		Flags: []cli.Flag{
 			cmdcom.AccountWalletFlag,
 			cmdcom.AccountPasswordFlag,
+			cmdcom.AccountSignerFlag,
 			cmdcom.CRCProposalHashFlag,
 			cmdcom.CRCProposalStageFlag,
 			cmdcom.TransactionAmountFlag,
@@ -106,6 +139,7 @@ var buildTxCommand = []cli.Command{
 			cmdcom.TransactionNodePublicKeyFlag,
 			cmdcom.AccountWalletFlag,
 			cmdcom.AccountPasswordFlag,
+			cmdcom.AccountSignerFlag,
 		},
 		Action: func(c *cli.Context) error {
 			if err := CreateActivateProducerTransaction(c); err != nil {
@@ -203,6 +237,11 @@ func signTx(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return err
+	}
+	defer closeSigner()
 
 	txHex, err := getTransactionHex(c)
 	if err != nil {
@@ -231,7 +270,20 @@ func signTx(c *cli.Context) error {
 		return errors.New("transaction was fully signed, no need more sign")
 	}
 
-	txnSigned, err := client.Sign(&txn)
+	// the external signer shows the fee by the referenced transactions of
+	// the inputs before signing
+	var prevTxs []*types.Transaction
+	if c.String("signer") != "" {
+		for _, input := range txn.Inputs {
+			prevTx, err := getRawTransaction(input.Previous.TxID)
+			if err != nil {
+				return err
+			}
+			prevTxs = append(prevTxs, prevTx)
+		}
+	}
+
+	txnSigned, err := client.SignWithPreviousTxs(&txn, prevTxs)
 	if err != nil {
 		return err
 	}
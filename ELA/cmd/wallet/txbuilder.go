@@ -13,12 +13,13 @@ import (
 	"math"
 	"math/rand"
 	"os"
+	"sort"
 	"strconv"
 
 	"github.com/elastos/Elastos.ELA/account"
+	"github.com/elastos/Elastos.ELA/blockchain"
 	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
 	"github.com/elastos/Elastos.ELA/common"
-	"github.com/elastos/Elastos.ELA/core/contract"
 	pg "github.com/elastos/Elastos.ELA/core/contract/program"
 	"github.com/elastos/Elastos.ELA/core/types"
 	"github.com/elastos/Elastos.ELA/core/types/outputpayload"
@@ -28,8 +29,9 @@ import (
 )
 
 type OutputInfo struct {
-	Recipient string
-	Amount    *common.Fixed64
+	Recipient  string
+	Amount     *common.Fixed64
+	OutputLock uint32
 }
 
 type CrossChainOutput struct {
@@ -53,6 +55,25 @@ func CreateTransaction(c *cli.Context) error {
 
 	from := c.String("from")
 
+	heights := &lockHeights{}
+	vesting, err := getVestingSchedule(c, heights)
+	if err != nil {
+		return err
+	}
+
+	outputLock, err := heights.parse(c.String("outputlock"))
+	if err != nil {
+		return errors.New("invalid output lock height")
+	}
+	if vesting != nil && outputLock > 0 {
+		return errors.New("'--outputlock' cannot be specified when specify '--vesting' option")
+	}
+
+	txLock, err := heights.parse(c.String("txlock"))
+	if err != nil {
+		return errors.New("invalid transaction lock height")
+	}
+
 	outputs := make([]*OutputInfo, 0)
 	to := c.String("to")
 	amountStr := c.String("amount")
@@ -64,7 +85,10 @@ func CreateTransaction(c *cli.Context) error {
 		if amountStr != "" {
 			return errors.New("'--amount' cannot be specified when specify '--tomany' option")
 		}
-		outputs, err = parseMultiOutput(toMany)
+		if vesting != nil {
+			return errors.New("'--vesting' cannot be specified when specify '--tomany' option")
+		}
+		outputs, err = parseMultiOutput(toMany, heights)
 		if err != nil {
 			return err
 		}
@@ -79,30 +103,24 @@ func CreateTransaction(c *cli.Context) error {
 		if to == "" {
 			return errors.New("use --to to specify recipient")
 		}
-		outputs = []*OutputInfo{{to, amount}}
-	}
-
-	outputLockStr := c.String("outputlock")
-	outputLock := uint64(0)
-	if outputLockStr != "" {
-		outputLock, err = strconv.ParseUint(outputLockStr, 10, 32)
-		if err != nil {
-			return errors.New("invalid output lock height")
+		if vesting != nil {
+			outputs, err = vesting.Outputs(to, *amount)
+			if err != nil {
+				return err
+			}
+		} else {
+			outputs = []*OutputInfo{{Recipient: to, Amount: amount}}
 		}
 	}
 
-	txLockStr := c.String("txlock")
-	txLock := uint64(0)
-	if txLockStr != "" {
-		txLock, err = strconv.ParseUint(txLockStr, 10, 32)
-		if err != nil {
-			return errors.New("invalid transaction lock height")
-		}
+	cs, err := getCoinSelection(c)
+	if err != nil {
+		return err
 	}
 
 	var txn *types.Transaction
-	txn, err = createTransaction(walletPath, from, *fee, uint32(outputLock),
-		uint32(txLock), outputs...)
+	txn, err = createTransaction(walletPath, from, *fee, outputLock,
+		txLock, cs, outputs...)
 	if err != nil {
 		return errors.New("create transaction failed: " + err.Error())
 	}
@@ -114,13 +132,11 @@ func CreateTransaction(c *cli.Context) error {
 
 func getSender(walletPath string, from string) (*account.AccountData, error) {
 	var sender *account.AccountData
-	mainAccount, err := account.GetWalletMainAccountData(walletPath)
-	if err != nil {
-		return nil, err
-	}
-
 	if from == "" {
-		from = mainAccount.Address
+		mainAccount, err := account.GetWalletMainAccountData(walletPath)
+		if err != nil {
+			return nil, errors.New(err.Error() + ", use --from to specify the sender")
+		}
 		sender = mainAccount
 	} else {
 		storeAccounts, err := account.GetWalletAccountData(walletPath)
@@ -137,18 +153,33 @@ func getSender(walletPath string, from string) (*account.AccountData, error) {
 			return nil, errors.New(from + " is not local account")
 		}
 	}
+	if sender.RedeemScript == "" {
+		return nil, errors.New("the redeem script of watch-only address " +
+			sender.Address + " is unknown, watch it by public key or redeem script to spend")
+	}
 
 	return sender, nil
 }
 
 func createInputs(fromAddr string, totalAmount common.Fixed64) ([]*types.Input,
+	[]*types.Output, error) {
+	coins, changeOutputs, err := createCoins(fromAddr, totalAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return coinInputs(coins), changeOutputs, nil
+}
+
+// createCoins gets the UTXOs of address to cover the total amount from node,
+// and creates the change output if needed.
+func createCoins(fromAddr string, totalAmount common.Fixed64) ([]*coin,
 	[]*types.Output, error) {
 	UTXOs, err := getUTXOsByAmount(fromAddr, totalAmount)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var txInputs []*types.Input
+	var coins []*coin
 	var changeOutputs []*types.Output
 	for _, utxo := range UTXOs {
 		txIDReverse, _ := hex.DecodeString(utxo.TxID)
@@ -164,11 +195,15 @@ func createInputs(fromAddr string, totalAmount common.Fixed64) ([]*types.Input,
 			},
 			Sequence: uint32(sequence),
 		}
-		txInputs = append(txInputs, input)
 		amount, err := common.StringToFixed64(utxo.Amount)
 		if err != nil {
 			return nil, nil, err
 		}
+		coins = append(coins, &coin{
+			input:      input,
+			amount:     *amount,
+			outputLock: utxo.OutputLock,
+		})
 		programHash, err := common.Uint168FromAddress(fromAddr)
 		if err != nil {
 			return nil, nil, err
@@ -196,9 +231,11 @@ func createInputs(fromAddr string, totalAmount common.Fixed64) ([]*types.Input,
 		return nil, nil, errors.New("[Wallet], Available token is not enough")
 	}
 
-	return txInputs, changeOutputs, nil
+	return coins, changeOutputs, nil
 }
 
+// createNormalOutputs creates the outputs to the recipients, the output is
+// locked until the lock height of itself, or lockedUntil if it's not set.
 func createNormalOutputs(outputs []*OutputInfo, fee common.Fixed64, lockedUntil uint32) ([]*types.Output, common.Fixed64, error) {
 	var totalAmount = common.Fixed64(0) // The total amount will be spend
 	var txOutputs []*types.Output       // The outputs in transaction
@@ -210,11 +247,15 @@ func createNormalOutputs(outputs []*OutputInfo, fee common.Fixed64, lockedUntil
 			return nil, 0, errors.New(fmt.Sprint("invalid receiver address: ", output.Recipient, ", error: ", err))
 		}
 
+		outputLock := lockedUntil
+		if output.OutputLock > 0 {
+			outputLock = output.OutputLock
+		}
 		txOutput := &types.Output{
 			AssetID:     *account.SystemAssetID,
 			ProgramHash: *recipient,
 			Value:       *output.Amount,
-			OutputLock:  lockedUntil,
+			OutputLock:  outputLock,
 			Type:        types.OTNone,
 			Payload:     &outputpayload.DefaultOutput{},
 		}
@@ -268,7 +309,7 @@ func createVoteOutputs(output *OutputInfo, candidateList []string) ([]*types.Out
 }
 
 func createTransaction(walletPath string, from string, fee common.Fixed64, outputLock uint32,
-	txLock uint32, outputs ...*OutputInfo) (*types.Transaction, error) {
+	txLock uint32, cs *CoinSelection, outputs ...*OutputInfo) (*types.Transaction, error) {
 	// check output
 	if len(outputs) == 0 {
 		return nil, errors.New("invalid transaction target")
@@ -287,12 +328,19 @@ func createTransaction(walletPath string, from string, fee common.Fixed64, outpu
 	}
 
 	// create inputs
-	txInputs, changeOutputs, err := createInputs(sender.Address, totalAmount)
+	coins, changeOutputs, err := selectInputs(sender.Address, totalAmount, cs)
 	if err != nil {
 		return nil, err
 	}
 	txOutputs = append(txOutputs, changeOutputs...)
 
+	return newTransferTransaction(sender, coins, txOutputs, txLock)
+}
+
+// newTransferTransaction creates a transfer transaction spending the coins,
+// the transaction is checked by the rules of blockchain before it's signed.
+func newTransferTransaction(sender *account.AccountData, coins []*coin,
+	txOutputs []*types.Output, txLock uint32) (*types.Transaction, error) {
 	redeemScript, err := common.HexStringToBytes(sender.RedeemScript)
 	if err != nil {
 		return nil, err
@@ -307,16 +355,118 @@ func createTransaction(walletPath string, from string, fee common.Fixed64, outpu
 		Code:      redeemScript,
 		Parameter: nil,
 	}
-	return &types.Transaction{
+	txn := &types.Transaction{
 		Version:    types.TxVersion09,
 		TxType:     types.TransferAsset,
 		Payload:    &payload.TransferAsset{},
 		Attributes: txAttributes,
-		Inputs:     txInputs,
+		Inputs:     coinInputs(coins),
 		Outputs:    txOutputs,
 		Programs:   []*pg.Program{txProgram},
 		LockTime:   txLock,
-	}, nil
+	}
+	// the outputs are checked at the next block height of node, so the rules
+	// of the network the node runs on are applied
+	height, err := getCurrentHeight()
+	if err != nil {
+		return nil, err
+	}
+	if err := blockchain.CheckTransactionOutputs(txn, height+1,
+		true); err != nil {
+		return nil, err
+	}
+	var outputLock uint32
+	references := make(map[*types.Input]types.Output, len(coins))
+	for _, c := range coins {
+		references[c.input] = types.Output{OutputLock: c.outputLock}
+		if c.outputLock > outputLock {
+			outputLock = c.outputLock
+		}
+	}
+	if err := blockchain.CheckTransactionUTXOLock(txn, references); err != nil {
+		return nil, fmt.Errorf("%s, the transaction lock height should not "+
+			"be less than %d", err, outputLock)
+	}
+	if size := txn.GetSize(); size > maxTransactionSize {
+		return nil, fmt.Errorf("transaction size %d exceeds the max size %d, "+
+			"use --maxinputs to reduce the inputs", size, maxTransactionSize)
+	}
+	return txn, nil
+}
+
+func CreateConsolidateTransaction(c *cli.Context) error {
+	walletPath := c.String("wallet")
+
+	feeStr := c.String("fee")
+	if feeStr == "" {
+		return errors.New("use --fee to specify transfer fee")
+	}
+	fee, err := common.StringToFixed64(feeStr)
+	if err != nil {
+		return errors.New("invalid transaction fee")
+	}
+	maxInputs := c.Int("maxinputs")
+	if maxInputs <= 0 {
+		maxInputs = DefaultMaxInputs
+	}
+
+	sender, err := getSender(walletPath, c.String("from"))
+	if err != nil {
+		return err
+	}
+	to := c.String("to")
+	if to == "" {
+		to = sender.Address
+	}
+	programHash, err := common.Uint168FromAddress(to)
+	if err != nil {
+		return errors.New("invalid receiver address")
+	}
+
+	coins, err := listCoins(sender.Address)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(coins, func(i, j int) bool {
+		return coins[i].amount < coins[j].amount
+	})
+	if len(coins) > maxInputs {
+		coins = coins[:maxInputs]
+	}
+	if len(coins) < 2 {
+		return errors.New("no enough UTXOs to consolidate")
+	}
+
+	// the locked UTXOs are spent after the max output lock of them
+	var amount common.Fixed64
+	var txLock uint32
+	for _, coin := range coins {
+		amount += coin.amount
+		if coin.outputLock > txLock {
+			txLock = coin.outputLock
+		}
+	}
+	if amount <= *fee {
+		return errors.New("the amount of UTXOs is not enough to pay the fee")
+	}
+	txOutputs := []*types.Output{{
+		AssetID:     *account.SystemAssetID,
+		Value:       amount - *fee,
+		OutputLock:  0,
+		ProgramHash: *programHash,
+		Type:        types.OTNone,
+		Payload:     &outputpayload.DefaultOutput{},
+	}}
+
+	txn, err := newTransferTransaction(sender, coins, txOutputs, txLock)
+	if err != nil {
+		return errors.New("create transaction failed: " + err.Error())
+	}
+	fmt.Println(len(coins), "UTXOs consolidated, amount:", amount.String())
+
+	OutputTx(0, 1, txn)
+
+	return nil
 }
 
 func CreateActivateProducerTransaction(c *cli.Context) error {
@@ -330,33 +480,16 @@ func CreateActivateProducerTransaction(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return err
+	}
+	defer closeSigner()
 
-	var acc *account.Account
-	var nodePublicKey []byte
-
-	nodePublicKeyStr := c.String("nodepublickey")
-	if nodePublicKeyStr != "" {
-		nodePublicKey, err = common.HexStringToBytes(nodePublicKeyStr)
-		if err != nil {
-			return err
-		}
-		codeHash, err := contract.PublicKeyToStandardCodeHash(nodePublicKey)
-		if err != nil {
-			return err
-		}
-		acc = client.GetAccountByCodeHash(*codeHash)
-		if acc == nil {
-			return errors.New("no available account in wallet")
-		}
-	} else {
-		acc = client.GetMainAccount()
-		if contract.GetPrefixType(acc.ProgramHash) != contract.PrefixStandard {
-			return errors.New("main account is not a standard account")
-		}
-		nodePublicKey, err = acc.PublicKey.EncodePoint(true)
-		if err != nil {
-			return err
-		}
+	acc, nodePublicKey, err := getStandardAccount(client,
+		c.String("nodepublickey"))
+	if err != nil {
+		return err
 	}
 
 	buf := new(bytes.Buffer)
@@ -426,14 +559,12 @@ func CreateCRCProposalWithdrawTransaction(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	var acc *account.Account
-	var SponsorPublicKey []byte
-
-	acc = client.GetMainAccount()
-	if contract.GetPrefixType(acc.ProgramHash) != contract.PrefixStandard {
-		return errors.New("main account is not a standard account")
+	closeSigner, err := setSigner(c, client)
+	if err != nil {
+		return err
 	}
-	SponsorPublicKey, err = acc.PublicKey.EncodePoint(true)
+	defer closeSigner()
+	acc, SponsorPublicKey, err := getStandardAccount(client, "")
 	if err != nil {
 		return err
 	}
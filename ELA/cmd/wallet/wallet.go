@@ -12,7 +12,13 @@ import (
 func NewCommand() *cli.Command {
 	var subCommands []cli.Command
 	subCommands = append(subCommands, txCommand...)
+	subCommands = append(subCommands, pstxCommand...)
 	subCommands = append(subCommands, accountCommand...)
+	subCommands = append(subCommands, historyCommand...)
+	subCommands = append(subCommands, signerCommand...)
+	subCommands = append(subCommands, producerCommand...)
+	subCommands = append(subCommands, crCommand...)
+	subCommands = append(subCommands, proposalCommand...)
 
 	return &cli.Command{
 		Name:        "wallet",
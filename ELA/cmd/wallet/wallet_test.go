@@ -0,0 +1,109 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package wallet
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/account"
+	cmdcom "github.com/elastos/Elastos.ELA/cmd/common"
+
+	"github.com/urfave/cli"
+)
+
+const testPassword = "password"
+
+// newTestContext returns a command context with the flags set to the values
+// and the arguments.
+func newTestContext(t *testing.T, flags []cli.Flag,
+	values map[string]string, args ...string) *cli.Context {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	for _, f := range flags {
+		f.Apply(set)
+	}
+	if err := set.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range values {
+		if err := set.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cli.NewContext(nil, set, nil)
+}
+
+// newTestWallet creates a wallet with a main account in a temporary
+// directory, the directory is removed by the returned function.
+func newTestWallet(t *testing.T) (string, *account.Client, func()) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "keystore.dat")
+	client, err := account.Create(path, []byte(testPassword))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return path, client, func() { os.RemoveAll(dir) }
+}
+
+// startTestRPC starts a JSON-RPC server answering the methods by handlers,
+// and points the RPC calls of wallet to it until the returned function is
+// called.
+func startTestRPC(t *testing.T,
+	handlers map[string]func(params map[string]interface{}) interface{}) func() {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				Method string                 `json:"method"`
+				Params map[string]interface{} `json:"params"`
+			}
+			resp := map[string]interface{}{"jsonrpc": "2.0"}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				resp["error"] = map[string]interface{}{
+					"code": -32700, "message": err.Error()}
+			} else if handler, ok := handlers[req.Method]; ok {
+				resp["result"] = handler(req.Params)
+			} else {
+				resp["error"] = map[string]interface{}{
+					"code": -32601, "message": "method not found"}
+			}
+			json.NewEncoder(w).Encode(resp)
+		}))
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	setRPCPort := func(port string) {
+		cmdcom.SetRpcConfig(newTestContext(t,
+			[]cli.Flag{cmdcom.RPCPortFlag, cmdcom.RPCUserFlag,
+				cmdcom.RPCPasswordFlag},
+			map[string]string{"rpcport": port}))
+	}
+	setRPCPort(u.Port())
+	return func() {
+		server.Close()
+		setRPCPort("20336")
+	}
+}
+
+// testCurrentHeight returns a handler of getcurrentheight answering the
+// height.
+func testCurrentHeight(height uint32) func(map[string]interface{}) interface{} {
+	return func(map[string]interface{}) interface{} {
+		return height
+	}
+}
@@ -26,9 +26,27 @@ type PowConfiguration struct {
 
 // RpcConfiguration defines the JSON-RPC authenticate parameters.
 type RpcConfiguration struct {
-	User        string   `json:"User"`
-	Pass        string   `json:"Pass"`
-	WhiteIPList []string `json:"WhiteIPList"`
+	User        string          `json:"User"`
+	Pass        string          `json:"Pass"`
+	WhiteIPList []string        `json:"WhiteIPList"`
+	Credentials []RpcCredential `json:"Credentials"`
+	// MaxBatchSize is the maximum count of requests in a JSON-RPC batch,
+	// the default value is used if it's not set.
+	MaxBatchSize int `json:"MaxBatchSize"`
+}
+
+// RpcCredential defines a client of the RPC services with its own
+// permissions, a credential without User and APIKey is used for anonymous
+// requests.
+type RpcCredential struct {
+	Name         string   `json:"Name"`
+	User         string   `json:"User"`
+	Pass         string   `json:"Pass"`
+	APIKey       string   `json:"APIKey"`
+	Methods      []string `json:"Methods"`
+	RateLimit    float64  `json:"RateLimit"`
+	RateBurst    int      `json:"RateBurst"`
+	AllowedCIDRs []string `json:"AllowedCIDRs"`
 }
 
 // Configuration defines the configurable parameters to run a ELA node.
@@ -46,6 +64,13 @@ type Configuration struct {
 	HttpWsStart                 bool              `json:"HttpWsStart"`
 	HttpJsonPort                int               `json:"HttpJsonPort"`
 	EnableRPC                   bool              `json:"EnableRPC"`
+	GRPCPort                    int               `json:"GRPCPort"`
+	GRPCHost                    string            `json:"GRPCHost"`
+	GRPCCertPath                string            `json:"GRPCCertPath"`
+	GRPCKeyPath                 string            `json:"GRPCKeyPath"`
+	EnableGRPC                  bool              `json:"EnableGRPC"`
+	MetricsPort                 int               `json:"MetricsPort"`
+	EnableMetrics               bool              `json:"EnableMetrics"`
 	NodePort                    uint16            `json:"NodePort"`
 	PrintLevel                  uint32            `json:"PrintLevel"`
 	MaxLogsSize                 int64             `json:"MaxLogsSize"`
@@ -76,7 +101,10 @@ type Configuration struct {
 	MaxTxPerBlock               uint32            `json:"MaxTxPerBlock"`
 	EnableHistory               bool              `json:"EnableHistory"`
 	HistoryStartHeight          uint32            `json:"HistoryStartHeight"`
+	HistoryCapacity             uint32            `json:"HistoryCapacity"`
 	EnableUtxoDB                bool              `json:"EnableUtxoDB"`
+	EnableAddressIndex          bool              `json:"EnableAddressIndex"`
+	EnableSpentIndex            bool              `json:"EnableSpentIndex"`
 	WalletPath                  string            `json:"WalletPath"`
 	RPCServiceLevel             string            `json:"RPCServiceLevel"`
 	NodeProfileStrategy         string            `json:"NodeProfileStrategy"`
@@ -556,6 +556,12 @@ type Params struct {
 	// EnableUtxoDB indicate whether to enable utxo database.
 	EnableUtxoDB bool
 
+	// EnableAddressIndex indicate whether to enable address transaction index.
+	EnableAddressIndex bool
+
+	// EnableSpentIndex indicate whether to enable spent outpoint index.
+	EnableSpentIndex bool
+
 	// WalletPath defines the wallet path used by DPoS arbiters and CR members.
 	WalletPath string
 
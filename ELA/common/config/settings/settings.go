@@ -527,6 +527,7 @@ func NewSettings() *Settings {
 		params.CkpManager = checkpoint.NewManager(&checkpoint.Config{
 			EnableHistory:      conf.EnableHistory,
 			HistoryStartHeight: conf.HistoryStartHeight,
+			HistoryCapacity:    conf.HistoryCapacity,
 			NeedSave:           false,
 		})
 		return nil
@@ -546,12 +547,31 @@ func NewSettings() *Settings {
 		ConfigPath:   "HistoryStartHeight",
 		ParamName:    ""})
 
+	result.Add(&settingItem{
+		Flag:         nil,
+		DefaultValue: uint32(0),
+		ConfigSetter: ckpManagerSetter,
+		ConfigPath:   "HistoryCapacity",
+		ParamName:    ""})
+
 	result.Add(&settingItem{
 		Flag:         nil,
 		DefaultValue: false,
 		ConfigPath:   "EnableUtxoDB",
 		ParamName:    "EnableUtxoDB"})
 
+	result.Add(&settingItem{
+		Flag:         nil,
+		DefaultValue: false,
+		ConfigPath:   "EnableAddressIndex",
+		ParamName:    "EnableAddressIndex"})
+
+	result.Add(&settingItem{
+		Flag:         nil,
+		DefaultValue: false,
+		ConfigPath:   "EnableSpentIndex",
+		ParamName:    "EnableSpentIndex"})
+
 	result.Add(&settingItem{
 		Flag:         cmdcom.AutoMiningFlag,
 		DefaultValue: false,
@@ -929,6 +949,12 @@ func (s *Settings) mainNetDefault(cfg *config.Configuration) error {
 		20335); err != nil {
 		return err
 	}
+	if cfg.GRPCPort == 0 {
+		cfg.GRPCPort = 20337
+	}
+	if cfg.MetricsPort == 0 {
+		cfg.MetricsPort = 20338
+	}
 	return s.trySetPortValue(cmdcom.RPCPortFlag.Name,
 		&cfg.HttpJsonPort, 20336)
 }
@@ -947,6 +973,12 @@ func (s *Settings) testNetDefault(cfg *config.Configuration) error {
 		21335); err != nil {
 		return err
 	}
+	if cfg.GRPCPort == 0 {
+		cfg.GRPCPort = 21337
+	}
+	if cfg.MetricsPort == 0 {
+		cfg.MetricsPort = 21338
+	}
 	return s.trySetPortValue(cmdcom.RPCPortFlag.Name,
 		&cfg.HttpJsonPort, 21336)
 }
@@ -965,6 +997,12 @@ func (s *Settings) regNetDefault(cfg *config.Configuration) error {
 		22335); err != nil {
 		return err
 	}
+	if cfg.GRPCPort == 0 {
+		cfg.GRPCPort = 22337
+	}
+	if cfg.MetricsPort == 0 {
+		cfg.MetricsPort = 22338
+	}
 	return s.trySetPortValue(cmdcom.RPCPortFlag.Name,
 		&cfg.HttpJsonPort, 22336)
 }
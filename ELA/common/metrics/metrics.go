@@ -0,0 +1,88 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+// Package metrics defines the Prometheus metrics of node internals, which are
+// exported by the metrics server when it is enabled. Metrics are collected
+// whether the server is enabled or not, updating them is cheap.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace is the prefix of all metric names of the node.
+const Namespace = "ela"
+
+var (
+	// BlockProcessDuration is the time to process a block received by the
+	// block chain, including the validation and connecting of it.
+	BlockProcessDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "chain",
+		Name:      "block_process_seconds",
+		Help:      "Time to process a block.",
+		Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	})
+
+	// CheckpointSaveDuration is the time to save a checkpoint to disk, by
+	// the key of the checkpoint.
+	CheckpointSaveDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "checkpoint",
+		Name:      "save_seconds",
+		Help:      "Time to save a checkpoint.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"checkpoint"})
+
+	// DPoSViewChanges is the count of view changes of DPoS consensus.
+	DPoSViewChanges = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "dpos",
+		Name:      "view_changes_total",
+		Help:      "Count of DPoS consensus view changes.",
+	})
+
+	// DPoSConsensusDuration is the time from a consensus started to it is
+	// finished.
+	DPoSConsensusDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "dpos",
+		Name:      "consensus_seconds",
+		Help:      "Time of DPoS consensus of a block.",
+		Buckets:   []float64{1, 2.5, 5, 10, 15, 30, 60, 120, 300},
+	})
+
+	// DPoSOnDuty is 1 if the node is the on duty arbiter of current view, or
+	// 0 otherwise.
+	DPoSOnDuty = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "dpos",
+		Name:      "on_duty",
+		Help:      "Whether the node is the on duty arbiter, 1 for true.",
+	})
+)
+
+// Registry is the registry of all metrics of the node.
+var Registry = prometheus.NewRegistry()
+
+// ObserveDuration observes the time elapsed since start by the observer,
+// usually deferred at the beginning of a function.
+func ObserveDuration(o prometheus.Observer, start time.Time) {
+	o.Observe(time.Since(start).Seconds())
+}
+
+func init() {
+	Registry.MustRegister(
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		prometheus.NewGoCollector(),
+		BlockProcessDuration,
+		CheckpointSaveDuration,
+		DPoSViewChanges,
+		DPoSConsensusDuration,
+		DPoSOnDuty,
+	)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	// metrics without labels are exported before they are updated, vectors
+	// only after a label is used
+	CheckpointSaveDuration.WithLabelValues("test")
+
+	families, err := Registry.Gather()
+	assert.NoError(t, err)
+	names := make(map[string]struct{})
+	for _, f := range families {
+		names[f.GetName()] = struct{}{}
+	}
+	for _, name := range []string{
+		"ela_chain_block_process_seconds",
+		"ela_checkpoint_save_seconds",
+		"ela_dpos_view_changes_total",
+		"ela_dpos_consensus_seconds",
+		"ela_dpos_on_duty",
+		"go_goroutines",
+	} {
+		assert.Contains(t, names, name)
+	}
+
+	// all metrics are registered already
+	assert.Error(t, Registry.Register(DPoSOnDuty))
+}
+
+func TestObserveDuration(t *testing.T) {
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "test_seconds",
+		Buckets: []float64{1, 10},
+	})
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(histogram)
+	ObserveDuration(histogram, time.Now().Add(-2*time.Second))
+
+	families, err := registry.Gather()
+	if !assert.NoError(t, err) || !assert.Len(t, families, 1) {
+		return
+	}
+	h := families[0].GetMetric()[0].GetHistogram()
+	assert.Equal(t, uint64(1), h.GetSampleCount())
+	assert.True(t, h.GetSampleSum() >= 2)
+	buckets := h.GetBucket()
+	assert.Equal(t, uint64(0), buckets[0].GetCumulativeCount())
+	assert.Equal(t, uint64(1), buckets[1].GetCumulativeCount())
+}
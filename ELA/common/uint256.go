@@ -79,3 +79,13 @@ func Uint256FromHexString(hexHash string) (*Uint256, error) {
 
 	return &hash, nil
 }
+
+// Uint256FromReversedHexString parses a hash in the byte reversed hex string
+// format, which is used to show hashes in RPC results and by wallets.
+func Uint256FromReversedHexString(reversed string) (*Uint256, error) {
+	hash, err := HexStringToBytes(reversed)
+	if err != nil {
+		return nil, err
+	}
+	return Uint256FromBytes(BytesReverse(hash))
+}
@@ -51,3 +51,16 @@ func TestUint256_IsEqual(t *testing.T) {
 	assert.Equal(t, true, u1.IsEqual(u2))
 	assert.Equal(t, true, (&u1).IsEqual(u2))
 }
+
+func TestUint256FromReversedHexString(t *testing.T) {
+	hash := Uint256{1, 2, 3}
+	reversed := BytesToHexString(BytesReverse(hash.Bytes()))
+	parsed, err := Uint256FromReversedHexString(reversed)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, *parsed)
+
+	_, err = Uint256FromReversedHexString("zz")
+	assert.Error(t, err)
+	_, err = Uint256FromReversedHexString("0102")
+	assert.Error(t, err)
+}
@@ -13,7 +13,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/elastos/Elastos.ELA/common/metrics"
 	"github.com/elastos/Elastos.ELA/utils"
 )
 
@@ -30,6 +32,10 @@ type heightFileMsg struct {
 type fileChannels struct {
 	cfg *Config
 
+	// history indicates whether the checkpoint implements IHistoryCheckPoint
+	// interface to keep snapshots as history.
+	history bool
+
 	save    chan fileMsg
 	clean   chan fileMsg
 	reset   chan fileMsg
@@ -88,6 +94,8 @@ func (c *fileChannels) messageLoop() {
 
 func (c *fileChannels) saveCheckpoint(msg *fileMsg) (err error) {
 	defer c.replyMsg(msg)
+	defer metrics.ObserveDuration(metrics.CheckpointSaveDuration.
+		WithLabelValues(msg.checkpoint.Key()), time.Now())
 
 	dir := getCheckpointDirectory(c.cfg.DataPath, msg.checkpoint)
 	if !utils.FileExisted(dir) {
@@ -114,9 +122,34 @@ func (c *fileChannels) saveCheckpoint(msg *fileMsg) (err error) {
 		return
 	}
 
-	if !c.cfg.EnableHistory {
+	if !c.recordHistory(msg.checkpoint.GetHeight()) {
 		return c.cleanCheckpoints(msg, false, false)
 	}
+	return c.pruneHistory(msg)
+}
+
+// pruneHistory removes the oldest snapshots of the checkpoint if count of
+// them exceeds HistoryCapacity in Config struct.
+func (c *fileChannels) pruneHistory(msg *fileMsg) error {
+	if c.cfg.HistoryCapacity == 0 {
+		return nil
+	}
+	capacity := int(c.cfg.HistoryCapacity)
+	// the previous snapshot is required to replace the default checkpoint
+	if capacity < 2 {
+		capacity = 2
+	}
+
+	heights, err := getSavedHeights(c.cfg.DataPath, msg.checkpoint)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(heights)-capacity; i++ {
+		path := getFilePathByHeight(c.cfg.DataPath, msg.checkpoint, heights[i])
+		if e := os.Remove(path); e != nil {
+			msg.checkpoint.LogError(e)
+		}
+	}
 	return nil
 }
 
@@ -169,7 +202,7 @@ func (c *fileChannels) replaceCheckpoints(msg *heightFileMsg) (err error) {
 		}
 	}
 
-	if !c.cfg.EnableHistory {
+	if !c.recordHistory(msg.height) {
 		err = os.Rename(sourceFullName, defaultFullName)
 	} else {
 		var srcFile, desFile *os.File
@@ -190,6 +223,12 @@ func (c *fileChannels) replaceCheckpoints(msg *heightFileMsg) (err error) {
 	return
 }
 
+// recordHistory returns if the snapshot of checkpoint at the height should
+// be kept as history.
+func (c *fileChannels) recordHistory(height uint32) bool {
+	return c.history && c.cfg.EnableHistory && height >= c.cfg.HistoryStartHeight
+}
+
 func (c *fileChannels) replyMsg(msg *fileMsg) {
 	if msg.reply != nil {
 		msg.reply <- true
@@ -18,6 +18,7 @@ func TestFileChannels_LifeCycle(t *testing.T) {
 	})
 	data := uint64(1)
 	currentHeight := uint32(10)
+	channels.history = true
 	pt := &checkpoint{
 		data:   &data,
 		height: currentHeight,
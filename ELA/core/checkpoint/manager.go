@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/elastos/Elastos.ELA/common"
@@ -97,6 +98,16 @@ type ICheckPoint interface {
 	StartHeight() uint32
 }
 
+// IHistoryCheckPoint is implemented by checkpoints whose snapshots should be
+// kept as history if EnableHistory in Config struct is true, snapshots of
+// other checkpoints are cleaned after saved.
+type IHistoryCheckPoint interface {
+	ICheckPoint
+
+	// RecordHistory is a marker method to opt in recording history.
+	RecordHistory()
+}
+
 // Config holds checkpoint related configurations.
 type Config struct {
 	// EnableHistory is a switch about recording history of snapshots of
@@ -107,6 +118,11 @@ type Config struct {
 	// snapshots of checkpoints.
 	HistoryStartHeight uint32
 
+	// HistoryCapacity defines the max count of history snapshots kept for
+	// each checkpoint, the oldest ones are pruned when exceeded. Zero means
+	// no limit, otherwise at least two snapshots are kept.
+	HistoryCapacity uint32
+
 	// DataPath defines root directory path of all checkpoint related files.
 	DataPath string
 
@@ -153,7 +169,9 @@ func (m *Manager) Register(checkpoint ICheckPoint) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 	m.checkpoints[checkpoint.Key()] = checkpoint
-	m.channels[checkpoint.Key()] = NewFileChannels(m.cfg)
+	channels := NewFileChannels(m.cfg)
+	_, channels.history = checkpoint.(IHistoryCheckPoint)
+	m.channels[checkpoint.Key()] = channels
 }
 
 // Unregister will unregister a checkpoint with key in checkpoint as the
@@ -180,11 +198,11 @@ func (m *Manager) GetCheckpoint(key string, height uint32) (
 	defer m.mtx.RUnlock()
 
 	checkpoint, found = m.checkpoints[key]
-	if height >= checkpoint.GetHeight() {
+	if !found {
 		return
 	}
 
-	if !found {
+	if height >= checkpoint.GetHeight() {
 		return
 	}
 
@@ -195,6 +213,52 @@ func (m *Manager) GetCheckpoint(key string, height uint32) (
 	}
 }
 
+// GetHistoryCheckpoint get the snapshot of checkpoint by key saved at exactly
+// the given height. Snapshots are saved every save period, so an error with
+// the nearest lower height of saved snapshots is returned if no snapshot is
+// saved at the height. Only snapshots of checkpoints implement the
+// IHistoryCheckPoint interface are kept if EnableHistory in Config struct is
+// true, an error will be returned in other cases.
+func (m *Manager) GetHistoryCheckpoint(key string, height uint32) (
+	ICheckPoint, error) {
+	if !m.cfg.EnableHistory {
+		return nil, errors.New("history of checkpoints is not enabled")
+	}
+
+	m.mtx.RLock()
+	current, ok := m.checkpoints[key]
+	channels := m.channels[key]
+	m.mtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("checkpoint %s not registered", key)
+	}
+	if !channels.history {
+		return nil, fmt.Errorf("history of checkpoint %s is not recorded",
+			key)
+	}
+
+	if height < m.cfg.HistoryStartHeight {
+		return nil, fmt.Errorf("height %d is lower than history start "+
+			"height %d", height, m.cfg.HistoryStartHeight)
+	}
+	bestHeight, ok := m.findHistoryHeight(current, height)
+	if !ok {
+		return nil, fmt.Errorf("no checkpoint %s saved at or below height %d",
+			key, height)
+	}
+	if bestHeight != height {
+		return nil, fmt.Errorf("no checkpoint %s saved at height %d, the "+
+			"nearest lower height is %d", key, height, bestHeight)
+	}
+	checkpoint, ok := m.constructCheckpoint(current,
+		getFilePathByHeight(m.cfg.DataPath, current, height))
+	if !ok {
+		return nil, fmt.Errorf("load checkpoint %s at height %d failed",
+			key, height)
+	}
+	return checkpoint, nil
+}
+
 // Restore will load all data of each checkpoints file and store in
 // corresponding meta-data.
 func (m *Manager) Restore() (err error) {
@@ -285,12 +349,15 @@ func (m *Manager) onBlockSaved(block *types.DposBlock,
 		}
 		v.OnBlockSaved(block)
 
-		if !m.cfg.NeedSave {
+		// history of checkpoints should be recorded even if the block chain
+		// is not synced yet.
+		if !m.cfg.NeedSave &&
+			!m.channels[v.Key()].recordHistory(block.Height) {
 			continue
 		}
 
 		originalHeight := v.GetHeight()
-		if originalHeight > 0 &&
+		if m.cfg.NeedSave && originalHeight > 0 &&
 			block.Height == originalHeight+v.EffectivePeriod() {
 			reply := make(chan bool, 1)
 			m.channels[v.Key()].Replace(v, reply, originalHeight)
@@ -317,20 +384,62 @@ func (m *Manager) onBlockSaved(block *types.DposBlock,
 
 func (m *Manager) findHistoryCheckpoint(current ICheckPoint,
 	findHeight uint32) (checkpoint ICheckPoint, found bool) {
-	bestHeight := current.GetHeight()
-	for bestHeight > findHeight && bestHeight >= current.SavePeriod() {
-		bestHeight -= current.SavePeriod()
-	}
-	// bestHeight still larger than findHeight means findHeight less than
-	// current.SavePeriod(), then set to zero directly
-	if bestHeight > findHeight {
-		bestHeight = 0
+	bestHeight, found := m.findHistoryHeight(current, findHeight)
+	if !found {
+		return nil, false
 	}
 
 	path := getFilePathByHeight(m.cfg.DataPath, current, bestHeight)
 	return m.constructCheckpoint(current, path)
 }
 
+// findHistoryHeight returns the highest height of saved checkpoints not larger
+// than findHeight. Saved checkpoints are looked up from files, since heights
+// of them may not be consecutive by save period if the node has restarted.
+func (m *Manager) findHistoryHeight(current ICheckPoint,
+	findHeight uint32) (bestHeight uint32, found bool) {
+	heights, err := getSavedHeights(m.cfg.DataPath, current)
+	if err != nil {
+		current.LogError(err)
+		return 0, false
+	}
+
+	for _, height := range heights {
+		if height <= findHeight && (!found || height > bestHeight) {
+			bestHeight = height
+			found = true
+		}
+	}
+	return
+}
+
+// getSavedHeights returns heights of saved snapshots of the checkpoint in
+// ascending order.
+func getSavedHeights(root string, checkpoint ICheckPoint) ([]uint32, error) {
+	files, err := ioutil.ReadDir(getCheckpointDirectory(root, checkpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	var heights []uint32
+	for _, f := range files {
+		name := f.Name()
+		if !strings.HasSuffix(name, checkpoint.DataExtension()) {
+			continue
+		}
+		height, err := strconv.ParseUint(
+			strings.TrimSuffix(name, checkpoint.DataExtension()), 10, 32)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, uint32(height))
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+	return heights, nil
+}
+
 func (m *Manager) loadDefaultCheckpoint(current ICheckPoint) (err error) {
 	path := getDefaultPath(m.cfg.DataPath, current)
 	data, err := m.readFileBuffer(path)
@@ -363,7 +472,8 @@ func (m *Manager) constructCheckpoint(proto ICheckPoint, path string) (
 		proto.LogError(err)
 		return nil, false
 	}
-	return proto.Generator()(data), true
+	checkpoint := proto.Generator()(data)
+	return checkpoint, checkpoint != nil
 }
 
 func getFilePath(root string, checkpoint ICheckPoint) string {
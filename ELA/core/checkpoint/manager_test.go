@@ -8,6 +8,7 @@ package checkpoint
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"math/rand"
@@ -128,6 +129,13 @@ func (c *checkpoint) OnRollbackTo(height uint32) error {
 	return nil
 }
 
+// historyCheckpoint is a checkpoint opts in recording history.
+type historyCheckpoint struct {
+	checkpoint
+}
+
+func (c *historyCheckpoint) RecordHistory() {}
+
 func TestManager_SaveAndRestore(t *testing.T) {
 	data := uint64(1)
 	currentHeight := uint32(10)
@@ -254,6 +262,133 @@ func TestManager_GetCheckpoint_EnableHistory(t *testing.T) {
 	cleanCheckpoints()
 }
 
+func TestManager_GetHistoryCheckpoint(t *testing.T) {
+	data := uint64(1)
+	startHeight := uint32(10)
+	pt := &historyCheckpoint{checkpoint{
+		data:   &data,
+		height: 0,
+	}}
+
+	manager := NewManager(&Config{
+		EnableHistory:      true,
+		HistoryStartHeight: startHeight,
+	})
+	manager.Register(pt)
+
+	// history should be recorded even if NeedSave is false, and heights of
+	// saved checkpoints are not consecutive by save period after restarting.
+	heights := []uint32{startHeight, startHeight + pt.SavePeriod(),
+		startHeight + pt.SavePeriod()*2 + 1}
+	for _, height := range heights {
+		manager.onBlockSaved(&types.DposBlock{
+			Block: &types.Block{
+				Header: types.Header{Height: height},
+			},
+		}, nil, false)
+	}
+
+	_, err := manager.GetHistoryCheckpoint(pt.Key(), startHeight-1)
+	assert.EqualError(t, err, "height 9 is lower than history start height 10")
+
+	for i, height := range heights {
+		result, err := manager.GetHistoryCheckpoint(pt.Key(), height)
+		assert.NoError(t, err)
+		assert.Equal(t, height, result.GetHeight())
+
+		// only the snapshot saved at exactly the height is returned
+		if i > 0 {
+			_, err = manager.GetHistoryCheckpoint(pt.Key(), height-1)
+			assert.EqualError(t, err, fmt.Sprintf("no checkpoint %s saved "+
+				"at height %d, the nearest lower height is %d", pt.Key(),
+				height-1, heights[i-1]))
+		}
+	}
+
+	manager2 := NewManager(&Config{
+		EnableHistory: false,
+	})
+	manager2.Register(pt)
+	_, err = manager2.GetHistoryCheckpoint(pt.Key(), startHeight)
+	assert.EqualError(t, err, "history of checkpoints is not enabled")
+
+	cleanCheckpoints()
+}
+
+func TestManager_HistoryOptIn(t *testing.T) {
+	data := uint64(1)
+	startHeight := uint32(10)
+	pt := &checkpoint{
+		data:   &data,
+		height: 0,
+	}
+
+	manager := NewManager(&Config{
+		EnableHistory:      true,
+		HistoryStartHeight: startHeight,
+		NeedSave:           true,
+	})
+	manager.Register(pt)
+
+	// snapshots of checkpoints not opt in history are cleaned after saved
+	for i := uint32(0); i < 4; i++ {
+		manager.onBlockSaved(&types.DposBlock{
+			Block: &types.Block{
+				Header: types.Header{Height: startHeight + pt.SavePeriod()*i},
+			},
+		}, nil, false)
+	}
+	heights, err := getSavedHeights("", pt)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{startHeight + pt.SavePeriod()*2,
+		startHeight + pt.SavePeriod()*3}, heights)
+
+	_, err = manager.GetHistoryCheckpoint(pt.Key(), startHeight)
+	assert.EqualError(t, err, fmt.Sprintf("history of checkpoint %s is "+
+		"not recorded", pt.Key()))
+
+	cleanCheckpoints()
+}
+
+func TestManager_PruneHistory(t *testing.T) {
+	data := uint64(1)
+	startHeight := uint32(10)
+	pt := &historyCheckpoint{checkpoint{
+		data:   &data,
+		height: 0,
+	}}
+
+	manager := NewManager(&Config{
+		EnableHistory:      true,
+		HistoryStartHeight: startHeight,
+		HistoryCapacity:    3,
+	})
+	manager.Register(pt)
+
+	var heights []uint32
+	for i := uint32(0); i < 5; i++ {
+		height := startHeight + pt.SavePeriod()*i
+		heights = append(heights, height)
+		manager.onBlockSaved(&types.DposBlock{
+			Block: &types.Block{
+				Header: types.Header{Height: height},
+			},
+		}, nil, false)
+	}
+
+	// only the latest snapshots are kept
+	saved, err := getSavedHeights("", pt)
+	assert.NoError(t, err)
+	assert.Equal(t, heights[2:], saved)
+	_, err = manager.GetHistoryCheckpoint(pt.Key(), heights[1])
+	assert.Error(t, err)
+	result, err := manager.GetHistoryCheckpoint(pt.Key(), heights[2])
+	assert.NoError(t, err)
+	assert.Equal(t, heights[2], result.GetHeight())
+
+	cleanCheckpoints()
+}
+
 func TestManager_OnRollbackTo(t *testing.T) {
 	data := uint64(1)
 	currentHeight := uint32(10)
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"strings"
 
 	"github.com/elastos/Elastos.ELA/common"
 	"github.com/elastos/Elastos.ELA/crypto"
@@ -67,6 +68,17 @@ func (pt CRCProposalType) Name() string {
 	}
 }
 
+// ParseCRCProposalType returns the proposal type of the name, the name is
+// case insensitive.
+func ParseCRCProposalType(name string) (CRCProposalType, error) {
+	for _, t := range []CRCProposalType{Normal, ELIP} {
+		if strings.EqualFold(t.Name(), name) {
+			return t, nil
+		}
+	}
+	return 0, errors.New("invalid proposal type " + name)
+}
+
 const (
 	// CRCProposalVersion indicates the version of CRC proposal payload
 	CRCProposalVersion byte = 0x00
@@ -97,6 +109,17 @@ func (b InstallmentType) Name() string {
 	}
 }
 
+// ParseInstallmentType returns the installment type of the name, the name is
+// case insensitive.
+func ParseInstallmentType(name string) (InstallmentType, error) {
+	for _, t := range []InstallmentType{Imprest, NormalPayment, FinalPayment} {
+		if strings.EqualFold(t.Name(), name) {
+			return t, nil
+		}
+	}
+	return 0, errors.New("invalid budget type " + name)
+}
+
 type Budget struct {
 	Type   InstallmentType
 	Stage  byte
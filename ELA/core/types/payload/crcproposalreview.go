@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"strings"
 
 	"github.com/elastos/Elastos.ELA/common"
 	"github.com/elastos/Elastos.ELA/crypto"
@@ -37,6 +38,17 @@ func (v VoteResult) Name() string {
 	}
 }
 
+// ParseVoteResult returns the vote result of the name, the name is case
+// insensitive.
+func ParseVoteResult(name string) (VoteResult, error) {
+	for _, r := range []VoteResult{Approve, Reject, Abstain} {
+		if strings.EqualFold(r.Name(), name) {
+			return r, nil
+		}
+	}
+	return 0, errors.New("invalid vote result " + name)
+}
+
 type CRCProposalReview struct {
 	ProposalHash common.Uint256
 	VoteResult   VoteResult
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"strings"
 
 	"github.com/elastos/Elastos.ELA/common"
 	"github.com/elastos/Elastos.ELA/crypto"
@@ -60,6 +61,18 @@ func (pt CRCProposalTrackingType) Name() string {
 	}
 }
 
+// ParseCRCProposalTrackingType returns the proposal tracking type of the
+// name, the name is case insensitive.
+func ParseCRCProposalTrackingType(name string) (CRCProposalTrackingType, error) {
+	for _, t := range []CRCProposalTrackingType{Common, Progress, Rejected,
+		Terminated, ChangeOwner, Finalized} {
+		if strings.EqualFold(t.Name(), name) {
+			return t, nil
+		}
+	}
+	return 0, errors.New("invalid tracking type " + name)
+}
+
 const CRCProposalTrackingVersion byte = 0x00
 
 type CRCProposalTracking struct {
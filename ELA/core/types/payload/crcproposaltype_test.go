@@ -0,0 +1,59 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package payload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCRCProposalType(t *testing.T) {
+	for _, pt := range []CRCProposalType{Normal, ELIP} {
+		parsed, err := ParseCRCProposalType(pt.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, pt, parsed)
+	}
+	parsed, err := ParseCRCProposalType("elip")
+	assert.NoError(t, err)
+	assert.Equal(t, ELIP, parsed)
+	_, err = ParseCRCProposalType("Unknown")
+	assert.EqualError(t, err, "invalid proposal type Unknown")
+}
+
+func TestParseInstallmentType(t *testing.T) {
+	for _, it := range []InstallmentType{Imprest, NormalPayment, FinalPayment} {
+		parsed, err := ParseInstallmentType(it.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, it, parsed)
+	}
+	_, err := ParseInstallmentType("payment")
+	assert.EqualError(t, err, "invalid budget type payment")
+}
+
+func TestParseVoteResult(t *testing.T) {
+	for _, r := range []VoteResult{Approve, Reject, Abstain} {
+		parsed, err := ParseVoteResult(r.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, r, parsed)
+	}
+	parsed, err := ParseVoteResult("REJECT")
+	assert.NoError(t, err)
+	assert.Equal(t, Reject, parsed)
+	_, err = ParseVoteResult("")
+	assert.EqualError(t, err, "invalid vote result ")
+}
+
+func TestParseCRCProposalTrackingType(t *testing.T) {
+	for _, pt := range []CRCProposalTrackingType{Common, Progress, Rejected,
+		Terminated, ChangeOwner, Finalized} {
+		parsed, err := ParseCRCProposalTrackingType(pt.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, pt, parsed)
+	}
+	_, err := ParseCRCProposalTrackingType("unknown")
+	assert.EqualError(t, err, "invalid tracking type unknown")
+}
@@ -7,6 +7,7 @@ package state
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 
 	"github.com/elastos/Elastos.ELA/common"
@@ -25,14 +26,21 @@ const (
 	// checkpointHeight defines interval height between two neighbor check
 	// points.
 	checkpointHeight = uint32(720)
+
+	// checkpointVersion is the version of serialized checkpoint. Checkpoints
+	// saved before the version is introduced have no version and proposal key
+	// frame after the state key frame, they are decoded as version zero.
+	checkpointVersion = byte(1)
 )
 
 // Checkpoint hold all CR related states to recover from scratch.
 type Checkpoint struct {
 	KeyFrame
 	StateKeyFrame
+	ProposalKeyFrame
 
 	height    uint32
+	version   byte
 	committee *Committee
 }
 
@@ -50,6 +58,7 @@ func (c *Checkpoint) OnRollbackTo(height uint32) error {
 			state:                NewState(c.committee.params),
 			params:               c.committee.params,
 			KeyFrame:             *keyFrame,
+			manager:              NewProposalManager(c.committee.params),
 			firstHistory:         utils.NewHistory(maxHistoryCapacity),
 			lastHistory:          utils.NewHistory(maxHistoryCapacity),
 			appropriationHistory: utils.NewHistory(maxHistoryCapacity),
@@ -69,8 +78,19 @@ func (c *Checkpoint) Key() string {
 }
 
 func (c *Checkpoint) Snapshot() checkpoint.ICheckPoint {
+	// key frames of checkpoint are not updated by the committee after
+	// initialized, so take key frames from the committee directly.
+	c.committee.mtx.RLock()
+	point := &Checkpoint{
+		height:           c.height,
+		KeyFrame:         c.committee.KeyFrame,
+		StateKeyFrame:    c.committee.state.StateKeyFrame,
+		ProposalKeyFrame: c.committee.manager.ProposalKeyFrame,
+	}
 	buf := new(bytes.Buffer)
-	if err := c.Serialize(buf); err != nil {
+	err := point.Serialize(buf)
+	c.committee.mtx.RUnlock()
+	if err != nil {
 		c.LogError(err)
 		return nil
 	}
@@ -124,6 +144,10 @@ func (c *Checkpoint) Priority() checkpoint.Priority {
 	return checkpoint.MediumHigh
 }
 
+// RecordHistory implements the checkpoint.IHistoryCheckPoint interface to keep
+// snapshots of CR states as history.
+func (c *Checkpoint) RecordHistory() {}
+
 func (c *Checkpoint) OnInit() {
 	c.committee.Recover(c)
 }
@@ -141,7 +165,15 @@ func (c *Checkpoint) Serialize(w io.Writer) (err error) {
 		return
 	}
 
-	return c.StateKeyFrame.Serialize(w)
+	if err = c.StateKeyFrame.Serialize(w); err != nil {
+		return
+	}
+
+	if err = common.WriteUint8(w, checkpointVersion); err != nil {
+		return
+	}
+
+	return c.ProposalKeyFrame.Serialize(w)
 }
 
 func (c *Checkpoint) Deserialize(r io.Reader) (err error) {
@@ -153,12 +185,60 @@ func (c *Checkpoint) Deserialize(r io.Reader) (err error) {
 		return
 	}
 
-	return c.StateKeyFrame.Deserialize(r)
+	if err = c.StateKeyFrame.Deserialize(r); err != nil {
+		return
+	}
+
+	// checkpoints of version zero end with the state key frame
+	if c.version, err = common.ReadUint8(r); err == io.EOF {
+		c.version = 0
+		c.ProposalKeyFrame = *NewProposalKeyFrame()
+		return nil
+	} else if err != nil {
+		return
+	}
+	if c.version != checkpointVersion {
+		return fmt.Errorf("unknown checkpoint version %d", c.version)
+	}
+
+	return c.ProposalKeyFrame.Deserialize(r)
 }
 
 func (c *Checkpoint) initFromCommittee(committee *Committee) {
 	c.StateKeyFrame = committee.state.StateKeyFrame
 	c.KeyFrame = committee.KeyFrame
+	c.ProposalKeyFrame = committee.manager.ProposalKeyFrame
+}
+
+// GetHistoryCommittee returns a committee recovered from the checkpoint saved
+// at exactly the given height, an error is returned if no checkpoint is saved
+// at the height. Proposals are not saved in checkpoints of version zero, so
+// an error is also returned for them if withProposals is true. The committee
+// is only used to query the historical members, candidates and proposals, it
+// works only if history of checkpoints is enabled.
+func (c *Committee) GetHistoryCommittee(height uint32,
+	withProposals bool) (*Committee, error) {
+	point, err := c.params.CkpManager.GetHistoryCheckpoint(checkpointKey,
+		height)
+	if err != nil {
+		return nil, err
+	}
+	cp := point.(*Checkpoint)
+	if withProposals && cp.version == 0 {
+		return nil, fmt.Errorf("proposals are not saved in checkpoint at "+
+			"height %d", height)
+	}
+
+	committee := &Committee{
+		state:    NewState(c.params),
+		params:   c.params,
+		KeyFrame: cp.KeyFrame,
+		manager:  NewProposalManager(c.params),
+	}
+	committee.state.StateKeyFrame = cp.StateKeyFrame
+	committee.manager.ProposalKeyFrame = cp.ProposalKeyFrame
+	committee.state.SetManager(committee.manager)
+	return committee, nil
 }
 
 func NewCheckpoint(committee *Committee) *Checkpoint {
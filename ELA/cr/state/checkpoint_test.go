@@ -0,0 +1,108 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package state
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoint_Deserialize(t *testing.T) {
+	point := &Checkpoint{
+		height:           rand.Uint32(),
+		KeyFrame:         *randomKeyFrame(5, rand.Uint32()),
+		StateKeyFrame:    *randomStateKeyFrame(5, true),
+		ProposalKeyFrame: *randomProposalKeyframe(),
+	}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, point.Serialize(buf))
+	point2 := &Checkpoint{}
+	assert.NoError(t, point2.Deserialize(buf))
+	assert.Equal(t, point.height, point2.height)
+	assert.Equal(t, checkpointVersion, point2.version)
+	assert.True(t, keyframeEqual(&point.KeyFrame, &point2.KeyFrame))
+	assert.True(t, stateKeyframeEqual(&point.StateKeyFrame,
+		&point2.StateKeyFrame))
+	assert.True(t, proposalKeyFrameEqual(&point.ProposalKeyFrame,
+		&point2.ProposalKeyFrame))
+}
+
+func TestCheckpoint_DeserializeVersion0(t *testing.T) {
+	point := &Checkpoint{
+		height:        rand.Uint32(),
+		KeyFrame:      *randomKeyFrame(5, rand.Uint32()),
+		StateKeyFrame: *randomStateKeyFrame(5, true),
+	}
+
+	// checkpoints of version zero end with the state key frame
+	buf := new(bytes.Buffer)
+	assert.NoError(t, common.WriteUint32(buf, point.height))
+	assert.NoError(t, point.KeyFrame.Serialize(buf))
+	assert.NoError(t, point.StateKeyFrame.Serialize(buf))
+	data := buf.Bytes()
+
+	point2 := &Checkpoint{}
+	assert.NoError(t, point2.Deserialize(bytes.NewReader(data)))
+	assert.Equal(t, point.height, point2.height)
+	assert.Equal(t, byte(0), point2.version)
+	assert.True(t, keyframeEqual(&point.KeyFrame, &point2.KeyFrame))
+	assert.True(t, stateKeyframeEqual(&point.StateKeyFrame,
+		&point2.StateKeyFrame))
+	assert.Empty(t, point2.ProposalKeyFrame.Proposals)
+	assert.NotNil(t, point2.ProposalKeyFrame.Proposals)
+
+	// unknown version
+	data = append(data, checkpointVersion+1)
+	assert.Error(t, (&Checkpoint{}).Deserialize(bytes.NewReader(data)))
+}
+
+func TestCommittee_RecoverVersion0(t *testing.T) {
+	committee := NewCommittee(&config.DefaultParams)
+	manager := committee.GetProposalManager()
+	newProposal := func(did common.Uint168, nonce byte) *types.Transaction {
+		return &types.Transaction{
+			TxType: types.CRCProposal,
+			Payload: &payload.CRCProposal{
+				CRCouncilMemberDID: did,
+				DraftHash:          common.Uint256{nonce},
+			},
+		}
+	}
+	did := *randomUint168()
+	history := utils.NewHistory(10)
+	registered := newProposal(did, 1)
+	manager.registerProposal(registered, 1, 1, history)
+	history.Commit(1)
+
+	// restore a checkpoint of version zero, which has no proposals
+	buf := new(bytes.Buffer)
+	assert.NoError(t, common.WriteUint32(buf, 10))
+	assert.NoError(t, NewKeyFrame().Serialize(buf))
+	assert.NoError(t, NewStateKeyFrame().Serialize(buf))
+	point := &Checkpoint{}
+	assert.NoError(t, point.Deserialize(buf))
+	committee.Recover(point)
+
+	// proposals registered before are kept, and more can be registered
+	assert.NotNil(t, committee.GetProposal(
+		registered.Payload.(*payload.CRCProposal).Hash()))
+	proposal := newProposal(did, 2)
+	manager.registerProposal(proposal, 11, 1, history)
+	history.Commit(11)
+	assert.NotNil(t, committee.GetProposal(
+		proposal.Payload.(*payload.CRCProposal).Hash()))
+	assert.Equal(t, 2, manager.getProposalCount(did))
+}
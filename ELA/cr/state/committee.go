@@ -120,6 +120,15 @@ func (c *Committee) IsAppropriationNeeded() bool {
 	return c.NeedAppropriation
 }
 
+// GetCirculationAmount returns the circulation amount of ELA calculated by
+// the committee.
+func (c *Committee) GetCirculationAmount() common.Fixed64 {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.CirculationAmount
+}
+
 func (c *Committee) GetMembersDIDs() []common.Uint168 {
 	c.mtx.RLock()
 	defer c.mtx.RUnlock()
@@ -682,6 +691,11 @@ func (c *Committee) Recover(checkpoint *Checkpoint) {
 	defer c.mtx.Unlock()
 	c.state.StateKeyFrame = checkpoint.StateKeyFrame
 	c.KeyFrame = checkpoint.KeyFrame
+	// proposals are not saved in checkpoints of version zero, so the
+	// manager keeps its proposals or rebuilds them from blocks
+	if checkpoint.version != 0 {
+		c.manager.ProposalKeyFrame = checkpoint.ProposalKeyFrame
+	}
 }
 
 func (c *Committee) shouldChange(height uint32) bool {
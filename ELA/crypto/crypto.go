@@ -74,6 +74,8 @@ func Sign(priKey []byte, data []byte) ([]byte, error) {
 	privateKey.Curve = DefaultCurve
 	privateKey.D = big.NewInt(0)
 	privateKey.D.SetBytes(priKey)
+	// ecdsa.Sign of recent Go versions requires the public key
+	privateKey.X, privateKey.Y = DefaultCurve.ScalarBaseMult(priKey)
 
 	r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest[:])
 	if err != nil {
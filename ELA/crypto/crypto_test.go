@@ -58,3 +58,30 @@ func TestEncryptDecrypt(t *testing.T) {
 
 	assert.Equal(t, message, m)
 }
+
+func TestSignVerify(t *testing.T) {
+	priKey, pubKey, err := GenerateKeyPair()
+	if !assert.NoError(t, err) {
+		return
+	}
+	otherPriKey, otherPubKey, err := GenerateKeyPair()
+	if !assert.NoError(t, err) {
+		return
+	}
+	data := []byte("Hello World!")
+
+	// the key is built from the private key bytes only
+	signature, err := Sign(priKey, data)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, signature, SignatureLength)
+	assert.NoError(t, Verify(*pubKey, data, signature))
+	assert.Error(t, Verify(*otherPubKey, data, signature))
+	assert.Error(t, Verify(*pubKey, []byte("Hello World?"), signature))
+
+	otherSignature, err := Sign(otherPriKey, data)
+	assert.NoError(t, err)
+	assert.NoError(t, Verify(*otherPubKey, data, otherSignature))
+	assert.Error(t, Verify(*pubKey, data, otherSignature))
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/elastos/Elastos.ELA/blockchain"
+	"github.com/elastos/Elastos.ELA/common"
 	"github.com/elastos/Elastos.ELA/common/config"
 	"github.com/elastos/Elastos.ELA/core/types"
 	"github.com/elastos/Elastos.ELA/core/types/payload"
@@ -181,6 +182,10 @@ func NewArbitrator(account account.Account, cfg Config) (*Arbitrator, error) {
 	}
 
 	eventMonitor := log.NewEventMonitor()
+	eventMonitor.RegisterListener(&log.EventMetrics{
+		PublicKey: common.BytesToHexString(account.PublicKeyBytes()),
+	})
+	eventMonitor.RegisterListener(log.NewEventNotifier())
 
 	if cfg.EnableEventLog {
 		eventLogs := &log.EventLogs{}
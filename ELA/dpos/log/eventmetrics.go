@@ -0,0 +1,58 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package log
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastos/Elastos.ELA/common/metrics"
+)
+
+// EventMetrics updates the DPoS metrics by consensus events.
+type EventMetrics struct {
+	// PublicKey is the hex string of the public key of the node, to tell if
+	// the node is on duty.
+	PublicKey string
+
+	mtx       sync.Mutex
+	startTime time.Time
+}
+
+func (e *EventMetrics) OnProposalArrived(prop *ProposalEvent) {}
+
+func (e *EventMetrics) OnProposalFinished(prop *ProposalEvent) {}
+
+func (e *EventMetrics) OnVoteArrived(vote *VoteEvent) {}
+
+func (e *EventMetrics) OnViewStarted(view *ViewEvent) {
+	if view.Offset > 0 {
+		metrics.DPoSViewChanges.Inc()
+	}
+	if view.OnDutyArbitrator == e.PublicKey {
+		metrics.DPoSOnDuty.Set(1)
+	} else {
+		metrics.DPoSOnDuty.Set(0)
+	}
+}
+
+func (e *EventMetrics) OnConsensusStarted(cons *ConsensusEvent) {
+	e.mtx.Lock()
+	e.startTime = cons.StartTime
+	e.mtx.Unlock()
+}
+
+func (e *EventMetrics) OnConsensusFinished(cons *ConsensusEvent) {
+	e.mtx.Lock()
+	startTime := e.startTime
+	e.startTime = time.Time{}
+	e.mtx.Unlock()
+
+	if !startTime.IsZero() {
+		metrics.DPoSConsensusDuration.Observe(
+			cons.EndTime.Sub(startTime).Seconds())
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elastos/Elastos.ELA/common/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func consensusSamples(t *testing.T) (uint64, float64) {
+	families, err := metrics.Registry.Gather()
+	assert.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() == "ela_dpos_consensus_seconds" {
+			h := f.GetMetric()[0].GetHistogram()
+			return h.GetSampleCount(), h.GetSampleSum()
+		}
+	}
+	t.Fatal("consensus duration not found")
+	return 0, 0
+}
+
+func TestEventMetrics(t *testing.T) {
+	e := &EventMetrics{PublicKey: "self"}
+	var listener EventListener = e
+
+	viewChanges := testutil.ToFloat64(metrics.DPoSViewChanges)
+	listener.OnViewStarted(&ViewEvent{OnDutyArbitrator: "self", Offset: 0})
+	assert.Equal(t, viewChanges, testutil.ToFloat64(metrics.DPoSViewChanges))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.DPoSOnDuty))
+
+	listener.OnViewStarted(&ViewEvent{OnDutyArbitrator: "other", Offset: 1})
+	assert.Equal(t, viewChanges+1, testutil.ToFloat64(metrics.DPoSViewChanges))
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.DPoSOnDuty))
+
+	count, sum := consensusSamples(t)
+	start := time.Now()
+	listener.OnConsensusStarted(&ConsensusEvent{StartTime: start})
+	listener.OnConsensusFinished(&ConsensusEvent{EndTime: start.Add(3 * time.Second)})
+	newCount, newSum := consensusSamples(t)
+	assert.Equal(t, count+1, newCount)
+	assert.InDelta(t, sum+3, newSum, 1e-9)
+
+	// a consensus finished without being started is not observed
+	listener.OnConsensusFinished(&ConsensusEvent{EndTime: time.Now()})
+	newCount, _ = consensusSamples(t)
+	assert.Equal(t, count+1, newCount)
+}
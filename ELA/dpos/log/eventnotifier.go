@@ -0,0 +1,72 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package log
+
+import (
+	"github.com/elastos/Elastos.ELA/events"
+)
+
+// notifyQueueSize is the max count of consensus events waiting to be notified.
+const notifyQueueSize = 1000
+
+type notifyEvent struct {
+	typ  events.EventType
+	data interface{}
+}
+
+// EventNotifier notifies consensus events through the events package, so they
+// can be pushed to API clients. Events are notified in another goroutine by
+// the order they happened, and dropped if the queue is full to avoid blocking
+// the consensus.
+type EventNotifier struct {
+	queue chan notifyEvent
+}
+
+func (e *EventNotifier) OnProposalArrived(prop *ProposalEvent) {
+	e.notify(events.ETDPOSProposalArrived, prop)
+}
+
+func (e *EventNotifier) OnProposalFinished(prop *ProposalEvent) {
+	e.notify(events.ETDPOSProposalFinished, prop)
+}
+
+func (e *EventNotifier) OnVoteArrived(vote *VoteEvent) {
+	e.notify(events.ETDPOSVoteArrived, vote)
+}
+
+func (e *EventNotifier) OnViewStarted(view *ViewEvent) {
+	e.notify(events.ETDPOSViewStarted, view)
+}
+
+func (e *EventNotifier) OnConsensusStarted(cons *ConsensusEvent) {
+	e.notify(events.ETDPOSConsensusStarted, cons)
+}
+
+func (e *EventNotifier) OnConsensusFinished(cons *ConsensusEvent) {
+	e.notify(events.ETDPOSConsensusFinished, cons)
+}
+
+func (e *EventNotifier) notify(typ events.EventType, data interface{}) {
+	select {
+	case e.queue <- notifyEvent{typ: typ, data: data}:
+	default:
+		Warn("[EventNotifier] queue is full, drop event ", typ)
+	}
+}
+
+func (e *EventNotifier) notifyHandler() {
+	for event := range e.queue {
+		events.Notify(event.typ, event.data)
+	}
+}
+
+func NewEventNotifier() *EventNotifier {
+	e := &EventNotifier{
+		queue: make(chan notifyEvent, notifyQueueSize),
+	}
+	go e.notifyHandler()
+	return e
+}
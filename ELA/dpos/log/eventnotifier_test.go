@@ -0,0 +1,95 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elastos/Elastos.ELA/events"
+	"github.com/elastos/Elastos.ELA/utils/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	Init(test.DataDir, 0, 0, 0)
+}
+
+func TestEventNotifier(t *testing.T) {
+	notified := make(chan *events.Event, 10)
+	events.Subscribe(func(e *events.Event) {
+		switch e.Type {
+		case events.ETDPOSProposalArrived, events.ETDPOSProposalFinished,
+			events.ETDPOSVoteArrived, events.ETDPOSViewStarted,
+			events.ETDPOSConsensusStarted, events.ETDPOSConsensusFinished:
+			// callbacks can't be unsubscribed, so never block the later
+			// notifications once the test is done
+			select {
+			case notified <- e:
+			default:
+			}
+		}
+	})
+
+	proposal := &ProposalEvent{Sponsor: "sponsor"}
+	finished := &ProposalEvent{Sponsor: "sponsor", Result: true}
+	vote := &VoteEvent{Signer: "signer"}
+	view := &ViewEvent{OnDutyArbitrator: "arbitrator"}
+	started := &ConsensusEvent{Height: 1}
+	ended := &ConsensusEvent{Height: 1, EndTime: time.Now()}
+
+	var listener EventListener = NewEventNotifier()
+	listener.OnProposalArrived(proposal)
+	listener.OnProposalFinished(finished)
+	listener.OnVoteArrived(vote)
+	listener.OnViewStarted(view)
+	listener.OnConsensusStarted(started)
+	listener.OnConsensusFinished(ended)
+
+	// events are notified in the order they happened
+	for _, expected := range []events.Event{
+		{Type: events.ETDPOSProposalArrived, Data: proposal},
+		{Type: events.ETDPOSProposalFinished, Data: finished},
+		{Type: events.ETDPOSVoteArrived, Data: vote},
+		{Type: events.ETDPOSViewStarted, Data: view},
+		{Type: events.ETDPOSConsensusStarted, Data: started},
+		{Type: events.ETDPOSConsensusFinished, Data: ended},
+	} {
+		select {
+		case e := <-notified:
+			assert.Equal(t, expected.Type, e.Type)
+			assert.True(t, expected.Data == e.Data, expected.Type.String())
+		case <-time.After(5 * time.Second):
+			t.Fatal("event not notified ", expected.Type)
+		}
+	}
+}
+
+func TestEventNotifier_QueueFull(t *testing.T) {
+	// events are dropped instead of blocking the consensus once the queue
+	// is full
+	e := &EventNotifier{queue: make(chan notifyEvent, 2)}
+	done := make(chan struct{})
+	go func() {
+		for i := uint32(0); i < 5; i++ {
+			e.OnConsensusStarted(&ConsensusEvent{Height: i})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("notifier blocked")
+	}
+
+	assert.Len(t, e.queue, 2)
+	for i := uint32(0); i < 2; i++ {
+		event := <-e.queue
+		assert.Equal(t, events.ETDPOSConsensusStarted, event.typ)
+		assert.Equal(t, i, event.data.(*ConsensusEvent).Height)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/elastos/Elastos.ELA/core/types/payload"
 	"github.com/elastos/Elastos.ELA/dpos/log"
 	dmsg "github.com/elastos/Elastos.ELA/dpos/p2p/msg"
+	"github.com/elastos/Elastos.ELA/events"
 	"github.com/elastos/Elastos.ELA/p2p/msg"
 )
 
@@ -31,6 +32,9 @@ type IllegalBehaviorMonitor struct {
 
 func (i *IllegalBehaviorMonitor) AddEvidence(evidence payload.DPOSIllegalData) {
 	i.evidenceCache.AddEvidence(evidence)
+	if evidence != nil {
+		go events.Notify(events.ETDPOSIllegalEvidence, evidence)
+	}
 }
 
 func (i *IllegalBehaviorMonitor) SetInactiveArbitratorsTxHash(
@@ -84,6 +84,10 @@ func (c *CheckPoint) Key() string {
 	return checkpointKey
 }
 
+// RecordHistory implements the checkpoint.IHistoryCheckPoint interface to keep
+// snapshots of DPoS states as history.
+func (c *CheckPoint) RecordHistory() {}
+
 func (c *CheckPoint) OnInit() {
 	c.arbitrators.RecoverFromCheckPoints(c)
 }
@@ -468,3 +472,21 @@ func NewCheckpoint(ar *arbitrators) *CheckPoint {
 	cp.initFromArbitrators(ar)
 	return cp
 }
+
+// GetHistoryState returns a state recovered from the checkpoint saved at
+// exactly the given height, an error is returned if no checkpoint is saved at
+// the height. The state is only used to query the historical producers and
+// votes, it works only if history of checkpoints is enabled.
+func (s *State) GetHistoryState(height uint32) (*State, error) {
+	point, err := s.chainParams.CkpManager.GetHistoryCheckpoint(
+		checkpointKey, height)
+	if err != nil {
+		return nil, err
+	}
+	cp := point.(*CheckPoint)
+
+	state := NewState(s.chainParams, s.getArbiters,
+		s.getProducerDepositAmount)
+	state.StateKeyFrame = &cp.StateKeyFrame
+	return state, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/core/checkpoint"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_GetHistoryState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoints")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	manager := checkpoint.NewManager(&checkpoint.Config{
+		EnableHistory: true,
+		DataPath:      dir,
+	})
+	manager.Register(&CheckPoint{})
+	state := NewState(&config.Params{CkpManager: manager}, nil, nil)
+
+	// a snapshot saved by the checkpoint manager
+	height := CheckPointInterval * 10
+	point := generateCheckPoint(height)
+	buf := new(bytes.Buffer)
+	assert.NoError(t, point.Serialize(buf))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, checkpointKey), 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, checkpointKey,
+		fmt.Sprint(height)+checkpointExtension), buf.Bytes(), 0600))
+
+	history, err := state.GetHistoryState(height)
+	if assert.NoError(t, err) {
+		assert.True(t, stateKeyFrameEqual(&point.StateKeyFrame,
+			history.StateKeyFrame))
+	}
+
+	// states between snapshots are not returned as the nearest snapshot
+	_, err = state.GetHistoryState(height + 1)
+	assert.EqualError(t, err, fmt.Sprintf("no checkpoint dpos saved at "+
+		"height %d, the nearest lower height is %d", height+1, height))
+	_, err = state.GetHistoryState(height - 1)
+	assert.Error(t, err)
+}
@@ -55,6 +55,30 @@ const (
 
 	// ETIllegalEvidence indicates a illegal block received.
 	ETIllegalBlockEvidence
+
+	// ETDPOSProposalArrived indicates a DPOS proposal received by the arbiter.
+	ETDPOSProposalArrived
+
+	// ETDPOSProposalFinished indicates a DPOS proposal has been approved or
+	// rejected by the arbiter.
+	ETDPOSProposalFinished
+
+	// ETDPOSVoteArrived indicates a vote of DPOS proposal received by the
+	// arbiter.
+	ETDPOSVoteArrived
+
+	// ETDPOSViewStarted indicates a new view of DPOS consensus started.
+	ETDPOSViewStarted
+
+	// ETDPOSConsensusStarted indicates DPOS consensus on a new height started.
+	ETDPOSConsensusStarted
+
+	// ETDPOSConsensusFinished indicates DPOS consensus on a height finished.
+	ETDPOSConsensusFinished
+
+	// ETDPOSIllegalEvidence indicates an evidence of illegal behaviors or
+	// inactive arbitrators added by the arbiter.
+	ETDPOSIllegalEvidence
 )
 
 // notificationTypeStrings is a map of notification types back to their constant
@@ -67,6 +91,17 @@ var notificationTypeStrings = map[EventType]string{
 	ETNewBlockReceived:    "ETNewBlockReceived",
 	ETConfirmAccepted:     "ETConfirmAccepted",
 	ETDirectPeersChanged:  "ETDirectPeersChanged",
+
+	ETBlockConfirmAccepted:  "ETBlockConfirmAccepted",
+	ETBlockProcessed:        "ETBlockProcessed",
+	ETIllegalBlockEvidence:  "ETIllegalBlockEvidence",
+	ETDPOSProposalArrived:   "ETDPOSProposalArrived",
+	ETDPOSProposalFinished:  "ETDPOSProposalFinished",
+	ETDPOSVoteArrived:       "ETDPOSVoteArrived",
+	ETDPOSViewStarted:       "ETDPOSViewStarted",
+	ETDPOSConsensusStarted:  "ETDPOSConsensusStarted",
+	ETDPOSConsensusFinished: "ETDPOSConsensusFinished",
+	ETDPOSIllegalEvidence:   "ETDPOSIllegalEvidence",
 }
 
 // String returns the EventType in human-readable form.
@@ -84,6 +119,11 @@ func (n EventType) String() string {
 // 	- ETBlockConnected:    *types.Block
 // 	- ETBlockDisconnected: *types.Block
 // 	- ETTransactionAccepted: *types.Transaction
+// 	- ETDPOSProposalArrived, ETDPOSProposalFinished: *log.ProposalEvent
+// 	- ETDPOSVoteArrived: *log.VoteEvent
+// 	- ETDPOSViewStarted: *log.ViewEvent
+// 	- ETDPOSConsensusStarted, ETDPOSConsensusFinished: *log.ConsensusEvent
+// 	- ETDPOSIllegalEvidence: payload.DPOSIllegalData
 type Event struct {
 	Type EventType
 	Data interface{}
@@ -34,7 +34,9 @@ import (
 	"github.com/elastos/Elastos.ELA/p2p/msg"
 	"github.com/elastos/Elastos.ELA/pow"
 	"github.com/elastos/Elastos.ELA/servers"
+	"github.com/elastos/Elastos.ELA/servers/grpcserver"
 	"github.com/elastos/Elastos.ELA/servers/httpjsonrpc"
+	"github.com/elastos/Elastos.ELA/servers/httpmetrics"
 	"github.com/elastos/Elastos.ELA/servers/httpnodeinfo"
 	"github.com/elastos/Elastos.ELA/servers/httprestful"
 	"github.com/elastos/Elastos.ELA/servers/httpwebsocket"
@@ -290,6 +292,10 @@ func startNode(c *cli.Context, st *settings.Settings) {
 	servers.TxMemPool = txMemPool
 	servers.Server = server
 	servers.Arbiters = arbiters
+	servers.ACL, err = servers.NewAccessControl(&st.Config().RpcConfiguration)
+	if err != nil {
+		printErrorAndExit(err)
+	}
 	servers.Pow = pow.NewService(&pow.Config{
 		PayToAddr:   st.Config().PowConfiguration.PayToAddr,
 		MinerInfo:   st.Config().PowConfiguration.MinerInfo,
@@ -325,6 +331,12 @@ func startNode(c *cli.Context, st *settings.Settings) {
 	if st.Config().HttpWsStart {
 		go httpwebsocket.Start()
 	}
+	if st.Config().EnableGRPC {
+		go grpcserver.StartServer()
+	}
+	if st.Config().EnableMetrics {
+		go httpmetrics.StartServer()
+	}
 	if st.Config().HttpInfoStart {
 		go httpnodeinfo.StartServer()
 	}
@@ -0,0 +1,409 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+)
+
+const (
+	// EstimateFeeMaxConfirms is the maximum number of confirmations the fee
+	// estimator is able to give an estimation for.
+	EstimateFeeMaxConfirms = 25
+
+	// estimateFeeBucketCount defines how many fee rate buckets are tracked.
+	estimateFeeBucketCount = 40
+
+	// estimateFeeMinBucketRate is the upper bound (sela per KB) of the
+	// lowest fee rate bucket.
+	estimateFeeMinBucketRate = 1000.0
+
+	// estimateFeeBucketSpacing is the ratio between the bounds of two
+	// neighbor fee rate buckets.
+	estimateFeeBucketSpacing = 1.2
+
+	// estimateFeeDecay is applied to all statistics on each new block, so
+	// that recent blocks weigh more than old ones.
+	estimateFeeDecay = 0.998
+
+	// estimateFeeSufficientTxs is the minimum (decayed) count of transactions
+	// a group of buckets should have before its success ratio is trusted.
+	estimateFeeSufficientTxs = 2.0
+
+	// estimateFeeSuccessThreshold is the ratio of transactions that must be
+	// confirmed within the target before a fee rate is considered enough.
+	estimateFeeSuccessThreshold = 0.85
+
+	// estimateFeeUndoBlocks is the count of recent blocks can be rolled back
+	// from the statistics, rolling back deeper resets the statistics.
+	estimateFeeUndoBlocks = 720
+
+	// estimateFeeMaxObservedTxs is the maximum count of transactions observed
+	// and waiting for confirmation, which is about the count of the smallest
+	// transactions a full transaction pool holds.
+	estimateFeeMaxObservedTxs = 200000
+)
+
+var (
+	// ErrInsufficientFeeData indicates the estimator has not seen enough
+	// transactions confirmed to give a fee estimation.
+	ErrInsufficientFeeData = errors.New("insufficient data to estimate fee")
+)
+
+// FeeEstimate is the result of a fee estimation.
+type FeeEstimate struct {
+	// FeeRate is the estimated fee rate in sela per KB.
+	FeeRate common.Fixed64
+
+	// Blocks is the confirmations target the estimation is given for.
+	Blocks uint32
+
+	// Confidence is the ratio of transactions paying FeeRate that have been
+	// confirmed within Blocks blocks.
+	Confidence float64
+}
+
+// feeBucket holds the confirm statistics of transactions within a fee rate
+// range.
+type feeBucket struct {
+	// confirmed[i] is the count of transactions confirmed within i+1 blocks.
+	confirmed [EstimateFeeMaxConfirms]float64
+
+	// total is the count of transactions either confirmed or expired.
+	total float64
+
+	// feeRateSum is the sum of fee rates of all transactions counted in
+	// total.
+	feeRateSum float64
+}
+
+// observedTx records when a transaction entered transaction pool.
+type observedTx struct {
+	feeRate float64
+	height  uint32
+}
+
+// removedTx is an observed transaction removed by a block, blocks is the
+// count of blocks it was confirmed within, or zero if it expired.
+type removedTx struct {
+	hash   common.Uint256
+	obs    *observedTx
+	blocks uint32
+}
+
+// FeeEstimator tracks how many blocks transactions of each fee rate bucket
+// take to be confirmed, and estimates the fee rate needed for a transaction
+// to be confirmed within a given count of blocks.
+type FeeEstimator struct {
+	buckets    [estimateFeeBucketCount]feeBucket
+	observed   map[common.Uint256]*observedTx
+	lastHeight uint32
+
+	// undo holds the observed transactions removed by each recent block, so
+	// the block can be rolled back from the statistics.
+	undo map[uint32][]removedTx
+	sync.RWMutex
+}
+
+// ObserveTransaction records a transaction entered transaction pool at the
+// given best height.
+func (e *FeeEstimator) ObserveTransaction(tx *types.Transaction,
+	height uint32) {
+	size := tx.GetSize()
+	if size <= 0 {
+		return
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	hash := tx.Hash()
+	if _, ok := e.observed[hash]; ok {
+		return
+	}
+	if len(e.observed) >= estimateFeeMaxObservedTxs {
+		return
+	}
+	e.observed[hash] = &observedTx{
+		feeRate: float64(tx.Fee) * 1000 / float64(size),
+		height:  height,
+	}
+}
+
+// ProcessBlock updates statistics with transactions confirmed in the given
+// block, and expires observed transactions not confirmed in time.
+func (e *FeeEstimator) ProcessBlock(block *types.Block) {
+	e.Lock()
+	defer e.Unlock()
+
+	// Blocks already counted will be seen again when recovering from
+	// checkpoints, ignore them.
+	if block.Height <= e.lastHeight {
+		return
+	}
+	e.lastHeight = block.Height
+
+	for i := range e.buckets {
+		e.buckets[i].decay()
+	}
+
+	var removed []removedTx
+	for _, tx := range block.Transactions {
+		hash := tx.Hash()
+		obs, ok := e.observed[hash]
+		if !ok {
+			continue
+		}
+		delete(e.observed, hash)
+
+		blocks := uint32(1)
+		if block.Height > obs.height {
+			blocks = block.Height - obs.height
+		}
+		e.buckets[bucketIndex(obs.feeRate)].record(obs.feeRate, blocks)
+		removed = append(removed, removedTx{hash: hash, obs: obs, blocks: blocks})
+	}
+
+	for hash, obs := range e.observed {
+		if block.Height < obs.height+EstimateFeeMaxConfirms {
+			continue
+		}
+		delete(e.observed, hash)
+		e.buckets[bucketIndex(obs.feeRate)].record(obs.feeRate, 0)
+		removed = append(removed, removedTx{hash: hash, obs: obs})
+	}
+
+	e.undo[block.Height] = removed
+	if len(e.undo) > estimateFeeUndoBlocks {
+		for height := range e.undo {
+			if height+estimateFeeUndoBlocks <= block.Height {
+				delete(e.undo, height)
+			}
+		}
+	}
+}
+
+// Rollback removes the blocks processed above the given height from the
+// statistics, so they will be counted only once when reconnected. If the
+// blocks are too old to roll back, the statistics are reset.
+func (e *FeeEstimator) Rollback(height uint32) {
+	e.Lock()
+	defer e.Unlock()
+
+	for e.lastHeight > height {
+		removed, ok := e.undo[e.lastHeight]
+		if !ok {
+			e.reset(height)
+			return
+		}
+		delete(e.undo, e.lastHeight)
+
+		for _, r := range removed {
+			e.buckets[bucketIndex(r.obs.feeRate)].unrecord(r.obs.feeRate,
+				r.blocks)
+			e.observed[r.hash] = r.obs
+		}
+		for i := range e.buckets {
+			e.buckets[i].undecay()
+		}
+		e.lastHeight--
+	}
+}
+
+// reset clears all statistics and observed transactions.
+func (e *FeeEstimator) reset(height uint32) {
+	e.buckets = [estimateFeeBucketCount]feeBucket{}
+	e.observed = make(map[common.Uint256]*observedTx)
+	e.undo = make(map[uint32][]removedTx)
+	e.lastHeight = height
+}
+
+// EstimateFee returns the lowest fee rate by which transactions have been
+// confirmed within the given count of blocks with enough confidence.
+func (e *FeeEstimator) EstimateFee(confirms uint32) (*FeeEstimate, error) {
+	if confirms == 0 || confirms > EstimateFeeMaxConfirms {
+		return nil, fmt.Errorf("confirmations should be in range 1-%d",
+			EstimateFeeMaxConfirms)
+	}
+
+	e.RLock()
+	defer e.RUnlock()
+
+	// Go through buckets from the highest fee rate to the lowest, buckets with
+	// not enough data are grouped with lower neighbors, and stop at the first
+	// group which confirmed ratio less than the threshold.
+	var confirmed, total, feeRateSum float64
+	var result *FeeEstimate
+	for i := len(e.buckets) - 1; i >= 0; i-- {
+		b := &e.buckets[i]
+		confirmed += b.confirmed[confirms-1]
+		total += b.total
+		feeRateSum += b.feeRateSum
+		if total < estimateFeeSufficientTxs {
+			continue
+		}
+
+		ratio := confirmed / total
+		if ratio < estimateFeeSuccessThreshold {
+			break
+		}
+		result = &FeeEstimate{
+			FeeRate:    common.Fixed64(math.Ceil(feeRateSum / total)),
+			Blocks:     confirms,
+			Confidence: ratio,
+		}
+		confirmed, total, feeRateSum = 0, 0, 0
+	}
+
+	if result == nil {
+		return nil, ErrInsufficientFeeData
+	}
+	return result, nil
+}
+
+func (e *FeeEstimator) Serialize(w io.Writer) (err error) {
+	if err = common.WriteUint32(w, e.lastHeight); err != nil {
+		return
+	}
+	for i := range e.buckets {
+		if err = e.buckets[i].Serialize(w); err != nil {
+			return
+		}
+	}
+
+	if err = common.WriteVarUint(w, uint64(len(e.observed))); err != nil {
+		return
+	}
+	for k, v := range e.observed {
+		if err = k.Serialize(w); err != nil {
+			return
+		}
+		if err = common.WriteElements(w, v.feeRate, v.height); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (e *FeeEstimator) Deserialize(r io.Reader) (err error) {
+	if e.lastHeight, err = common.ReadUint32(r); err != nil {
+		return
+	}
+	for i := range e.buckets {
+		if err = e.buckets[i].Deserialize(r); err != nil {
+			return
+		}
+	}
+
+	var count uint64
+	if count, err = common.ReadVarUint(r, 0); err != nil {
+		return
+	}
+	if count > estimateFeeMaxObservedTxs {
+		return fmt.Errorf("observed transactions count %d exceeds the "+
+			"maximum %d", count, estimateFeeMaxObservedTxs)
+	}
+	e.observed = make(map[common.Uint256]*observedTx, count)
+	for i := uint64(0); i < count; i++ {
+		var hash common.Uint256
+		if err = hash.Deserialize(r); err != nil {
+			return
+		}
+		obs := &observedTx{}
+		if err = common.ReadElements(r, &obs.feeRate, &obs.height); err != nil {
+			return
+		}
+		e.observed[hash] = obs
+	}
+	return
+}
+
+// record counts a transaction of the given fee rate confirmed within the
+// given blocks, zero blocks means the transaction has expired.
+func (b *feeBucket) record(feeRate float64, blocks uint32) {
+	b.total++
+	b.feeRateSum += feeRate
+	if blocks == 0 || blocks > EstimateFeeMaxConfirms {
+		return
+	}
+	for i := blocks - 1; i < EstimateFeeMaxConfirms; i++ {
+		b.confirmed[i]++
+	}
+}
+
+// unrecord removes a transaction counted by record.
+func (b *feeBucket) unrecord(feeRate float64, blocks uint32) {
+	b.total--
+	b.feeRateSum -= feeRate
+	if blocks == 0 || blocks > EstimateFeeMaxConfirms {
+		return
+	}
+	for i := blocks - 1; i < EstimateFeeMaxConfirms; i++ {
+		b.confirmed[i]--
+	}
+}
+
+func (b *feeBucket) decay() {
+	for i := range b.confirmed {
+		b.confirmed[i] *= estimateFeeDecay
+	}
+	b.total *= estimateFeeDecay
+	b.feeRateSum *= estimateFeeDecay
+}
+
+// undecay reverts decay.
+func (b *feeBucket) undecay() {
+	for i := range b.confirmed {
+		b.confirmed[i] /= estimateFeeDecay
+	}
+	b.total /= estimateFeeDecay
+	b.feeRateSum /= estimateFeeDecay
+}
+
+func (b *feeBucket) Serialize(w io.Writer) error {
+	for _, v := range b.confirmed {
+		if err := common.WriteElement(w, v); err != nil {
+			return err
+		}
+	}
+	return common.WriteElements(w, b.total, b.feeRateSum)
+}
+
+func (b *feeBucket) Deserialize(r io.Reader) error {
+	for i := range b.confirmed {
+		if err := common.ReadElement(r, &b.confirmed[i]); err != nil {
+			return err
+		}
+	}
+	return common.ReadElements(r, &b.total, &b.feeRateSum)
+}
+
+// bucketIndex returns the index of bucket the given fee rate belongs to.
+func bucketIndex(feeRate float64) int {
+	if feeRate < estimateFeeMinBucketRate {
+		return 0
+	}
+	index := int(math.Log(feeRate/estimateFeeMinBucketRate)/
+		math.Log(estimateFeeBucketSpacing)) + 1
+	if index >= estimateFeeBucketCount {
+		index = estimateFeeBucketCount - 1
+	}
+	return index
+}
+
+func NewFeeEstimator() *FeeEstimator {
+	return &FeeEstimator{
+		observed: make(map[common.Uint256]*observedTx),
+		undo:     make(map[uint32][]removedTx),
+	}
+}
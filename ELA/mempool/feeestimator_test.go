@@ -0,0 +1,255 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package mempool
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEstimatorTestTx(fee common.Fixed64) *types.Transaction {
+	return &types.Transaction{
+		TxType:  types.TransferAsset,
+		Payload: &payload.TransferAsset{},
+		Attributes: []*types.Attribute{
+			{
+				Usage: types.Nonce,
+				Data:  randomNonceData(),
+			},
+		},
+		Fee: fee,
+	}
+}
+
+func TestFeeEstimator_EstimateFee(t *testing.T) {
+	estimator := NewFeeEstimator()
+
+	_, err := estimator.EstimateFee(0)
+	assert.Error(t, err)
+	_, err = estimator.EstimateFee(EstimateFeeMaxConfirms + 1)
+	assert.Error(t, err)
+	_, err = estimator.EstimateFee(1)
+	assert.Equal(t, ErrInsufficientFeeData, err)
+
+	// Each round, high fee transactions are confirmed in the next block, and
+	// low fee transactions are confirmed after 10 blocks.
+	height := uint32(100)
+	var pending [][]*types.Transaction
+	for i := 0; i < 50; i++ {
+		high := newEstimatorTestTx(1000)
+		low := newEstimatorTestTx(10)
+		estimator.ObserveTransaction(high, height)
+		estimator.ObserveTransaction(low, height)
+		pending = append(pending, []*types.Transaction{low})
+
+		height++
+		txs := []*types.Transaction{high}
+		if len(pending) > 10 {
+			txs = append(txs, pending[0]...)
+			pending = pending[1:]
+		}
+		estimator.ProcessBlock(&types.Block{
+			Header:       types.Header{Height: height},
+			Transactions: txs,
+		})
+	}
+
+	highRate := common.Fixed64(1000 * 1000 /
+		newEstimatorTestTx(1000).GetSize())
+	fast, err := estimator.EstimateFee(1)
+	assert.NoError(t, err)
+	assert.True(t, fast.FeeRate >= highRate)
+	assert.True(t, fast.Confidence >= estimateFeeSuccessThreshold)
+
+	slow, err := estimator.EstimateFee(12)
+	assert.NoError(t, err)
+	assert.True(t, slow.FeeRate < highRate)
+	assert.Equal(t, uint32(12), slow.Blocks)
+
+	// Blocks already processed should be ignored.
+	processed := len(estimator.observed)
+	estimator.ProcessBlock(&types.Block{
+		Header:       types.Header{Height: height},
+		Transactions: pending[0],
+	})
+	assert.Equal(t, processed, len(estimator.observed))
+}
+
+func TestFeeEstimator_Expire(t *testing.T) {
+	estimator := NewFeeEstimator()
+	tx := newEstimatorTestTx(100)
+	estimator.ObserveTransaction(tx, 1)
+	assert.Equal(t, 1, len(estimator.observed))
+
+	for h := uint32(2); h <= EstimateFeeMaxConfirms+1; h++ {
+		estimator.ProcessBlock(&types.Block{Header: types.Header{Height: h}})
+	}
+	assert.Equal(t, 0, len(estimator.observed))
+
+	var total float64
+	for _, b := range estimator.buckets {
+		total += b.total
+		assert.Equal(t, float64(0), b.confirmed[EstimateFeeMaxConfirms-1])
+	}
+	assert.True(t, total > 0)
+}
+
+func TestFeeEstimatorCheckpoint_Serialize(t *testing.T) {
+	estimator := NewFeeEstimator()
+	for i := uint32(1); i < 10; i++ {
+		tx := newEstimatorTestTx(common.Fixed64(i * 100))
+		estimator.ObserveTransaction(tx, i)
+		estimator.ObserveTransaction(newEstimatorTestTx(1), i)
+		estimator.ProcessBlock(&types.Block{
+			Header:       types.Header{Height: i + 1},
+			Transactions: []*types.Transaction{tx},
+		})
+	}
+	ckp := newFeeEstimatorCheckpoint(estimator)
+	ckp.SetHeight(10)
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, ckp.Serialize(buf))
+	restored := newFeeEstimatorCheckpoint(NewFeeEstimator())
+	assert.NoError(t, restored.Deserialize(buf))
+
+	assert.Equal(t, ckp.GetHeight(), restored.GetHeight())
+	assert.Equal(t, estimator.lastHeight, restored.estimator.lastHeight)
+	assert.Equal(t, estimator.buckets, restored.estimator.buckets)
+	assert.Equal(t, estimator.observed, restored.estimator.observed)
+
+	snapshot := ckp.Snapshot().(*feeEstimatorCheckpoint)
+	assert.False(t, snapshot.estimator == estimator)
+	assert.Equal(t, estimator.buckets, snapshot.estimator.buckets)
+}
+
+func assertBucketsEqual(t *testing.T, expected,
+	actual [estimateFeeBucketCount]feeBucket) {
+	for i := range expected {
+		for j := range expected[i].confirmed {
+			assert.InDelta(t, expected[i].confirmed[j],
+				actual[i].confirmed[j], 1e-9, "bucket %d", i)
+		}
+		assert.InDelta(t, expected[i].total, actual[i].total, 1e-9,
+			"bucket %d", i)
+		assert.InDelta(t, expected[i].feeRateSum, actual[i].feeRateSum, 1e-6,
+			"bucket %d", i)
+	}
+}
+
+func TestFeeEstimator_Rollback(t *testing.T) {
+	estimator := NewFeeEstimator()
+
+	// each block confirms the fast transaction observed at the previous
+	// height and the slow one observed 5 blocks ago, and the others expire
+	var blocks []*types.Block
+	var pending []*types.Transaction
+	for i := 0; i < 40; i++ {
+		height := uint32(100 + i)
+		fast := newEstimatorTestTx(common.Fixed64(1000 + i))
+		slow := newEstimatorTestTx(common.Fixed64(10 + i))
+		estimator.ObserveTransaction(fast, height)
+		estimator.ObserveTransaction(slow, height)
+		estimator.ObserveTransaction(newEstimatorTestTx(1), height)
+		pending = append(pending, slow)
+
+		txs := []*types.Transaction{fast}
+		if len(pending) > 5 {
+			txs = append(txs, pending[0])
+			pending = pending[1:]
+		}
+		blocks = append(blocks, &types.Block{
+			Header:       types.Header{Height: height + 1},
+			Transactions: txs,
+		})
+	}
+	process := func(blocks []*types.Block) {
+		for _, block := range blocks {
+			estimator.ProcessBlock(block)
+		}
+	}
+	process(blocks[:30])
+	buckets := estimator.buckets
+	observedTxs := make(map[common.Uint256]observedTx)
+	for hash, obs := range estimator.observed {
+		observedTxs[hash] = *obs
+	}
+	process(blocks[30:])
+	finalBuckets := estimator.buckets
+	finalObserved := len(estimator.observed)
+
+	// rolling back restores the statistics and observed transactions
+	estimator.Rollback(blocks[29].Height)
+	assert.Equal(t, blocks[29].Height, estimator.lastHeight)
+	assertBucketsEqual(t, buckets, estimator.buckets)
+	assert.Equal(t, len(observedTxs), len(estimator.observed))
+	for hash, obs := range estimator.observed {
+		assert.Equal(t, observedTxs[hash], *obs)
+	}
+
+	// the reconnected blocks are counted only once
+	process(blocks[30:])
+	assertBucketsEqual(t, finalBuckets, estimator.buckets)
+	assert.Equal(t, finalObserved, len(estimator.observed))
+
+	// rolling back to a higher height does nothing
+	estimator.Rollback(blocks[39].Height + 1)
+	assert.Equal(t, blocks[39].Height, estimator.lastHeight)
+	assertBucketsEqual(t, finalBuckets, estimator.buckets)
+
+	// the statistics are reset if the blocks can not be rolled back, such as
+	// the blocks processed before the estimator restored from checkpoint
+	buf := new(bytes.Buffer)
+	assert.NoError(t, estimator.Serialize(buf))
+	restored := NewFeeEstimator()
+	assert.NoError(t, restored.Deserialize(buf))
+	restored.Rollback(blocks[35].Height)
+	assert.Equal(t, blocks[35].Height, restored.lastHeight)
+	assert.Equal(t, [estimateFeeBucketCount]feeBucket{}, restored.buckets)
+	assert.Empty(t, restored.observed)
+	restored.ProcessBlock(blocks[36])
+	assert.Equal(t, blocks[36].Height, restored.lastHeight)
+
+	// old undo records are dropped
+	assert.True(t, len(estimator.undo) <= estimateFeeUndoBlocks)
+	for h := uint32(1000); h < 1000+2*estimateFeeUndoBlocks; h++ {
+		estimator.ProcessBlock(&types.Block{Header: types.Header{Height: h}})
+	}
+	assert.Equal(t, estimateFeeUndoBlocks, len(estimator.undo))
+}
+
+func TestFeeEstimator_Deserialize(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.NoError(t, NewFeeEstimator().Serialize(buf))
+	data := buf.Bytes()
+	// replace the count of observed transactions
+	data = append(data[:len(data)-1:len(data)-1], 0xfe)
+	data = append(data, 0x41, 0x0d, 0x03, 0x00)
+	err := NewFeeEstimator().Deserialize(bytes.NewReader(data))
+	assert.EqualError(t, err, "observed transactions count 200001 exceeds "+
+		"the maximum 200000")
+
+	// a full estimator is still accepted
+	estimator := NewFeeEstimator()
+	for i := 0; i < estimateFeeMaxObservedTxs; i++ {
+		var hash common.Uint256
+		hash[0], hash[1], hash[2] = byte(i), byte(i>>8), byte(i>>16)
+		estimator.observed[hash] = &observedTx{feeRate: 1, height: 1}
+	}
+	// no more transactions are observed when it is full
+	estimator.ObserveTransaction(newEstimatorTestTx(100), 1)
+	assert.Equal(t, estimateFeeMaxObservedTxs, len(estimator.observed))
+	buf = new(bytes.Buffer)
+	assert.NoError(t, estimator.Serialize(buf))
+	restored := NewFeeEstimator()
+	assert.NoError(t, restored.Deserialize(buf))
+	assert.Equal(t, estimateFeeMaxObservedTxs, len(restored.observed))
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package mempool
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/log"
+	"github.com/elastos/Elastos.ELA/core/checkpoint"
+	"github.com/elastos/Elastos.ELA/core/types"
+)
+
+const (
+	// estimatorCheckpointKey defines key of fee estimator checkpoint.
+	estimatorCheckpointKey = "feeEstimator"
+
+	// estimatorCheckpointExtension defines checkpoint file extension of fee
+	// estimator checkpoint.
+	estimatorCheckpointExtension = ".fecp"
+
+	// estimatorCheckpointHeight defines interval height between two neighbor
+	// check points, the statistics of blocks after the last saved checkpoint
+	// are lost on restart, which only makes the estimation slightly stale.
+	estimatorCheckpointHeight = uint32(720)
+)
+
+// feeEstimatorCheckpoint saves statistics of fee estimator, so that fee
+// estimation will survive node restarts.
+type feeEstimatorCheckpoint struct {
+	estimator *FeeEstimator
+	height    uint32
+}
+
+func (c *feeEstimatorCheckpoint) OnBlockSaved(block *types.DposBlock) {
+	c.estimator.ProcessBlock(block.Block)
+}
+
+func (c *feeEstimatorCheckpoint) OnRollbackTo(height uint32) error {
+	c.estimator.Rollback(height)
+	return nil
+}
+
+func (c *feeEstimatorCheckpoint) Key() string {
+	return estimatorCheckpointKey
+}
+
+func (c *feeEstimatorCheckpoint) Snapshot() checkpoint.ICheckPoint {
+	buf := bytes.Buffer{}
+	if err := c.Serialize(&buf); err != nil {
+		c.LogError(err)
+		return nil
+	}
+	result := newFeeEstimatorCheckpoint(NewFeeEstimator())
+	if err := result.Deserialize(&buf); err != nil {
+		c.LogError(err)
+		return nil
+	}
+	return result
+}
+
+func (c *feeEstimatorCheckpoint) GetHeight() uint32 {
+	return c.height
+}
+
+func (c *feeEstimatorCheckpoint) SetHeight(height uint32) {
+	c.height = height
+}
+
+func (c *feeEstimatorCheckpoint) SavePeriod() uint32 {
+	return estimatorCheckpointHeight
+}
+
+func (c *feeEstimatorCheckpoint) EffectivePeriod() uint32 {
+	return estimatorCheckpointHeight
+}
+
+func (c *feeEstimatorCheckpoint) DataExtension() string {
+	return estimatorCheckpointExtension
+}
+
+func (c *feeEstimatorCheckpoint) Generator() func(buf []byte) checkpoint.ICheckPoint {
+	return func(buf []byte) checkpoint.ICheckPoint {
+		stream := bytes.Buffer{}
+		stream.Write(buf)
+
+		result := newFeeEstimatorCheckpoint(NewFeeEstimator())
+		if err := result.Deserialize(&stream); err != nil {
+			c.LogError(err)
+			return nil
+		}
+		return result
+	}
+}
+
+func (c *feeEstimatorCheckpoint) LogError(err error) {
+	log.Warn(err)
+}
+
+func (c *feeEstimatorCheckpoint) Priority() checkpoint.Priority {
+	return checkpoint.VeryLow
+}
+
+func (c *feeEstimatorCheckpoint) OnInit() {
+}
+
+func (c *feeEstimatorCheckpoint) StartHeight() uint32 {
+	return uint32(1)
+}
+
+func (c *feeEstimatorCheckpoint) Serialize(w io.Writer) (err error) {
+	c.estimator.RLock()
+	defer c.estimator.RUnlock()
+
+	if err = common.WriteUint32(w, c.height); err != nil {
+		return
+	}
+	return c.estimator.Serialize(w)
+}
+
+func (c *feeEstimatorCheckpoint) Deserialize(r io.Reader) (err error) {
+	c.estimator.Lock()
+	defer c.estimator.Unlock()
+
+	if c.height, err = common.ReadUint32(r); err != nil {
+		return
+	}
+	return c.estimator.Deserialize(r)
+}
+
+func newFeeEstimatorCheckpoint(
+	estimator *FeeEstimator) *feeEstimatorCheckpoint {
+	return &feeEstimatorCheckpoint{
+		estimator: estimator,
+		height:    0,
+	}
+}
@@ -105,6 +105,10 @@ func (l *txFeeOrderedList) GetSize() int {
 	return len(l.list)
 }
 
+func (l *txFeeOrderedList) GetTotalSize() uint64 {
+	return l.totalSize
+}
+
 func (l *txFeeOrderedList) OverSize(size uint64) bool {
 	return l.totalSize+size > l.maxSize
 }
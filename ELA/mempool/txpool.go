@@ -25,7 +25,8 @@ import (
 type TxPool struct {
 	conflictManager
 	*txPoolCheckpoint
-	chainParams *config.Params
+	feeEstimator *FeeEstimator
+	chainParams  *config.Params
 	//proposal of txpool used amout
 	proposalsUsedAmount Fixed64
 	sync.RWMutex
@@ -97,10 +98,61 @@ func (mp *TxPool) appendToTxPool(tx *Transaction) elaerr.ELAError {
 		mp.removeTx(tx)
 		return err
 	}
+	mp.feeEstimator.ObserveTransaction(tx, bestHeight)
 
 	return nil
 }
 
+// TestAcceptTransaction checks the transaction the same way as it is appended
+// to the pool, but the transaction is neither added to the pool nor relayed.
+// The fee of the transaction is returned if references of it are found.
+func (mp *TxPool) TestAcceptTransaction(
+	tx *Transaction) (Fixed64, elaerr.ELAError) {
+	mp.RLock()
+	defer mp.RUnlock()
+
+	if _, ok := mp.txnList[tx.Hash()]; ok {
+		return 0, elaerr.Simple(elaerr.ErrTxDuplicate, nil)
+	}
+	if tx.IsCoinBaseTx() {
+		return 0, elaerr.Simple(elaerr.ErrBlockIneffectiveCoinbase, nil)
+	}
+
+	chain := blockchain.DefaultLedger.Blockchain
+	bestHeight := chain.GetHeight()
+	if errCode := chain.CheckTransactionSanity(bestHeight+1, tx); errCode != nil {
+		return 0, errCode
+	}
+	references, err := chain.UTXOCache.GetTxReference(tx)
+	if err != nil {
+		return 0, elaerr.Simple(elaerr.ErrTxUnknownReferredTx, err)
+	}
+	var fee Fixed64
+	for _, output := range references {
+		fee += output.Value
+	}
+	for _, output := range tx.Outputs {
+		fee -= output.Value
+	}
+	if errCode := chain.CheckTransactionContext(bestHeight+1, tx, references,
+		mp.proposalsUsedAmount); errCode != nil {
+		return fee, errCode
+	}
+	if errCode := mp.VerifyTx(tx); errCode != nil {
+		return fee, errCode
+	}
+	if mp.txFees.OverSize(uint64(tx.GetSize())) {
+		return fee, elaerr.Simple(elaerr.ErrTxPoolOverCapacity, nil)
+	}
+	return fee, nil
+}
+
+// EstimateFee returns the fee rate in sela per KB by which a transaction is
+// expected to be confirmed within the given count of blocks.
+func (mp *TxPool) EstimateFee(confirms uint32) (*FeeEstimate, error) {
+	return mp.feeEstimator.EstimateFee(confirms)
+}
+
 // GetUsedUTXO returns all used refer keys of inputs.
 func (mp *TxPool) GetUsedUTXOs() map[string]struct{} {
 	mp.RLock()
@@ -425,6 +477,14 @@ func (mp *TxPool) GetTransactionCount() int {
 	return len(mp.txnList)
 }
 
+// GetTransactionsSize returns the total size in bytes of transactions in the
+// pool.
+func (mp *TxPool) GetTransactionsSize() uint64 {
+	mp.RLock()
+	defer mp.RUnlock()
+	return mp.txFees.GetTotalSize()
+}
+
 func (mp *TxPool) getInputUTXOList(input *Input) *Transaction {
 	return mp.GetTx(input.ReferKey(), slotTxInputsReferKeys)
 }
@@ -518,6 +578,7 @@ func (mp *TxPool) onPopBack(hash Uint256) {
 func NewTxPool(params *config.Params) *TxPool {
 	rtn := &TxPool{
 		conflictManager:     newConflictManager(),
+		feeEstimator:        NewFeeEstimator(),
 		chainParams:         params,
 		proposalsUsedAmount: 0,
 	}
@@ -530,5 +591,6 @@ func NewTxPool(params *config.Params) *TxPool {
 			}
 		})
 	params.CkpManager.Register(rtn.txPoolCheckpoint)
+	params.CkpManager.Register(newFeeEstimatorCheckpoint(rtn.feeEstimator))
 	return rtn
 }
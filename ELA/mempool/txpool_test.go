@@ -532,6 +532,36 @@ func TestTxPool_AppendToTxnPool(t *testing.T) {
 	assert.Equal(t, errCode.Code(), elaerr.ErrBlockIneffectiveCoinbase)
 }
 
+func TestTxPool_TestAcceptTransaction(t *testing.T) {
+	tx := new(types.Transaction)
+	txBytes, _ := hex.DecodeString("000403454c41010008803e6306563b26de010" +
+		"000000000000000000000000000000000000000000000000000000000000000ffff" +
+		"ffffffff02b037db964a231458d2d6ffd5ea18944c4f90e63d547c5d3b9874df66a" +
+		"4ead0a39becdc01000000000000000012c8a2e0677227144df822b7d9246c58df68" +
+		"eb11ceb037db964a231458d2d6ffd5ea18944c4f90e63d547c5d3b9874df66a4ead" +
+		"0a3c1d258040000000000000000129e9cf1c5f336fcf3a6c954444ed482c5d916e5" +
+		"06dd00000000")
+	tx.Deserialize(bytes.NewReader(txBytes))
+	count := txPool.GetTransactionCount()
+	_, errCode := txPool.TestAcceptTransaction(tx)
+	assert.Equal(t, elaerr.ErrBlockIneffectiveCoinbase, errCode.Code())
+
+	// The transaction should not be added to the pool anyway.
+	tx = &types.Transaction{
+		Version: types.TxVersion09,
+		TxType:  types.TransferAsset,
+		Payload: &payload.TransferAsset{},
+		Inputs: []*types.Input{{
+			Previous: *types.NewOutPoint(common.Uint256{1}, 0),
+		}},
+		Outputs: []*types.Output{},
+	}
+	_, errCode = txPool.TestAcceptTransaction(tx)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, count, txPool.GetTransactionCount())
+	assert.False(t, txPool.HaveTransaction(tx.Hash()))
+}
+
 func TestTxPool_CleanSubmittedTransactions(t *testing.T) {
 	appendTx := func(tx *types.Transaction) elaerr.ELAError {
 		if err := txPool.AppendTx(tx); err != nil {
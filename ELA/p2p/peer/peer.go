@@ -92,6 +92,8 @@ type StatsSnap struct {
 	LastBlock      uint32
 	LastPingTime   time.Time
 	LastPingMicros int64
+	BytesSent      uint64
+	BytesRecv      uint64
 }
 
 // MessageFunc is a message handler in peer's configuration
@@ -153,10 +155,12 @@ type HostToNetAddrFunc func(host string, port uint16, services uint64) (*p2p.Net
 
 type Peer struct {
 	// The following variables must only be used atomically.
-	lastRecv   int64
-	lastSend   int64
-	connected  int32
-	disconnect int32
+	bytesReceived uint64
+	bytesSent     uint64
+	lastRecv      int64
+	lastSend      int64
+	connected     int32
+	disconnect    int32
 
 	conn net.Conn
 
@@ -261,6 +265,8 @@ func (p *Peer) StatsSnapshot() *StatsSnap {
 		LastBlock:      p.height,
 		LastPingMicros: p.lastPingMicros,
 		LastPingTime:   p.lastPingTime,
+		BytesSent:      p.BytesSent(),
+		BytesRecv:      p.BytesReceived(),
 	}
 
 	p.statsMtx.RUnlock()
@@ -425,6 +431,20 @@ func (p *Peer) LastRecv() time.Time {
 	return time.Unix(atomic.LoadInt64(&p.lastRecv), 0)
 }
 
+// BytesSent returns the total number of bytes sent by the peer.
+//
+// This function is safe for concurrent access.
+func (p *Peer) BytesSent() uint64 {
+	return atomic.LoadUint64(&p.bytesSent)
+}
+
+// BytesReceived returns the total number of bytes received by the peer.
+//
+// This function is safe for concurrent access.
+func (p *Peer) BytesReceived() uint64 {
+	return atomic.LoadUint64(&p.bytesReceived)
+}
+
 // LocalAddr returns the local address of the connection.
 //
 // This function is safe fo concurrent access.
@@ -1108,6 +1128,24 @@ func (p *Peer) start() error {
 	return nil
 }
 
+// statsConn wraps the connection of a peer to count bytes sent and received.
+type statsConn struct {
+	net.Conn
+	peer *Peer
+}
+
+func (c *statsConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	atomic.AddUint64(&c.peer.bytesReceived, uint64(n))
+	return n, err
+}
+
+func (c *statsConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	atomic.AddUint64(&c.peer.bytesSent, uint64(n))
+	return n, err
+}
+
 // AssociateConnection associates the given conn to the peer.   Calling this
 // function when the peer is already connected will have no effect.
 func (p *Peer) AssociateConnection(conn net.Conn) {
@@ -1116,7 +1154,7 @@ func (p *Peer) AssociateConnection(conn net.Conn) {
 		return
 	}
 
-	p.conn = conn
+	p.conn = &statsConn{Conn: conn, peer: p}
 	p.timeConnected = time.Now()
 	go func() {
 		if err := p.start(); err != nil {
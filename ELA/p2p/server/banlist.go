@@ -0,0 +1,69 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// banListFileName is the name of the file to store banned hosts in the data
+// directory, so bans persist across restarts.
+const banListFileName = "banlist.json"
+
+// loadBanList loads the banned hosts and their ban end time from the given
+// file, expired bans are ignored.  If the file is missing or malformed, an
+// empty list is returned.
+func loadBanList(file string) map[string]time.Time {
+	banned := make(map[string]time.Time)
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warnf("Failed to read ban list %s: %v", file, err)
+		}
+		return banned
+	}
+
+	var list map[string]int64
+	if err := json.Unmarshal(data, &list); err != nil {
+		log.Warnf("Failed to parse ban list %s: %v", file, err)
+		return banned
+	}
+
+	now := time.Now()
+	for host, until := range list {
+		banEnd := time.Unix(until, 0)
+		if banEnd.After(now) {
+			banned[host] = banEnd
+		}
+	}
+	return banned
+}
+
+// saveBanList saves the banned hosts and their ban end time to the given
+// file, expired bans are removed from the list.
+func saveBanList(file string, banned map[string]time.Time) {
+	now := time.Now()
+	list := make(map[string]int64, len(banned))
+	for host, banEnd := range banned {
+		if banEnd.Before(now) {
+			delete(banned, host)
+			continue
+		}
+		list[host] = banEnd.Unix()
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		log.Errorf("Failed to encode ban list %s: %v", file, err)
+		return
+	}
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		log.Errorf("Failed to write ban list %s: %v", file, err)
+	}
+}
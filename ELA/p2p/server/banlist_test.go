@@ -0,0 +1,60 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestBanList ensures banned hosts are saved and loaded as expected, and
+// expired bans are dropped.
+func TestBanList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "banlist")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, banListFileName)
+
+	// Missing file loads an empty list.
+	if banned := loadBanList(file); len(banned) != 0 {
+		t.Fatalf("loadBanList: got %d hosts, want 0", len(banned))
+	}
+
+	now := time.Now()
+	banned := map[string]time.Time{
+		"127.0.0.1": now.Add(time.Hour),
+		"::1":       now.Add(time.Minute),
+		"10.0.0.1":  now.Add(-time.Minute),
+	}
+	saveBanList(file, banned)
+	if _, ok := banned["10.0.0.1"]; ok {
+		t.Fatalf("saveBanList: expired ban not removed")
+	}
+
+	loaded := loadBanList(file)
+	if len(loaded) != 2 {
+		t.Fatalf("loadBanList: got %d hosts, want 2", len(loaded))
+	}
+	for host, banEnd := range banned {
+		if loaded[host].Unix() != banEnd.Unix() {
+			t.Errorf("loadBanList: host %s ban end %v, want %v", host,
+				loaded[host], banEnd)
+		}
+	}
+
+	// Malformed file loads an empty list.
+	if err := ioutil.WriteFile(file, []byte("{"), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if banned := loadBanList(file); len(banned) != 0 {
+		t.Fatalf("loadBanList: got %d hosts, want 0", len(banned))
+	}
+}
@@ -82,6 +82,21 @@ type IServer interface {
 	// error.
 	DisconnectByAddr(addr string) error
 
+	// BanHost bans the provided host until the given time, and disconnects
+	// peers connected from or to the host.  If banEnd is zero, the host will
+	// be banned for the configured ban duration.
+	BanHost(host string, banEnd time.Time) error
+
+	// UnbanHost removes the provided host from the banned list.  Attempting
+	// to unban a host that is not banned will return an error.
+	UnbanHost(host string) error
+
+	// BannedHosts returns the banned hosts and the time their bans end.
+	BannedHosts() map[string]time.Time
+
+	// ClearBanned removes all hosts from the banned list.
+	ClearBanned()
+
 	// ConnectedCount returns the number of currently connected peers.
 	ConnectedCount() int32
 
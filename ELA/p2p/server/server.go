@@ -11,6 +11,7 @@ import (
 	"math/rand"
 	"net"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -129,6 +130,7 @@ type server struct {
 	wg          sync.WaitGroup
 	quit        chan struct{}
 	nat         NAT
+	banListFile string
 }
 
 // IPeer extends the peer to maintain state shared by the server.
@@ -485,6 +487,7 @@ func (s *server) handleAddPeerMsg(state *peerState, sp *serverPeer) bool {
 
 		log.Infof("Peer %s is no longer banned", host)
 		delete(state.banned, host)
+		saveBanList(s.banListFile, state.banned)
 	}
 
 	// Limit max number of total peers.
@@ -584,6 +587,7 @@ func (s *server) handleBanPeerMsg(state *peerState, sp *serverPeer) {
 	direction := directionString(sp.Inbound())
 	log.Infof("Banned peer %s (%s) for %v", host, direction, s.cfg.BanDuration)
 	state.banned[host] = time.Now().Add(s.cfg.BanDuration)
+	saveBanList(s.banListFile, state.banned)
 }
 
 // handleBroadcastMsg deals with broadcasting messages to peers.  It is invoked
@@ -637,6 +641,22 @@ type removeNodeMsg struct {
 	reply chan error
 }
 
+type banHostMsg struct {
+	host   string
+	banEnd time.Time
+}
+
+type unbanHostMsg struct {
+	host  string
+	reply chan error
+}
+
+type getBannedMsg struct {
+	reply chan map[string]time.Time
+}
+
+type clearBannedMsg struct{}
+
 // handleQuery is the central handler for all queries and commands from other
 // goroutines related to peer state.
 func (s *server) handleQuery(state *peerState, querymsg interface{}) {
@@ -746,6 +766,44 @@ func (s *server) handleQuery(state *peerState, querymsg interface{}) {
 		}
 
 		msg.reply <- errors.New("peer not found")
+
+	case banHostMsg:
+		log.Infof("Banned host %s until %v", msg.host, msg.banEnd)
+		state.banned[msg.host] = msg.banEnd
+		saveBanList(s.banListFile, state.banned)
+
+		// Disconnect peers connected from or to the banned host.
+		state.forAllPeers(func(sp *serverPeer) {
+			host, _, err := net.SplitHostPort(sp.Addr())
+			if err == nil && host == msg.host {
+				sp.Disconnect()
+			}
+		})
+
+	case unbanHostMsg:
+		if _, ok := state.banned[msg.host]; !ok {
+			msg.reply <- errors.New("host not banned")
+			return
+		}
+		log.Infof("Unbanned host %s", msg.host)
+		delete(state.banned, msg.host)
+		saveBanList(s.banListFile, state.banned)
+		msg.reply <- nil
+
+	case getBannedMsg:
+		now := time.Now()
+		banned := make(map[string]time.Time, len(state.banned))
+		for host, banEnd := range state.banned {
+			if banEnd.After(now) {
+				banned[host] = banEnd
+			}
+		}
+		msg.reply <- banned
+
+	case clearBannedMsg:
+		log.Infof("Cleared %d banned hosts", len(state.banned))
+		state.banned = make(map[string]time.Time)
+		saveBanList(s.banListFile, state.banned)
 	}
 }
 
@@ -878,7 +936,7 @@ func (s *server) peerHandler() {
 		inboundPeers:    make(map[uint64]*serverPeer),
 		persistentPeers: make(map[uint64]*serverPeer),
 		outboundPeers:   make(map[uint64]*serverPeer),
-		banned:          make(map[string]time.Time),
+		banned:          loadBanList(s.banListFile),
 		outboundGroups:  make(map[string]int),
 	}
 
@@ -1226,6 +1284,61 @@ func (s *server) DisconnectByAddr(addr string) error {
 	return <-replyChan
 }
 
+// BanHost bans the provided host until the given time, and disconnects peers
+// connected from or to the host.  If banEnd is zero, the host will be banned
+// for the configured ban duration.  Bans are saved in the data directory and
+// persist across restarts.
+//
+// This function is safe for concurrent access and is part of the
+// IServer interface implementation.
+func (s *server) BanHost(host string, banEnd time.Time) error {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid host %s", host)
+	}
+	if banEnd.IsZero() {
+		banEnd = time.Now().Add(s.cfg.BanDuration)
+	}
+	if !banEnd.After(time.Now()) {
+		return errors.New("ban end time has passed")
+	}
+	s.query <- banHostMsg{host: ip.String(), banEnd: banEnd}
+	return nil
+}
+
+// UnbanHost removes the provided host from the banned list.  Attempting to
+// unban a host that is not banned will return an error.
+//
+// This function is safe for concurrent access and is part of the
+// IServer interface implementation.
+func (s *server) UnbanHost(host string) error {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid host %s", host)
+	}
+	replyChan := make(chan error)
+	s.query <- unbanHostMsg{host: ip.String(), reply: replyChan}
+	return <-replyChan
+}
+
+// BannedHosts returns the banned hosts and the time their bans end.
+//
+// This function is safe for concurrent access and is part of the
+// IServer interface implementation.
+func (s *server) BannedHosts() map[string]time.Time {
+	replyChan := make(chan map[string]time.Time)
+	s.query <- getBannedMsg{reply: replyChan}
+	return <-replyChan
+}
+
+// ClearBanned removes all hosts from the banned list.
+//
+// This function is safe for concurrent access and is part of the
+// IServer interface implementation.
+func (s *server) ClearBanned() {
+	s.query <- clearBannedMsg{}
+}
+
 // ConnectedPeers returns an array consisting of all connected peers.
 //
 // This function is safe for concurrent access and is part of the
@@ -1300,6 +1413,7 @@ func newServer(origCfg *Config) (*server, error) {
 		broadcast:   make(chan broadcastMsg, cfg.MaxPeers),
 		quit:        make(chan struct{}),
 		nat:         nat,
+		banListFile: filepath.Join(dataDir, banListFileName),
 	}
 	s.addrManager.SetCheckAddr(s.checkAddr)
 
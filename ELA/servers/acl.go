@@ -0,0 +1,307 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package servers
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastos/Elastos.ELA/common/config"
+	. "github.com/elastos/Elastos.ELA/servers/errors"
+)
+
+const (
+	// APIKeyHeader is the HTTP header carrying the API key of a request.
+	APIKeyHeader = "X-API-Key"
+
+	// APIKeyQuery is the URL query parameter carrying the API key of a
+	// request, for clients can not set headers such as WebSocket clients in
+	// browsers.
+	APIKeyQuery = "apikey"
+)
+
+// AccessError is the error of a request rejected by the access control.
+type AccessError struct {
+	Code    ServerErrCode
+	Message string
+}
+
+func (e *AccessError) Error() string {
+	return e.Message
+}
+
+// HTTPStatus returns the HTTP status code of the error.
+func (e *AccessError) HTTPStatus() int {
+	switch e.Code {
+	case InvalidToken:
+		return http.StatusUnauthorized
+	case RateLimited:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusForbidden
+	}
+}
+
+var (
+	errAuthFailed = &AccessError{InvalidToken, "Client authenticate failed"}
+	errIPDenied   = &AccessError{PermissionDenied, "Client ip is not allowed"}
+	errRateLimit  = &AccessError{RateLimited, "Too many requests"}
+)
+
+// Credential is a client of the RPC services with its own permissions.
+type Credential struct {
+	name      string
+	basicAuth *[sha256.Size]byte
+	apiKey    *[sha256.Size]byte
+	methods   []string
+	nets      []*net.IPNet
+	limiter   *rateLimiter
+}
+
+// Name returns the name of the credential.
+func (c *Credential) Name() string {
+	return c.name
+}
+
+// Allow checks if the credential is permitted to call the method, and takes
+// one request from its rate limit.
+func (c *Credential) Allow(method string) *AccessError {
+	if !c.permits(strings.ToLower(method)) {
+		return &AccessError{PermissionDenied,
+			"method " + method + " is not allowed"}
+	}
+	if c.limiter != nil && !c.limiter.allow() {
+		return errRateLimit
+	}
+	return nil
+}
+
+// Permits checks if the credential is permitted to call the method without
+// taking a request from its rate limit, it's used to decide if pushes of the
+// method are sent to the credential.
+func (c *Credential) Permits(method string) bool {
+	return c.permits(strings.ToLower(method))
+}
+
+func (c *Credential) permits(method string) bool {
+	for _, m := range c.methods {
+		if m == "*" || m == method {
+			return true
+		}
+		if strings.HasSuffix(m, "*") &&
+			strings.HasPrefix(method, m[:len(m)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Credential) allowIP(ip net.IP) bool {
+	if len(c.nets) == 0 {
+		return true
+	}
+	for _, n := range c.nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessControl authorizes requests of the RPC services by the credentials
+// in RpcConfiguration. Methods are named the same way in all services, by
+// the JSON-RPC method name, the WebSocket action name, the REST v1 action
+// name and the lower case operation ID of REST v2 routes.
+type AccessControl struct {
+	credentials []*Credential
+	anonymous   *Credential
+}
+
+// Authenticate returns the credential of a request by the remote address,
+// the Authorization header and the API key of it. A request without
+// Authorization and API key is taken as anonymous.
+func (a *AccessControl) Authenticate(remoteAddr, authorization,
+	apiKey string) (*Credential, *AccessError) {
+	var credential *Credential
+	switch {
+	case apiKey != "":
+		hash := sha256.Sum256([]byte(apiKey))
+		for _, c := range a.credentials {
+			if c.apiKey != nil &&
+				subtle.ConstantTimeCompare(hash[:], c.apiKey[:]) == 1 {
+				credential = c
+			}
+		}
+	case authorization != "":
+		hash := sha256.Sum256([]byte(authorization))
+		for _, c := range a.credentials {
+			if c.basicAuth != nil &&
+				subtle.ConstantTimeCompare(hash[:], c.basicAuth[:]) == 1 {
+				credential = c
+			}
+		}
+	default:
+		credential = a.anonymous
+	}
+	if credential == nil {
+		return nil, errAuthFailed
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return nil, errIPDenied
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !credential.allowIP(ip) {
+		return nil, errIPDenied
+	}
+	return credential, nil
+}
+
+// AuthenticateRequest returns the credential of an HTTP request, the API key
+// is taken from the APIKeyHeader header or the APIKeyQuery query parameter.
+func (a *AccessControl) AuthenticateRequest(
+	r *http.Request) (*Credential, *AccessError) {
+	apiKey := r.Header.Get(APIKeyHeader)
+	if apiKey == "" {
+		apiKey = r.URL.Query().Get(APIKeyQuery)
+	}
+	return a.Authenticate(r.RemoteAddr, r.Header.Get("Authorization"), apiKey)
+}
+
+// NewAccessControl creates the access control of the credentials in the
+// configuration, nil is returned if there is no credential configured and
+// then the User, Pass and WhiteIPList settings work as before.
+func NewAccessControl(cfg *config.RpcConfiguration) (*AccessControl, error) {
+	if len(cfg.Credentials) == 0 {
+		return nil, nil
+	}
+
+	a := &AccessControl{}
+	apiKeys := make(map[string]struct{})
+	users := make(map[string]struct{})
+	for i, c := range cfg.Credentials {
+		credential := &Credential{name: c.Name}
+		if credential.name == "" {
+			credential.name = "credential" + strconv.Itoa(i)
+		}
+
+		if c.APIKey != "" {
+			if _, ok := apiKeys[c.APIKey]; ok {
+				return nil, fmt.Errorf("duplicated API key of %s",
+					credential.name)
+			}
+			apiKeys[c.APIKey] = struct{}{}
+			hash := sha256.Sum256([]byte(c.APIKey))
+			credential.apiKey = &hash
+		}
+		if c.User != "" {
+			if _, ok := users[c.User]; ok {
+				return nil, fmt.Errorf("duplicated user of %s",
+					credential.name)
+			}
+			users[c.User] = struct{}{}
+			auth := "Basic " + base64.StdEncoding.EncodeToString(
+				[]byte(c.User+":"+c.Pass))
+			hash := sha256.Sum256([]byte(auth))
+			credential.basicAuth = &hash
+		}
+		if credential.apiKey == nil && credential.basicAuth == nil {
+			if a.anonymous != nil {
+				return nil, errors.New("only one anonymous credential " +
+					"is allowed")
+			}
+			a.anonymous = credential
+		}
+
+		for _, m := range c.Methods {
+			credential.methods = append(credential.methods, strings.ToLower(m))
+		}
+
+		for _, s := range c.AllowedCIDRs {
+			ipNet, err := parseCIDR(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %s of %s", s,
+					credential.name)
+			}
+			credential.nets = append(credential.nets, ipNet)
+		}
+
+		if c.RateLimit < 0 || c.RateBurst < 0 {
+			return nil, fmt.Errorf("invalid rate limit of %s",
+				credential.name)
+		}
+		if c.RateLimit > 0 {
+			burst := c.RateBurst
+			if burst == 0 {
+				burst = int(math.Ceil(c.RateLimit))
+			}
+			credential.limiter = newRateLimiter(c.RateLimit, burst)
+		}
+
+		a.credentials = append(a.credentials, credential)
+	}
+	return a, nil
+}
+
+// parseCIDR parses a CIDR or a single IP address.
+func parseCIDR(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, errors.New("invalid IP address")
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(s)
+	return ipNet, err
+}
+
+// rateLimiter is a token bucket limiting the rate of requests.
+type rateLimiter struct {
+	mtx    sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (l *rateLimiter) allow() bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package servers
+
+import (
+	"encoding/base64"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/elastos/Elastos.ELA/common/config"
+	. "github.com/elastos/Elastos.ELA/servers/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func basicAuth(user, pass string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+pass))
+}
+
+func TestAccessControl(t *testing.T) {
+	acl, err := NewAccessControl(&config.RpcConfiguration{
+		Credentials: []config.RpcCredential{
+			{
+				Name:         "explorer",
+				APIKey:       "key",
+				Methods:      []string{"Get*", "listproducers"},
+				AllowedCIDRs: []string{"10.0.0.0/8"},
+			},
+			{
+				Name:         "admin",
+				User:         "user",
+				Pass:         "pass",
+				Methods:      []string{"*"},
+				AllowedCIDRs: []string{"127.0.0.1"},
+			},
+			{
+				Methods: []string{"getblockcount"},
+			},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		authorization string
+		apiKey        string
+		method        string
+		credential    string
+		code          ServerErrCode
+	}{
+		{"api key", "10.1.2.3:1000", "", "key", "getblock", "explorer", Success},
+		{"api key exact method", "10.1.2.3:1000", "", "key", "ListProducers", "explorer", Success},
+		{"api key method denied", "10.1.2.3:1000", "", "key", "sendrawtransaction", "explorer", PermissionDenied},
+		{"api key ip denied", "192.168.0.1:1000", "", "key", "getblock", "", PermissionDenied},
+		{"unknown api key", "10.1.2.3:1000", "", "unknown", "getblock", "", InvalidToken},
+		{"api key before basic auth", "10.1.2.3:1000", basicAuth("user", "pass"), "key", "getblock", "explorer", Success},
+		{"basic auth", "127.0.0.1:1000", basicAuth("user", "pass"), "", "setloglevel", "admin", Success},
+		{"basic auth ip denied", "10.1.2.3:1000", basicAuth("user", "pass"), "", "setloglevel", "", PermissionDenied},
+		{"wrong password", "127.0.0.1:1000", basicAuth("user", "wrong"), "", "getblock", "", InvalidToken},
+		{"anonymous", "192.168.0.1:1000", "", "", "getblockcount", "credential2", Success},
+		{"anonymous method denied", "192.168.0.1:1000", "", "", "getblock", "credential2", PermissionDenied},
+		{"invalid remote address", "invalid", "", "key", "getblock", "", PermissionDenied},
+	}
+	for _, test := range tests {
+		credential, accessErr := acl.Authenticate(test.remoteAddr,
+			test.authorization, test.apiKey)
+		if accessErr == nil {
+			assert.Equal(t, test.credential, credential.Name(), test.name)
+			accessErr = credential.Allow(test.method)
+		} else {
+			assert.Empty(t, test.credential, test.name)
+		}
+		if test.code == Success {
+			assert.Nil(t, accessErr, test.name)
+		} else if assert.NotNil(t, accessErr, test.name) {
+			assert.Equal(t, test.code, accessErr.Code, test.name)
+		}
+	}
+
+	// the API key is also taken from the query of HTTP requests
+	r := httptest.NewRequest("GET", "/api/v1/block/height?apikey=key", nil)
+	r.RemoteAddr = "10.1.2.3:1000"
+	credential, accessErr := acl.AuthenticateRequest(r)
+	if assert.Nil(t, accessErr) {
+		assert.Equal(t, "explorer", credential.Name())
+	}
+	r = httptest.NewRequest("GET", "/api/v1/block/height", nil)
+	r.RemoteAddr = "10.1.2.3:1000"
+	r.Header.Set(APIKeyHeader, "key")
+	credential, accessErr = acl.AuthenticateRequest(r)
+	if assert.Nil(t, accessErr) {
+		assert.Equal(t, "explorer", credential.Name())
+	}
+
+	// no anonymous credential
+	acl, err = NewAccessControl(&config.RpcConfiguration{
+		Credentials: []config.RpcCredential{{APIKey: "key", Methods: []string{"*"}}},
+	})
+	assert.NoError(t, err)
+	_, accessErr = acl.Authenticate("127.0.0.1:1000", "", "")
+	if assert.NotNil(t, accessErr) {
+		assert.Equal(t, InvalidToken, accessErr.Code)
+	}
+}
+
+func TestNewAccessControl(t *testing.T) {
+	acl, err := NewAccessControl(&config.RpcConfiguration{})
+	assert.NoError(t, err)
+	assert.Nil(t, acl)
+
+	for _, credentials := range [][]config.RpcCredential{
+		{{APIKey: "key"}, {APIKey: "key"}},
+		{{User: "user", Pass: "a"}, {User: "user", Pass: "b"}},
+		{{Name: "a"}, {Name: "b"}},
+		{{APIKey: "key", AllowedCIDRs: []string{"10.0.0.0/33"}}},
+		{{APIKey: "key", AllowedCIDRs: []string{"localhost"}}},
+		{{APIKey: "key", RateLimit: -1}},
+		{{APIKey: "key", RateBurst: -1}},
+	} {
+		_, err := NewAccessControl(&config.RpcConfiguration{
+			Credentials: credentials,
+		})
+		assert.Error(t, err, credentials)
+	}
+
+	ipNet, err := parseCIDR("::1")
+	assert.NoError(t, err)
+	assert.Equal(t, "::1/128", ipNet.String())
+	ipNet, err = parseCIDR("10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1/32", ipNet.String())
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(2, 3)
+
+	// the burst is allowed at once
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.allow())
+	}
+	assert.False(t, l.allow())
+
+	// tokens are refilled by the rate
+	l.last = l.last.Add(-time.Second)
+	assert.True(t, l.allow())
+	assert.True(t, l.allow())
+	assert.False(t, l.allow())
+
+	// tokens never exceed the burst
+	l.last = l.last.Add(-time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.allow())
+	}
+	assert.False(t, l.allow())
+
+	// the default burst is the rate rounded up
+	acl, err := NewAccessControl(&config.RpcConfiguration{
+		Credentials: []config.RpcCredential{
+			{Methods: []string{"*"}, RateLimit: 1.5},
+		},
+	})
+	assert.NoError(t, err)
+	credential, accessErr := acl.Authenticate("127.0.0.1:1000", "", "")
+	assert.Nil(t, accessErr)
+	assert.Nil(t, credential.Allow("getblock"))
+	assert.Nil(t, credential.Allow("getblock"))
+	accessErr = credential.Allow("getblock")
+	if assert.NotNil(t, accessErr) {
+		assert.Equal(t, RateLimited, accessErr.Code)
+	}
+	// permission checks of pushes don't take requests
+	assert.True(t, credential.Permits("sendNewTransaction"))
+}
+
+func TestCheckRPCServiceLevel(t *testing.T) {
+	defer func(params *config.Params, acl *AccessControl) {
+		ChainParams, ACL = params, acl
+	}(ChainParams, ACL)
+
+	ChainParams = &config.Params{RPCServiceLevel: config.QueryOnly.String()}
+	ACL = nil
+	assert.NotNil(t, checkRPCServiceLevel(config.WalletPermitted))
+	assert.Nil(t, checkRPCServiceLevel(config.QueryOnly))
+
+	ChainParams.RPCServiceLevel = config.WalletPermitted.String()
+	assert.Nil(t, checkRPCServiceLevel(config.WalletPermitted))
+	assert.NotNil(t, checkRPCServiceLevel(config.ConfigurationPermitted))
+
+	// the methods of credentials decide once access control is configured
+	ACL = &AccessControl{}
+	assert.Nil(t, checkRPCServiceLevel(config.ConfigurationPermitted))
+}
@@ -146,6 +146,40 @@ type PeerInfo struct {
 	LastPingMicros int64  `json:"lastpingmicros"`
 }
 
+type RpcPeerInfo struct {
+	ID             uint64 `json:"id"`
+	Addr           string `json:"addr"`
+	Services       string `json:"services"`
+	RelayTx        bool   `json:"relaytx"`
+	LastSend       int64  `json:"lastsend"`
+	LastRecv       int64  `json:"lastrecv"`
+	BytesSent      uint64 `json:"bytessent"`
+	BytesRecv      uint64 `json:"bytesrecv"`
+	ConnTime       int64  `json:"conntime"`
+	TimeOffset     int64  `json:"timeoffset"`
+	Version        uint32 `json:"version"`
+	Inbound        bool   `json:"inbound"`
+	StartingHeight uint32 `json:"startingheight"`
+	Height         uint32 `json:"height"`
+	BanScore       uint32 `json:"banscore"`
+	PingTime       int64  `json:"pingtime"`
+}
+
+type TxOutSetInfo struct {
+	Height            uint32            `json:"height"`
+	BestBlock         string            `json:"bestblock"`
+	TxOuts            uint64            `json:"txouts"`
+	Addresses         uint64            `json:"addresses"`
+	Total             map[string]string `json:"total"`
+	Hash              string            `json:"hash"`
+	CirculationAmount string            `json:"circulationamount"`
+}
+
+type BannedHostInfo struct {
+	Host        string `json:"host"`
+	BannedUntil int64  `json:"banneduntil"`
+}
+
 type ArbitratorGroupInfo struct {
 	OnDutyArbitratorIndex int      `json:"ondutyarbitratorindex"`
 	Arbitrators           []string `json:"arbitrators"`
@@ -292,6 +326,24 @@ type UTXOInfo struct {
 	Confirmations uint32 `json:"confirmations"`
 }
 
+type AddressTransactionInfo struct {
+	TxID   string `json:"txid"`
+	Height uint32 `json:"height"`
+	Delta  string `json:"delta"`
+}
+
+type AddressTransactionsInfo struct {
+	Transactions []AddressTransactionInfo `json:"transactions"`
+	TotalCount   uint32                   `json:"totalcount"`
+}
+
+type SpendingInfo struct {
+	Spent  bool    `json:"spent"`
+	TxID   string  `json:"txid,omitempty"`
+	VIn    *uint16 `json:"vin,omitempty"`
+	Height uint32  `json:"height,omitempty"`
+}
+
 type SidechainIllegalDataInfo struct {
 	IllegalType         uint8    `json:"illegaltype"`
 	Height              uint32   `json:"height"`
@@ -39,6 +39,8 @@ const (
 	InvalidMethod        ServerErrCode = 42001
 	InvalidParams        ServerErrCode = 42002
 	InvalidToken         ServerErrCode = 42003
+	PermissionDenied     ServerErrCode = 42004
+	RateLimited          ServerErrCode = 42005
 	InvalidTransaction   ServerErrCode = 43001
 	InvalidAsset         ServerErrCode = 43002
 	UnknownTransaction   ServerErrCode = 44001
@@ -56,6 +58,8 @@ var ErrMap = map[ServerErrCode]string{
 	InvalidMethod:               "Invalid method",
 	InvalidParams:               "Invalid Params",
 	InvalidToken:                "Verify token error",
+	PermissionDenied:            "Permission denied",
+	RateLimited:                 "Too many requests",
 	InvalidTransaction:          "Invalid transaction",
 	InvalidAsset:                "Invalid asset",
 	UnknownTransaction:          "Unknown Transaction",
@@ -35,6 +35,8 @@ func TestErrCode_Message(t *testing.T) {
 		InvalidMethod,
 		InvalidParams,
 		InvalidToken,
+		PermissionDenied,
+		RateLimited,
 		InvalidTransaction,
 		InvalidAsset,
 		UnknownTransaction,
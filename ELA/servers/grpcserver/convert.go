@@ -0,0 +1,422 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package grpcserver
+
+import (
+	"github.com/elastos/Elastos.ELA/servers"
+	"github.com/elastos/Elastos.ELA/servers/grpcserver/pb"
+)
+
+func toBlock(info *servers.BlockInfo) *pb.Block {
+	block := &pb.Block{
+		Hash:              info.Hash,
+		Confirmations:     info.Confirmations,
+		Size:              info.Size,
+		Height:            info.Height,
+		Version:           info.Version,
+		MerkleRoot:        info.MerkleRoot,
+		Time:              info.Time,
+		Nonce:             info.Nonce,
+		Bits:              info.Bits,
+		Difficulty:        info.Difficulty,
+		PreviousBlockHash: info.PreviousBlockHash,
+		NextBlockHash:     info.NextBlockHash,
+		AuxPow:            info.AuxPow,
+		MinerInfo:         info.MinerInfo,
+	}
+	for _, tx := range info.Tx {
+		switch t := tx.(type) {
+		case string:
+			block.TxHashes = append(block.TxHashes, t)
+		case *servers.TransactionContextInfo:
+			block.TxHashes = append(block.TxHashes, t.TxID)
+			block.Transactions = append(block.Transactions, toTransaction(t))
+		}
+	}
+	return block
+}
+
+func toTransaction(info *servers.TransactionContextInfo) *pb.Transaction {
+	tx := toTransactionInfo(info.TransactionInfo)
+	tx.BlockHash = info.BlockHash
+	tx.Confirmations = info.Confirmations
+	tx.Time = info.Time
+	tx.BlockTime = info.BlockTime
+	return tx
+}
+
+func toTransactionInfo(info *servers.TransactionInfo) *pb.Transaction {
+	tx := &pb.Transaction{
+		Txid:           info.TxID,
+		Hash:           info.Hash,
+		Size:           info.Size,
+		Vsize:          info.VSize,
+		Version:        uint32(info.Version),
+		Type:           uint32(info.TxType),
+		PayloadVersion: uint32(info.PayloadVersion),
+		Payload:        toPayload(info.Payload),
+		LockTime:       info.LockTime,
+	}
+	for _, a := range info.Attributes {
+		tx.Attributes = append(tx.Attributes, &pb.Attribute{
+			Usage: uint32(a.Usage),
+			Data:  a.Data,
+		})
+	}
+	for _, i := range info.Inputs {
+		tx.Inputs = append(tx.Inputs, &pb.Input{
+			Txid:     i.TxID,
+			Vout:     uint32(i.VOut),
+			Sequence: i.Sequence,
+		})
+	}
+	for _, o := range info.Outputs {
+		tx.Outputs = append(tx.Outputs, toOutput(&o))
+	}
+	for _, p := range info.Programs {
+		tx.Programs = append(tx.Programs, &pb.Program{
+			Code:      p.Code,
+			Parameter: p.Parameter,
+		})
+	}
+	return tx
+}
+
+func toOutput(info *servers.OutputInfo) *pb.Output {
+	output := &pb.Output{
+		Value:      info.Value,
+		N:          info.Index,
+		Address:    info.Address,
+		AssetId:    info.AssetID,
+		OutputLock: info.OutputLock,
+		Type:       info.OutputType,
+	}
+	switch p := info.OutputPayload.(type) {
+	case *servers.DefaultOutputInfo:
+		output.Payload = &pb.Output_DefaultOutput{
+			DefaultOutput: &pb.DefaultOutput{},
+		}
+	case *servers.VoteOutputInfo:
+		vote := &pb.VoteOutput{Version: uint32(p.Version)}
+		for _, content := range p.Contents {
+			c := &pb.VoteContent{VoteType: uint32(content.VoteType)}
+			for _, cv := range content.CandidatesInfo {
+				c.Candidates = append(c.Candidates, &pb.CandidateVotes{
+					Candidate: cv.Candidate,
+					Votes:     cv.Votes,
+				})
+			}
+			vote.Contents = append(vote.Contents, c)
+		}
+		output.Payload = &pb.Output_VoteOutput{VoteOutput: vote}
+	}
+	return output
+}
+
+// toPayload converts the payload information returned by the JSON-RPC
+// interface, nil is returned for transaction types without payload
+// information.
+func toPayload(info servers.PayloadInfo) *pb.Payload {
+	switch p := info.(type) {
+	case *servers.CoinbaseInfo:
+		return &pb.Payload{Payload: &pb.Payload_Coinbase{
+			Coinbase: &pb.Coinbase{CoinbaseData: p.CoinbaseData},
+		}}
+	case *servers.RegisterAssetInfo:
+		return &pb.Payload{Payload: &pb.Payload_RegisterAsset{
+			RegisterAsset: &pb.RegisterAsset{
+				Asset: &pb.Asset{
+					Name:        p.Asset.Name,
+					Description: p.Asset.Description,
+					Precision:   uint32(p.Asset.Precision),
+					AssetType:   uint32(p.Asset.AssetType),
+					RecordType:  uint32(p.Asset.RecordType),
+				},
+				Amount:     p.Amount,
+				Controller: p.Controller,
+			},
+		}}
+	case *servers.SideChainPowInfo:
+		return &pb.Payload{Payload: &pb.Payload_SideChainPow{
+			SideChainPow: &pb.SideChainPow{
+				BlockHeight:     p.BlockHeight,
+				SideBlockHash:   p.SideBlockHash,
+				SideGenesisHash: p.SideGenesisHash,
+				Signature:       p.Signature,
+			},
+		}}
+	case *servers.WithdrawFromSideChainInfo:
+		return &pb.Payload{Payload: &pb.Payload_WithdrawFromSideChain{
+			WithdrawFromSideChain: &pb.WithdrawFromSideChain{
+				BlockHeight:                p.BlockHeight,
+				GenesisBlockAddress:        p.GenesisBlockAddress,
+				SideChainTransactionHashes: p.SideChainTransactionHashes,
+			},
+		}}
+	case *servers.TransferCrossChainAssetInfo:
+		amounts := make([]string, 0, len(p.CrossChainAmounts))
+		for _, amount := range p.CrossChainAmounts {
+			amounts = append(amounts, amount.String())
+		}
+		return &pb.Payload{Payload: &pb.Payload_TransferCrossChainAsset{
+			TransferCrossChainAsset: &pb.TransferCrossChainAsset{
+				CrossChainAddresses: p.CrossChainAddresses,
+				OutputIndexes:       p.OutputIndexes,
+				CrossChainAmounts:   amounts,
+			},
+		}}
+	case *servers.ProducerInfo:
+		return &pb.Payload{Payload: &pb.Payload_ProducerInfo{
+			ProducerInfo: &pb.ProducerInfo{
+				OwnerPublicKey: p.OwnerPublicKey,
+				NodePublicKey:  p.NodePublicKey,
+				NickName:       p.NickName,
+				Url:            p.Url,
+				Location:       p.Location,
+				NetAddress:     p.NetAddress,
+				Signature:      p.Signature,
+			},
+		}}
+	case *servers.CancelProducerInfo:
+		return &pb.Payload{Payload: &pb.Payload_CancelProducer{
+			CancelProducer: &pb.CancelProducer{
+				OwnerPublicKey: p.OwnerPublicKey,
+				Signature:      p.Signature,
+			},
+		}}
+	case *servers.InactiveArbitratorsInfo:
+		return &pb.Payload{Payload: &pb.Payload_InactiveArbitrators{
+			InactiveArbitrators: &pb.InactiveArbitrators{
+				Sponsor:     p.Sponsor,
+				Arbitrators: p.Arbitrators,
+				BlockHeight: p.BlockHeight,
+			},
+		}}
+	case *servers.ActivateProducerInfo:
+		return &pb.Payload{Payload: &pb.Payload_ActivateProducer{
+			ActivateProducer: &pb.ActivateProducer{
+				NodePublicKey: p.NodePublicKey,
+				Signature:     p.Signature,
+			},
+		}}
+	case *servers.UpdateVersionInfo:
+		return &pb.Payload{Payload: &pb.Payload_UpdateVersion{
+			UpdateVersion: &pb.UpdateVersion{
+				StartHeight: p.StartHeight,
+				EndHeight:   p.EndHeight,
+			},
+		}}
+	case *servers.CRInfo:
+		return &pb.Payload{Payload: &pb.Payload_CrInfo{
+			CrInfo: &pb.CRInfo{
+				Code:      p.Code,
+				Cid:       p.CID,
+				Did:       p.DID,
+				NickName:  p.NickName,
+				Url:       p.Url,
+				Location:  p.Location,
+				Signature: p.Signature,
+			},
+		}}
+	case *servers.UnregisterCRInfo:
+		return &pb.Payload{Payload: &pb.Payload_UnregisterCr{
+			UnregisterCr: &pb.UnregisterCR{
+				Cid:       p.CID,
+				Signature: p.Signature,
+			},
+		}}
+	case *servers.CRCProposalInfo:
+		proposal := &pb.CRCProposal{
+			ProposalType:             p.ProposalType,
+			CategoryData:             p.CategoryData,
+			OwnerPublicKey:           p.OwnerPublicKey,
+			DraftHash:                p.DraftHash,
+			Recipient:                p.Recipient,
+			Signature:                p.Signature,
+			CrCouncilMemberDid:       p.CRCouncilMemberDID,
+			CrCouncilMemberSignature: p.CRCouncilMemberSignature,
+			Hash:                     p.Hash,
+		}
+		for _, b := range p.Budgets {
+			proposal.Budgets = append(proposal.Budgets, &pb.Budget{
+				Type:   b.Type,
+				Stage:  uint32(b.Stage),
+				Amount: b.Amount,
+			})
+		}
+		return &pb.Payload{Payload: &pb.Payload_CrcProposal{
+			CrcProposal: proposal,
+		}}
+	case *servers.CRCProposalReviewInfo:
+		return &pb.Payload{Payload: &pb.Payload_CrcProposalReview{
+			CrcProposalReview: &pb.CRCProposalReview{
+				ProposalHash: p.ProposalHash,
+				VoteResult:   p.VoteResult,
+				OpinionHash:  p.OpinionHash,
+				Did:          p.DID,
+				Sign:         p.Sign,
+			},
+		}}
+	case *servers.CRCProposalTrackingInfo:
+		return &pb.Payload{Payload: &pb.Payload_CrcProposalTracking{
+			CrcProposalTracking: &pb.CRCProposalTracking{
+				ProposalTrackingType:        p.ProposalTrackingType,
+				ProposalHash:                p.ProposalHash,
+				MessageHash:                 p.MessageHash,
+				Stage:                       uint32(p.Stage),
+				OwnerPublicKey:              p.OwnerPublicKey,
+				NewOwnerPublicKey:           p.NewOwnerPublicKey,
+				OwnerSignature:              p.OwnerSignature,
+				NewOwnerSignature:           p.NewOwnerSignature,
+				SecretaryGeneralOpinionHash: p.SecretaryGeneralOpinionHash,
+				SecretaryGeneralSignature:   p.SecretaryGeneralSignature,
+			},
+		}}
+	case *servers.CRCProposalWithdrawInfo:
+		return &pb.Payload{Payload: &pb.Payload_CrcProposalWithdraw{
+			CrcProposalWithdraw: &pb.CRCProposalWithdraw{
+				ProposalHash:   p.ProposalHash,
+				OwnerPublicKey: p.OwnerPublicKey,
+				Signature:      p.Signature,
+			},
+		}}
+	}
+	return nil
+}
+
+func toProducers(info *servers.RpcProducersInfo) *pb.Producers {
+	producers := &pb.Producers{
+		TotalVotes: info.TotalVotes,
+		TotalCount: info.TotalCounts,
+	}
+	for _, p := range info.ProducerInfoSlice {
+		producers.Producers = append(producers.Producers, &pb.Producer{
+			OwnerPublicKey: p.OwnerPublicKey,
+			NodePublicKey:  p.NodePublicKey,
+			Nickname:       p.Nickname,
+			Url:            p.Url,
+			Location:       p.Location,
+			Active:         p.Active,
+			Votes:          p.Votes,
+			State:          p.State,
+			RegisterHeight: p.RegisterHeight,
+			CancelHeight:   p.CancelHeight,
+			InactiveHeight: p.InactiveHeight,
+			IllegalHeight:  p.IllegalHeight,
+			Index:          p.Index,
+		})
+	}
+	return producers
+}
+
+func toArbitersInfo(info *servers.RpcArbitersInfo) *pb.ArbitersInfo {
+	return &pb.ArbitersInfo{
+		Arbiters:               info.Arbiters,
+		Candidates:             info.Candidates,
+		NextArbiters:           info.NextArbiters,
+		NextCandidates:         info.NextCandidates,
+		OnDutyArbiter:          info.OnDutyArbiter,
+		CurrentTurnStartHeight: int64(info.CurrentTurnStartHeight),
+		NextTurnStartHeight:    int64(info.NextTurnStartHeight),
+	}
+}
+
+func toCRCandidates(info *servers.RpcCrCandidatesInfo) *pb.CRCandidates {
+	candidates := &pb.CRCandidates{
+		TotalVotes: info.TotalVotes,
+		TotalCount: info.TotalCounts,
+	}
+	for _, c := range info.CRCandidateInfoSlice {
+		candidates.Candidates = append(candidates.Candidates, &pb.CRCandidate{
+			Code:           c.Code,
+			Cid:            c.CID,
+			Did:            c.DID,
+			Nickname:       c.NickName,
+			Url:            c.Url,
+			Location:       c.Location,
+			State:          c.State,
+			Votes:          c.Votes,
+			RegisterHeight: c.RegisterHeight,
+			CancelHeight:   c.CancelHeight,
+			Index:          c.Index,
+		})
+	}
+	return candidates
+}
+
+func toCRMembers(info *servers.RpcCrMembersInfo) *pb.CRMembers {
+	members := &pb.CRMembers{TotalCount: info.TotalCounts}
+	for _, m := range info.CRMemberInfoSlice {
+		members.Members = append(members.Members, &pb.CRMember{
+			Code:             m.Code,
+			Cid:              m.CID,
+			Did:              m.DID,
+			Nickname:         m.NickName,
+			Url:              m.Url,
+			Location:         m.Location,
+			ImpeachmentVotes: m.ImpeachmentVotes,
+			DepositAmount:    m.DepositAmount,
+			DepositAddress:   m.DepositAddress,
+			Penalty:          m.Penalty,
+			State:            m.State,
+			Index:            m.Index,
+		})
+	}
+	return members
+}
+
+func toCRProposalBaseStates(
+	info *servers.RpcCRProposalBaseStateInfo) *pb.CRProposalBaseStates {
+	proposals := &pb.CRProposalBaseStates{TotalCount: info.TotalCounts}
+	for _, p := range info.ProposalBaseStates {
+		proposals.Proposals = append(proposals.Proposals, &pb.CRProposalBaseState{
+			Status:             p.Status,
+			ProposalHash:       p.ProposalHash,
+			TxHash:             p.TxHash,
+			CrVotes:            p.CRVotes,
+			VotersRejectAmount: p.VotersRejectAmount,
+			RegisterHeight:     p.RegisterHeight,
+			TerminatedHeight:   p.TerminatedHeight,
+			TrackingCount:      uint32(p.TrackingCount),
+			ProposalOwner:      p.ProposalOwner,
+			Index:              p.Index,
+		})
+	}
+	return proposals
+}
+
+func toCRProposalState(info *servers.RpcCRProposalStateInfo) *pb.CRProposalState {
+	s := &info.ProposalState
+	proposal := &pb.CRCProposal{
+		ProposalType:       s.Proposal.ProposalType,
+		OwnerPublicKey:     s.Proposal.OwnerPublicKey,
+		CrCouncilMemberDid: s.Proposal.CRCouncilMemberDID,
+		DraftHash:          s.Proposal.DraftHash,
+		Recipient:          s.Proposal.Recipient,
+		Hash:               s.ProposalHash,
+	}
+	for _, b := range s.Proposal.Budgets {
+		proposal.Budgets = append(proposal.Budgets, &pb.Budget{
+			Type:   b.Type,
+			Stage:  uint32(b.Stage),
+			Amount: b.Amount,
+			Status: b.Status,
+		})
+	}
+	return &pb.CRProposalState{
+		Status:             s.Status,
+		Proposal:           proposal,
+		ProposalHash:       s.ProposalHash,
+		TxHash:             s.TxHash,
+		CrVotes:            s.CRVotes,
+		VotersRejectAmount: s.VotersRejectAmount,
+		RegisterHeight:     s.RegisterHeight,
+		TerminatedHeight:   s.TerminatedHeight,
+		TrackingCount:      uint32(s.TrackingCount),
+		ProposalOwner:      s.ProposalOwner,
+		AvailableAmount:    s.AvailableAmount,
+	}
+}
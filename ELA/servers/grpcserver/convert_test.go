@@ -0,0 +1,99 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package grpcserver
+
+import (
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/core/types/outputpayload"
+	"github.com/elastos/Elastos.ELA/servers"
+	"github.com/elastos/Elastos.ELA/servers/grpcserver/pb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToBlock(t *testing.T) {
+	tx := &servers.TransactionContextInfo{
+		TransactionInfo: &servers.TransactionInfo{
+			TxID:    "txid2",
+			Outputs: []servers.OutputInfo{{Value: "1", Index: 0}},
+		},
+		BlockHash:     "blockhash",
+		Confirmations: 3,
+	}
+	block := toBlock(&servers.BlockInfo{
+		Hash:   "blockhash",
+		Height: 100,
+		Tx:     []interface{}{"txid1", tx},
+	})
+	assert.Equal(t, "blockhash", block.Hash)
+	assert.Equal(t, uint32(100), block.Height)
+	assert.Equal(t, []string{"txid1", "txid2"}, block.TxHashes)
+	if assert.Len(t, block.Transactions, 1) {
+		assert.Equal(t, "txid2", block.Transactions[0].Txid)
+		assert.Equal(t, "blockhash", block.Transactions[0].BlockHash)
+		assert.Equal(t, uint32(3), block.Transactions[0].Confirmations)
+		assert.Len(t, block.Transactions[0].Outputs, 1)
+	}
+}
+
+func TestToOutput(t *testing.T) {
+	output := toOutput(&servers.OutputInfo{
+		Value:         "1.5",
+		Index:         1,
+		Address:       "address",
+		OutputPayload: &servers.DefaultOutputInfo{},
+	})
+	assert.Equal(t, "1.5", output.Value)
+	assert.Equal(t, uint32(1), output.N)
+	assert.IsType(t, &pb.Output_DefaultOutput{}, output.Payload)
+
+	output = toOutput(&servers.OutputInfo{
+		OutputType: 1,
+		OutputPayload: &servers.VoteOutputInfo{
+			Version: 1,
+			Contents: []servers.VoteContentInfo{{
+				VoteType: outputpayload.CRC,
+				CandidatesInfo: []servers.CandidateVotes{
+					{Candidate: "a", Votes: "1"},
+					{Candidate: "b", Votes: "2"},
+				},
+			}},
+		},
+	})
+	vote := output.Payload.(*pb.Output_VoteOutput).VoteOutput
+	assert.Equal(t, uint32(1), vote.Version)
+	if assert.Len(t, vote.Contents, 1) {
+		assert.Equal(t, uint32(outputpayload.CRC), vote.Contents[0].VoteType)
+		assert.Equal(t, []*pb.CandidateVotes{
+			{Candidate: "a", Votes: "1"},
+			{Candidate: "b", Votes: "2"},
+		}, vote.Contents[0].Candidates)
+	}
+}
+
+func TestToPayload(t *testing.T) {
+	assert.Nil(t, toPayload(nil))
+
+	p := toPayload(&servers.CoinbaseInfo{CoinbaseData: "data"})
+	assert.Equal(t, "data", p.GetCoinbase().CoinbaseData)
+
+	p = toPayload(&servers.CRCProposalInfo{
+		ProposalType: "normal",
+		Hash:         "hash",
+		Budgets: []servers.BudgetBaseInfo{
+			{Type: "imprest", Stage: 0, Amount: "1"},
+			{Type: "finalpayment", Stage: 1, Amount: "2"},
+		},
+	})
+	proposal := p.GetCrcProposal()
+	assert.Equal(t, "normal", proposal.ProposalType)
+	assert.Equal(t, "hash", proposal.Hash)
+	if assert.Len(t, proposal.Budgets, 2) {
+		assert.Equal(t, uint32(1), proposal.Budgets[1].Stage)
+		assert.Equal(t, "2", proposal.Budgets[1].Amount)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package grpcserver
+
+import (
+	"sync"
+
+	"github.com/elastos/Elastos.ELA/core/types"
+)
+
+// subscriberQueueSize is the max count of notifications queued for a
+// subscriber, the subscriber is dropped once the queue is full.
+const subscriberQueueSize = 256
+
+// subscriber is a stream subscribing to notifications.
+type subscriber struct {
+	queue    chan interface{}
+	overflow chan struct{}
+}
+
+type subscriberSet map[*subscriber]struct{}
+
+// notifier dispatches chain events to subscribed streams. Events are sent
+// to subscribers without blocking, so a slow stream will not block the
+// chain, instead it is closed when it can not keep up.
+type notifier struct {
+	mtx              sync.Mutex
+	blockSubscribers subscriberSet
+	txSubscribers    subscriberSet
+}
+
+func (n *notifier) subscribe(set subscriberSet) *subscriber {
+	sub := &subscriber{
+		queue:    make(chan interface{}, subscriberQueueSize),
+		overflow: make(chan struct{}),
+	}
+	n.mtx.Lock()
+	set[sub] = struct{}{}
+	n.mtx.Unlock()
+	return sub
+}
+
+func (n *notifier) unsubscribe(set subscriberSet, sub *subscriber) {
+	n.mtx.Lock()
+	delete(set, sub)
+	n.mtx.Unlock()
+}
+
+func (n *notifier) notify(set subscriberSet, data interface{}) {
+	n.mtx.Lock()
+	for sub := range set {
+		select {
+		case sub.queue <- data:
+		default:
+			close(sub.overflow)
+			delete(set, sub)
+		}
+	}
+	n.mtx.Unlock()
+}
+
+func (n *notifier) notifyBlock(block *types.Block) {
+	n.notify(n.blockSubscribers, block)
+}
+
+func (n *notifier) notifyTransaction(tx *types.Transaction) {
+	n.notify(n.txSubscribers, tx)
+}
+
+func newNotifier() *notifier {
+	return &notifier{
+		blockSubscribers: make(subscriberSet),
+		txSubscribers:    make(subscriberSet),
+	}
+}
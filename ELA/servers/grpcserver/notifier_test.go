@@ -0,0 +1,61 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package grpcserver
+
+import (
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/core/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifier(t *testing.T) {
+	n := newNotifier()
+	blockSub := n.subscribe(n.blockSubscribers)
+	txSub := n.subscribe(n.txSubscribers)
+
+	block := &types.Block{}
+	tx := &types.Transaction{}
+	n.notifyBlock(block)
+	n.notifyTransaction(tx)
+	assert.Equal(t, block, <-blockSub.queue)
+	assert.Equal(t, tx, <-txSub.queue)
+	assert.Len(t, blockSub.queue, 0)
+	assert.Len(t, txSub.queue, 0)
+
+	n.unsubscribe(n.txSubscribers, txSub)
+	n.notifyTransaction(tx)
+	assert.Len(t, txSub.queue, 0)
+	assert.Len(t, n.txSubscribers, 0)
+}
+
+func TestNotifier_Overflow(t *testing.T) {
+	n := newNotifier()
+	slow := n.subscribe(n.blockSubscribers)
+	fast := n.subscribe(n.blockSubscribers)
+
+	for i := 0; i < subscriberQueueSize; i++ {
+		n.notifyBlock(&types.Block{})
+		<-fast.queue
+	}
+	select {
+	case <-slow.overflow:
+		t.Fatal("subscriber dropped before the queue is full")
+	default:
+	}
+
+	// the slow subscriber is dropped without blocking the others
+	n.notifyBlock(&types.Block{})
+	<-slow.overflow
+	assert.Len(t, fast.queue, 1)
+	assert.Len(t, n.blockSubscribers, 1)
+	assert.Contains(t, n.blockSubscribers, fast)
+
+	// unsubscribing a dropped subscriber is harmless
+	n.unsubscribe(n.blockSubscribers, slow)
+	assert.Len(t, n.blockSubscribers, 1)
+}
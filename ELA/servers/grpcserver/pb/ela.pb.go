@@ -0,0 +1,5692 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+// The gRPC interface of an ELA node, it mirrors the JSON-RPC interface and
+// uses the same string formats for hashes, addresses and amounts.
+//
+// Regenerate ela.pb.go and ela_grpc.pb.go after editing this file:
+//
+//   protoc --go_out=paths=source_relative:. \
+//     --go-grpc_out=paths=source_relative:. ela.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        (unknown)
+// source: ela.proto
+
+package pb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{0}
+}
+
+type BestBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height uint32 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash   string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *BestBlock) Reset() {
+	*x = BestBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BestBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BestBlock) ProtoMessage() {}
+
+func (x *BestBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BestBlock.ProtoReflect.Descriptor instead.
+func (*BestBlock) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BestBlock) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *BestBlock) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type GetBlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Block:
+	//	*GetBlockRequest_Hash
+	//	*GetBlockRequest_Height
+	Block isGetBlockRequest_Block `protobuf_oneof:"block"`
+	// verbose indicates whether to return full transactions or only hashes.
+	Verbose bool `protobuf:"varint,3,opt,name=verbose,proto3" json:"verbose,omitempty"`
+}
+
+func (x *GetBlockRequest) Reset() {
+	*x = GetBlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRequest) ProtoMessage() {}
+
+func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *GetBlockRequest) GetBlock() isGetBlockRequest_Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (x *GetBlockRequest) GetHash() string {
+	if x, ok := x.GetBlock().(*GetBlockRequest_Hash); ok {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *GetBlockRequest) GetHeight() uint32 {
+	if x, ok := x.GetBlock().(*GetBlockRequest_Height); ok {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetBlockRequest) GetVerbose() bool {
+	if x != nil {
+		return x.Verbose
+	}
+	return false
+}
+
+type isGetBlockRequest_Block interface {
+	isGetBlockRequest_Block()
+}
+
+type GetBlockRequest_Hash struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3,oneof"`
+}
+
+type GetBlockRequest_Height struct {
+	Height uint32 `protobuf:"varint,2,opt,name=height,proto3,oneof"`
+}
+
+func (*GetBlockRequest_Hash) isGetBlockRequest_Block() {}
+
+func (*GetBlockRequest_Height) isGetBlockRequest_Block() {}
+
+type GetTransactionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetTransactionRequest) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+type SendRawTransactionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// data is the hex string of the serialized transaction.
+	Data string `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *SendRawTransactionRequest) Reset() {
+	*x = SendRawTransactionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendRawTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendRawTransactionRequest) ProtoMessage() {}
+
+func (x *SendRawTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendRawTransactionRequest.ProtoReflect.Descriptor instead.
+func (*SendRawTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SendRawTransactionRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type SendRawTransactionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+}
+
+func (x *SendRawTransactionResponse) Reset() {
+	*x = SendRawTransactionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendRawTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendRawTransactionResponse) ProtoMessage() {}
+
+func (x *SendRawTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendRawTransactionResponse.ProtoReflect.Descriptor instead.
+func (*SendRawTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SendRawTransactionResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+type EstimateSmartFeeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Confirmations uint32 `protobuf:"varint,1,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+}
+
+func (x *EstimateSmartFeeRequest) Reset() {
+	*x = EstimateSmartFeeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EstimateSmartFeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateSmartFeeRequest) ProtoMessage() {}
+
+func (x *EstimateSmartFeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateSmartFeeRequest.ProtoReflect.Descriptor instead.
+func (*EstimateSmartFeeRequest) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EstimateSmartFeeRequest) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+type FeeEstimate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FeeRate    int64   `protobuf:"varint,1,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+	Blocks     uint32  `protobuf:"varint,2,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	Confidence float64 `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"`
+}
+
+func (x *FeeEstimate) Reset() {
+	*x = FeeEstimate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeeEstimate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeEstimate) ProtoMessage() {}
+
+func (x *FeeEstimate) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeEstimate.ProtoReflect.Descriptor instead.
+func (*FeeEstimate) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FeeEstimate) GetFeeRate() int64 {
+	if x != nil {
+		return x.FeeRate
+	}
+	return 0
+}
+
+func (x *FeeEstimate) GetBlocks() uint32 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *FeeEstimate) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+type ListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// state filters items by state, the accepted values are the same as the
+	// JSON-RPC methods.
+	State string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Start int64  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	// limit is the max count of items returned, 0 means no limit.
+	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ListRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Block struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash              string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Confirmations     uint32   `protobuf:"varint,2,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Size              uint32   `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Height            uint32   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Version           uint32   `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	MerkleRoot        string   `protobuf:"bytes,6,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
+	Time              uint32   `protobuf:"varint,7,opt,name=time,proto3" json:"time,omitempty"`
+	Nonce             uint32   `protobuf:"varint,8,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Bits              uint32   `protobuf:"varint,9,opt,name=bits,proto3" json:"bits,omitempty"`
+	Difficulty        string   `protobuf:"bytes,10,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	PreviousBlockHash string   `protobuf:"bytes,11,opt,name=previous_block_hash,json=previousBlockHash,proto3" json:"previous_block_hash,omitempty"`
+	NextBlockHash     string   `protobuf:"bytes,12,opt,name=next_block_hash,json=nextBlockHash,proto3" json:"next_block_hash,omitempty"`
+	AuxPow            string   `protobuf:"bytes,13,opt,name=aux_pow,json=auxPow,proto3" json:"aux_pow,omitempty"`
+	MinerInfo         string   `protobuf:"bytes,14,opt,name=miner_info,json=minerInfo,proto3" json:"miner_info,omitempty"`
+	TxHashes          []string `protobuf:"bytes,15,rep,name=tx_hashes,json=txHashes,proto3" json:"tx_hashes,omitempty"`
+	// transactions is set only if verbose is requested.
+	Transactions []*Transaction `protobuf:"bytes,16,rep,name=transactions,proto3" json:"transactions,omitempty"`
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Block) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Block) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *Block) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Block) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Block) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Block) GetMerkleRoot() string {
+	if x != nil {
+		return x.MerkleRoot
+	}
+	return ""
+}
+
+func (x *Block) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Block) GetNonce() uint32 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *Block) GetBits() uint32 {
+	if x != nil {
+		return x.Bits
+	}
+	return 0
+}
+
+func (x *Block) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *Block) GetPreviousBlockHash() string {
+	if x != nil {
+		return x.PreviousBlockHash
+	}
+	return ""
+}
+
+func (x *Block) GetNextBlockHash() string {
+	if x != nil {
+		return x.NextBlockHash
+	}
+	return ""
+}
+
+func (x *Block) GetAuxPow() string {
+	if x != nil {
+		return x.AuxPow
+	}
+	return ""
+}
+
+func (x *Block) GetMinerInfo() string {
+	if x != nil {
+		return x.MinerInfo
+	}
+	return ""
+}
+
+func (x *Block) GetTxHashes() []string {
+	if x != nil {
+		return x.TxHashes
+	}
+	return nil
+}
+
+func (x *Block) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type Transactions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+}
+
+func (x *Transactions) Reset() {
+	*x = Transactions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transactions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transactions) ProtoMessage() {}
+
+func (x *Transactions) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transactions.ProtoReflect.Descriptor instead.
+func (*Transactions) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Transactions) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type Transaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txid           string       `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Hash           string       `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Size           uint32       `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Vsize          uint32       `protobuf:"varint,4,opt,name=vsize,proto3" json:"vsize,omitempty"`
+	Version        uint32       `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Type           uint32       `protobuf:"varint,6,opt,name=type,proto3" json:"type,omitempty"`
+	PayloadVersion uint32       `protobuf:"varint,7,opt,name=payload_version,json=payloadVersion,proto3" json:"payload_version,omitempty"`
+	Payload        *Payload     `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
+	Attributes     []*Attribute `protobuf:"bytes,9,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	Inputs         []*Input     `protobuf:"bytes,10,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs        []*Output    `protobuf:"bytes,11,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	LockTime       uint32       `protobuf:"varint,12,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	Programs       []*Program   `protobuf:"bytes,13,rep,name=programs,proto3" json:"programs,omitempty"`
+	BlockHash      string       `protobuf:"bytes,14,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Confirmations  uint32       `protobuf:"varint,15,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Time           uint32       `protobuf:"varint,16,opt,name=time,proto3" json:"time,omitempty"`
+	BlockTime      uint32       `protobuf:"varint,17,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Transaction) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *Transaction) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Transaction) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Transaction) GetVsize() uint32 {
+	if x != nil {
+		return x.Vsize
+	}
+	return 0
+}
+
+func (x *Transaction) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Transaction) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Transaction) GetPayloadVersion() uint32 {
+	if x != nil {
+		return x.PayloadVersion
+	}
+	return 0
+}
+
+func (x *Transaction) GetPayload() *Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Transaction) GetAttributes() []*Attribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Transaction) GetInputs() []*Input {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *Transaction) GetOutputs() []*Output {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *Transaction) GetLockTime() uint32 {
+	if x != nil {
+		return x.LockTime
+	}
+	return 0
+}
+
+func (x *Transaction) GetPrograms() []*Program {
+	if x != nil {
+		return x.Programs
+	}
+	return nil
+}
+
+func (x *Transaction) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *Transaction) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *Transaction) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Transaction) GetBlockTime() uint32 {
+	if x != nil {
+		return x.BlockTime
+	}
+	return 0
+}
+
+type Attribute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Usage uint32 `protobuf:"varint,1,opt,name=usage,proto3" json:"usage,omitempty"`
+	Data  string `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Attribute) Reset() {
+	*x = Attribute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Attribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attribute) ProtoMessage() {}
+
+func (x *Attribute) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attribute.ProtoReflect.Descriptor instead.
+func (*Attribute) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Attribute) GetUsage() uint32 {
+	if x != nil {
+		return x.Usage
+	}
+	return 0
+}
+
+func (x *Attribute) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type Input struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txid     string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Vout     uint32 `protobuf:"varint,2,opt,name=vout,proto3" json:"vout,omitempty"`
+	Sequence uint32 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *Input) Reset() {
+	*x = Input{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Input) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Input) ProtoMessage() {}
+
+func (x *Input) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Input.ProtoReflect.Descriptor instead.
+func (*Input) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Input) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *Input) GetVout() uint32 {
+	if x != nil {
+		return x.Vout
+	}
+	return 0
+}
+
+func (x *Input) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type Output struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value      string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	N          uint32 `protobuf:"varint,2,opt,name=n,proto3" json:"n,omitempty"`
+	Address    string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	AssetId    string `protobuf:"bytes,4,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	OutputLock uint32 `protobuf:"varint,5,opt,name=output_lock,json=outputLock,proto3" json:"output_lock,omitempty"`
+	Type       uint32 `protobuf:"varint,6,opt,name=type,proto3" json:"type,omitempty"`
+	// Types that are assignable to Payload:
+	//	*Output_DefaultOutput
+	//	*Output_VoteOutput
+	Payload isOutput_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *Output) Reset() {
+	*x = Output{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Output) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Output) ProtoMessage() {}
+
+func (x *Output) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Output.ProtoReflect.Descriptor instead.
+func (*Output) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Output) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Output) GetN() uint32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *Output) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Output) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *Output) GetOutputLock() uint32 {
+	if x != nil {
+		return x.OutputLock
+	}
+	return 0
+}
+
+func (x *Output) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (m *Output) GetPayload() isOutput_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *Output) GetDefaultOutput() *DefaultOutput {
+	if x, ok := x.GetPayload().(*Output_DefaultOutput); ok {
+		return x.DefaultOutput
+	}
+	return nil
+}
+
+func (x *Output) GetVoteOutput() *VoteOutput {
+	if x, ok := x.GetPayload().(*Output_VoteOutput); ok {
+		return x.VoteOutput
+	}
+	return nil
+}
+
+type isOutput_Payload interface {
+	isOutput_Payload()
+}
+
+type Output_DefaultOutput struct {
+	DefaultOutput *DefaultOutput `protobuf:"bytes,7,opt,name=default_output,json=defaultOutput,proto3,oneof"`
+}
+
+type Output_VoteOutput struct {
+	VoteOutput *VoteOutput `protobuf:"bytes,8,opt,name=vote_output,json=voteOutput,proto3,oneof"`
+}
+
+func (*Output_DefaultOutput) isOutput_Payload() {}
+
+func (*Output_VoteOutput) isOutput_Payload() {}
+
+type DefaultOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DefaultOutput) Reset() {
+	*x = DefaultOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DefaultOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefaultOutput) ProtoMessage() {}
+
+func (x *DefaultOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefaultOutput.ProtoReflect.Descriptor instead.
+func (*DefaultOutput) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{15}
+}
+
+type VoteOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version  uint32         `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Contents []*VoteContent `protobuf:"bytes,2,rep,name=contents,proto3" json:"contents,omitempty"`
+}
+
+func (x *VoteOutput) Reset() {
+	*x = VoteOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoteOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteOutput) ProtoMessage() {}
+
+func (x *VoteOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteOutput.ProtoReflect.Descriptor instead.
+func (*VoteOutput) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VoteOutput) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *VoteOutput) GetContents() []*VoteContent {
+	if x != nil {
+		return x.Contents
+	}
+	return nil
+}
+
+type VoteContent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VoteType   uint32            `protobuf:"varint,1,opt,name=vote_type,json=voteType,proto3" json:"vote_type,omitempty"`
+	Candidates []*CandidateVotes `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
+}
+
+func (x *VoteContent) Reset() {
+	*x = VoteContent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoteContent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteContent) ProtoMessage() {}
+
+func (x *VoteContent) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteContent.ProtoReflect.Descriptor instead.
+func (*VoteContent) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *VoteContent) GetVoteType() uint32 {
+	if x != nil {
+		return x.VoteType
+	}
+	return 0
+}
+
+func (x *VoteContent) GetCandidates() []*CandidateVotes {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+type CandidateVotes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Candidate string `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	Votes     string `protobuf:"bytes,2,opt,name=votes,proto3" json:"votes,omitempty"`
+}
+
+func (x *CandidateVotes) Reset() {
+	*x = CandidateVotes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CandidateVotes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandidateVotes) ProtoMessage() {}
+
+func (x *CandidateVotes) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandidateVotes.ProtoReflect.Descriptor instead.
+func (*CandidateVotes) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CandidateVotes) GetCandidate() string {
+	if x != nil {
+		return x.Candidate
+	}
+	return ""
+}
+
+func (x *CandidateVotes) GetVotes() string {
+	if x != nil {
+		return x.Votes
+	}
+	return ""
+}
+
+type Program struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code      string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Parameter string `protobuf:"bytes,2,opt,name=parameter,proto3" json:"parameter,omitempty"`
+}
+
+func (x *Program) Reset() {
+	*x = Program{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Program) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Program) ProtoMessage() {}
+
+func (x *Program) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Program.ProtoReflect.Descriptor instead.
+func (*Program) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Program) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Program) GetParameter() string {
+	if x != nil {
+		return x.Parameter
+	}
+	return ""
+}
+
+// Payload is the payload of a transaction, it is not set for transaction
+// types without payload information.
+type Payload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payload:
+	//	*Payload_Coinbase
+	//	*Payload_RegisterAsset
+	//	*Payload_SideChainPow
+	//	*Payload_WithdrawFromSideChain
+	//	*Payload_TransferCrossChainAsset
+	//	*Payload_ProducerInfo
+	//	*Payload_CancelProducer
+	//	*Payload_InactiveArbitrators
+	//	*Payload_ActivateProducer
+	//	*Payload_UpdateVersion
+	//	*Payload_CrInfo
+	//	*Payload_UnregisterCr
+	//	*Payload_CrcProposal
+	//	*Payload_CrcProposalReview
+	//	*Payload_CrcProposalTracking
+	//	*Payload_CrcProposalWithdraw
+	Payload isPayload_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *Payload) Reset() {
+	*x = Payload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Payload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payload) ProtoMessage() {}
+
+func (x *Payload) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payload.ProtoReflect.Descriptor instead.
+func (*Payload) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{20}
+}
+
+func (m *Payload) GetPayload() isPayload_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *Payload) GetCoinbase() *Coinbase {
+	if x, ok := x.GetPayload().(*Payload_Coinbase); ok {
+		return x.Coinbase
+	}
+	return nil
+}
+
+func (x *Payload) GetRegisterAsset() *RegisterAsset {
+	if x, ok := x.GetPayload().(*Payload_RegisterAsset); ok {
+		return x.RegisterAsset
+	}
+	return nil
+}
+
+func (x *Payload) GetSideChainPow() *SideChainPow {
+	if x, ok := x.GetPayload().(*Payload_SideChainPow); ok {
+		return x.SideChainPow
+	}
+	return nil
+}
+
+func (x *Payload) GetWithdrawFromSideChain() *WithdrawFromSideChain {
+	if x, ok := x.GetPayload().(*Payload_WithdrawFromSideChain); ok {
+		return x.WithdrawFromSideChain
+	}
+	return nil
+}
+
+func (x *Payload) GetTransferCrossChainAsset() *TransferCrossChainAsset {
+	if x, ok := x.GetPayload().(*Payload_TransferCrossChainAsset); ok {
+		return x.TransferCrossChainAsset
+	}
+	return nil
+}
+
+func (x *Payload) GetProducerInfo() *ProducerInfo {
+	if x, ok := x.GetPayload().(*Payload_ProducerInfo); ok {
+		return x.ProducerInfo
+	}
+	return nil
+}
+
+func (x *Payload) GetCancelProducer() *CancelProducer {
+	if x, ok := x.GetPayload().(*Payload_CancelProducer); ok {
+		return x.CancelProducer
+	}
+	return nil
+}
+
+func (x *Payload) GetInactiveArbitrators() *InactiveArbitrators {
+	if x, ok := x.GetPayload().(*Payload_InactiveArbitrators); ok {
+		return x.InactiveArbitrators
+	}
+	return nil
+}
+
+func (x *Payload) GetActivateProducer() *ActivateProducer {
+	if x, ok := x.GetPayload().(*Payload_ActivateProducer); ok {
+		return x.ActivateProducer
+	}
+	return nil
+}
+
+func (x *Payload) GetUpdateVersion() *UpdateVersion {
+	if x, ok := x.GetPayload().(*Payload_UpdateVersion); ok {
+		return x.UpdateVersion
+	}
+	return nil
+}
+
+func (x *Payload) GetCrInfo() *CRInfo {
+	if x, ok := x.GetPayload().(*Payload_CrInfo); ok {
+		return x.CrInfo
+	}
+	return nil
+}
+
+func (x *Payload) GetUnregisterCr() *UnregisterCR {
+	if x, ok := x.GetPayload().(*Payload_UnregisterCr); ok {
+		return x.UnregisterCr
+	}
+	return nil
+}
+
+func (x *Payload) GetCrcProposal() *CRCProposal {
+	if x, ok := x.GetPayload().(*Payload_CrcProposal); ok {
+		return x.CrcProposal
+	}
+	return nil
+}
+
+func (x *Payload) GetCrcProposalReview() *CRCProposalReview {
+	if x, ok := x.GetPayload().(*Payload_CrcProposalReview); ok {
+		return x.CrcProposalReview
+	}
+	return nil
+}
+
+func (x *Payload) GetCrcProposalTracking() *CRCProposalTracking {
+	if x, ok := x.GetPayload().(*Payload_CrcProposalTracking); ok {
+		return x.CrcProposalTracking
+	}
+	return nil
+}
+
+func (x *Payload) GetCrcProposalWithdraw() *CRCProposalWithdraw {
+	if x, ok := x.GetPayload().(*Payload_CrcProposalWithdraw); ok {
+		return x.CrcProposalWithdraw
+	}
+	return nil
+}
+
+type isPayload_Payload interface {
+	isPayload_Payload()
+}
+
+type Payload_Coinbase struct {
+	Coinbase *Coinbase `protobuf:"bytes,1,opt,name=coinbase,proto3,oneof"`
+}
+
+type Payload_RegisterAsset struct {
+	RegisterAsset *RegisterAsset `protobuf:"bytes,2,opt,name=register_asset,json=registerAsset,proto3,oneof"`
+}
+
+type Payload_SideChainPow struct {
+	SideChainPow *SideChainPow `protobuf:"bytes,3,opt,name=side_chain_pow,json=sideChainPow,proto3,oneof"`
+}
+
+type Payload_WithdrawFromSideChain struct {
+	WithdrawFromSideChain *WithdrawFromSideChain `protobuf:"bytes,4,opt,name=withdraw_from_side_chain,json=withdrawFromSideChain,proto3,oneof"`
+}
+
+type Payload_TransferCrossChainAsset struct {
+	TransferCrossChainAsset *TransferCrossChainAsset `protobuf:"bytes,5,opt,name=transfer_cross_chain_asset,json=transferCrossChainAsset,proto3,oneof"`
+}
+
+type Payload_ProducerInfo struct {
+	ProducerInfo *ProducerInfo `protobuf:"bytes,6,opt,name=producer_info,json=producerInfo,proto3,oneof"`
+}
+
+type Payload_CancelProducer struct {
+	CancelProducer *CancelProducer `protobuf:"bytes,7,opt,name=cancel_producer,json=cancelProducer,proto3,oneof"`
+}
+
+type Payload_InactiveArbitrators struct {
+	InactiveArbitrators *InactiveArbitrators `protobuf:"bytes,8,opt,name=inactive_arbitrators,json=inactiveArbitrators,proto3,oneof"`
+}
+
+type Payload_ActivateProducer struct {
+	ActivateProducer *ActivateProducer `protobuf:"bytes,9,opt,name=activate_producer,json=activateProducer,proto3,oneof"`
+}
+
+type Payload_UpdateVersion struct {
+	UpdateVersion *UpdateVersion `protobuf:"bytes,10,opt,name=update_version,json=updateVersion,proto3,oneof"`
+}
+
+type Payload_CrInfo struct {
+	CrInfo *CRInfo `protobuf:"bytes,11,opt,name=cr_info,json=crInfo,proto3,oneof"`
+}
+
+type Payload_UnregisterCr struct {
+	UnregisterCr *UnregisterCR `protobuf:"bytes,12,opt,name=unregister_cr,json=unregisterCr,proto3,oneof"`
+}
+
+type Payload_CrcProposal struct {
+	CrcProposal *CRCProposal `protobuf:"bytes,13,opt,name=crc_proposal,json=crcProposal,proto3,oneof"`
+}
+
+type Payload_CrcProposalReview struct {
+	CrcProposalReview *CRCProposalReview `protobuf:"bytes,14,opt,name=crc_proposal_review,json=crcProposalReview,proto3,oneof"`
+}
+
+type Payload_CrcProposalTracking struct {
+	CrcProposalTracking *CRCProposalTracking `protobuf:"bytes,15,opt,name=crc_proposal_tracking,json=crcProposalTracking,proto3,oneof"`
+}
+
+type Payload_CrcProposalWithdraw struct {
+	CrcProposalWithdraw *CRCProposalWithdraw `protobuf:"bytes,16,opt,name=crc_proposal_withdraw,json=crcProposalWithdraw,proto3,oneof"`
+}
+
+func (*Payload_Coinbase) isPayload_Payload() {}
+
+func (*Payload_RegisterAsset) isPayload_Payload() {}
+
+func (*Payload_SideChainPow) isPayload_Payload() {}
+
+func (*Payload_WithdrawFromSideChain) isPayload_Payload() {}
+
+func (*Payload_TransferCrossChainAsset) isPayload_Payload() {}
+
+func (*Payload_ProducerInfo) isPayload_Payload() {}
+
+func (*Payload_CancelProducer) isPayload_Payload() {}
+
+func (*Payload_InactiveArbitrators) isPayload_Payload() {}
+
+func (*Payload_ActivateProducer) isPayload_Payload() {}
+
+func (*Payload_UpdateVersion) isPayload_Payload() {}
+
+func (*Payload_CrInfo) isPayload_Payload() {}
+
+func (*Payload_UnregisterCr) isPayload_Payload() {}
+
+func (*Payload_CrcProposal) isPayload_Payload() {}
+
+func (*Payload_CrcProposalReview) isPayload_Payload() {}
+
+func (*Payload_CrcProposalTracking) isPayload_Payload() {}
+
+func (*Payload_CrcProposalWithdraw) isPayload_Payload() {}
+
+type Coinbase struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CoinbaseData string `protobuf:"bytes,1,opt,name=coinbase_data,json=coinbaseData,proto3" json:"coinbase_data,omitempty"`
+}
+
+func (x *Coinbase) Reset() {
+	*x = Coinbase{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Coinbase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coinbase) ProtoMessage() {}
+
+func (x *Coinbase) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coinbase.ProtoReflect.Descriptor instead.
+func (*Coinbase) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Coinbase) GetCoinbaseData() string {
+	if x != nil {
+		return x.CoinbaseData
+	}
+	return ""
+}
+
+type Asset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Precision   uint32 `protobuf:"varint,3,opt,name=precision,proto3" json:"precision,omitempty"`
+	AssetType   uint32 `protobuf:"varint,4,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`
+	RecordType  uint32 `protobuf:"varint,5,opt,name=record_type,json=recordType,proto3" json:"record_type,omitempty"`
+}
+
+func (x *Asset) Reset() {
+	*x = Asset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Asset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asset) ProtoMessage() {}
+
+func (x *Asset) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asset.ProtoReflect.Descriptor instead.
+func (*Asset) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Asset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Asset) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Asset) GetPrecision() uint32 {
+	if x != nil {
+		return x.Precision
+	}
+	return 0
+}
+
+func (x *Asset) GetAssetType() uint32 {
+	if x != nil {
+		return x.AssetType
+	}
+	return 0
+}
+
+func (x *Asset) GetRecordType() uint32 {
+	if x != nil {
+		return x.RecordType
+	}
+	return 0
+}
+
+type RegisterAsset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Asset      *Asset `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount     string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Controller string `protobuf:"bytes,3,opt,name=controller,proto3" json:"controller,omitempty"`
+}
+
+func (x *RegisterAsset) Reset() {
+	*x = RegisterAsset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterAsset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterAsset) ProtoMessage() {}
+
+func (x *RegisterAsset) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterAsset.ProtoReflect.Descriptor instead.
+func (*RegisterAsset) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RegisterAsset) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *RegisterAsset) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *RegisterAsset) GetController() string {
+	if x != nil {
+		return x.Controller
+	}
+	return ""
+}
+
+type SideChainPow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHeight     uint32 `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	SideBlockHash   string `protobuf:"bytes,2,opt,name=side_block_hash,json=sideBlockHash,proto3" json:"side_block_hash,omitempty"`
+	SideGenesisHash string `protobuf:"bytes,3,opt,name=side_genesis_hash,json=sideGenesisHash,proto3" json:"side_genesis_hash,omitempty"`
+	Signature       string `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *SideChainPow) Reset() {
+	*x = SideChainPow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SideChainPow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SideChainPow) ProtoMessage() {}
+
+func (x *SideChainPow) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SideChainPow.ProtoReflect.Descriptor instead.
+func (*SideChainPow) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SideChainPow) GetBlockHeight() uint32 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *SideChainPow) GetSideBlockHash() string {
+	if x != nil {
+		return x.SideBlockHash
+	}
+	return ""
+}
+
+func (x *SideChainPow) GetSideGenesisHash() string {
+	if x != nil {
+		return x.SideGenesisHash
+	}
+	return ""
+}
+
+func (x *SideChainPow) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type WithdrawFromSideChain struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHeight                uint32   `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	GenesisBlockAddress        string   `protobuf:"bytes,2,opt,name=genesis_block_address,json=genesisBlockAddress,proto3" json:"genesis_block_address,omitempty"`
+	SideChainTransactionHashes []string `protobuf:"bytes,3,rep,name=side_chain_transaction_hashes,json=sideChainTransactionHashes,proto3" json:"side_chain_transaction_hashes,omitempty"`
+}
+
+func (x *WithdrawFromSideChain) Reset() {
+	*x = WithdrawFromSideChain{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithdrawFromSideChain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawFromSideChain) ProtoMessage() {}
+
+func (x *WithdrawFromSideChain) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawFromSideChain.ProtoReflect.Descriptor instead.
+func (*WithdrawFromSideChain) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WithdrawFromSideChain) GetBlockHeight() uint32 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *WithdrawFromSideChain) GetGenesisBlockAddress() string {
+	if x != nil {
+		return x.GenesisBlockAddress
+	}
+	return ""
+}
+
+func (x *WithdrawFromSideChain) GetSideChainTransactionHashes() []string {
+	if x != nil {
+		return x.SideChainTransactionHashes
+	}
+	return nil
+}
+
+type TransferCrossChainAsset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CrossChainAddresses []string `protobuf:"bytes,1,rep,name=cross_chain_addresses,json=crossChainAddresses,proto3" json:"cross_chain_addresses,omitempty"`
+	OutputIndexes       []uint64 `protobuf:"varint,2,rep,packed,name=output_indexes,json=outputIndexes,proto3" json:"output_indexes,omitempty"`
+	CrossChainAmounts   []string `protobuf:"bytes,3,rep,name=cross_chain_amounts,json=crossChainAmounts,proto3" json:"cross_chain_amounts,omitempty"`
+}
+
+func (x *TransferCrossChainAsset) Reset() {
+	*x = TransferCrossChainAsset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferCrossChainAsset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferCrossChainAsset) ProtoMessage() {}
+
+func (x *TransferCrossChainAsset) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferCrossChainAsset.ProtoReflect.Descriptor instead.
+func (*TransferCrossChainAsset) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TransferCrossChainAsset) GetCrossChainAddresses() []string {
+	if x != nil {
+		return x.CrossChainAddresses
+	}
+	return nil
+}
+
+func (x *TransferCrossChainAsset) GetOutputIndexes() []uint64 {
+	if x != nil {
+		return x.OutputIndexes
+	}
+	return nil
+}
+
+func (x *TransferCrossChainAsset) GetCrossChainAmounts() []string {
+	if x != nil {
+		return x.CrossChainAmounts
+	}
+	return nil
+}
+
+type ProducerInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerPublicKey string `protobuf:"bytes,1,opt,name=owner_public_key,json=ownerPublicKey,proto3" json:"owner_public_key,omitempty"`
+	NodePublicKey  string `protobuf:"bytes,2,opt,name=node_public_key,json=nodePublicKey,proto3" json:"node_public_key,omitempty"`
+	NickName       string `protobuf:"bytes,3,opt,name=nick_name,json=nickName,proto3" json:"nick_name,omitempty"`
+	Url            string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Location       uint64 `protobuf:"varint,5,opt,name=location,proto3" json:"location,omitempty"`
+	NetAddress     string `protobuf:"bytes,6,opt,name=net_address,json=netAddress,proto3" json:"net_address,omitempty"`
+	Signature      string `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *ProducerInfo) Reset() {
+	*x = ProducerInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProducerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProducerInfo) ProtoMessage() {}
+
+func (x *ProducerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProducerInfo.ProtoReflect.Descriptor instead.
+func (*ProducerInfo) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ProducerInfo) GetOwnerPublicKey() string {
+	if x != nil {
+		return x.OwnerPublicKey
+	}
+	return ""
+}
+
+func (x *ProducerInfo) GetNodePublicKey() string {
+	if x != nil {
+		return x.NodePublicKey
+	}
+	return ""
+}
+
+func (x *ProducerInfo) GetNickName() string {
+	if x != nil {
+		return x.NickName
+	}
+	return ""
+}
+
+func (x *ProducerInfo) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ProducerInfo) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *ProducerInfo) GetNetAddress() string {
+	if x != nil {
+		return x.NetAddress
+	}
+	return ""
+}
+
+func (x *ProducerInfo) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type CancelProducer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerPublicKey string `protobuf:"bytes,1,opt,name=owner_public_key,json=ownerPublicKey,proto3" json:"owner_public_key,omitempty"`
+	Signature      string `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *CancelProducer) Reset() {
+	*x = CancelProducer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelProducer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelProducer) ProtoMessage() {}
+
+func (x *CancelProducer) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelProducer.ProtoReflect.Descriptor instead.
+func (*CancelProducer) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CancelProducer) GetOwnerPublicKey() string {
+	if x != nil {
+		return x.OwnerPublicKey
+	}
+	return ""
+}
+
+func (x *CancelProducer) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type InactiveArbitrators struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sponsor     string   `protobuf:"bytes,1,opt,name=sponsor,proto3" json:"sponsor,omitempty"`
+	Arbitrators []string `protobuf:"bytes,2,rep,name=arbitrators,proto3" json:"arbitrators,omitempty"`
+	BlockHeight uint32   `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+}
+
+func (x *InactiveArbitrators) Reset() {
+	*x = InactiveArbitrators{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InactiveArbitrators) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InactiveArbitrators) ProtoMessage() {}
+
+func (x *InactiveArbitrators) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InactiveArbitrators.ProtoReflect.Descriptor instead.
+func (*InactiveArbitrators) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *InactiveArbitrators) GetSponsor() string {
+	if x != nil {
+		return x.Sponsor
+	}
+	return ""
+}
+
+func (x *InactiveArbitrators) GetArbitrators() []string {
+	if x != nil {
+		return x.Arbitrators
+	}
+	return nil
+}
+
+func (x *InactiveArbitrators) GetBlockHeight() uint32 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+type ActivateProducer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodePublicKey string `protobuf:"bytes,1,opt,name=node_public_key,json=nodePublicKey,proto3" json:"node_public_key,omitempty"`
+	Signature     string `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *ActivateProducer) Reset() {
+	*x = ActivateProducer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActivateProducer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateProducer) ProtoMessage() {}
+
+func (x *ActivateProducer) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateProducer.ProtoReflect.Descriptor instead.
+func (*ActivateProducer) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ActivateProducer) GetNodePublicKey() string {
+	if x != nil {
+		return x.NodePublicKey
+	}
+	return ""
+}
+
+func (x *ActivateProducer) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type UpdateVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartHeight uint32 `protobuf:"varint,1,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	EndHeight   uint32 `protobuf:"varint,2,opt,name=end_height,json=endHeight,proto3" json:"end_height,omitempty"`
+}
+
+func (x *UpdateVersion) Reset() {
+	*x = UpdateVersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVersion) ProtoMessage() {}
+
+func (x *UpdateVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVersion.ProtoReflect.Descriptor instead.
+func (*UpdateVersion) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateVersion) GetStartHeight() uint32 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+func (x *UpdateVersion) GetEndHeight() uint32 {
+	if x != nil {
+		return x.EndHeight
+	}
+	return 0
+}
+
+type CRInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code      string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Cid       string `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Did       string `protobuf:"bytes,3,opt,name=did,proto3" json:"did,omitempty"`
+	NickName  string `protobuf:"bytes,4,opt,name=nick_name,json=nickName,proto3" json:"nick_name,omitempty"`
+	Url       string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	Location  uint64 `protobuf:"varint,6,opt,name=location,proto3" json:"location,omitempty"`
+	Signature string `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *CRInfo) Reset() {
+	*x = CRInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRInfo) ProtoMessage() {}
+
+func (x *CRInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRInfo.ProtoReflect.Descriptor instead.
+func (*CRInfo) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CRInfo) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CRInfo) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *CRInfo) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *CRInfo) GetNickName() string {
+	if x != nil {
+		return x.NickName
+	}
+	return ""
+}
+
+func (x *CRInfo) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CRInfo) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *CRInfo) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type UnregisterCR struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cid       string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	Signature string `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *UnregisterCR) Reset() {
+	*x = UnregisterCR{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnregisterCR) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterCR) ProtoMessage() {}
+
+func (x *UnregisterCR) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterCR.ProtoReflect.Descriptor instead.
+func (*UnregisterCR) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UnregisterCR) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *UnregisterCR) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type Budget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type   string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Stage  uint32 `protobuf:"varint,2,opt,name=stage,proto3" json:"stage,omitempty"`
+	Amount string `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// status is set only in proposal states.
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *Budget) Reset() {
+	*x = Budget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Budget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Budget) ProtoMessage() {}
+
+func (x *Budget) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Budget.ProtoReflect.Descriptor instead.
+func (*Budget) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Budget) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Budget) GetStage() uint32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+func (x *Budget) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Budget) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type CRCProposal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProposalType             string    `protobuf:"bytes,1,opt,name=proposal_type,json=proposalType,proto3" json:"proposal_type,omitempty"`
+	CategoryData             string    `protobuf:"bytes,2,opt,name=category_data,json=categoryData,proto3" json:"category_data,omitempty"`
+	OwnerPublicKey           string    `protobuf:"bytes,3,opt,name=owner_public_key,json=ownerPublicKey,proto3" json:"owner_public_key,omitempty"`
+	DraftHash                string    `protobuf:"bytes,4,opt,name=draft_hash,json=draftHash,proto3" json:"draft_hash,omitempty"`
+	Budgets                  []*Budget `protobuf:"bytes,5,rep,name=budgets,proto3" json:"budgets,omitempty"`
+	Recipient                string    `protobuf:"bytes,6,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Signature                string    `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	CrCouncilMemberDid       string    `protobuf:"bytes,8,opt,name=cr_council_member_did,json=crCouncilMemberDid,proto3" json:"cr_council_member_did,omitempty"`
+	CrCouncilMemberSignature string    `protobuf:"bytes,9,opt,name=cr_council_member_signature,json=crCouncilMemberSignature,proto3" json:"cr_council_member_signature,omitempty"`
+	Hash                     string    `protobuf:"bytes,10,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *CRCProposal) Reset() {
+	*x = CRCProposal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRCProposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRCProposal) ProtoMessage() {}
+
+func (x *CRCProposal) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRCProposal.ProtoReflect.Descriptor instead.
+func (*CRCProposal) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CRCProposal) GetProposalType() string {
+	if x != nil {
+		return x.ProposalType
+	}
+	return ""
+}
+
+func (x *CRCProposal) GetCategoryData() string {
+	if x != nil {
+		return x.CategoryData
+	}
+	return ""
+}
+
+func (x *CRCProposal) GetOwnerPublicKey() string {
+	if x != nil {
+		return x.OwnerPublicKey
+	}
+	return ""
+}
+
+func (x *CRCProposal) GetDraftHash() string {
+	if x != nil {
+		return x.DraftHash
+	}
+	return ""
+}
+
+func (x *CRCProposal) GetBudgets() []*Budget {
+	if x != nil {
+		return x.Budgets
+	}
+	return nil
+}
+
+func (x *CRCProposal) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *CRCProposal) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *CRCProposal) GetCrCouncilMemberDid() string {
+	if x != nil {
+		return x.CrCouncilMemberDid
+	}
+	return ""
+}
+
+func (x *CRCProposal) GetCrCouncilMemberSignature() string {
+	if x != nil {
+		return x.CrCouncilMemberSignature
+	}
+	return ""
+}
+
+func (x *CRCProposal) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type CRCProposalReview struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProposalHash string `protobuf:"bytes,1,opt,name=proposal_hash,json=proposalHash,proto3" json:"proposal_hash,omitempty"`
+	VoteResult   string `protobuf:"bytes,2,opt,name=vote_result,json=voteResult,proto3" json:"vote_result,omitempty"`
+	OpinionHash  string `protobuf:"bytes,3,opt,name=opinion_hash,json=opinionHash,proto3" json:"opinion_hash,omitempty"`
+	Did          string `protobuf:"bytes,4,opt,name=did,proto3" json:"did,omitempty"`
+	Sign         string `protobuf:"bytes,5,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (x *CRCProposalReview) Reset() {
+	*x = CRCProposalReview{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRCProposalReview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRCProposalReview) ProtoMessage() {}
+
+func (x *CRCProposalReview) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRCProposalReview.ProtoReflect.Descriptor instead.
+func (*CRCProposalReview) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CRCProposalReview) GetProposalHash() string {
+	if x != nil {
+		return x.ProposalHash
+	}
+	return ""
+}
+
+func (x *CRCProposalReview) GetVoteResult() string {
+	if x != nil {
+		return x.VoteResult
+	}
+	return ""
+}
+
+func (x *CRCProposalReview) GetOpinionHash() string {
+	if x != nil {
+		return x.OpinionHash
+	}
+	return ""
+}
+
+func (x *CRCProposalReview) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *CRCProposalReview) GetSign() string {
+	if x != nil {
+		return x.Sign
+	}
+	return ""
+}
+
+type CRCProposalTracking struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProposalTrackingType        string `protobuf:"bytes,1,opt,name=proposal_tracking_type,json=proposalTrackingType,proto3" json:"proposal_tracking_type,omitempty"`
+	ProposalHash                string `protobuf:"bytes,2,opt,name=proposal_hash,json=proposalHash,proto3" json:"proposal_hash,omitempty"`
+	MessageHash                 string `protobuf:"bytes,3,opt,name=message_hash,json=messageHash,proto3" json:"message_hash,omitempty"`
+	Stage                       uint32 `protobuf:"varint,4,opt,name=stage,proto3" json:"stage,omitempty"`
+	OwnerPublicKey              string `protobuf:"bytes,5,opt,name=owner_public_key,json=ownerPublicKey,proto3" json:"owner_public_key,omitempty"`
+	NewOwnerPublicKey           string `protobuf:"bytes,6,opt,name=new_owner_public_key,json=newOwnerPublicKey,proto3" json:"new_owner_public_key,omitempty"`
+	OwnerSignature              string `protobuf:"bytes,7,opt,name=owner_signature,json=ownerSignature,proto3" json:"owner_signature,omitempty"`
+	NewOwnerSignature           string `protobuf:"bytes,8,opt,name=new_owner_signature,json=newOwnerSignature,proto3" json:"new_owner_signature,omitempty"`
+	SecretaryGeneralOpinionHash string `protobuf:"bytes,9,opt,name=secretary_general_opinion_hash,json=secretaryGeneralOpinionHash,proto3" json:"secretary_general_opinion_hash,omitempty"`
+	SecretaryGeneralSignature   string `protobuf:"bytes,10,opt,name=secretary_general_signature,json=secretaryGeneralSignature,proto3" json:"secretary_general_signature,omitempty"`
+}
+
+func (x *CRCProposalTracking) Reset() {
+	*x = CRCProposalTracking{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRCProposalTracking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRCProposalTracking) ProtoMessage() {}
+
+func (x *CRCProposalTracking) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRCProposalTracking.ProtoReflect.Descriptor instead.
+func (*CRCProposalTracking) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CRCProposalTracking) GetProposalTrackingType() string {
+	if x != nil {
+		return x.ProposalTrackingType
+	}
+	return ""
+}
+
+func (x *CRCProposalTracking) GetProposalHash() string {
+	if x != nil {
+		return x.ProposalHash
+	}
+	return ""
+}
+
+func (x *CRCProposalTracking) GetMessageHash() string {
+	if x != nil {
+		return x.MessageHash
+	}
+	return ""
+}
+
+func (x *CRCProposalTracking) GetStage() uint32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+func (x *CRCProposalTracking) GetOwnerPublicKey() string {
+	if x != nil {
+		return x.OwnerPublicKey
+	}
+	return ""
+}
+
+func (x *CRCProposalTracking) GetNewOwnerPublicKey() string {
+	if x != nil {
+		return x.NewOwnerPublicKey
+	}
+	return ""
+}
+
+func (x *CRCProposalTracking) GetOwnerSignature() string {
+	if x != nil {
+		return x.OwnerSignature
+	}
+	return ""
+}
+
+func (x *CRCProposalTracking) GetNewOwnerSignature() string {
+	if x != nil {
+		return x.NewOwnerSignature
+	}
+	return ""
+}
+
+func (x *CRCProposalTracking) GetSecretaryGeneralOpinionHash() string {
+	if x != nil {
+		return x.SecretaryGeneralOpinionHash
+	}
+	return ""
+}
+
+func (x *CRCProposalTracking) GetSecretaryGeneralSignature() string {
+	if x != nil {
+		return x.SecretaryGeneralSignature
+	}
+	return ""
+}
+
+type CRCProposalWithdraw struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProposalHash   string `protobuf:"bytes,1,opt,name=proposal_hash,json=proposalHash,proto3" json:"proposal_hash,omitempty"`
+	OwnerPublicKey string `protobuf:"bytes,2,opt,name=owner_public_key,json=ownerPublicKey,proto3" json:"owner_public_key,omitempty"`
+	Signature      string `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *CRCProposalWithdraw) Reset() {
+	*x = CRCProposalWithdraw{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRCProposalWithdraw) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRCProposalWithdraw) ProtoMessage() {}
+
+func (x *CRCProposalWithdraw) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRCProposalWithdraw.ProtoReflect.Descriptor instead.
+func (*CRCProposalWithdraw) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CRCProposalWithdraw) GetProposalHash() string {
+	if x != nil {
+		return x.ProposalHash
+	}
+	return ""
+}
+
+func (x *CRCProposalWithdraw) GetOwnerPublicKey() string {
+	if x != nil {
+		return x.OwnerPublicKey
+	}
+	return ""
+}
+
+func (x *CRCProposalWithdraw) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type Producer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerPublicKey string `protobuf:"bytes,1,opt,name=owner_public_key,json=ownerPublicKey,proto3" json:"owner_public_key,omitempty"`
+	NodePublicKey  string `protobuf:"bytes,2,opt,name=node_public_key,json=nodePublicKey,proto3" json:"node_public_key,omitempty"`
+	Nickname       string `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Url            string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Location       uint64 `protobuf:"varint,5,opt,name=location,proto3" json:"location,omitempty"`
+	Active         bool   `protobuf:"varint,6,opt,name=active,proto3" json:"active,omitempty"`
+	Votes          string `protobuf:"bytes,7,opt,name=votes,proto3" json:"votes,omitempty"`
+	State          string `protobuf:"bytes,8,opt,name=state,proto3" json:"state,omitempty"`
+	RegisterHeight uint32 `protobuf:"varint,9,opt,name=register_height,json=registerHeight,proto3" json:"register_height,omitempty"`
+	CancelHeight   uint32 `protobuf:"varint,10,opt,name=cancel_height,json=cancelHeight,proto3" json:"cancel_height,omitempty"`
+	InactiveHeight uint32 `protobuf:"varint,11,opt,name=inactive_height,json=inactiveHeight,proto3" json:"inactive_height,omitempty"`
+	IllegalHeight  uint32 `protobuf:"varint,12,opt,name=illegal_height,json=illegalHeight,proto3" json:"illegal_height,omitempty"`
+	Index          uint64 `protobuf:"varint,13,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *Producer) Reset() {
+	*x = Producer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Producer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Producer) ProtoMessage() {}
+
+func (x *Producer) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Producer.ProtoReflect.Descriptor instead.
+func (*Producer) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Producer) GetOwnerPublicKey() string {
+	if x != nil {
+		return x.OwnerPublicKey
+	}
+	return ""
+}
+
+func (x *Producer) GetNodePublicKey() string {
+	if x != nil {
+		return x.NodePublicKey
+	}
+	return ""
+}
+
+func (x *Producer) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *Producer) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Producer) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *Producer) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Producer) GetVotes() string {
+	if x != nil {
+		return x.Votes
+	}
+	return ""
+}
+
+func (x *Producer) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Producer) GetRegisterHeight() uint32 {
+	if x != nil {
+		return x.RegisterHeight
+	}
+	return 0
+}
+
+func (x *Producer) GetCancelHeight() uint32 {
+	if x != nil {
+		return x.CancelHeight
+	}
+	return 0
+}
+
+func (x *Producer) GetInactiveHeight() uint32 {
+	if x != nil {
+		return x.InactiveHeight
+	}
+	return 0
+}
+
+func (x *Producer) GetIllegalHeight() uint32 {
+	if x != nil {
+		return x.IllegalHeight
+	}
+	return 0
+}
+
+func (x *Producer) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type Producers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Producers  []*Producer `protobuf:"bytes,1,rep,name=producers,proto3" json:"producers,omitempty"`
+	TotalVotes string      `protobuf:"bytes,2,opt,name=total_votes,json=totalVotes,proto3" json:"total_votes,omitempty"`
+	TotalCount uint64      `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+}
+
+func (x *Producers) Reset() {
+	*x = Producers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Producers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Producers) ProtoMessage() {}
+
+func (x *Producers) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Producers.ProtoReflect.Descriptor instead.
+func (*Producers) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Producers) GetProducers() []*Producer {
+	if x != nil {
+		return x.Producers
+	}
+	return nil
+}
+
+func (x *Producers) GetTotalVotes() string {
+	if x != nil {
+		return x.TotalVotes
+	}
+	return ""
+}
+
+func (x *Producers) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type ArbitersInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Arbiters               []string `protobuf:"bytes,1,rep,name=arbiters,proto3" json:"arbiters,omitempty"`
+	Candidates             []string `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	NextArbiters           []string `protobuf:"bytes,3,rep,name=next_arbiters,json=nextArbiters,proto3" json:"next_arbiters,omitempty"`
+	NextCandidates         []string `protobuf:"bytes,4,rep,name=next_candidates,json=nextCandidates,proto3" json:"next_candidates,omitempty"`
+	OnDutyArbiter          string   `protobuf:"bytes,5,opt,name=on_duty_arbiter,json=onDutyArbiter,proto3" json:"on_duty_arbiter,omitempty"`
+	CurrentTurnStartHeight int64    `protobuf:"varint,6,opt,name=current_turn_start_height,json=currentTurnStartHeight,proto3" json:"current_turn_start_height,omitempty"`
+	NextTurnStartHeight    int64    `protobuf:"varint,7,opt,name=next_turn_start_height,json=nextTurnStartHeight,proto3" json:"next_turn_start_height,omitempty"`
+}
+
+func (x *ArbitersInfo) Reset() {
+	*x = ArbitersInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArbitersInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArbitersInfo) ProtoMessage() {}
+
+func (x *ArbitersInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArbitersInfo.ProtoReflect.Descriptor instead.
+func (*ArbitersInfo) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ArbitersInfo) GetArbiters() []string {
+	if x != nil {
+		return x.Arbiters
+	}
+	return nil
+}
+
+func (x *ArbitersInfo) GetCandidates() []string {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *ArbitersInfo) GetNextArbiters() []string {
+	if x != nil {
+		return x.NextArbiters
+	}
+	return nil
+}
+
+func (x *ArbitersInfo) GetNextCandidates() []string {
+	if x != nil {
+		return x.NextCandidates
+	}
+	return nil
+}
+
+func (x *ArbitersInfo) GetOnDutyArbiter() string {
+	if x != nil {
+		return x.OnDutyArbiter
+	}
+	return ""
+}
+
+func (x *ArbitersInfo) GetCurrentTurnStartHeight() int64 {
+	if x != nil {
+		return x.CurrentTurnStartHeight
+	}
+	return 0
+}
+
+func (x *ArbitersInfo) GetNextTurnStartHeight() int64 {
+	if x != nil {
+		return x.NextTurnStartHeight
+	}
+	return 0
+}
+
+type CRCandidate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code           string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Cid            string `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Did            string `protobuf:"bytes,3,opt,name=did,proto3" json:"did,omitempty"`
+	Nickname       string `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Url            string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	Location       uint64 `protobuf:"varint,6,opt,name=location,proto3" json:"location,omitempty"`
+	State          string `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	Votes          string `protobuf:"bytes,8,opt,name=votes,proto3" json:"votes,omitempty"`
+	RegisterHeight uint32 `protobuf:"varint,9,opt,name=register_height,json=registerHeight,proto3" json:"register_height,omitempty"`
+	CancelHeight   uint32 `protobuf:"varint,10,opt,name=cancel_height,json=cancelHeight,proto3" json:"cancel_height,omitempty"`
+	Index          uint64 `protobuf:"varint,11,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *CRCandidate) Reset() {
+	*x = CRCandidate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRCandidate) ProtoMessage() {}
+
+func (x *CRCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRCandidate.ProtoReflect.Descriptor instead.
+func (*CRCandidate) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CRCandidate) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CRCandidate) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *CRCandidate) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *CRCandidate) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *CRCandidate) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CRCandidate) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *CRCandidate) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *CRCandidate) GetVotes() string {
+	if x != nil {
+		return x.Votes
+	}
+	return ""
+}
+
+func (x *CRCandidate) GetRegisterHeight() uint32 {
+	if x != nil {
+		return x.RegisterHeight
+	}
+	return 0
+}
+
+func (x *CRCandidate) GetCancelHeight() uint32 {
+	if x != nil {
+		return x.CancelHeight
+	}
+	return 0
+}
+
+func (x *CRCandidate) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type CRCandidates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Candidates []*CRCandidate `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	TotalVotes string         `protobuf:"bytes,2,opt,name=total_votes,json=totalVotes,proto3" json:"total_votes,omitempty"`
+	TotalCount uint64         `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+}
+
+func (x *CRCandidates) Reset() {
+	*x = CRCandidates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRCandidates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRCandidates) ProtoMessage() {}
+
+func (x *CRCandidates) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRCandidates.ProtoReflect.Descriptor instead.
+func (*CRCandidates) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CRCandidates) GetCandidates() []*CRCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *CRCandidates) GetTotalVotes() string {
+	if x != nil {
+		return x.TotalVotes
+	}
+	return ""
+}
+
+func (x *CRCandidates) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type CRMember struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code             string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Cid              string `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Did              string `protobuf:"bytes,3,opt,name=did,proto3" json:"did,omitempty"`
+	Nickname         string `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Url              string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	Location         uint64 `protobuf:"varint,6,opt,name=location,proto3" json:"location,omitempty"`
+	ImpeachmentVotes string `protobuf:"bytes,7,opt,name=impeachment_votes,json=impeachmentVotes,proto3" json:"impeachment_votes,omitempty"`
+	DepositAmount    string `protobuf:"bytes,8,opt,name=deposit_amount,json=depositAmount,proto3" json:"deposit_amount,omitempty"`
+	DepositAddress   string `protobuf:"bytes,9,opt,name=deposit_address,json=depositAddress,proto3" json:"deposit_address,omitempty"`
+	Penalty          string `protobuf:"bytes,10,opt,name=penalty,proto3" json:"penalty,omitempty"`
+	State            string `protobuf:"bytes,11,opt,name=state,proto3" json:"state,omitempty"`
+	Index            uint64 `protobuf:"varint,12,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *CRMember) Reset() {
+	*x = CRMember{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRMember) ProtoMessage() {}
+
+func (x *CRMember) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRMember.ProtoReflect.Descriptor instead.
+func (*CRMember) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CRMember) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CRMember) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *CRMember) GetDid() string {
+	if x != nil {
+		return x.Did
+	}
+	return ""
+}
+
+func (x *CRMember) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *CRMember) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CRMember) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *CRMember) GetImpeachmentVotes() string {
+	if x != nil {
+		return x.ImpeachmentVotes
+	}
+	return ""
+}
+
+func (x *CRMember) GetDepositAmount() string {
+	if x != nil {
+		return x.DepositAmount
+	}
+	return ""
+}
+
+func (x *CRMember) GetDepositAddress() string {
+	if x != nil {
+		return x.DepositAddress
+	}
+	return ""
+}
+
+func (x *CRMember) GetPenalty() string {
+	if x != nil {
+		return x.Penalty
+	}
+	return ""
+}
+
+func (x *CRMember) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *CRMember) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type CRMembers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Members    []*CRMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	TotalCount uint64      `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+}
+
+func (x *CRMembers) Reset() {
+	*x = CRMembers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRMembers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRMembers) ProtoMessage() {}
+
+func (x *CRMembers) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRMembers.ProtoReflect.Descriptor instead.
+func (*CRMembers) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CRMembers) GetMembers() []*CRMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *CRMembers) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type CRProposalBaseState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status             string            `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	ProposalHash       string            `protobuf:"bytes,2,opt,name=proposal_hash,json=proposalHash,proto3" json:"proposal_hash,omitempty"`
+	TxHash             string            `protobuf:"bytes,3,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	CrVotes            map[string]string `protobuf:"bytes,4,rep,name=cr_votes,json=crVotes,proto3" json:"cr_votes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	VotersRejectAmount string            `protobuf:"bytes,5,opt,name=voters_reject_amount,json=votersRejectAmount,proto3" json:"voters_reject_amount,omitempty"`
+	RegisterHeight     uint32            `protobuf:"varint,6,opt,name=register_height,json=registerHeight,proto3" json:"register_height,omitempty"`
+	TerminatedHeight   uint32            `protobuf:"varint,7,opt,name=terminated_height,json=terminatedHeight,proto3" json:"terminated_height,omitempty"`
+	TrackingCount      uint32            `protobuf:"varint,8,opt,name=tracking_count,json=trackingCount,proto3" json:"tracking_count,omitempty"`
+	ProposalOwner      string            `protobuf:"bytes,9,opt,name=proposal_owner,json=proposalOwner,proto3" json:"proposal_owner,omitempty"`
+	Index              uint64            `protobuf:"varint,10,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *CRProposalBaseState) Reset() {
+	*x = CRProposalBaseState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRProposalBaseState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRProposalBaseState) ProtoMessage() {}
+
+func (x *CRProposalBaseState) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRProposalBaseState.ProtoReflect.Descriptor instead.
+func (*CRProposalBaseState) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CRProposalBaseState) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CRProposalBaseState) GetProposalHash() string {
+	if x != nil {
+		return x.ProposalHash
+	}
+	return ""
+}
+
+func (x *CRProposalBaseState) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *CRProposalBaseState) GetCrVotes() map[string]string {
+	if x != nil {
+		return x.CrVotes
+	}
+	return nil
+}
+
+func (x *CRProposalBaseState) GetVotersRejectAmount() string {
+	if x != nil {
+		return x.VotersRejectAmount
+	}
+	return ""
+}
+
+func (x *CRProposalBaseState) GetRegisterHeight() uint32 {
+	if x != nil {
+		return x.RegisterHeight
+	}
+	return 0
+}
+
+func (x *CRProposalBaseState) GetTerminatedHeight() uint32 {
+	if x != nil {
+		return x.TerminatedHeight
+	}
+	return 0
+}
+
+func (x *CRProposalBaseState) GetTrackingCount() uint32 {
+	if x != nil {
+		return x.TrackingCount
+	}
+	return 0
+}
+
+func (x *CRProposalBaseState) GetProposalOwner() string {
+	if x != nil {
+		return x.ProposalOwner
+	}
+	return ""
+}
+
+func (x *CRProposalBaseState) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type CRProposalBaseStates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proposals  []*CRProposalBaseState `protobuf:"bytes,1,rep,name=proposals,proto3" json:"proposals,omitempty"`
+	TotalCount uint64                 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+}
+
+func (x *CRProposalBaseStates) Reset() {
+	*x = CRProposalBaseStates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRProposalBaseStates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRProposalBaseStates) ProtoMessage() {}
+
+func (x *CRProposalBaseStates) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRProposalBaseStates.ProtoReflect.Descriptor instead.
+func (*CRProposalBaseStates) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CRProposalBaseStates) GetProposals() []*CRProposalBaseState {
+	if x != nil {
+		return x.Proposals
+	}
+	return nil
+}
+
+func (x *CRProposalBaseStates) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type GetCRProposalStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Proposal:
+	//	*GetCRProposalStateRequest_ProposalHash
+	//	*GetCRProposalStateRequest_DraftHash
+	Proposal isGetCRProposalStateRequest_Proposal `protobuf_oneof:"proposal"`
+}
+
+func (x *GetCRProposalStateRequest) Reset() {
+	*x = GetCRProposalStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCRProposalStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCRProposalStateRequest) ProtoMessage() {}
+
+func (x *GetCRProposalStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCRProposalStateRequest.ProtoReflect.Descriptor instead.
+func (*GetCRProposalStateRequest) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{48}
+}
+
+func (m *GetCRProposalStateRequest) GetProposal() isGetCRProposalStateRequest_Proposal {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+func (x *GetCRProposalStateRequest) GetProposalHash() string {
+	if x, ok := x.GetProposal().(*GetCRProposalStateRequest_ProposalHash); ok {
+		return x.ProposalHash
+	}
+	return ""
+}
+
+func (x *GetCRProposalStateRequest) GetDraftHash() string {
+	if x, ok := x.GetProposal().(*GetCRProposalStateRequest_DraftHash); ok {
+		return x.DraftHash
+	}
+	return ""
+}
+
+type isGetCRProposalStateRequest_Proposal interface {
+	isGetCRProposalStateRequest_Proposal()
+}
+
+type GetCRProposalStateRequest_ProposalHash struct {
+	ProposalHash string `protobuf:"bytes,1,opt,name=proposal_hash,json=proposalHash,proto3,oneof"`
+}
+
+type GetCRProposalStateRequest_DraftHash struct {
+	DraftHash string `protobuf:"bytes,2,opt,name=draft_hash,json=draftHash,proto3,oneof"`
+}
+
+func (*GetCRProposalStateRequest_ProposalHash) isGetCRProposalStateRequest_Proposal() {}
+
+func (*GetCRProposalStateRequest_DraftHash) isGetCRProposalStateRequest_Proposal() {}
+
+type CRProposalState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status             string            `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Proposal           *CRCProposal      `protobuf:"bytes,2,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	ProposalHash       string            `protobuf:"bytes,3,opt,name=proposal_hash,json=proposalHash,proto3" json:"proposal_hash,omitempty"`
+	TxHash             string            `protobuf:"bytes,4,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	CrVotes            map[string]string `protobuf:"bytes,5,rep,name=cr_votes,json=crVotes,proto3" json:"cr_votes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	VotersRejectAmount string            `protobuf:"bytes,6,opt,name=voters_reject_amount,json=votersRejectAmount,proto3" json:"voters_reject_amount,omitempty"`
+	RegisterHeight     uint32            `protobuf:"varint,7,opt,name=register_height,json=registerHeight,proto3" json:"register_height,omitempty"`
+	TerminatedHeight   uint32            `protobuf:"varint,8,opt,name=terminated_height,json=terminatedHeight,proto3" json:"terminated_height,omitempty"`
+	TrackingCount      uint32            `protobuf:"varint,9,opt,name=tracking_count,json=trackingCount,proto3" json:"tracking_count,omitempty"`
+	ProposalOwner      string            `protobuf:"bytes,10,opt,name=proposal_owner,json=proposalOwner,proto3" json:"proposal_owner,omitempty"`
+	AvailableAmount    string            `protobuf:"bytes,11,opt,name=available_amount,json=availableAmount,proto3" json:"available_amount,omitempty"`
+}
+
+func (x *CRProposalState) Reset() {
+	*x = CRProposalState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CRProposalState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CRProposalState) ProtoMessage() {}
+
+func (x *CRProposalState) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CRProposalState.ProtoReflect.Descriptor instead.
+func (*CRProposalState) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CRProposalState) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CRProposalState) GetProposal() *CRCProposal {
+	if x != nil {
+		return x.Proposal
+	}
+	return nil
+}
+
+func (x *CRProposalState) GetProposalHash() string {
+	if x != nil {
+		return x.ProposalHash
+	}
+	return ""
+}
+
+func (x *CRProposalState) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *CRProposalState) GetCrVotes() map[string]string {
+	if x != nil {
+		return x.CrVotes
+	}
+	return nil
+}
+
+func (x *CRProposalState) GetVotersRejectAmount() string {
+	if x != nil {
+		return x.VotersRejectAmount
+	}
+	return ""
+}
+
+func (x *CRProposalState) GetRegisterHeight() uint32 {
+	if x != nil {
+		return x.RegisterHeight
+	}
+	return 0
+}
+
+func (x *CRProposalState) GetTerminatedHeight() uint32 {
+	if x != nil {
+		return x.TerminatedHeight
+	}
+	return 0
+}
+
+func (x *CRProposalState) GetTrackingCount() uint32 {
+	if x != nil {
+		return x.TrackingCount
+	}
+	return 0
+}
+
+func (x *CRProposalState) GetProposalOwner() string {
+	if x != nil {
+		return x.ProposalOwner
+	}
+	return ""
+}
+
+func (x *CRProposalState) GetAvailableAmount() string {
+	if x != nil {
+		return x.AvailableAmount
+	}
+	return ""
+}
+
+type SubscribeBlocksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// verbose indicates whether to stream full transactions or only hashes.
+	Verbose bool `protobuf:"varint,1,opt,name=verbose,proto3" json:"verbose,omitempty"`
+}
+
+func (x *SubscribeBlocksRequest) Reset() {
+	*x = SubscribeBlocksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeBlocksRequest) ProtoMessage() {}
+
+func (x *SubscribeBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeBlocksRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SubscribeBlocksRequest) GetVerbose() bool {
+	if x != nil {
+		return x.Verbose
+	}
+	return false
+}
+
+type SubscribeTransactionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// addresses filters transactions which have outputs to any of the
+	// addresses, all transactions are streamed if empty.
+	Addresses []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *SubscribeTransactionsRequest) Reset() {
+	*x = SubscribeTransactionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ela_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeTransactionsRequest) ProtoMessage() {}
+
+func (x *SubscribeTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ela_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_ela_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SubscribeTransactionsRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+var File_ela_proto protoreflect.FileDescriptor
+
+var file_ela_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x65, 0x6c, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x65, 0x6c, 0x61,
+	0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x37, 0x0a, 0x09, 0x42, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x64, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x22, 0x2b, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64, 0x22, 0x2f, 0x0a,
+	0x19, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x61, 0x77, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x30,
+	0x0a, 0x1a, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x61, 0x77, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x78, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64,
+	0x22, 0x3f, 0x0a, 0x17, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x6d, 0x61, 0x72,
+	0x74, 0x46, 0x65, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0x60, 0x0a, 0x0b, 0x46, 0x65, 0x65, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x66, 0x65, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65,
+	0x6e, 0x63, 0x65, 0x22, 0x4f, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0xf1, 0x03, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x69, 0x74,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x62, 0x69, 0x74, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x12, 0x2e, 0x0a,
+	0x13, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x6f, 0x75, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x26, 0x0a,
+	0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x75, 0x78, 0x5f, 0x70, 0x6f, 0x77,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x78, 0x50, 0x6f, 0x77, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x4c, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xc0, 0x04, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x76, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x2e, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x50,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x36, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c,
+	0x61, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x52, 0x0a, 0x61, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f,
+	0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x0b,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65,
+	0x6c, 0x61, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x30, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x35, 0x0a, 0x09, 0x41, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x4b, 0x0a, 0x05, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x76, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x76, 0x6f, 0x75,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xa2, 0x02,
+	0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x0c,
+	0x0a, 0x01, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49,
+	0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c, 0x6f,
+	0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x43, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x44, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x76,
+	0x6f, 0x74, 0x65, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x56,
+	0x6f, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x76, 0x6f, 0x74,
+	0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x22, 0x0f, 0x0a, 0x0d, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x22, 0x5c, 0x0a, 0x0a, 0x56, 0x6f, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x08, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x56, 0x6f, 0x74, 0x65,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x73, 0x22, 0x67, 0x0a, 0x0b, 0x56, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x76, 0x6f, 0x74, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x76, 0x6f, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x3b, 0x0a,
+	0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e,
+	0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x0a,
+	0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x44, 0x0a, 0x0e, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73,
+	0x22, 0x3b, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x22, 0xbc, 0x09,
+	0x0a, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x33, 0x0a, 0x08, 0x63, 0x6f, 0x69,
+	0x6e, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x6c,
+	0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x62, 0x61,
+	0x73, 0x65, 0x48, 0x00, 0x52, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x12, 0x43,
+	0x0a, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73,
+	0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x41, 0x73, 0x73,
+	0x65, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x41, 0x73,
+	0x73, 0x65, 0x74, 0x12, 0x41, 0x0a, 0x0e, 0x73, 0x69, 0x64, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x5f, 0x70, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65, 0x6c,
+	0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x50, 0x6f, 0x77, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x69, 0x64, 0x65, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x50, 0x6f, 0x77, 0x12, 0x5d, 0x0a, 0x18, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72,
+	0x61, 0x77, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x69, 0x64, 0x65, 0x5f, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74,
+	0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x46,
+	0x72, 0x6f, 0x6d, 0x53, 0x69, 0x64, 0x65, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x48, 0x00, 0x52, 0x15,
+	0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x69, 0x64, 0x65,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x63, 0x0a, 0x1a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x5f, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x65, 0x6c, 0x61, 0x73,
+	0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x43, 0x72, 0x6f, 0x73, 0x73, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x48,
+	0x00, 0x52, 0x17, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x43, 0x72, 0x6f, 0x73, 0x73,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x40, 0x0a, 0x0d, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e,
+	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x0c,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x46, 0x0a, 0x0f,
+	0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e,
+	0x65, 0x6c, 0x61, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x65, 0x72, 0x48, 0x00, 0x52, 0x0e, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x50, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x65, 0x72, 0x12, 0x55, 0x0a, 0x14, 0x69, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x5f, 0x61, 0x72, 0x62, 0x69, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61,
+	0x2e, 0x49, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x41, 0x72, 0x62, 0x69, 0x74, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x48, 0x00, 0x52, 0x13, 0x69, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x41, 0x72, 0x62, 0x69, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x4c, 0x0a, 0x11, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73,
+	0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x65, 0x72, 0x48, 0x00, 0x52, 0x10, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74,
+	0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x12, 0x43, 0x0a, 0x0e, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e,
+	0x0a, 0x07, 0x63, 0x72, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x43, 0x52,
+	0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x06, 0x63, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x40,
+	0x0a, 0x0d, 0x75, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x72, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e,
+	0x65, 0x6c, 0x61, 0x2e, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x52,
+	0x48, 0x00, 0x52, 0x0c, 0x75, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x72,
+	0x12, 0x3d, 0x0a, 0x0c, 0x63, 0x72, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73,
+	0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x43, 0x52, 0x43, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
+	0x48, 0x00, 0x52, 0x0b, 0x63, 0x72, 0x63, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12,
+	0x50, 0x0a, 0x13, 0x63, 0x72, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f,
+	0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65,
+	0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x43, 0x52, 0x43, 0x50, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x48, 0x00, 0x52, 0x11,
+	0x63, 0x72, 0x63, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x52, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x12, 0x56, 0x0a, 0x15, 0x63, 0x72, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x43,
+	0x52, 0x43, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69,
+	0x6e, 0x67, 0x48, 0x00, 0x52, 0x13, 0x63, 0x72, 0x63, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x56, 0x0a, 0x15, 0x63, 0x72, 0x63,
+	0x5f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72,
+	0x61, 0x77, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74,
+	0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x43, 0x52, 0x43, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73,
+	0x61, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x48, 0x00, 0x52, 0x13, 0x63, 0x72,
+	0x63, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61,
+	0x77, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x2f, 0x0a, 0x08,
+	0x43, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x69, 0x6e,
+	0x62, 0x61, 0x73, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x63, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x9b, 0x01,
+	0x0a, 0x05, 0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x61, 0x73, 0x73, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x22, 0x71, 0x0a, 0x0d, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x28, 0x0a, 0x05,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6c,
+	0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52,
+	0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x22, 0xa3,
+	0x01, 0x0a, 0x0c, 0x53, 0x69, 0x64, 0x65, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x50, 0x6f, 0x77, 0x12,
+	0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x69, 0x64, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x69, 0x64,
+	0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x69,
+	0x64, 0x65, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x69, 0x64, 0x65, 0x47, 0x65, 0x6e, 0x65, 0x73,
+	0x69, 0x73, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x22, 0xb1, 0x01, 0x0a, 0x15, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61,
+	0x77, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x69, 0x64, 0x65, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x21,
+	0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x32, 0x0a, 0x15, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x5f, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x13, 0x67, 0x65, 0x6e, 0x65, 0x73, 0x69, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x41, 0x0a, 0x1d, 0x73, 0x69, 0x64, 0x65, 0x5f, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x1a, 0x73, 0x69,
+	0x64, 0x65, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0xa4, 0x01, 0x0a, 0x17, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x41,
+	0x73, 0x73, 0x65, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x5f, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x13, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04,
+	0x52, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x12,
+	0x2e, 0x0a, 0x13, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x63, 0x72,
+	0x6f, 0x73, 0x73, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x22,
+	0xea, 0x01, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x28, 0x0a, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x6f, 0x64, 0x65, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
+	0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x69, 0x63, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6e, 0x65, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x58, 0x0a, 0x0e,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x12, 0x28,
+	0x0a, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x74, 0x0a, 0x13, 0x49, 0x6e, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x41, 0x72, 0x62, 0x69, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x6f, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x72, 0x62, 0x69, 0x74,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x72,
+	0x62, 0x69, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x58, 0x0a, 0x10,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72,
+	0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x6f, 0x64, 0x65, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x51, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e,
+	0x64, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
+	0x65, 0x6e, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xa9, 0x01, 0x0a, 0x06, 0x43, 0x52,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x6e, 0x69, 0x63, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6e, 0x69, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x3e, 0x0a, 0x0c, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x43, 0x52, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x62, 0x0a, 0x06, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x91, 0x03, 0x0a, 0x0b, 0x43, 0x52,
+	0x43, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a,
+	0x0a, 0x64, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x64, 0x72, 0x61, 0x66, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2d, 0x0a, 0x07,
+	0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x42, 0x75, 0x64, 0x67,
+	0x65, 0x74, 0x52, 0x07, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x31, 0x0a, 0x15, 0x63, 0x72, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x63, 0x69, 0x6c, 0x5f, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x64, 0x69, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x63, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x63, 0x69,
+	0x6c, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x44, 0x69, 0x64, 0x12, 0x3d, 0x0a, 0x1b, 0x63, 0x72,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x63, 0x69, 0x6c, 0x5f, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x5f,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x18, 0x63, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x63, 0x69, 0x6c, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0xa2, 0x01,
+	0x0a, 0x11, 0x43, 0x52, 0x43, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x52, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x61, 0x6c, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x6f, 0x74, 0x65,
+	0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76,
+	0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x70, 0x69,
+	0x6e, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x6f, 0x70, 0x69, 0x6e, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03,
+	0x64, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69,
+	0x67, 0x6e, 0x22, 0xe2, 0x03, 0x0a, 0x13, 0x43, 0x52, 0x43, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73,
+	0x61, 0x6c, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x70, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x48, 0x61, 0x73, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x28,
+	0x0a, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x14, 0x6e, 0x65, 0x77, 0x5f,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6e, 0x65, 0x77, 0x4f, 0x77, 0x6e, 0x65, 0x72,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x6e, 0x65, 0x77, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x6e, 0x65, 0x77, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x12, 0x43, 0x0a, 0x1e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x61, 0x72, 0x79, 0x5f,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x5f, 0x6f, 0x70, 0x69, 0x6e, 0x69, 0x6f, 0x6e, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1b, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x61, 0x72, 0x79, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x4f, 0x70, 0x69, 0x6e,
+	0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x3e, 0x0a, 0x1b, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x61, 0x72, 0x79, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x19, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x61, 0x72, 0x79, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x82, 0x01, 0x0a, 0x13, 0x43, 0x52, 0x43, 0x50,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x12,
+	0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x9e, 0x03, 0x0a,
+	0x08, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x6f,
+	0x64, 0x65, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6e,
+	0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e,
+	0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x6f,
+	0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x63, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0e, 0x69, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6c, 0x6c, 0x65, 0x67, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x69, 0x6c, 0x6c, 0x65, 0x67, 0x61,
+	0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x82, 0x01,
+	0x0a, 0x09, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x50, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x65, 0x72, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x65,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0xb0, 0x02, 0x0a, 0x0c, 0x41, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72, 0x73, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x61, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x61, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x65, 0x78, 0x74, 0x41, 0x72, 0x62, 0x69,
+	0x74, 0x65, 0x72, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x61, 0x6e,
+	0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x6e,
+	0x65, 0x78, 0x74, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a,
+	0x0f, 0x6f, 0x6e, 0x5f, 0x64, 0x75, 0x74, 0x79, 0x5f, 0x61, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x6e, 0x44, 0x75, 0x74, 0x79, 0x41, 0x72,
+	0x62, 0x69, 0x74, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x19, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x5f, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x16, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x54, 0x75, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x33, 0x0a, 0x16, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x13, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x75, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x9f, 0x02, 0x0a, 0x0b, 0x43, 0x52, 0x43, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x64,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x69, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x6f,
+	0x74, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x72, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0c, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x8a, 0x01, 0x0a, 0x0c, 0x43, 0x52, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65,
+	0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x43, 0x52, 0x43, 0x61, 0x6e,
+	0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x76, 0x6f, 0x74, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f,
+	0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0xcf, 0x02, 0x0a, 0x08, 0x43, 0x52, 0x4d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63,
+	0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63,
+	0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x6d, 0x70, 0x65, 0x61, 0x63, 0x68, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x69, 0x6d, 0x70, 0x65, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x73,
+	0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x5d, 0x0a, 0x09, 0x43, 0x52, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65,
+	0x6c, 0x61, 0x2e, 0x43, 0x52, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x07, 0x6d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xdd, 0x03, 0x0a, 0x13, 0x43, 0x52, 0x50, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x61, 0x6c, 0x42, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x48, 0x61, 0x73, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x78,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x48, 0x0a, 0x08, 0x63, 0x72, 0x5f, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e,
+	0x65, 0x6c, 0x61, 0x2e, 0x43, 0x52, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x42, 0x61,
+	0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x72, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x63, 0x72, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x30, 0x0a,
+	0x14, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x76, 0x6f, 0x74,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x27, 0x0a, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x65, 0x72, 0x6d,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x10, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e,
+	0x67, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e,
+	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x4f, 0x77,
+	0x6e, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x1a, 0x3a, 0x0a, 0x0c, 0x43, 0x72, 0x56,
+	0x6f, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x77, 0x0a, 0x14, 0x43, 0x52, 0x50, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x61, 0x6c, 0x42, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x3e, 0x0a,
+	0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x43,
+	0x52, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x42, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x6f,
+	0x0a, 0x19, 0x47, 0x65, 0x74, 0x43, 0x52, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0d, 0x70,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x1f, 0x0a, 0x0a, 0x64, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x64, 0x72, 0x61, 0x66, 0x74, 0x48,
+	0x61, 0x73, 0x68, 0x42, 0x0a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x22,
+	0xa0, 0x04, 0x0a, 0x0f, 0x43, 0x52, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x08, 0x70,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x43, 0x52, 0x43, 0x50,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73,
+	0x61, 0x6c, 0x48, 0x61, 0x73, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x44, 0x0a, 0x08, 0x63, 0x72, 0x5f, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e,
+	0x43, 0x52, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e,
+	0x43, 0x72, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x63, 0x72,
+	0x56, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x5f,
+	0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x12, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x6a, 0x65, 0x63,
+	0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x2b, 0x0a, 0x11, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x74, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x25, 0x0a,
+	0x0e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
+	0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x3a, 0x0a, 0x0c, 0x43, 0x72, 0x56, 0x6f, 0x74, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x32, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x22, 0x3c, 0x0a, 0x1c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x32, 0xb6, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x3a, 0x0a,
+	0x0c, 0x47, 0x65, 0x74, 0x42, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x2e,
+	0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x16, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e,
+	0x42, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x3c, 0x0a, 0x08, 0x47, 0x65, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1c, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e,
+	0x65, 0x6c, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c,
+	0x61, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x4e, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x2e, 0x65, 0x6c, 0x61, 0x73,
+	0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x65, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64, 0x52,
+	0x61, 0x77, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x2e,
+	0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x53, 0x65, 0x6e, 0x64,
+	0x52, 0x61, 0x77, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e,
+	0x65, 0x6c, 0x61, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x61, 0x77, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b,
+	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x6d, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x12, 0x2e, 0x65,
+	0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x19, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x52, 0x0a, 0x10, 0x45,
+	0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x6d, 0x61, 0x72, 0x74, 0x46, 0x65, 0x65, 0x12,
+	0x24, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x45, 0x73,
+	0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x6d, 0x61, 0x72, 0x74, 0x46, 0x65, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e,
+	0x65, 0x6c, 0x61, 0x2e, 0x46, 0x65, 0x65, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x12,
+	0x41, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x73,
+	0x12, 0x18, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x65, 0x6c, 0x61,
+	0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65,
+	0x72, 0x73, 0x12, 0x40, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72,
+	0x73, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e,
+	0x65, 0x6c, 0x61, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x65, 0x6c, 0x61, 0x73,
+	0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x41, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72, 0x73,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x47, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x52, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74,
+	0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61,
+	0x2e, 0x43, 0x52, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x3c, 0x0a,
+	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x52, 0x73, 0x12,
+	0x12, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c,
+	0x61, 0x2e, 0x43, 0x52, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x56, 0x0a, 0x17, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x52, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x42, 0x61, 0x73,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73,
+	0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x21, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x43,
+	0x52, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x42, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x73, 0x12, 0x5a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x52, 0x50, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x26, 0x2e, 0x65, 0x6c, 0x61, 0x73,
+	0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x52, 0x50, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e,
+	0x43, 0x52, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x4c, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x12, 0x23, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61,
+	0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f,
+	0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x30, 0x01, 0x12, 0x5e, 0x0a,
+	0x15, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x29, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73,
+	0x2e, 0x65, 0x6c, 0x61, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x65, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x65, 0x6c, 0x61, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x30, 0x01, 0x42, 0x36, 0x5a,
+	0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x6c, 0x61, 0x73,
+	0x74, 0x6f, 0x73, 0x2f, 0x45, 0x6c, 0x61, 0x73, 0x74, 0x6f, 0x73, 0x2e, 0x45, 0x4c, 0x41, 0x2f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ela_proto_rawDescOnce sync.Once
+	file_ela_proto_rawDescData = file_ela_proto_rawDesc
+)
+
+func file_ela_proto_rawDescGZIP() []byte {
+	file_ela_proto_rawDescOnce.Do(func() {
+		file_ela_proto_rawDescData = protoimpl.X.CompressGZIP(file_ela_proto_rawDescData)
+	})
+	return file_ela_proto_rawDescData
+}
+
+var file_ela_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
+var file_ela_proto_goTypes = []interface{}{
+	(*Empty)(nil),                        // 0: elastos.ela.Empty
+	(*BestBlock)(nil),                    // 1: elastos.ela.BestBlock
+	(*GetBlockRequest)(nil),              // 2: elastos.ela.GetBlockRequest
+	(*GetTransactionRequest)(nil),        // 3: elastos.ela.GetTransactionRequest
+	(*SendRawTransactionRequest)(nil),    // 4: elastos.ela.SendRawTransactionRequest
+	(*SendRawTransactionResponse)(nil),   // 5: elastos.ela.SendRawTransactionResponse
+	(*EstimateSmartFeeRequest)(nil),      // 6: elastos.ela.EstimateSmartFeeRequest
+	(*FeeEstimate)(nil),                  // 7: elastos.ela.FeeEstimate
+	(*ListRequest)(nil),                  // 8: elastos.ela.ListRequest
+	(*Block)(nil),                        // 9: elastos.ela.Block
+	(*Transactions)(nil),                 // 10: elastos.ela.Transactions
+	(*Transaction)(nil),                  // 11: elastos.ela.Transaction
+	(*Attribute)(nil),                    // 12: elastos.ela.Attribute
+	(*Input)(nil),                        // 13: elastos.ela.Input
+	(*Output)(nil),                       // 14: elastos.ela.Output
+	(*DefaultOutput)(nil),                // 15: elastos.ela.DefaultOutput
+	(*VoteOutput)(nil),                   // 16: elastos.ela.VoteOutput
+	(*VoteContent)(nil),                  // 17: elastos.ela.VoteContent
+	(*CandidateVotes)(nil),               // 18: elastos.ela.CandidateVotes
+	(*Program)(nil),                      // 19: elastos.ela.Program
+	(*Payload)(nil),                      // 20: elastos.ela.Payload
+	(*Coinbase)(nil),                     // 21: elastos.ela.Coinbase
+	(*Asset)(nil),                        // 22: elastos.ela.Asset
+	(*RegisterAsset)(nil),                // 23: elastos.ela.RegisterAsset
+	(*SideChainPow)(nil),                 // 24: elastos.ela.SideChainPow
+	(*WithdrawFromSideChain)(nil),        // 25: elastos.ela.WithdrawFromSideChain
+	(*TransferCrossChainAsset)(nil),      // 26: elastos.ela.TransferCrossChainAsset
+	(*ProducerInfo)(nil),                 // 27: elastos.ela.ProducerInfo
+	(*CancelProducer)(nil),               // 28: elastos.ela.CancelProducer
+	(*InactiveArbitrators)(nil),          // 29: elastos.ela.InactiveArbitrators
+	(*ActivateProducer)(nil),             // 30: elastos.ela.ActivateProducer
+	(*UpdateVersion)(nil),                // 31: elastos.ela.UpdateVersion
+	(*CRInfo)(nil),                       // 32: elastos.ela.CRInfo
+	(*UnregisterCR)(nil),                 // 33: elastos.ela.UnregisterCR
+	(*Budget)(nil),                       // 34: elastos.ela.Budget
+	(*CRCProposal)(nil),                  // 35: elastos.ela.CRCProposal
+	(*CRCProposalReview)(nil),            // 36: elastos.ela.CRCProposalReview
+	(*CRCProposalTracking)(nil),          // 37: elastos.ela.CRCProposalTracking
+	(*CRCProposalWithdraw)(nil),          // 38: elastos.ela.CRCProposalWithdraw
+	(*Producer)(nil),                     // 39: elastos.ela.Producer
+	(*Producers)(nil),                    // 40: elastos.ela.Producers
+	(*ArbitersInfo)(nil),                 // 41: elastos.ela.ArbitersInfo
+	(*CRCandidate)(nil),                  // 42: elastos.ela.CRCandidate
+	(*CRCandidates)(nil),                 // 43: elastos.ela.CRCandidates
+	(*CRMember)(nil),                     // 44: elastos.ela.CRMember
+	(*CRMembers)(nil),                    // 45: elastos.ela.CRMembers
+	(*CRProposalBaseState)(nil),          // 46: elastos.ela.CRProposalBaseState
+	(*CRProposalBaseStates)(nil),         // 47: elastos.ela.CRProposalBaseStates
+	(*GetCRProposalStateRequest)(nil),    // 48: elastos.ela.GetCRProposalStateRequest
+	(*CRProposalState)(nil),              // 49: elastos.ela.CRProposalState
+	(*SubscribeBlocksRequest)(nil),       // 50: elastos.ela.SubscribeBlocksRequest
+	(*SubscribeTransactionsRequest)(nil), // 51: elastos.ela.SubscribeTransactionsRequest
+	nil,                                  // 52: elastos.ela.CRProposalBaseState.CrVotesEntry
+	nil,                                  // 53: elastos.ela.CRProposalState.CrVotesEntry
+}
+var file_ela_proto_depIdxs = []int32{
+	11, // 0: elastos.ela.Block.transactions:type_name -> elastos.ela.Transaction
+	11, // 1: elastos.ela.Transactions.transactions:type_name -> elastos.ela.Transaction
+	20, // 2: elastos.ela.Transaction.payload:type_name -> elastos.ela.Payload
+	12, // 3: elastos.ela.Transaction.attributes:type_name -> elastos.ela.Attribute
+	13, // 4: elastos.ela.Transaction.inputs:type_name -> elastos.ela.Input
+	14, // 5: elastos.ela.Transaction.outputs:type_name -> elastos.ela.Output
+	19, // 6: elastos.ela.Transaction.programs:type_name -> elastos.ela.Program
+	15, // 7: elastos.ela.Output.default_output:type_name -> elastos.ela.DefaultOutput
+	16, // 8: elastos.ela.Output.vote_output:type_name -> elastos.ela.VoteOutput
+	17, // 9: elastos.ela.VoteOutput.contents:type_name -> elastos.ela.VoteContent
+	18, // 10: elastos.ela.VoteContent.candidates:type_name -> elastos.ela.CandidateVotes
+	21, // 11: elastos.ela.Payload.coinbase:type_name -> elastos.ela.Coinbase
+	23, // 12: elastos.ela.Payload.register_asset:type_name -> elastos.ela.RegisterAsset
+	24, // 13: elastos.ela.Payload.side_chain_pow:type_name -> elastos.ela.SideChainPow
+	25, // 14: elastos.ela.Payload.withdraw_from_side_chain:type_name -> elastos.ela.WithdrawFromSideChain
+	26, // 15: elastos.ela.Payload.transfer_cross_chain_asset:type_name -> elastos.ela.TransferCrossChainAsset
+	27, // 16: elastos.ela.Payload.producer_info:type_name -> elastos.ela.ProducerInfo
+	28, // 17: elastos.ela.Payload.cancel_producer:type_name -> elastos.ela.CancelProducer
+	29, // 18: elastos.ela.Payload.inactive_arbitrators:type_name -> elastos.ela.InactiveArbitrators
+	30, // 19: elastos.ela.Payload.activate_producer:type_name -> elastos.ela.ActivateProducer
+	31, // 20: elastos.ela.Payload.update_version:type_name -> elastos.ela.UpdateVersion
+	32, // 21: elastos.ela.Payload.cr_info:type_name -> elastos.ela.CRInfo
+	33, // 22: elastos.ela.Payload.unregister_cr:type_name -> elastos.ela.UnregisterCR
+	35, // 23: elastos.ela.Payload.crc_proposal:type_name -> elastos.ela.CRCProposal
+	36, // 24: elastos.ela.Payload.crc_proposal_review:type_name -> elastos.ela.CRCProposalReview
+	37, // 25: elastos.ela.Payload.crc_proposal_tracking:type_name -> elastos.ela.CRCProposalTracking
+	38, // 26: elastos.ela.Payload.crc_proposal_withdraw:type_name -> elastos.ela.CRCProposalWithdraw
+	22, // 27: elastos.ela.RegisterAsset.asset:type_name -> elastos.ela.Asset
+	34, // 28: elastos.ela.CRCProposal.budgets:type_name -> elastos.ela.Budget
+	39, // 29: elastos.ela.Producers.producers:type_name -> elastos.ela.Producer
+	42, // 30: elastos.ela.CRCandidates.candidates:type_name -> elastos.ela.CRCandidate
+	44, // 31: elastos.ela.CRMembers.members:type_name -> elastos.ela.CRMember
+	52, // 32: elastos.ela.CRProposalBaseState.cr_votes:type_name -> elastos.ela.CRProposalBaseState.CrVotesEntry
+	46, // 33: elastos.ela.CRProposalBaseStates.proposals:type_name -> elastos.ela.CRProposalBaseState
+	35, // 34: elastos.ela.CRProposalState.proposal:type_name -> elastos.ela.CRCProposal
+	53, // 35: elastos.ela.CRProposalState.cr_votes:type_name -> elastos.ela.CRProposalState.CrVotesEntry
+	0,  // 36: elastos.ela.Node.GetBestBlock:input_type -> elastos.ela.Empty
+	2,  // 37: elastos.ela.Node.GetBlock:input_type -> elastos.ela.GetBlockRequest
+	3,  // 38: elastos.ela.Node.GetTransaction:input_type -> elastos.ela.GetTransactionRequest
+	4,  // 39: elastos.ela.Node.SendRawTransaction:input_type -> elastos.ela.SendRawTransactionRequest
+	0,  // 40: elastos.ela.Node.GetMempool:input_type -> elastos.ela.Empty
+	6,  // 41: elastos.ela.Node.EstimateSmartFee:input_type -> elastos.ela.EstimateSmartFeeRequest
+	8,  // 42: elastos.ela.Node.ListProducers:input_type -> elastos.ela.ListRequest
+	0,  // 43: elastos.ela.Node.GetArbitersInfo:input_type -> elastos.ela.Empty
+	8,  // 44: elastos.ela.Node.ListCRCandidates:input_type -> elastos.ela.ListRequest
+	0,  // 45: elastos.ela.Node.ListCurrentCRs:input_type -> elastos.ela.Empty
+	8,  // 46: elastos.ela.Node.ListCRProposalBaseState:input_type -> elastos.ela.ListRequest
+	48, // 47: elastos.ela.Node.GetCRProposalState:input_type -> elastos.ela.GetCRProposalStateRequest
+	50, // 48: elastos.ela.Node.SubscribeBlocks:input_type -> elastos.ela.SubscribeBlocksRequest
+	51, // 49: elastos.ela.Node.SubscribeTransactions:input_type -> elastos.ela.SubscribeTransactionsRequest
+	1,  // 50: elastos.ela.Node.GetBestBlock:output_type -> elastos.ela.BestBlock
+	9,  // 51: elastos.ela.Node.GetBlock:output_type -> elastos.ela.Block
+	11, // 52: elastos.ela.Node.GetTransaction:output_type -> elastos.ela.Transaction
+	5,  // 53: elastos.ela.Node.SendRawTransaction:output_type -> elastos.ela.SendRawTransactionResponse
+	10, // 54: elastos.ela.Node.GetMempool:output_type -> elastos.ela.Transactions
+	7,  // 55: elastos.ela.Node.EstimateSmartFee:output_type -> elastos.ela.FeeEstimate
+	40, // 56: elastos.ela.Node.ListProducers:output_type -> elastos.ela.Producers
+	41, // 57: elastos.ela.Node.GetArbitersInfo:output_type -> elastos.ela.ArbitersInfo
+	43, // 58: elastos.ela.Node.ListCRCandidates:output_type -> elastos.ela.CRCandidates
+	45, // 59: elastos.ela.Node.ListCurrentCRs:output_type -> elastos.ela.CRMembers
+	47, // 60: elastos.ela.Node.ListCRProposalBaseState:output_type -> elastos.ela.CRProposalBaseStates
+	49, // 61: elastos.ela.Node.GetCRProposalState:output_type -> elastos.ela.CRProposalState
+	9,  // 62: elastos.ela.Node.SubscribeBlocks:output_type -> elastos.ela.Block
+	11, // 63: elastos.ela.Node.SubscribeTransactions:output_type -> elastos.ela.Transaction
+	50, // [50:64] is the sub-list for method output_type
+	36, // [36:50] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
+}
+
+func init() { file_ela_proto_init() }
+func file_ela_proto_init() {
+	if File_ela_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ela_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BestBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransactionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendRawTransactionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendRawTransactionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EstimateSmartFeeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeeEstimate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Block); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Transactions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Transaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attribute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Input); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Output); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DefaultOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoteOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoteContent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CandidateVotes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Program); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Payload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Coinbase); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Asset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterAsset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SideChainPow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithdrawFromSideChain); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferCrossChainAsset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProducerInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelProducer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InactiveArbitrators); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActivateProducer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateVersion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnregisterCR); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Budget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRCProposal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRCProposalReview); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRCProposalTracking); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRCProposalWithdraw); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Producer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Producers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArbitersInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRCandidate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRCandidates); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRMember); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRMembers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRProposalBaseState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRProposalBaseStates); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCRProposalStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CRProposalState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeBlocksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ela_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeTransactionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_ela_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*GetBlockRequest_Hash)(nil),
+		(*GetBlockRequest_Height)(nil),
+	}
+	file_ela_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*Output_DefaultOutput)(nil),
+		(*Output_VoteOutput)(nil),
+	}
+	file_ela_proto_msgTypes[20].OneofWrappers = []interface{}{
+		(*Payload_Coinbase)(nil),
+		(*Payload_RegisterAsset)(nil),
+		(*Payload_SideChainPow)(nil),
+		(*Payload_WithdrawFromSideChain)(nil),
+		(*Payload_TransferCrossChainAsset)(nil),
+		(*Payload_ProducerInfo)(nil),
+		(*Payload_CancelProducer)(nil),
+		(*Payload_InactiveArbitrators)(nil),
+		(*Payload_ActivateProducer)(nil),
+		(*Payload_UpdateVersion)(nil),
+		(*Payload_CrInfo)(nil),
+		(*Payload_UnregisterCr)(nil),
+		(*Payload_CrcProposal)(nil),
+		(*Payload_CrcProposalReview)(nil),
+		(*Payload_CrcProposalTracking)(nil),
+		(*Payload_CrcProposalWithdraw)(nil),
+	}
+	file_ela_proto_msgTypes[48].OneofWrappers = []interface{}{
+		(*GetCRProposalStateRequest_ProposalHash)(nil),
+		(*GetCRProposalStateRequest_DraftHash)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ela_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   54,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ela_proto_goTypes,
+		DependencyIndexes: file_ela_proto_depIdxs,
+		MessageInfos:      file_ela_proto_msgTypes,
+	}.Build()
+	File_ela_proto = out.File
+	file_ela_proto_rawDesc = nil
+	file_ela_proto_goTypes = nil
+	file_ela_proto_depIdxs = nil
+}
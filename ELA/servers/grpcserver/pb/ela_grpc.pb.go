@@ -0,0 +1,658 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// NodeClient is the client API for Node service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NodeClient interface {
+	// GetBestBlock returns the height and hash of the best block.
+	GetBestBlock(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BestBlock, error)
+	// GetBlock returns the block of the given hash or height.
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error)
+	// GetTransaction returns the transaction of the given id, it is looked
+	// up in the transaction pool if not found in the chain.
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	// SendRawTransaction submits a serialized transaction to the node.
+	SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendRawTransactionResponse, error)
+	// GetMempool returns transactions in the transaction pool.
+	GetMempool(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Transactions, error)
+	// EstimateSmartFee returns the fee rate needed for a transaction to be
+	// confirmed within the given count of blocks.
+	EstimateSmartFee(ctx context.Context, in *EstimateSmartFeeRequest, opts ...grpc.CallOption) (*FeeEstimate, error)
+	// ListProducers returns producers of the given state sorted by votes.
+	ListProducers(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*Producers, error)
+	// GetArbitersInfo returns current and next arbiters.
+	GetArbitersInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ArbitersInfo, error)
+	// ListCRCandidates returns CR candidates of the given state sorted by
+	// votes.
+	ListCRCandidates(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*CRCandidates, error)
+	// ListCurrentCRs returns members of current CR committee.
+	ListCurrentCRs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CRMembers, error)
+	// ListCRProposalBaseState returns CR proposals of the given state.
+	ListCRProposalBaseState(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*CRProposalBaseStates, error)
+	// GetCRProposalState returns the CR proposal of the given proposal hash
+	// or draft hash.
+	GetCRProposalState(ctx context.Context, in *GetCRProposalStateRequest, opts ...grpc.CallOption) (*CRProposalState, error)
+	// SubscribeBlocks streams blocks connected to the main chain.
+	SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (Node_SubscribeBlocksClient, error)
+	// SubscribeTransactions streams transactions accepted by the transaction
+	// pool.
+	SubscribeTransactions(ctx context.Context, in *SubscribeTransactionsRequest, opts ...grpc.CallOption) (Node_SubscribeTransactionsClient, error)
+}
+
+type nodeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNodeClient(cc grpc.ClientConnInterface) NodeClient {
+	return &nodeClient{cc}
+}
+
+func (c *nodeClient) GetBestBlock(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BestBlock, error) {
+	out := new(BestBlock)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/GetBestBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/GetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendRawTransactionResponse, error) {
+	out := new(SendRawTransactionResponse)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/SendRawTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetMempool(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Transactions, error) {
+	out := new(Transactions)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/GetMempool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) EstimateSmartFee(ctx context.Context, in *EstimateSmartFeeRequest, opts ...grpc.CallOption) (*FeeEstimate, error) {
+	out := new(FeeEstimate)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/EstimateSmartFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ListProducers(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*Producers, error) {
+	out := new(Producers)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/ListProducers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetArbitersInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ArbitersInfo, error) {
+	out := new(ArbitersInfo)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/GetArbitersInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ListCRCandidates(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*CRCandidates, error) {
+	out := new(CRCandidates)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/ListCRCandidates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ListCurrentCRs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CRMembers, error) {
+	out := new(CRMembers)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/ListCurrentCRs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ListCRProposalBaseState(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*CRProposalBaseStates, error) {
+	out := new(CRProposalBaseStates)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/ListCRProposalBaseState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetCRProposalState(ctx context.Context, in *GetCRProposalStateRequest, opts ...grpc.CallOption) (*CRProposalState, error) {
+	out := new(CRProposalState)
+	err := c.cc.Invoke(ctx, "/elastos.ela.Node/GetCRProposalState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (Node_SubscribeBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Node_serviceDesc.Streams[0], "/elastos.ela.Node/SubscribeBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeSubscribeBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_SubscribeBlocksClient interface {
+	Recv() (*Block, error)
+	grpc.ClientStream
+}
+
+type nodeSubscribeBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeSubscribeBlocksClient) Recv() (*Block, error) {
+	m := new(Block)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodeClient) SubscribeTransactions(ctx context.Context, in *SubscribeTransactionsRequest, opts ...grpc.CallOption) (Node_SubscribeTransactionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Node_serviceDesc.Streams[1], "/elastos.ela.Node/SubscribeTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeSubscribeTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_SubscribeTransactionsClient interface {
+	Recv() (*Transaction, error)
+	grpc.ClientStream
+}
+
+type nodeSubscribeTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeSubscribeTransactionsClient) Recv() (*Transaction, error) {
+	m := new(Transaction)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NodeServer is the server API for Node service.
+// All implementations must embed UnimplementedNodeServer
+// for forward compatibility
+type NodeServer interface {
+	// GetBestBlock returns the height and hash of the best block.
+	GetBestBlock(context.Context, *Empty) (*BestBlock, error)
+	// GetBlock returns the block of the given hash or height.
+	GetBlock(context.Context, *GetBlockRequest) (*Block, error)
+	// GetTransaction returns the transaction of the given id, it is looked
+	// up in the transaction pool if not found in the chain.
+	GetTransaction(context.Context, *GetTransactionRequest) (*Transaction, error)
+	// SendRawTransaction submits a serialized transaction to the node.
+	SendRawTransaction(context.Context, *SendRawTransactionRequest) (*SendRawTransactionResponse, error)
+	// GetMempool returns transactions in the transaction pool.
+	GetMempool(context.Context, *Empty) (*Transactions, error)
+	// EstimateSmartFee returns the fee rate needed for a transaction to be
+	// confirmed within the given count of blocks.
+	EstimateSmartFee(context.Context, *EstimateSmartFeeRequest) (*FeeEstimate, error)
+	// ListProducers returns producers of the given state sorted by votes.
+	ListProducers(context.Context, *ListRequest) (*Producers, error)
+	// GetArbitersInfo returns current and next arbiters.
+	GetArbitersInfo(context.Context, *Empty) (*ArbitersInfo, error)
+	// ListCRCandidates returns CR candidates of the given state sorted by
+	// votes.
+	ListCRCandidates(context.Context, *ListRequest) (*CRCandidates, error)
+	// ListCurrentCRs returns members of current CR committee.
+	ListCurrentCRs(context.Context, *Empty) (*CRMembers, error)
+	// ListCRProposalBaseState returns CR proposals of the given state.
+	ListCRProposalBaseState(context.Context, *ListRequest) (*CRProposalBaseStates, error)
+	// GetCRProposalState returns the CR proposal of the given proposal hash
+	// or draft hash.
+	GetCRProposalState(context.Context, *GetCRProposalStateRequest) (*CRProposalState, error)
+	// SubscribeBlocks streams blocks connected to the main chain.
+	SubscribeBlocks(*SubscribeBlocksRequest, Node_SubscribeBlocksServer) error
+	// SubscribeTransactions streams transactions accepted by the transaction
+	// pool.
+	SubscribeTransactions(*SubscribeTransactionsRequest, Node_SubscribeTransactionsServer) error
+	mustEmbedUnimplementedNodeServer()
+}
+
+// UnimplementedNodeServer must be embedded to have forward compatible implementations.
+type UnimplementedNodeServer struct {
+}
+
+func (UnimplementedNodeServer) GetBestBlock(context.Context, *Empty) (*BestBlock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBestBlock not implemented")
+}
+func (UnimplementedNodeServer) GetBlock(context.Context, *GetBlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedNodeServer) GetTransaction(context.Context, *GetTransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedNodeServer) SendRawTransaction(context.Context, *SendRawTransactionRequest) (*SendRawTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendRawTransaction not implemented")
+}
+func (UnimplementedNodeServer) GetMempool(context.Context, *Empty) (*Transactions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMempool not implemented")
+}
+func (UnimplementedNodeServer) EstimateSmartFee(context.Context, *EstimateSmartFeeRequest) (*FeeEstimate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateSmartFee not implemented")
+}
+func (UnimplementedNodeServer) ListProducers(context.Context, *ListRequest) (*Producers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducers not implemented")
+}
+func (UnimplementedNodeServer) GetArbitersInfo(context.Context, *Empty) (*ArbitersInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArbitersInfo not implemented")
+}
+func (UnimplementedNodeServer) ListCRCandidates(context.Context, *ListRequest) (*CRCandidates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCRCandidates not implemented")
+}
+func (UnimplementedNodeServer) ListCurrentCRs(context.Context, *Empty) (*CRMembers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCurrentCRs not implemented")
+}
+func (UnimplementedNodeServer) ListCRProposalBaseState(context.Context, *ListRequest) (*CRProposalBaseStates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCRProposalBaseState not implemented")
+}
+func (UnimplementedNodeServer) GetCRProposalState(context.Context, *GetCRProposalStateRequest) (*CRProposalState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCRProposalState not implemented")
+}
+func (UnimplementedNodeServer) SubscribeBlocks(*SubscribeBlocksRequest, Node_SubscribeBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlocks not implemented")
+}
+func (UnimplementedNodeServer) SubscribeTransactions(*SubscribeTransactionsRequest, Node_SubscribeTransactionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTransactions not implemented")
+}
+func (UnimplementedNodeServer) mustEmbedUnimplementedNodeServer() {}
+
+// UnsafeNodeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NodeServer will
+// result in compilation errors.
+type UnsafeNodeServer interface {
+	mustEmbedUnimplementedNodeServer()
+}
+
+func RegisterNodeServer(s grpc.ServiceRegistrar, srv NodeServer) {
+	s.RegisterService(&_Node_serviceDesc, srv)
+}
+
+func _Node_GetBestBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetBestBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/GetBestBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetBestBlock(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_SendRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRawTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).SendRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/SendRawTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).SendRawTransaction(ctx, req.(*SendRawTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetMempool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetMempool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/GetMempool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetMempool(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_EstimateSmartFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateSmartFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).EstimateSmartFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/EstimateSmartFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).EstimateSmartFee(ctx, req.(*EstimateSmartFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ListProducers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ListProducers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/ListProducers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ListProducers(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetArbitersInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetArbitersInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/GetArbitersInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetArbitersInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ListCRCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ListCRCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/ListCRCandidates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ListCRCandidates(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ListCurrentCRs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ListCurrentCRs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/ListCurrentCRs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ListCurrentCRs(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ListCRProposalBaseState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ListCRProposalBaseState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/ListCRProposalBaseState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ListCRProposalBaseState(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetCRProposalState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCRProposalStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetCRProposalState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/elastos.ela.Node/GetCRProposalState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetCRProposalState(ctx, req.(*GetCRProposalStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_SubscribeBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).SubscribeBlocks(m, &nodeSubscribeBlocksServer{stream})
+}
+
+type Node_SubscribeBlocksServer interface {
+	Send(*Block) error
+	grpc.ServerStream
+}
+
+type nodeSubscribeBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeSubscribeBlocksServer) Send(m *Block) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Node_SubscribeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).SubscribeTransactions(m, &nodeSubscribeTransactionsServer{stream})
+}
+
+type Node_SubscribeTransactionsServer interface {
+	Send(*Transaction) error
+	grpc.ServerStream
+}
+
+type nodeSubscribeTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeSubscribeTransactionsServer) Send(m *Transaction) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Node_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "elastos.ela.Node",
+	HandlerType: (*NodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBestBlock",
+			Handler:    _Node_GetBestBlock_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _Node_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _Node_GetTransaction_Handler,
+		},
+		{
+			MethodName: "SendRawTransaction",
+			Handler:    _Node_SendRawTransaction_Handler,
+		},
+		{
+			MethodName: "GetMempool",
+			Handler:    _Node_GetMempool_Handler,
+		},
+		{
+			MethodName: "EstimateSmartFee",
+			Handler:    _Node_EstimateSmartFee_Handler,
+		},
+		{
+			MethodName: "ListProducers",
+			Handler:    _Node_ListProducers_Handler,
+		},
+		{
+			MethodName: "GetArbitersInfo",
+			Handler:    _Node_GetArbitersInfo_Handler,
+		},
+		{
+			MethodName: "ListCRCandidates",
+			Handler:    _Node_ListCRCandidates_Handler,
+		},
+		{
+			MethodName: "ListCurrentCRs",
+			Handler:    _Node_ListCurrentCRs_Handler,
+		},
+		{
+			MethodName: "ListCRProposalBaseState",
+			Handler:    _Node_ListCRProposalBaseState_Handler,
+		},
+		{
+			MethodName: "GetCRProposalState",
+			Handler:    _Node_GetCRProposalState_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlocks",
+			Handler:       _Node_SubscribeBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeTransactions",
+			Handler:       _Node_SubscribeTransactions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "ela.proto",
+}
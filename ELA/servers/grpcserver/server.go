@@ -0,0 +1,522 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package grpcserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/common/log"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/events"
+	"github.com/elastos/Elastos.ELA/servers"
+	"github.com/elastos/Elastos.ELA/servers/errors"
+	"github.com/elastos/Elastos.ELA/servers/grpcserver/pb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultHost is the host the gRPC service listens on if GRPCHost is not
+// configured.
+const DefaultHost = "127.0.0.1"
+
+// server implements the Node service by the JSON-RPC handlers, so both
+// interfaces share the same parameter checks and service levels.
+type server struct {
+	pb.UnimplementedNodeServer
+
+	notifier *notifier
+}
+
+// StartServer starts the gRPC server, it blocks until the server stops.
+func StartServer() {
+	s := &server{notifier: newNotifier()}
+	events.Subscribe(func(e *events.Event) {
+		switch e.Type {
+		case events.ETBlockConnected:
+			s.notifier.notifyBlock(e.Data.(*types.Block))
+
+		case events.ETTransactionAccepted:
+			s.notifier.notifyTransaction(e.Data.(*types.Transaction))
+		}
+	})
+
+	opts, err := serverOptions()
+	if err != nil {
+		log.Fatal("gRPC server error: ", err.Error())
+		return
+	}
+	grpcServer := grpc.NewServer(opts...)
+	pb.RegisterNodeServer(grpcServer, s)
+
+	address := listenAddress()
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Fatal("Create listener error: ", err.Error())
+		return
+	}
+	if config.Parameters.GRPCCertPath == "" && !isLoopback(address) {
+		log.Warn("gRPC service listens on ", address, " without TLS")
+	}
+	if err := grpcServer.Serve(l); err != nil {
+		log.Fatal("gRPC serve error: ", err.Error())
+	}
+}
+
+// listenAddress returns the address the gRPC service listens on, which is
+// the loopback address if GRPCHost is not configured, so the service is not
+// exposed to other hosts unless it's asked to.
+func listenAddress() string {
+	host := config.Parameters.GRPCHost
+	if host == "" {
+		host = DefaultHost
+	}
+	return net.JoinHostPort(host, strconv.Itoa(config.Parameters.GRPCPort))
+}
+
+func isLoopback(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return host == "localhost" || ip != nil && ip.IsLoopback()
+}
+
+// serverOptions returns the options of the gRPC server, TLS is enabled if
+// the certificate and key are configured.
+func serverOptions() ([]grpc.ServerOption, error) {
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(unaryAuthInterceptor),
+		grpc.StreamInterceptor(streamAuthInterceptor),
+	}
+	certPath := config.Parameters.GRPCCertPath
+	keyPath := config.Parameters.GRPCKeyPath
+	if certPath == "" && keyPath == "" {
+		return opts, nil
+	}
+	if certPath == "" || keyPath == "" {
+		return nil, fmt.Errorf("both GRPCCertPath and GRPCKeyPath should" +
+			" be configured to enable TLS")
+	}
+	creds, err := credentials.NewServerTLSFromFile(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("load gRPC TLS certificate failed, %s", err)
+	}
+	return append(opts, grpc.Creds(creds)), nil
+}
+
+// call invokes the JSON-RPC handler and converts the error code of the
+// response to a gRPC status.
+func call(handler func(servers.Params) map[string]interface{},
+	params servers.Params) (interface{}, error) {
+	resp := handler(params)
+	code := resp["Error"].(errors.ServerErrCode)
+	if code != errors.Success {
+		return nil, status.Error(statusCode(code), fmt.Sprint(resp["Result"]))
+	}
+	return resp["Result"], nil
+}
+
+func statusCode(code errors.ServerErrCode) codes.Code {
+	switch code {
+	case errors.InvalidParams, errors.InvalidTransaction,
+		errors.IllegalDataFormat, errors.InvalidAsset:
+		return codes.InvalidArgument
+	case errors.InvalidMethod:
+		return codes.PermissionDenied
+	case errors.UnknownTransaction, errors.UnknownAsset, errors.UnknownBlock:
+		return codes.NotFound
+	case errors.PowServiceNotStarted:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+func (s *server) GetBestBlock(ctx context.Context,
+	req *pb.Empty) (*pb.BestBlock, error) {
+	result, err := call(servers.GetBlockHeight, nil)
+	if err != nil {
+		return nil, err
+	}
+	height := result.(uint32)
+	result, err = call(servers.GetBlockHash,
+		servers.Params{"height": float64(height)})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BestBlock{Height: height, Hash: result.(string)}, nil
+}
+
+func (s *server) GetBlock(ctx context.Context,
+	req *pb.GetBlockRequest) (*pb.Block, error) {
+	var hash string
+	switch b := req.Block.(type) {
+	case *pb.GetBlockRequest_Hash:
+		hash = b.Hash
+	case *pb.GetBlockRequest_Height:
+		result, err := call(servers.GetBlockHash,
+			servers.Params{"height": float64(b.Height)})
+		if err != nil {
+			return nil, status.Error(codes.NotFound, "unknown block height")
+		}
+		hash = result.(string)
+	default:
+		return nil, status.Error(codes.InvalidArgument,
+			"need block hash or height")
+	}
+
+	verbosity := 1
+	if req.Verbose {
+		verbosity = 2
+	}
+	result, err := call(servers.GetBlockByHash, servers.Params{
+		"blockhash": hash,
+		"verbosity": float64(verbosity),
+	})
+	if err != nil {
+		return nil, err
+	}
+	info := result.(servers.BlockInfo)
+	return toBlock(&info), nil
+}
+
+func (s *server) GetTransaction(ctx context.Context,
+	req *pb.GetTransactionRequest) (*pb.Transaction, error) {
+	result, err := call(servers.GetRawTransaction, servers.Params{
+		"txid":    req.Txid,
+		"verbose": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTransaction(result.(*servers.TransactionContextInfo)), nil
+}
+
+func (s *server) SendRawTransaction(ctx context.Context,
+	req *pb.SendRawTransactionRequest) (*pb.SendRawTransactionResponse, error) {
+	result, err := call(servers.SendRawTransaction,
+		servers.Params{"data": req.Data})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SendRawTransactionResponse{Txid: result.(string)}, nil
+}
+
+func (s *server) GetMempool(ctx context.Context,
+	req *pb.Empty) (*pb.Transactions, error) {
+	result, err := call(servers.GetTransactionPool, nil)
+	if err != nil {
+		return nil, err
+	}
+	txs := &pb.Transactions{}
+	for _, tx := range result.([]*servers.TransactionContextInfo) {
+		txs.Transactions = append(txs.Transactions, toTransaction(tx))
+	}
+	return txs, nil
+}
+
+func (s *server) EstimateSmartFee(ctx context.Context,
+	req *pb.EstimateSmartFeeRequest) (*pb.FeeEstimate, error) {
+	result, err := call(servers.EstimateSmartFee, servers.Params{
+		"confirmations": float64(req.Confirmations),
+		"verbose":       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	estimate := result.(*servers.RpcFeeEstimate)
+	return &pb.FeeEstimate{
+		FeeRate:    estimate.FeeRate,
+		Blocks:     estimate.Blocks,
+		Confidence: estimate.Confidence,
+	}, nil
+}
+
+// listParams converts a list request to the parameters of JSON-RPC list
+// methods.
+func listParams(req *pb.ListRequest) servers.Params {
+	params := servers.Params{"start": float64(req.Start)}
+	if req.State != "" {
+		params["state"] = req.State
+	}
+	if req.Limit > 0 {
+		params["limit"] = float64(req.Limit)
+	}
+	return params
+}
+
+func (s *server) ListProducers(ctx context.Context,
+	req *pb.ListRequest) (*pb.Producers, error) {
+	result, err := call(servers.ListProducers, listParams(req))
+	if err != nil {
+		return nil, err
+	}
+	return toProducers(result.(*servers.RpcProducersInfo)), nil
+}
+
+func (s *server) GetArbitersInfo(ctx context.Context,
+	req *pb.Empty) (*pb.ArbitersInfo, error) {
+	result, err := call(servers.GetArbitersInfo, nil)
+	if err != nil {
+		return nil, err
+	}
+	return toArbitersInfo(result.(*servers.RpcArbitersInfo)), nil
+}
+
+func (s *server) ListCRCandidates(ctx context.Context,
+	req *pb.ListRequest) (*pb.CRCandidates, error) {
+	result, err := call(servers.ListCRCandidates, listParams(req))
+	if err != nil {
+		return nil, err
+	}
+	return toCRCandidates(result.(*servers.RpcCrCandidatesInfo)), nil
+}
+
+func (s *server) ListCurrentCRs(ctx context.Context,
+	req *pb.Empty) (*pb.CRMembers, error) {
+	result, err := call(servers.ListCurrentCRs, nil)
+	if err != nil {
+		return nil, err
+	}
+	return toCRMembers(result.(*servers.RpcCrMembersInfo)), nil
+}
+
+func (s *server) ListCRProposalBaseState(ctx context.Context,
+	req *pb.ListRequest) (*pb.CRProposalBaseStates, error) {
+	result, err := call(servers.ListCRProposalBaseState, listParams(req))
+	if err != nil {
+		return nil, err
+	}
+	return toCRProposalBaseStates(
+		result.(*servers.RpcCRProposalBaseStateInfo)), nil
+}
+
+func (s *server) GetCRProposalState(ctx context.Context,
+	req *pb.GetCRProposalStateRequest) (*pb.CRProposalState, error) {
+	params := make(servers.Params)
+	switch p := req.Proposal.(type) {
+	case *pb.GetCRProposalStateRequest_ProposalHash:
+		params["proposalhash"] = p.ProposalHash
+	case *pb.GetCRProposalStateRequest_DraftHash:
+		params["drafthash"] = p.DraftHash
+	}
+	result, err := call(servers.GetCRProposalState, params)
+	if err != nil {
+		return nil, err
+	}
+	return toCRProposalState(result.(*servers.RpcCRProposalStateInfo)), nil
+}
+
+func (s *server) SubscribeBlocks(req *pb.SubscribeBlocksRequest,
+	stream pb.Node_SubscribeBlocksServer) error {
+	sub := s.notifier.subscribe(s.notifier.blockSubscribers)
+	defer s.notifier.unsubscribe(s.notifier.blockSubscribers, sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+
+		case <-sub.overflow:
+			return status.Error(codes.ResourceExhausted,
+				"subscriber can not keep up with new blocks")
+
+		case data := <-sub.queue:
+			info := servers.GetBlockInfo(data.(*types.Block), req.Verbose)
+			if err := stream.Send(toBlock(&info)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *server) SubscribeTransactions(req *pb.SubscribeTransactionsRequest,
+	stream pb.Node_SubscribeTransactionsServer) error {
+	programHashes := make(map[common.Uint168]struct{})
+	for _, address := range req.Addresses {
+		programHash, err := common.Uint168FromAddress(address)
+		if err != nil {
+			return status.Error(codes.InvalidArgument,
+				"invalid address: "+address)
+		}
+		programHashes[*programHash] = struct{}{}
+	}
+
+	sub := s.notifier.subscribe(s.notifier.txSubscribers)
+	defer s.notifier.unsubscribe(s.notifier.txSubscribers, sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+
+		case <-sub.overflow:
+			return status.Error(codes.ResourceExhausted,
+				"subscriber can not keep up with new transactions")
+
+		case data := <-sub.queue:
+			tx := data.(*types.Transaction)
+			if !paysTo(tx, programHashes) {
+				continue
+			}
+			info := servers.GetTransactionContextInfo(nil, tx)
+			if err := stream.Send(toTransaction(info)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// paysTo returns if the transaction has outputs to any of the program
+// hashes, it always returns true if no program hash is given.
+func paysTo(tx *types.Transaction, programHashes map[common.Uint168]struct{}) bool {
+	if len(programHashes) == 0 {
+		return true
+	}
+	for _, output := range tx.Outputs {
+		if _, ok := programHashes[output.ProgramHash]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func unaryAuthInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func streamAuthInterceptor(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// authorize checks the client address and the credentials in metadata by
+// the RpcConfiguration, the same way as the JSON-RPC server. Methods are
+// named by lower case gRPC method names in the access control.
+func authorize(ctx context.Context, fullMethod string) error {
+	if servers.ACL != nil {
+		credential, err := authenticate(ctx)
+		if err == nil {
+			err = credential.Allow(path.Base(fullMethod))
+		}
+		if err != nil {
+			log.Warn(err.Message)
+			return status.Error(accessCode(err.Code), err.Message)
+		}
+		return nil
+	}
+
+	if !clientAllowed(ctx) {
+		log.Warn("Client ip is not allowed")
+		return status.Error(codes.PermissionDenied, "Client ip is not allowed")
+	}
+	if !checkAuth(ctx) {
+		log.Warn("Client authenticate failed")
+		return status.Error(codes.Unauthenticated, "Client authenticate failed")
+	}
+	return nil
+}
+
+// authenticate returns the credential of the request by the access control,
+// the API key is taken from the "x-api-key" metadata.
+func authenticate(ctx context.Context) (*servers.Credential, *servers.AccessError) {
+	var remoteAddr, authorization, apiKey string
+	if p, ok := peer.FromContext(ctx); ok {
+		remoteAddr = p.Addr.String()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("authorization"); len(v) > 0 {
+			authorization = v[0]
+		}
+		if v := md.Get(strings.ToLower(servers.APIKeyHeader)); len(v) > 0 {
+			apiKey = v[0]
+		}
+	}
+	return servers.ACL.Authenticate(remoteAddr, authorization, apiKey)
+}
+
+func accessCode(code errors.ServerErrCode) codes.Code {
+	switch code {
+	case errors.InvalidToken:
+		return codes.Unauthenticated
+	case errors.RateLimited:
+		return codes.ResourceExhausted
+	default:
+		return codes.PermissionDenied
+	}
+}
+
+func clientAllowed(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	ipAbbr, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return false
+	}
+	remoteIp := net.ParseIP(ipAbbr)
+	if remoteIp == nil {
+		return false
+	}
+	if remoteIp.IsLoopback() {
+		return true
+	}
+
+	for _, cfgIp := range config.Parameters.RpcConfiguration.WhiteIPList {
+		// WhiteIPList have 0.0.0.0 allow all ip in
+		if cfgIp == "0.0.0.0" || cfgIp == remoteIp.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func checkAuth(ctx context.Context) bool {
+	rpcConfig := config.Parameters.RpcConfiguration
+	if rpcConfig.User == rpcConfig.Pass && len(rpcConfig.User) == 0 {
+		return true
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	authHeader := md.Get("authorization")
+	if len(authHeader) <= 0 {
+		return false
+	}
+
+	authSha256 := sha256.Sum256([]byte(authHeader[0]))
+
+	login := rpcConfig.User + ":" + rpcConfig.Pass
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
+	cfgAuthSha256 := sha256.Sum256([]byte(auth))
+
+	return subtle.ConstantTimeCompare(authSha256[:], cfgAuthSha256[:]) == 1
+}
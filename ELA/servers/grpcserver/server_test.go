@@ -0,0 +1,330 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package grpcserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/common/log"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/outputpayload"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/servers"
+	"github.com/elastos/Elastos.ELA/servers/errors"
+	"github.com/elastos/Elastos.ELA/servers/grpcserver/pb"
+	"github.com/elastos/Elastos.ELA/utils/test"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func init() {
+	log.NewDefault(test.NodeLogPath, 0, 0, 0)
+	config.Parameters = &config.Configuration{}
+}
+
+func TestCall(t *testing.T) {
+	result, err := call(func(servers.Params) map[string]interface{} {
+		return servers.ResponsePack(errors.Success, "result")
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "result", result)
+
+	_, err = call(func(servers.Params) map[string]interface{} {
+		return servers.ResponsePack(errors.UnknownBlock, "unknown block")
+	}, nil)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "unknown block", status.Convert(err).Message())
+
+	assert.Equal(t, codes.InvalidArgument, statusCode(errors.InvalidParams))
+	assert.Equal(t, codes.PermissionDenied, statusCode(errors.InvalidMethod))
+	assert.Equal(t, codes.Unavailable, statusCode(errors.PowServiceNotStarted))
+	assert.Equal(t, codes.Internal, statusCode(errors.InternalError))
+}
+
+func TestPaysTo(t *testing.T) {
+	a := common.Uint168{1}
+	b := common.Uint168{2}
+	tx := &types.Transaction{Outputs: []*types.Output{{ProgramHash: a}}}
+
+	assert.True(t, paysTo(tx, nil))
+	assert.True(t, paysTo(tx, map[common.Uint168]struct{}{a: {}, b: {}}))
+	assert.False(t, paysTo(tx, map[common.Uint168]struct{}{b: {}}))
+}
+
+func TestAuthorize(t *testing.T) {
+	defer func(cfg config.RpcConfiguration) {
+		config.Parameters.RpcConfiguration = cfg
+	}(config.Parameters.RpcConfiguration)
+
+	fromAddr := func(ip string, auth string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 20000},
+		})
+		if auth != "" {
+			ctx = metadata.NewIncomingContext(ctx,
+				metadata.Pairs("authorization", auth))
+		}
+		return ctx
+	}
+	method := "/pb.Node/GetBestBlock"
+
+	config.Parameters.RpcConfiguration = config.RpcConfiguration{}
+	assert.NoError(t, authorize(fromAddr("127.0.0.1", ""), method))
+	assert.Equal(t, codes.PermissionDenied,
+		status.Code(authorize(fromAddr("10.0.0.1", ""), method)))
+	assert.Equal(t, codes.PermissionDenied,
+		status.Code(authorize(context.Background(), method)))
+
+	config.Parameters.RpcConfiguration.WhiteIPList = []string{"10.0.0.1"}
+	assert.NoError(t, authorize(fromAddr("10.0.0.1", ""), method))
+	assert.Error(t, authorize(fromAddr("10.0.0.2", ""), method))
+
+	config.Parameters.RpcConfiguration.User = "user"
+	config.Parameters.RpcConfiguration.Pass = "pass"
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:pass"))
+	wrong := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:wrong"))
+	assert.NoError(t, authorize(fromAddr("10.0.0.1", basic), method))
+	assert.Equal(t, codes.Unauthenticated,
+		status.Code(authorize(fromAddr("10.0.0.1", wrong), method)))
+	assert.Equal(t, codes.Unauthenticated,
+		status.Code(authorize(fromAddr("10.0.0.1", ""), method)))
+}
+
+func TestSubscribeTransactions(t *testing.T) {
+	s := &server{notifier: newNotifier()}
+	opts, err := serverOptions()
+	if !assert.NoError(t, err) {
+		return
+	}
+	grpcServer := grpc.NewServer(opts...)
+	pb.RegisterNodeServer(grpcServer, s)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	go grpcServer.Serve(l)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+	client := pb.NewNodeClient(conn)
+
+	programHash := common.Uint168{33, 1}
+	address, err := programHash.ToAddress()
+	assert.NoError(t, err)
+
+	invalid, err := client.SubscribeTransactions(context.Background(),
+		&pb.SubscribeTransactionsRequest{Addresses: []string{"invalid"}})
+	if assert.NoError(t, err) {
+		_, err = invalid.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.SubscribeTransactions(ctx,
+		&pb.SubscribeTransactionsRequest{Addresses: []string{address}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// wait for the subscription before notifying
+	for {
+		s.notifier.mtx.Lock()
+		count := len(s.notifier.txSubscribers)
+		s.notifier.mtx.Unlock()
+		if count > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	newTx := func(programHash common.Uint168, lockTime uint32) *types.Transaction {
+		return &types.Transaction{
+			TxType:   types.TransferAsset,
+			Payload:  &payload.TransferAsset{},
+			LockTime: lockTime,
+			Outputs: []*types.Output{{
+				ProgramHash: programHash,
+				Value:       common.Fixed64(100),
+				Type:        types.OTNone,
+				Payload:     &outputpayload.DefaultOutput{},
+			}},
+		}
+	}
+	other := newTx(common.Uint168{33, 2}, 1)
+	paid := newTx(programHash, 2)
+	s.notifier.notifyTransaction(other)
+	s.notifier.notifyTransaction(paid)
+
+	tx, err := stream.Recv()
+	if assert.NoError(t, err) {
+		assert.Equal(t, servers.ToReversedString(paid.Hash()), tx.Txid)
+		assert.Equal(t, uint32(2), tx.LockTime)
+		if assert.Len(t, tx.Outputs, 1) {
+			assert.Equal(t, address, tx.Outputs[0].Address)
+		}
+	}
+}
+
+func TestListenAddress(t *testing.T) {
+	defer func(host string, port int) {
+		config.Parameters.GRPCHost, config.Parameters.GRPCPort = host, port
+	}(config.Parameters.GRPCHost, config.Parameters.GRPCPort)
+
+	config.Parameters.GRPCPort = 20337
+	config.Parameters.GRPCHost = ""
+	assert.Equal(t, "127.0.0.1:20337", listenAddress())
+	assert.True(t, isLoopback(listenAddress()))
+
+	config.Parameters.GRPCHost = "0.0.0.0"
+	assert.Equal(t, "0.0.0.0:20337", listenAddress())
+	assert.False(t, isLoopback(listenAddress()))
+
+	config.Parameters.GRPCHost = "::1"
+	assert.Equal(t, "[::1]:20337", listenAddress())
+	assert.True(t, isLoopback(listenAddress()))
+}
+
+// writeTestCertificate writes a self-signed certificate of 127.0.0.1 and its
+// key into dir.
+func writeTestCertificate(t *testing.T, dir string) (certPath,
+	keyPath string, pool *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template,
+		&key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPath = filepath.Join(dir, "cert.pem")
+	keyPath = filepath.Join(dir, "key.pem")
+	err = ioutil.WriteFile(certPath, pem.EncodeToMemory(
+		&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(keyPath, pem.EncodeToMemory(
+		&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool = x509.NewCertPool()
+	pool.AddCert(cert)
+	return certPath, keyPath, pool
+}
+
+func TestServerOptions_TLS(t *testing.T) {
+	defer func(certPath, keyPath string) {
+		config.Parameters.GRPCCertPath = certPath
+		config.Parameters.GRPCKeyPath = keyPath
+	}(config.Parameters.GRPCCertPath, config.Parameters.GRPCKeyPath)
+	dir, err := ioutil.TempDir("", "grpcserver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certPath, keyPath, pool := writeTestCertificate(t, dir)
+
+	// both of the certificate and key are required
+	config.Parameters.GRPCCertPath, config.Parameters.GRPCKeyPath = certPath, ""
+	_, err = serverOptions()
+	assert.Error(t, err)
+	config.Parameters.GRPCCertPath, config.Parameters.GRPCKeyPath = "", keyPath
+	_, err = serverOptions()
+	assert.Error(t, err)
+	config.Parameters.GRPCCertPath = filepath.Join(dir, "missing.pem")
+	_, err = serverOptions()
+	assert.Error(t, err)
+
+	config.Parameters.GRPCCertPath = certPath
+	opts, err := serverOptions()
+	if !assert.NoError(t, err) {
+		return
+	}
+	grpcServer := grpc.NewServer(opts...)
+	pb.RegisterNodeServer(grpcServer, &server{notifier: newNotifier()})
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	go grpcServer.Serve(l)
+	defer grpcServer.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	request := &pb.SubscribeTransactionsRequest{Addresses: []string{"invalid"}}
+
+	// the request reaches the handler over TLS
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(
+		credentials.NewTLS(&tls.Config{RootCAs: pool})))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+	stream, err := pb.NewNodeClient(conn).SubscribeTransactions(ctx, request)
+	if assert.NoError(t, err) {
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	// plaintext clients are not served
+	plain, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer plain.Close()
+	stream, err = pb.NewNodeClient(plain).SubscribeTransactions(ctx, request)
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -6,6 +6,7 @@
 package httpjsonrpc
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
@@ -41,6 +42,10 @@ const (
 
 	// MaxRPCRead is the maximum buffer size for reading request.
 	MaxRPCRead = 1024 * 1024 * 8
+
+	// DefaultMaxBatchSize is the maximum count of requests in a batch if
+	// MaxBatchSize is not configured.
+	DefaultMaxBatchSize = 100
 )
 
 func StartRPCServer() {
@@ -58,18 +63,29 @@ func StartRPCServer() {
 	mainMux["getrawtransaction"] = GetRawTransaction
 	mainMux["getneighbors"] = GetNeighbors
 	mainMux["getnodestate"] = GetNodeState
+	mainMux["getpeerinfo"] = GetPeerInfo
+	mainMux["addnode"] = AddNode
+	mainMux["disconnectnode"] = DisconnectNode
+	mainMux["setban"] = SetBan
+	mainMux["listbanned"] = ListBanned
+	mainMux["clearbanned"] = ClearBanned
 	mainMux["sendrawtransaction"] = SendRawTransaction
+	mainMux["testmempoolaccept"] = TestMempoolAccept
 	mainMux["getarbitratorgroupbyheight"] = GetArbitratorGroupByHeight
 	mainMux["getbestblockhash"] = GetBestBlockHash
 	mainMux["getblockcount"] = GetBlockCount
 	mainMux["getblockbyheight"] = GetBlockByHeight
 	mainMux["getexistwithdrawtransactions"] = GetExistWithdrawTransactions
 	mainMux["getreceivedbyaddress"] = GetReceivedByAddress
+	mainMux["gettxoutsetinfo"] = GetTxOutSetInfo
 	// wallet interfaces
 	mainMux["getamountbyinputs"] = GetAmountByInputs
 	mainMux["getutxosbyamount"] = GetUTXOsByAmount
 	mainMux["listunspent"] = ListUnspent
+	mainMux["getaddresstransactions"] = GetAddressTransactions
+	mainMux["getspendinginfo"] = GetSpendingInfo
 	mainMux["createrawtransaction"] = CreateRawTransaction
+	mainMux["createrawspecialtransaction"] = CreateRawSpecialTransaction
 	mainMux["decoderawtransaction"] = DecodeRawTransaction
 	mainMux["signrawtransactionwithkey"] = SignRawTransactionWithKey
 	// aux interfaces
@@ -120,8 +136,18 @@ func StartRPCServer() {
 //this is the function that should be called in order to answer an rpc call
 //should be registered like "http.AddMethod("/", httpjsonrpc.Handle)"
 func Handle(w http.ResponseWriter, r *http.Request) {
-	isClientAllowed := clientAllowed(r)
-	if !isClientAllowed {
+	// Credentials in RpcConfiguration take the place of the User, Pass and
+	// WhiteIPList settings if configured.
+	var credential *Credential
+	if ACL != nil {
+		var accessErr *AccessError
+		credential, accessErr = ACL.AuthenticateRequest(r)
+		if accessErr != nil {
+			log.Warn(accessErr.Message)
+			RPCError(w, accessErr.HTTPStatus(), accessErr.Code, accessErr.Message)
+			return
+		}
+	} else if !clientAllowed(r) {
 		log.Warn("Client ip is not allowed")
 		RPCError(w, http.StatusForbidden, InternalError, "Client ip is not allowed")
 		return
@@ -139,8 +165,7 @@ func Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isCheckAuthOk := checkAuth(r)
-	if !isCheckAuthOk {
+	if credential == nil && !checkAuth(r) {
 		log.Warn("Client authenticate failed")
 		RPCError(w, http.StatusUnauthorized, InternalError, "Client authenticate failed")
 		return
@@ -153,6 +178,12 @@ func Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A JSON array is a batch of requests, see JSON-RPC 2.0 specification.
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		handleBatch(w, trimmed, credential)
+		return
+	}
+
 	request := make(map[string]interface{})
 	err = json.Unmarshal(body, &request)
 	if err != nil {
@@ -160,16 +191,107 @@ func Handle(w http.ResponseWriter, r *http.Request) {
 		RPCError(w, http.StatusBadRequest, ParseError, "JSON-RPC request parsing error:"+err.Error())
 		return
 	}
+
+	response, reqErr := callMethod(request, credential)
+	if isNotification(request) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if reqErr != nil {
+		RPCError(w, reqErr.httpStatus, reqErr.code, reqErr.message)
+		return
+	}
+
+	data, _ := json.Marshal(response)
+	w.Header().Set("Content-type", "application/json")
+	w.Write(data)
+}
+
+// requestError describes a request can not be processed, with the HTTP
+// status code returned if it is not in a batch.
+type requestError struct {
+	httpStatus int
+	code       elaErr.ServerErrCode
+	message    string
+}
+
+// handleBatch process a batch of requests in order, responses are returned
+// in the same order except notifications which have no response.
+func handleBatch(w http.ResponseWriter, body []byte, credential *Credential) {
+	var requests []interface{}
+	if err := json.Unmarshal(body, &requests); err != nil {
+		log.Error("JSON-RPC batch request parsing error: ", err)
+		RPCError(w, http.StatusBadRequest, ParseError, "JSON-RPC request parsing error:"+err.Error())
+		return
+	}
+	if len(requests) == 0 {
+		RPCError(w, http.StatusBadRequest, InvalidRequest, "JSON-RPC batch request is empty")
+		return
+	}
+	if maxSize := maxBatchSize(); len(requests) > maxSize {
+		RPCError(w, http.StatusBadRequest, InvalidRequest,
+			"JSON-RPC batch request exceeds the max size "+strconv.Itoa(maxSize))
+		return
+	}
+
+	responses := make([]map[string]interface{}, 0, len(requests))
+	for _, r := range requests {
+		request, ok := r.(map[string]interface{})
+		if !ok {
+			responses = append(responses, errorResponse(nil, InvalidRequest,
+				"JSON-RPC request must be an object"))
+			continue
+		}
+
+		response, reqErr := callMethod(request, credential)
+		if isNotification(request) {
+			continue
+		}
+		if reqErr != nil {
+			response = errorResponse(request["id"], reqErr.code, reqErr.message)
+		}
+		responses = append(responses, response)
+	}
+
+	// A batch of notifications has nothing to return.
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	data, _ := json.Marshal(responses)
+	w.Header().Set("Content-type", "application/json")
+	w.Write(data)
+}
+
+// maxBatchSize returns the maximum count of requests in a batch.
+func maxBatchSize() int {
+	if size := config.Parameters.RpcConfiguration.MaxBatchSize; size > 0 {
+		return size
+	}
+	return DefaultMaxBatchSize
+}
+
+// callMethod calls the corresponding function of the request and returns the
+// response, or an error if the request is invalid or not permitted by the
+// credential. The credential is nil if no credential is configured.
+func callMethod(request map[string]interface{},
+	credential *Credential) (map[string]interface{}, *requestError) {
 	//get the corresponding function
 	requestMethod, ok := request["method"].(string)
 	if !ok {
-		RPCError(w, http.StatusBadRequest, InvalidRequest, "JSON-RPC need a method")
-		return
+		return nil, &requestError{http.StatusBadRequest, InvalidRequest,
+			"JSON-RPC need a method"}
 	}
 	method, ok := mainMux[requestMethod]
 	if !ok {
-		RPCError(w, http.StatusNotFound, MethodNotFound, "JSON-RPC method "+requestMethod+" not found")
-		return
+		return nil, &requestError{http.StatusNotFound, MethodNotFound,
+			"JSON-RPC method " + requestMethod + " not found"}
+	}
+	if credential != nil {
+		if err := credential.Allow(requestMethod); err != nil {
+			return nil, &requestError{err.HTTPStatus(), err.Code, err.Message}
+		}
 	}
 
 	requestParams := request["params"]
@@ -185,15 +307,14 @@ func Handle(w http.ResponseWriter, r *http.Request) {
 	case map[string]interface{}:
 		params = Params(requestParams)
 	default:
-		RPCError(w, http.StatusBadRequest, InvalidRequest, "params format error, must be an array or a map")
-		return
+		return nil, &requestError{http.StatusBadRequest, InvalidRequest,
+			"params format error, must be an array or a map"}
 	}
 	log.Debug("RPC method:", requestMethod)
 
 	response := method(params)
-	var data []byte
 	if response["Error"] != elaErr.ServerErrCode(0) {
-		data, _ = json.Marshal(map[string]interface{}{
+		return map[string]interface{}{
 			"jsonrpc": "2.0",
 			"result":  nil,
 			"error": map[string]interface{}{
@@ -201,18 +322,39 @@ func Handle(w http.ResponseWriter, r *http.Request) {
 				"message": response["Result"],
 				"id":      request["id"],
 			},
-		})
+			"id": request["id"],
+		}, nil
+	}
 
-	} else {
-		data, _ = json.Marshal(map[string]interface{}{
-			"jsonrpc": "2.0",
-			"result":  response["Result"],
-			"id":      request["id"],
-			"error":   nil,
-		})
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"result":  response["Result"],
+		"id":      request["id"],
+		"error":   nil,
+	}, nil
+}
+
+// isNotification returns if the request is a JSON-RPC 2.0 notification, which
+// is a request without an "id" member and expects no response.
+func isNotification(request map[string]interface{}) bool {
+	if version, ok := request["jsonrpc"].(string); !ok || version != "2.0" {
+		return false
+	}
+	_, ok := request["id"]
+	return !ok
+}
+
+func errorResponse(id interface{}, code elaErr.ServerErrCode, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"result":  nil,
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+			"id":      id,
+		},
+		"id": id,
 	}
-	w.Header().Set("Content-type", "application/json")
-	w.Write(data)
 }
 
 func clientAllowed(r *http.Request) bool {
@@ -274,15 +416,7 @@ func checkAuth(r *http.Request) bool {
 }
 
 func RPCError(w http.ResponseWriter, httpStatus int, code elaErr.ServerErrCode, message string) {
-	data, _ := json.Marshal(map[string]interface{}{
-		"jsonrpc": "2.0",
-		"result":  nil,
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-			"id":      nil,
-		},
-	})
+	data, _ := json.Marshal(errorResponse(nil, code, message))
 	w.Header().Set("Content-type", "application/json")
 	w.WriteHeader(httpStatus)
 	w.Write(data)
@@ -300,6 +434,12 @@ func convertParams(method string, params []interface{}) Params {
 		return FromArray(params, "blockhash", "verbosity")
 	case "setloglevel":
 		return FromArray(params, "level")
+	case "addnode":
+		return FromArray(params, "node", "command")
+	case "disconnectnode":
+		return FromArray(params, "address", "nodeid")
+	case "setban":
+		return FromArray(params, "host", "command", "bantime", "absolute")
 	case "getrawtransaction":
 		return FromArray(params, "txid", "verbose")
 	case "getarbitratorgroupbyheight":
@@ -310,14 +450,20 @@ func convertParams(method string, params []interface{}) Params {
 		return FromArray(params, "count")
 	case "sendrawtransaction":
 		return FromArray(params, "data")
+	case "testmempoolaccept":
+		return FromArray(params, "data")
 	case "listunspent":
 		return FromArray(params, "addresses")
 	case "getreceivedbyaddress":
 		return FromArray(params, "address")
+	case "getaddresstransactions":
+		return FromArray(params, "address", "start", "limit")
+	case "getspendinginfo":
+		return FromArray(params, "txid", "vout")
 	case "getblockbyheight":
 		return FromArray(params, "height")
 	case "estimatesmartfee":
-		return FromArray(params, "confirmations")
+		return FromArray(params, "confirmations", "verbose")
 	default:
 		return Params{}
 	}
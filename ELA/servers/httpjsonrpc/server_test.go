@@ -0,0 +1,169 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package httpjsonrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/common/log"
+	. "github.com/elastos/Elastos.ELA/servers"
+	elaErr "github.com/elastos/Elastos.ELA/servers/errors"
+	"github.com/elastos/Elastos.ELA/utils/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	log.NewDefault(test.NodeLogPath, 0, 0, 0)
+	config.Parameters = &config.Configuration{}
+}
+
+func setupBatchTest() *int {
+	calls := 0
+	mainMux = map[string]func(Params) map[string]interface{}{
+		"echo": func(params Params) map[string]interface{} {
+			calls++
+			return map[string]interface{}{
+				"Result": params["value"],
+				"Error":  elaErr.ServerErrCode(0),
+			}
+		},
+	}
+	return &calls
+}
+
+func TestHandleBatch_Empty(t *testing.T) {
+	calls := setupBatchTest()
+
+	w := httptest.NewRecorder()
+	handleBatch(w, []byte("[]"), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, 0, *calls)
+
+	var resp map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, float64(InvalidRequest), resp["error"].(map[string]interface{})["code"])
+}
+
+func TestHandleBatch_MixedNotification(t *testing.T) {
+	calls := setupBatchTest()
+
+	body := `[
+		{"jsonrpc": "2.0", "method": "echo", "params": {"value": "a"}, "id": 1},
+		{"jsonrpc": "2.0", "method": "echo", "params": {"value": "b"}},
+		{"jsonrpc": "2.0", "method": "unknown", "id": 2},
+		1
+	]`
+	w := httptest.NewRecorder()
+	handleBatch(w, []byte(body), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, *calls)
+
+	var resp []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	if !assert.Len(t, resp, 3) {
+		return
+	}
+	assert.Equal(t, float64(1), resp[0]["id"])
+	assert.Equal(t, "a", resp[0]["result"])
+	assert.Nil(t, resp[0]["error"])
+	assert.Equal(t, float64(2), resp[1]["id"])
+	assert.Equal(t, float64(MethodNotFound),
+		resp[1]["error"].(map[string]interface{})["code"])
+	assert.Nil(t, resp[2]["id"])
+	assert.Equal(t, float64(InvalidRequest),
+		resp[2]["error"].(map[string]interface{})["code"])
+
+	// a batch of notifications has no response
+	calls = setupBatchTest()
+	w = httptest.NewRecorder()
+	handleBatch(w, []byte(`[{"jsonrpc": "2.0", "method": "echo"}]`), nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, 0, w.Body.Len())
+	assert.Equal(t, 1, *calls)
+}
+
+func TestHandleBatch_Oversized(t *testing.T) {
+	calls := setupBatchTest()
+	defer func(size int) {
+		config.Parameters.RpcConfiguration.MaxBatchSize = size
+	}(config.Parameters.RpcConfiguration.MaxBatchSize)
+
+	request := `{"jsonrpc": "2.0", "method": "echo", "id": 1}`
+	batch := func(n int) []byte {
+		return []byte("[" + strings.Repeat(request+",", n-1) + request + "]")
+	}
+
+	config.Parameters.RpcConfiguration.MaxBatchSize = 0
+	w := httptest.NewRecorder()
+	handleBatch(w, batch(DefaultMaxBatchSize+1), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, 0, *calls)
+
+	config.Parameters.RpcConfiguration.MaxBatchSize = 3
+	w = httptest.NewRecorder()
+	handleBatch(w, batch(4), nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, 0, *calls)
+
+	var resp map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, float64(InvalidRequest), resp["error"].(map[string]interface{})["code"])
+
+	w = httptest.NewRecorder()
+	handleBatch(w, batch(3), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestHandle_AccessControl(t *testing.T) {
+	calls := setupBatchTest()
+	defer func(acl *AccessControl) { ACL = acl }(ACL)
+
+	var err error
+	ACL, err = NewAccessControl(&config.RpcConfiguration{
+		Credentials: []config.RpcCredential{
+			{APIKey: "key", Methods: []string{"echo"}, RateLimit: 1},
+			{Methods: []string{"getblockcount"}},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	request := func(apiKey string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(
+			`{"jsonrpc": "2.0", "method": "echo", "params": {"value": "a"}, "id": 1}`))
+		r.Header.Set("Content-Type", "application/json")
+		if apiKey != "" {
+			r.Header.Set(APIKeyHeader, apiKey)
+		}
+		w := httptest.NewRecorder()
+		Handle(w, r)
+		return w
+	}
+
+	w := request("key")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, *calls)
+
+	// rate limit of the credential
+	w = request("key")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// method not allowed for the anonymous credential
+	w = request("")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = request("unknown")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, 1, *calls)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package httpmetrics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/common/log"
+	"github.com/elastos/Elastos.ELA/common/metrics"
+	dp2p "github.com/elastos/Elastos.ELA/dpos/p2p"
+	"github.com/elastos/Elastos.ELA/servers"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	chainHeightDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "chain", "height"),
+		"Height of the best block.", nil, nil)
+
+	mempoolTxsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "mempool", "transactions"),
+		"Count of transactions in the transaction pool.", nil, nil)
+
+	mempoolBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "mempool", "bytes"),
+		"Total size of transactions in the transaction pool.", nil, nil)
+
+	peersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "p2p", "peers"),
+		"Count of connected peers by direction.",
+		[]string{"direction"}, nil)
+
+	dposPeersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "dpos", "peers"),
+		"Count of DPoS peers by connection state.",
+		[]string{"state"}, nil)
+)
+
+// collector collects the metrics read from the node state on each scrape.
+type collector struct{}
+
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- chainHeightDesc
+	ch <- mempoolTxsDesc
+	ch <- mempoolBytesDesc
+	ch <- peersDesc
+	ch <- dposPeersDesc
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(chainHeightDesc,
+		prometheus.GaugeValue, float64(servers.Chain.GetHeight()))
+	ch <- prometheus.MustNewConstMetric(mempoolTxsDesc,
+		prometheus.GaugeValue, float64(servers.TxMemPool.GetTransactionCount()))
+	ch <- prometheus.MustNewConstMetric(mempoolBytesDesc,
+		prometheus.GaugeValue, float64(servers.TxMemPool.GetTransactionsSize()))
+
+	var inbound, outbound int
+	for _, p := range servers.Server.ConnectedPeers() {
+		if p.ToPeer().Inbound() {
+			inbound++
+		} else {
+			outbound++
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(peersDesc,
+		prometheus.GaugeValue, float64(inbound), "inbound")
+	ch <- prometheus.MustNewConstMetric(peersDesc,
+		prometheus.GaugeValue, float64(outbound), "outbound")
+
+	if servers.Arbiter == nil {
+		return
+	}
+	states := make(map[dp2p.ConnState]int)
+	for _, p := range servers.Arbiter.GetArbiterPeersInfo() {
+		states[p.State]++
+	}
+	for _, state := range []dp2p.ConnState{dp2p.CSNoneConnection,
+		dp2p.CSOutboundOnly, dp2p.CSInboundOnly, dp2p.CS2WayConnection} {
+		ch <- prometheus.MustNewConstMetric(dposPeersDesc,
+			prometheus.GaugeValue, float64(states[state]), state.String())
+	}
+}
+
+// newHandler registers the collector of node state and returns the handler
+// serving metrics at /metrics.
+func newHandler() (http.Handler, error) {
+	if err := metrics.Registry.Register(&collector{}); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics.Registry,
+		promhttp.HandlerOpts{}))
+	return mux, nil
+}
+
+// StartServer starts the metrics server, which exports metrics of the node
+// at /metrics in the Prometheus text format.
+func StartServer() {
+	handler, err := newHandler()
+	if err != nil {
+		log.Error("Register metrics collector error: ", err.Error())
+		return
+	}
+
+	err = http.ListenAndServe(":"+strconv.Itoa(config.Parameters.MetricsPort),
+		handler)
+	if err != nil {
+		log.Fatal("Metrics server error: ", err.Error())
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package httpmetrics
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/blockchain"
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/common/metrics"
+	"github.com/elastos/Elastos.ELA/core/checkpoint"
+	"github.com/elastos/Elastos.ELA/elanet"
+	"github.com/elastos/Elastos.ELA/mempool"
+	svr "github.com/elastos/Elastos.ELA/p2p/server"
+	"github.com/elastos/Elastos.ELA/servers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type server struct {
+	elanet.Server
+}
+
+func (s *server) ConnectedPeers() []svr.IPeer {
+	return nil
+}
+
+func TestMetricsHandler(t *testing.T) {
+	servers.Chain = &blockchain.BlockChain{
+		Nodes: make([]*blockchain.BlockNode, 11),
+	}
+	servers.TxMemPool = mempool.NewTxPool(&config.Params{
+		CkpManager: checkpoint.NewManager(&checkpoint.Config{}),
+	})
+	servers.Server = &server{}
+	servers.Arbiter = nil
+
+	handler, err := newHandler()
+	if !assert.NoError(t, err) {
+		return
+	}
+	metrics.DPoSViewChanges.Inc()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, 200, w.Code)
+	body, err := ioutil.ReadAll(w.Body)
+	assert.NoError(t, err)
+	for _, line := range []string{
+		"ela_chain_height 10",
+		"ela_mempool_transactions 0",
+		"ela_mempool_bytes 0",
+		`ela_p2p_peers{direction="inbound"} 0`,
+		`ela_p2p_peers{direction="outbound"} 0`,
+		"ela_dpos_view_changes_total 1",
+		"# TYPE ela_chain_block_process_seconds histogram",
+		"# TYPE go_goroutines gauge",
+	} {
+		assert.Contains(t, string(body), line)
+	}
+	// DPoS peers are absent if the node is not an arbiter
+	assert.NotContains(t, string(body), "ela_dpos_peers")
+
+	// the collector is registered only once
+	_, err = newHandler()
+	assert.Error(t, err)
+}
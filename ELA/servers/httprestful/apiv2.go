@@ -0,0 +1,178 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package httprestful
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/elastos/Elastos.ELA/common/log"
+	"github.com/elastos/Elastos.ELA/servers"
+)
+
+const (
+	ApiV2Producers    = "/api/v2/producers"
+	ApiV2Producer     = "/api/v2/producers/:publickey"
+	ApiV2Votes        = "/api/v2/votes/:address"
+	ApiV2CRCandidates = "/api/v2/cr/candidates"
+	ApiV2CRCandidate  = "/api/v2/cr/candidates/:cid"
+	ApiV2CRMembers    = "/api/v2/cr/members"
+	ApiV2CRProposals  = "/api/v2/cr/proposals"
+	ApiV2CRProposal   = "/api/v2/cr/proposals/:proposalhash"
+	ApiV2Arbitrators  = "/api/v2/arbitrators"
+	ApiV2OpenAPI      = "/api/v2/openapi.json"
+)
+
+// apiParam describes a query parameter of a v2 route, path parameters are
+// taken from the route path.
+type apiParam struct {
+	name        string
+	kind        string
+	description string
+}
+
+// apiRoute describes a v2 route, it is used to serve requests and to
+// generate the OpenAPI document.
+type apiRoute struct {
+	path        string
+	operationID string
+	summary     string
+	tag         string
+	query       []apiParam
+	// result is a value of the result type, used to generate the schema.
+	result  interface{}
+	handler func(servers.Params) map[string]interface{}
+}
+
+var (
+	stateParam = func(states string) apiParam {
+		return apiParam{"state", "string", "filter by state, one of " + states}
+	}
+	cursorParam = apiParam{"cursor", "string",
+		"the nextcursor returned by the previous page"}
+	limitParam = apiParam{"limit", "integer",
+		"the max count of items in a page, default 50, max 500"}
+)
+
+var apiV2Routes = []apiRoute{
+	{
+		path:        ApiV2Producers,
+		operationID: "listProducers",
+		summary:     "List producers sorted by votes",
+		tag:         "dpos",
+		query: []apiParam{stateParam("all, pending, active, inactive, " +
+			"canceled, illegal, returned, default pending and active"),
+			cursorParam, limitParam},
+		result:  []servers.RpcProducerInfo{},
+		handler: servers.ListProducersPage,
+	},
+	{
+		path:        ApiV2Producer,
+		operationID: "getProducer",
+		summary:     "Get a producer by owner or node public key",
+		tag:         "dpos",
+		result:      servers.RpcProducerInfo{},
+		handler:     servers.GetProducerInfo,
+	},
+	{
+		path:        ApiV2Votes,
+		operationID: "getVoteStatus",
+		summary:     "Get the voting status of an address",
+		tag:         "dpos",
+		result:      servers.RpcVoteStatus{},
+		handler:     servers.VoteStatus,
+	},
+	{
+		path:        ApiV2Arbitrators,
+		operationID: "getArbitrators",
+		summary:     "Get current and next arbitrators",
+		tag:         "dpos",
+		result:      servers.RpcArbitersInfo{},
+		handler:     servers.GetArbitersInfo,
+	},
+	{
+		path:        ApiV2CRCandidates,
+		operationID: "listCRCandidates",
+		summary:     "List CR candidates sorted by votes",
+		tag:         "cr",
+		query: []apiParam{stateParam("all, pending, active, canceled, " +
+			"returned, default pending and active"), cursorParam, limitParam},
+		result:  []servers.RpcCrCandidateInfo{},
+		handler: servers.ListCRCandidatesPage,
+	},
+	{
+		path:        ApiV2CRCandidate,
+		operationID: "getCRCandidate",
+		summary:     "Get a CR candidate by CID",
+		tag:         "cr",
+		result:      servers.RpcCrCandidateInfo{},
+		handler:     servers.GetCRCandidateInfo,
+	},
+	{
+		path:        ApiV2CRMembers,
+		operationID: "listCRMembers",
+		summary:     "List members of current CR committee",
+		tag:         "cr",
+		query:       []apiParam{cursorParam, limitParam},
+		result:      []servers.RpcCrMemberInfo{},
+		handler:     servers.ListCRMembersPage,
+	},
+	{
+		path:        ApiV2CRProposals,
+		operationID: "listCRProposals",
+		summary:     "List CR proposals sorted by proposal hash",
+		tag:         "cr",
+		query: []apiParam{stateParam("all, registered, cragreed, " +
+			"voteragreed, finished, crcanceled, votercanceled, aborted, " +
+			"terminated, default all"), cursorParam, limitParam},
+		result:  []servers.RpcProposalBaseState{},
+		handler: servers.ListCRProposalsPage,
+	},
+	{
+		path:        ApiV2CRProposal,
+		operationID: "getCRProposal",
+		summary:     "Get a CR proposal by proposal hash",
+		tag:         "cr",
+		result:      servers.RpcCRProposalStateInfo{},
+		handler:     servers.GetCRProposalState,
+	},
+}
+
+func (rt *restServer) initV2Handler() {
+	for _, r := range apiV2Routes {
+		route := r
+		rt.router.Get(route.path, func(w http.ResponseWriter, req *http.Request) {
+			// Methods of v2 routes are named by lower case operation IDs.
+			resp := rt.authorize(req, strings.ToLower(route.operationID))
+			if resp != nil {
+				rt.response(w, resp)
+				return
+			}
+
+			params := make(servers.Params)
+			for k, v := range req.Context().Value("route_params").(Params) {
+				params[k] = v
+			}
+			query := req.URL.Query()
+			for _, p := range route.query {
+				if v := query.Get(p.name); v != "" {
+					params[p.name] = v
+				}
+			}
+			rt.response(w, route.handler(params))
+		})
+	}
+
+	doc, err := json.Marshal(openAPIDocument(apiV2Routes))
+	if err != nil {
+		log.Error("generate OpenAPI document failed, ", err)
+		return
+	}
+	rt.router.Get(ApiV2OpenAPI, func(w http.ResponseWriter, r *http.Request) {
+		rt.write(w, doc)
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package httprestful
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common/log"
+	"github.com/elastos/Elastos.ELA/servers"
+	"github.com/elastos/Elastos.ELA/utils/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	log.NewDefault(test.NodeLogPath, 0, 0, 0)
+}
+
+type testItem struct {
+	Name   string   `json:"name"`
+	Votes  string   `json:"votes,omitempty"`
+	Tags   []string `json:"tags"`
+	Hidden string   `json:"-"`
+	Child  *testItem
+	hidden string
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	routes := []apiRoute{
+		{
+			path:        "/api/v2/items",
+			operationID: "listItems",
+			tag:         "Items",
+			query:       []apiParam{cursorParam, limitParam},
+			result:      []testItem{},
+		},
+		{
+			path:        "/api/v2/items/:name/:id",
+			operationID: "getItem",
+			tag:         "Items",
+			result:      testItem{},
+		},
+	}
+	doc := openAPIDocument(routes)
+	assert.Equal(t, "3.0.3", doc["openapi"])
+
+	paths := doc["paths"].(map[string]interface{})
+	assert.Len(t, paths, 2)
+	result := func(path string) openAPISchema {
+		get := paths[path].(openAPISchema)["get"].(openAPISchema)
+		content := get["responses"].(openAPISchema)["200"].(openAPISchema)["content"]
+		schema := content.(openAPISchema)["application/json"].(openAPISchema)["schema"]
+		return schema.(openAPISchema)["properties"].(openAPISchema)["Result"].(openAPISchema)
+	}
+
+	// query parameters and paged result of list routes
+	list := paths["/api/v2/items"].(openAPISchema)["get"].(openAPISchema)
+	assert.Equal(t, "listItems", list["operationId"])
+	params := list["parameters"].([]interface{})
+	if assert.Len(t, params, 2) {
+		assert.Equal(t, "cursor", params[0].(openAPISchema)["name"])
+		assert.Equal(t, "query", params[0].(openAPISchema)["in"])
+		assert.Equal(t, "limit", params[1].(openAPISchema)["name"])
+		assert.Equal(t, openAPISchema{"type": "integer"}, params[1].(openAPISchema)["schema"])
+	}
+	paged := result("/api/v2/items")["properties"].(openAPISchema)
+	assert.Equal(t, openAPISchema{"type": "array",
+		"items": openAPISchema{"$ref": "#/components/schemas/testItem"}}, paged["items"])
+	assert.Contains(t, paged, "nextcursor")
+	assert.Contains(t, paged, "totalcount")
+
+	// path parameters are converted to OpenAPI format
+	get, ok := paths["/api/v2/items/{name}/{id}"].(openAPISchema)
+	if assert.True(t, ok) {
+		params := get["get"].(openAPISchema)["parameters"].([]interface{})
+		if assert.Len(t, params, 2) {
+			assert.Equal(t, "name", params[0].(openAPISchema)["name"])
+			assert.Equal(t, "path", params[0].(openAPISchema)["in"])
+			assert.Equal(t, true, params[0].(openAPISchema)["required"])
+			assert.Equal(t, "id", params[1].(openAPISchema)["name"])
+		}
+	}
+	assert.Equal(t, openAPISchema{"$ref": "#/components/schemas/testItem"},
+		result("/api/v2/items/{name}/{id}"))
+
+	// named structs are put into components by json names
+	schemas := doc["components"].(openAPISchema)["schemas"].(map[string]openAPISchema)
+	item := schemas["testItem"]["properties"].(map[string]interface{})
+	assert.Equal(t, openAPISchema{"type": "string"}, item["name"])
+	assert.Equal(t, openAPISchema{"type": "string"}, item["votes"])
+	assert.Equal(t, openAPISchema{"type": "array",
+		"items": openAPISchema{"type": "string"}}, item["tags"])
+	assert.Equal(t, openAPISchema{"$ref": "#/components/schemas/testItem"}, item["Child"])
+	assert.NotContains(t, item, "Hidden")
+	assert.NotContains(t, item, "hidden")
+
+	// the document of the real routes is valid JSON
+	_, err := json.Marshal(openAPIDocument(apiV2Routes))
+	assert.NoError(t, err)
+}
+
+func TestV2Handler(t *testing.T) {
+	defer func(routes []apiRoute) { apiV2Routes = routes }(apiV2Routes)
+
+	var got servers.Params
+	apiV2Routes = []apiRoute{{
+		path:        "/api/v2/items/:name",
+		operationID: "getItem",
+		query:       []apiParam{cursorParam, limitParam},
+		result:      testItem{},
+		handler: func(params servers.Params) map[string]interface{} {
+			got = params
+			return servers.ResponsePack(0, testItem{Name: "item"})
+		},
+	}}
+	rt := &restServer{router: &Router{}}
+	rt.initV2Handler()
+
+	w := httptest.NewRecorder()
+	rt.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/api/v2/items/abc?limit=10&other=1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, servers.Params{"name": "abc", "limit": "10"}, got)
+
+	var resp struct {
+		Error  int
+		Result testItem
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 0, resp.Error)
+	assert.Equal(t, "item", resp.Result.Name)
+
+	w = httptest.NewRecorder()
+	rt.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ApiV2OpenAPI, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var doc map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Contains(t, doc["paths"], "/api/v2/items/{name}")
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package httprestful
+
+import (
+	"reflect"
+	"strings"
+)
+
+type openAPISchema map[string]interface{}
+
+// schemaGenerator generates OpenAPI schemas of Go types by reflection, named
+// struct types are put into components and referenced.
+type schemaGenerator struct {
+	components map[string]openAPISchema
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) openAPISchema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schema(t.Elem())
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		if _, ok := g.components[t.Name()]; !ok {
+			// Put a placeholder first in case of recursive types.
+			g.components[t.Name()] = openAPISchema{}
+			g.components[t.Name()] = g.structSchema(t)
+		}
+		return openAPISchema{"$ref": "#/components/schemas/" + t.Name()}
+	case reflect.Slice, reflect.Array:
+		return openAPISchema{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return openAPISchema{"type": "object",
+			"additionalProperties": g.schema(t.Elem())}
+	case reflect.String:
+		return openAPISchema{"type": "string"}
+	case reflect.Bool:
+		return openAPISchema{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8,
+		reflect.Uint16, reflect.Uint32:
+		return openAPISchema{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return openAPISchema{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return openAPISchema{"type": "number"}
+	default:
+		return openAPISchema{}
+	}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) openAPISchema {
+	properties := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		properties[name] = g.schema(field.Type)
+	}
+	return openAPISchema{"type": "object", "properties": properties}
+}
+
+// openAPIDocument generates the OpenAPI document of the given routes.
+func openAPIDocument(routes []apiRoute) openAPISchema {
+	g := &schemaGenerator{components: make(map[string]openAPISchema)}
+	paths := make(map[string]interface{})
+	for _, route := range routes {
+		var parameters []interface{}
+		for _, m := range paramsRegexp.FindAllStringSubmatch(route.path, -1) {
+			parameters = append(parameters, openAPISchema{
+				"name":     m[1],
+				"in":       "path",
+				"required": true,
+				"schema":   openAPISchema{"type": "string"},
+			})
+		}
+		for _, p := range route.query {
+			parameters = append(parameters, openAPISchema{
+				"name":        p.name,
+				"in":          "query",
+				"description": p.description,
+				"schema":      openAPISchema{"type": p.kind},
+			})
+		}
+
+		result := g.schema(reflect.TypeOf(route.result))
+		if reflect.TypeOf(route.result).Kind() == reflect.Slice {
+			result = openAPISchema{
+				"type": "object",
+				"properties": openAPISchema{
+					"items": result,
+					"nextcursor": openAPISchema{"type": "string",
+						"description": "cursor of the next page, " +
+							"absent on the last page"},
+					"totalcount": openAPISchema{"type": "integer",
+						"format": "int64"},
+				},
+			}
+		}
+
+		operation := openAPISchema{
+			"operationId": route.operationID,
+			"summary":     route.summary,
+			"tags":        []string{route.tag},
+			"responses": openAPISchema{
+				"200": openAPISchema{
+					"description": "Error is 0 on success, otherwise Desc " +
+						"and Result describe the error",
+					"content": openAPISchema{
+						"application/json": openAPISchema{
+							"schema": openAPISchema{
+								"type": "object",
+								"properties": openAPISchema{
+									"Error":  openAPISchema{"type": "integer"},
+									"Desc":   openAPISchema{"type": "string"},
+									"Result": result,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+		path := paramsRegexp.ReplaceAllString(route.path, "{$1}")
+		paths[path] = openAPISchema{"get": operation}
+	}
+
+	return openAPISchema{
+		"openapi": "3.0.3",
+		"info": openAPISchema{
+			"title":   "Elastos ELA node REST API",
+			"version": "2.0.0",
+		},
+		"paths":      paths,
+		"components": openAPISchema{"schemas": g.components},
+	}
+}
@@ -38,6 +38,7 @@ const (
 	ApiGetBalanceByAsset   = "/api/v1/asset/balance/:addr/:assetid"
 	ApiGetUTXOByAsset      = "/api/v1/asset/utxo/:addr/:assetid"
 	ApiGetUTXOByAddr       = "/api/v1/asset/utxos/:addr"
+	ApiGetAddressTxs       = "/api/v1/address/transactions/:addr"
 	ApiSendRawTransaction  = "/api/v1/transaction"
 	ApiGetTransactionPool  = "/api/v1/transactionpool"
 	ApiRestart             = "/api/v1/restart"
@@ -73,6 +74,7 @@ func InitRestServer() ApiServer {
 	rt.initializeMethod()
 	rt.initGetHandler()
 	rt.initPostHandler()
+	rt.initV2Handler()
 	return rt
 }
 
@@ -121,6 +123,7 @@ func (rt *restServer) initializeMethod() {
 		ApiGetUTXOByAsset:      {name: "getutxobyasset", handler: servers.GetUnspendOutput},
 		ApiGetBalanceByAddr:    {name: "getbalancebyaddr", handler: servers.GetBalanceByAddr},
 		ApiGetBalanceByAsset:   {name: "getbalancebyasset", handler: servers.GetBalanceByAsset},
+		ApiGetAddressTxs:       {name: "getaddresstransactions", handler: servers.GetAddressTransactions},
 		ApiRestart:             {name: "restart", handler: rt.Restart},
 	}
 
@@ -157,6 +160,8 @@ func (rt *restServer) getPath(url string) string {
 		return ApiGetUTXOByAsset
 	} else if strings.Contains(url, strings.TrimRight(ApiGetAsset, ":hash")) {
 		return ApiGetAsset
+	} else if strings.Contains(url, strings.TrimRight(ApiGetAddressTxs, ":addr")) {
+		return ApiGetAddressTxs
 	}
 	return url
 }
@@ -209,6 +214,16 @@ func (rt *restServer) getParams(r *http.Request, url string, req map[string]inte
 		req["addr"] = getParam(r, "addr")
 		req["assetid"] = getParam(r, "assetid")
 
+	case ApiGetAddressTxs:
+		req["address"] = getParam(r, "addr")
+		query := r.URL.Query()
+		if start := query.Get("start"); start != "" {
+			req["start"] = start
+		}
+		if limit := query.Get("limit"); limit != "" {
+			req["limit"] = limit
+		}
+
 	case ApiRestart:
 
 	case ApiSendRawTransaction:
@@ -228,8 +243,10 @@ func (rt *restServer) initGetHandler() {
 			url := rt.getPath(r.URL.Path)
 
 			if h, ok := rt.getMap[url]; ok {
-				req = rt.getParams(r, url, req)
-				resp = h.handler(req)
+				if resp = rt.authorize(r, h.name); resp == nil {
+					req = rt.getParams(r, url, req)
+					resp = h.handler(req)
+				}
 			} else {
 				resp = servers.ResponsePack(InvalidMethod, "")
 			}
@@ -250,11 +267,13 @@ func (rt *restServer) initPostHandler() {
 
 			url := rt.getPath(r.URL.Path)
 			if h, ok := rt.postMap[url]; ok {
-				if err := json.Unmarshal(body, &req); err == nil {
-					req = rt.getParams(r, url, req)
-					resp = h.handler(req)
-				} else {
-					resp = servers.ResponsePack(IllegalDataFormat, "")
+				if resp = rt.authorize(r, h.name); resp == nil {
+					if err := json.Unmarshal(body, &req); err == nil {
+						req = rt.getParams(r, url, req)
+						resp = h.handler(req)
+					} else {
+						resp = servers.ResponsePack(IllegalDataFormat, "")
+					}
 				}
 			} else {
 				resp = servers.ResponsePack(InvalidMethod, "")
@@ -271,6 +290,24 @@ func (rt *restServer) initPostHandler() {
 
 }
 
+// authorize checks if the request is permitted to call the method by the
+// access control, the error response is returned if not.
+func (rt *restServer) authorize(r *http.Request,
+	method string) map[string]interface{} {
+	if servers.ACL == nil {
+		return nil
+	}
+	credential, err := servers.ACL.AuthenticateRequest(r)
+	if err == nil {
+		err = credential.Allow(method)
+	}
+	if err != nil {
+		log.Warn(err.Message)
+		return servers.ResponsePack(err.Code, err.Message)
+	}
+	return nil
+}
+
 func (rt *restServer) write(w http.ResponseWriter, data []byte) {
 	w.Header().Add("Access-Control-Allow-Headers", "Content-Type")
 	w.Header().Set("content-type", "application/json;charset=utf-8")
@@ -0,0 +1,287 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package httpwebsocket
+
+import (
+	"bytes"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/log"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	dlog "github.com/elastos/Elastos.ELA/dpos/log"
+	"github.com/elastos/Elastos.ELA/servers"
+)
+
+// maxPushedEvidences is the max count of hashes of pushed evidences kept to
+// avoid pushing an evidence repeatedly.
+const maxPushedEvidences = 1000
+
+type DPOSProposalInfo struct {
+	Event        string `json:"event"`
+	Sponsor      string `json:"sponsor"`
+	BlockHash    string `json:"blockhash"`
+	ProposalHash string `json:"proposalhash"`
+	ViewOffset   uint32 `json:"viewoffset"`
+	Result       bool   `json:"result"`
+	Time         int64  `json:"time"`
+}
+
+type DPOSVoteInfo struct {
+	Signer       string `json:"signer"`
+	ProposalHash string `json:"proposalhash"`
+	Accept       bool   `json:"accept"`
+	Result       bool   `json:"result"`
+	Time         int64  `json:"time"`
+}
+
+type DPOSViewInfo struct {
+	OnDutyArbitrator string `json:"ondutyarbitrator"`
+	ViewOffset       uint32 `json:"viewoffset"`
+	Height           uint32 `json:"height"`
+	Time             int64  `json:"time"`
+}
+
+type DPOSConsensusInfo struct {
+	Event     string `json:"event"`
+	Height    uint32 `json:"height"`
+	BlockHash string `json:"blockhash,omitempty"`
+	StartTime int64  `json:"starttime,omitempty"`
+	EndTime   int64  `json:"endtime,omitempty"`
+}
+
+type InactiveArbitratorsInfo struct {
+	Hash string `json:"hash"`
+	servers.InactiveArbitratorsInfo
+}
+
+type IllegalEvidenceInfo struct {
+	Hash        string   `json:"hash"`
+	Type        string   `json:"type"`
+	BlockHeight uint32   `json:"blockheight"`
+	Signers     []string `json:"signers"`
+	Evidence    string   `json:"evidence"`
+}
+
+var illegalDataTypeStrings = map[payload.IllegalDataType]string{
+	payload.IllegalBlock:             "block",
+	payload.IllegalProposal:          "proposal",
+	payload.IllegalVote:              "vote",
+	payload.SidechainIllegalProposal: "sidechainproposal",
+	payload.SidechainIllegalVote:     "sidechainvote",
+}
+
+func SendDPOSEvent2Client(v interface{}) {
+	// Consensus events are notified in the order they happened by a
+	// dedicated goroutine, so push them in the same goroutine to keep order.
+	instance.pushDPOSEvent(v)
+}
+
+func SendIllegalEvidence2Client(v interface{}) {
+	go instance.pushIllegalEvidence(v)
+}
+
+// pushDPOSEvent pushes consensus events of the arbiter to sessions
+// subscribed the related topic.
+func (s *Server) pushDPOSEvent(v interface{}) {
+	var topic, action string
+	var result interface{}
+	switch e := v.(type) {
+	case *dlog.ProposalEvent:
+		topic, action = TopicDPOSProposal, "senddposproposal"
+		info := &DPOSProposalInfo{
+			Event:        "arrived",
+			Sponsor:      e.Sponsor,
+			BlockHash:    servers.ToReversedString(e.BlockHash),
+			ProposalHash: servers.ToReversedString(e.ProposalHash),
+			Result:       e.Result,
+			Time:         e.ReceivedTime.Unix(),
+		}
+		if !e.EndTime.IsZero() {
+			info.Event = "finished"
+			info.Time = e.EndTime.Unix()
+		}
+		if e.RawData != nil {
+			info.ViewOffset = e.RawData.ViewOffset
+		}
+		result = info
+
+	case *dlog.VoteEvent:
+		topic, action = TopicDPOSVote, "senddposvote"
+		info := &DPOSVoteInfo{
+			Signer: e.Signer,
+			Result: e.Result,
+			Time:   e.ReceivedTime.Unix(),
+		}
+		if e.RawData != nil {
+			info.ProposalHash = servers.ToReversedString(e.RawData.ProposalHash)
+			info.Accept = e.RawData.Accept
+		}
+		result = info
+
+	case *dlog.ViewEvent:
+		topic, action = TopicDPOSView, "senddposview"
+		result = &DPOSViewInfo{
+			OnDutyArbitrator: e.OnDutyArbitrator,
+			ViewOffset:       e.Offset,
+			Height:           e.Height,
+			Time:             e.StartTime.Unix(),
+		}
+
+	case *dlog.ConsensusEvent:
+		topic, action = TopicDPOSConsensus, "senddposconsensus"
+		info := &DPOSConsensusInfo{
+			Event:  "started",
+			Height: e.Height,
+		}
+		if !e.StartTime.IsZero() {
+			info.StartTime = e.StartTime.Unix()
+		}
+		if !e.EndTime.IsZero() {
+			info.Event = "finished"
+			info.EndTime = e.EndTime.Unix()
+		}
+		if e.RawData != nil {
+			info.BlockHash = servers.ToReversedString(e.RawData.Hash())
+		}
+		result = info
+
+	default:
+		return
+	}
+
+	var data []byte
+	s.sessions.Foreach(func(ss *session) {
+		if ss.subscription(topic) == nil {
+			return
+		}
+		if data == nil {
+			var err error
+			data, err = packPushData(action, result)
+			if err != nil {
+				log.Error("Websocket pushDPOSEvent:", err)
+				return
+			}
+		}
+		ss.Send(data)
+	})
+}
+
+// pushIllegalEvidence pushes evidences of illegal behaviors and inactive
+// arbitrators to sessions subscribed the related topic. An evidence may be
+// notified more than once, by the transaction pool and the arbiter, so only
+// the first one is pushed.
+func (s *Server) pushIllegalEvidence(v interface{}) {
+	var evidence payload.DPOSIllegalData
+	switch e := v.(type) {
+	case *types.Transaction:
+		evidence, _ = e.Payload.(payload.DPOSIllegalData)
+	case payload.DPOSIllegalData:
+		evidence = e
+	}
+	if evidence == nil {
+		return
+	}
+
+	if !s.addPushedEvidence(evidence.Hash()) {
+		return
+	}
+
+	var topic, action string
+	var result interface{}
+	if p, ok := evidence.(*payload.InactiveArbitrators); ok {
+		topic, action = TopicInactiveArbitrators, "sendinactivearbitrators"
+		arbitrators := make([]string, 0, len(p.Arbitrators))
+		for _, a := range p.Arbitrators {
+			arbitrators = append(arbitrators, common.BytesToHexString(a))
+		}
+		result = &InactiveArbitratorsInfo{
+			Hash: servers.ToReversedString(p.Hash()),
+			InactiveArbitratorsInfo: servers.InactiveArbitratorsInfo{
+				Sponsor:     common.BytesToHexString(p.Sponsor),
+				Arbitrators: arbitrators,
+				BlockHeight: p.BlockHeight,
+			},
+		}
+	} else {
+		topic, action = TopicIllegalEvidence, "sendillegalevidence"
+		buf := new(bytes.Buffer)
+		if err := evidence.Serialize(buf, 0); err != nil {
+			log.Error("Websocket pushIllegalEvidence:", err)
+			return
+		}
+		result = &IllegalEvidenceInfo{
+			Hash:        servers.ToReversedString(evidence.Hash()),
+			Type:        illegalDataTypeStrings[evidence.Type()],
+			BlockHeight: evidence.GetBlockHeight(),
+			Signers:     getIllegalSigners(evidence),
+			Evidence:    common.BytesToHexString(buf.Bytes()),
+		}
+	}
+
+	var data []byte
+	s.sessions.Foreach(func(ss *session) {
+		if ss.subscription(topic) == nil {
+			return
+		}
+		if data == nil {
+			var err error
+			data, err = packPushData(action, result)
+			if err != nil {
+				log.Error("Websocket pushIllegalEvidence:", err)
+				return
+			}
+		}
+		ss.Send(data)
+	})
+}
+
+// addPushedEvidence records the hash of a pushed evidence, and returns false
+// if the evidence has been pushed before.
+func (s *Server) addPushedEvidence(hash common.Uint256) bool {
+	s.evidenceMtx.Lock()
+	defer s.evidenceMtx.Unlock()
+
+	if _, ok := s.pushedEvidences[hash]; ok {
+		return false
+	}
+	if s.pushedEvidences == nil ||
+		len(s.pushedEvidences) >= maxPushedEvidences {
+		s.pushedEvidences = make(map[common.Uint256]struct{})
+	}
+	s.pushedEvidences[hash] = struct{}{}
+	return true
+}
+
+// getIllegalSigners returns public keys of arbiters who behaved illegally
+// according to the evidence.
+func getIllegalSigners(evidence payload.DPOSIllegalData) []string {
+	var signers [][]byte
+	switch e := evidence.(type) {
+	case *payload.DPOSIllegalProposals:
+		signers = append(signers, e.Evidence.Proposal.Sponsor)
+	case *payload.DPOSIllegalVotes:
+		signers = append(signers, e.Evidence.Vote.Signer)
+	case *payload.DPOSIllegalBlocks:
+		// Arbiters signed both blocks are illegal.
+		for _, s := range e.Evidence.Signers {
+			for _, c := range e.CompareEvidence.Signers {
+				if bytes.Equal(s, c) {
+					signers = append(signers, s)
+					break
+				}
+			}
+		}
+	case *payload.SidechainIllegalData:
+		signers = append(signers, e.IllegalSigner)
+	}
+
+	result := make([]string, 0, len(signers))
+	for _, s := range signers {
+		result = append(result, common.BytesToHexString(s))
+	}
+	return result
+}
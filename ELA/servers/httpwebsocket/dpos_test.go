@@ -0,0 +1,276 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package httpwebsocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	dlog "github.com/elastos/Elastos.ELA/dpos/log"
+	"github.com/elastos/Elastos.ELA/servers"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// readTestResults reads the pushes until the marker pushed after the events,
+// and returns the results of them by the actions.
+func readTestResults(t *testing.T, s *Server,
+	conn *websocket.Conn) map[string][]map[string]interface{} {
+	marker, _ := packPushData("marker", nil)
+	s.sessions.Foreach(func(ss *session) { ss.Send(marker) })
+
+	results := make(map[string][]map[string]interface{})
+	for {
+		push := readTestPush(t, conn)
+		action, _ := push["Action"].(string)
+		if action == "marker" {
+			return results
+		}
+		result, _ := push["Result"].(map[string]interface{})
+		results[action] = append(results[action], result)
+	}
+}
+
+func subscribeTestTopic(t *testing.T, url string, topic string) *websocket.Conn {
+	return dialTestServer(t, url, map[string]interface{}{
+		"action": "subscribe", "topic": topic})
+}
+
+func TestPushDPOSEvent(t *testing.T) {
+	s, url, stop := newTestServer(t)
+	defer stop()
+
+	topics := []string{TopicDPOSProposal, TopicDPOSVote, TopicDPOSView,
+		TopicDPOSConsensus}
+	clients := make(map[string]*websocket.Conn)
+	for _, topic := range topics {
+		clients[topic] = subscribeTestTopic(t, url, topic)
+		defer clients[topic].Close()
+	}
+	unsubscribed := dialTestServer(t, url)
+	defer unsubscribed.Close()
+
+	start := time.Unix(1600000000, 0)
+	proposal := &payload.DPOSProposal{Sponsor: []byte{1}, ViewOffset: 2}
+	vote := &payload.DPOSProposalVote{ProposalHash: proposal.Hash(),
+		Signer: []byte{2}, Accept: true}
+	header := &types.Header{Height: 100}
+	s.pushDPOSEvent(&dlog.ProposalEvent{Sponsor: "01",
+		ProposalHash: proposal.Hash(), ReceivedTime: start, RawData: proposal})
+	s.pushDPOSEvent(&dlog.ProposalEvent{Sponsor: "01",
+		ProposalHash: proposal.Hash(), ReceivedTime: start,
+		EndTime: start.Add(time.Second), Result: true, RawData: proposal})
+	s.pushDPOSEvent(&dlog.VoteEvent{Signer: "02", ReceivedTime: start,
+		Result: true, RawData: vote})
+	s.pushDPOSEvent(&dlog.ViewEvent{OnDutyArbitrator: "03", StartTime: start,
+		Offset: 1, Height: 100})
+	s.pushDPOSEvent(&dlog.ConsensusEvent{StartTime: start, Height: 100})
+	s.pushDPOSEvent(&dlog.ConsensusEvent{EndTime: start.Add(time.Minute),
+		Height: 100, RawData: header})
+	s.pushDPOSEvent("not an event")
+
+	proposalHash := servers.ToReversedString(proposal.Hash())
+	results := readTestResults(t, s, clients[TopicDPOSProposal])
+	if assert.Len(t, results["senddposproposal"], 2) {
+		arrived := results["senddposproposal"][0]
+		assert.Equal(t, "arrived", arrived["event"])
+		assert.Equal(t, proposalHash, arrived["proposalhash"])
+		assert.Equal(t, float64(2), arrived["viewoffset"])
+		assert.Equal(t, float64(start.Unix()), arrived["time"])
+		finished := results["senddposproposal"][1]
+		assert.Equal(t, "finished", finished["event"])
+		assert.Equal(t, true, finished["result"])
+		assert.Equal(t, float64(start.Unix()+1), finished["time"])
+	}
+	assert.Len(t, results, 1)
+
+	results = readTestResults(t, s, clients[TopicDPOSVote])
+	if assert.Len(t, results["senddposvote"], 1) {
+		assert.Equal(t, "02", results["senddposvote"][0]["signer"])
+		assert.Equal(t, proposalHash, results["senddposvote"][0]["proposalhash"])
+		assert.Equal(t, true, results["senddposvote"][0]["accept"])
+	}
+	assert.Len(t, results, 1)
+
+	results = readTestResults(t, s, clients[TopicDPOSView])
+	if assert.Len(t, results["senddposview"], 1) {
+		assert.Equal(t, "03", results["senddposview"][0]["ondutyarbitrator"])
+		assert.Equal(t, float64(1), results["senddposview"][0]["viewoffset"])
+	}
+	assert.Len(t, results, 1)
+
+	results = readTestResults(t, s, clients[TopicDPOSConsensus])
+	if assert.Len(t, results["senddposconsensus"], 2) {
+		started := results["senddposconsensus"][0]
+		assert.Equal(t, "started", started["event"])
+		assert.NotContains(t, started, "blockhash")
+		finished := results["senddposconsensus"][1]
+		assert.Equal(t, "finished", finished["event"])
+		assert.Equal(t, servers.ToReversedString(header.Hash()),
+			finished["blockhash"])
+	}
+	assert.Len(t, results, 1)
+
+	// consensus events are not pushed to sessions without subscriptions
+	assert.Empty(t, readTestResults(t, s, unsubscribed))
+}
+
+func TestPushIllegalEvidence(t *testing.T) {
+	s, url, stop := newTestServer(t)
+	defer stop()
+
+	illegal := subscribeTestTopic(t, url, TopicIllegalEvidence)
+	defer illegal.Close()
+	inactive := subscribeTestTopic(t, url, TopicInactiveArbitrators)
+	defer inactive.Close()
+
+	proposals := &payload.DPOSIllegalProposals{
+		Evidence: payload.ProposalEvidence{
+			Proposal:    payload.DPOSProposal{Sponsor: []byte{1}},
+			BlockHeight: 10,
+		},
+		CompareEvidence: payload.ProposalEvidence{
+			Proposal:    payload.DPOSProposal{Sponsor: []byte{1}, ViewOffset: 1},
+			BlockHeight: 10,
+		},
+	}
+	votes := &payload.DPOSIllegalVotes{
+		Evidence: payload.VoteEvidence{
+			Vote: payload.DPOSProposalVote{Signer: []byte{2}},
+		},
+		CompareEvidence: payload.VoteEvidence{
+			Vote: payload.DPOSProposalVote{Signer: []byte{2}, Accept: true},
+		},
+	}
+	arbitrators := &payload.InactiveArbitrators{
+		Sponsor:     []byte{3},
+		Arbitrators: [][]byte{{4}, {5}},
+		BlockHeight: 20,
+	}
+
+	// evidences are notified by the arbiter and by transactions, an
+	// evidence is pushed only once
+	s.pushIllegalEvidence(proposals)
+	s.pushIllegalEvidence(&types.Transaction{TxType: types.IllegalProposalEvidence,
+		Payload: proposals})
+	s.pushIllegalEvidence(&types.Transaction{TxType: types.IllegalVoteEvidence,
+		Payload: votes})
+	s.pushIllegalEvidence(votes)
+	s.pushIllegalEvidence(arbitrators)
+	s.pushIllegalEvidence(&types.Transaction{TxType: types.InactiveArbitrators,
+		Payload: arbitrators})
+
+	// transactions without evidences are not pushed
+	s.pushIllegalEvidence(&types.Transaction{TxType: types.TransferAsset,
+		Payload: &payload.TransferAsset{}})
+	s.pushIllegalEvidence("not an evidence")
+
+	results := readTestResults(t, s, illegal)
+	if assert.Len(t, results["sendillegalevidence"], 2) {
+		first := results["sendillegalevidence"][0]
+		assert.Equal(t, servers.ToReversedString(proposals.Hash()), first["hash"])
+		assert.Equal(t, "proposal", first["type"])
+		assert.Equal(t, float64(10), first["blockheight"])
+		assert.Equal(t, []interface{}{"01"}, first["signers"])
+		assert.NotEmpty(t, first["evidence"])
+		second := results["sendillegalevidence"][1]
+		assert.Equal(t, servers.ToReversedString(votes.Hash()), second["hash"])
+		assert.Equal(t, "vote", second["type"])
+		assert.Equal(t, []interface{}{"02"}, second["signers"])
+	}
+	assert.Len(t, results, 1)
+
+	results = readTestResults(t, s, inactive)
+	if assert.Len(t, results["sendinactivearbitrators"], 1) {
+		result := results["sendinactivearbitrators"][0]
+		assert.Equal(t, servers.ToReversedString(arbitrators.Hash()),
+			result["hash"])
+		assert.Equal(t, "03", result["sponsor"])
+		assert.Equal(t, []interface{}{"04", "05"}, result["arbitrators"])
+	}
+	assert.Len(t, results, 1)
+}
+
+func TestGetIllegalSigners(t *testing.T) {
+	tests := []struct {
+		name     string
+		evidence payload.DPOSIllegalData
+		signers  []string
+	}{
+		{
+			name: "proposals",
+			evidence: &payload.DPOSIllegalProposals{
+				Evidence: payload.ProposalEvidence{
+					Proposal: payload.DPOSProposal{Sponsor: []byte{1}}},
+			},
+			signers: []string{"01"},
+		},
+		{
+			name: "votes",
+			evidence: &payload.DPOSIllegalVotes{
+				Evidence: payload.VoteEvidence{
+					Vote: payload.DPOSProposalVote{Signer: []byte{2}}},
+			},
+			signers: []string{"02"},
+		},
+		{
+			name: "blocks signed by both",
+			evidence: &payload.DPOSIllegalBlocks{
+				Evidence: payload.BlockEvidence{
+					Signers: [][]byte{{1}, {2}, {3}}},
+				CompareEvidence: payload.BlockEvidence{
+					Signers: [][]byte{{3}, {4}, {1}}},
+			},
+			signers: []string{"01", "03"},
+		},
+		{
+			name: "blocks signed by different arbiters",
+			evidence: &payload.DPOSIllegalBlocks{
+				Evidence:        payload.BlockEvidence{Signers: [][]byte{{1}}},
+				CompareEvidence: payload.BlockEvidence{Signers: [][]byte{{2}}},
+			},
+			signers: []string{},
+		},
+		{
+			name: "sidechain",
+			evidence: &payload.SidechainIllegalData{
+				IllegalType:   payload.SidechainIllegalProposal,
+				IllegalSigner: []byte{5},
+			},
+			signers: []string{"05"},
+		},
+		{
+			name:     "inactive arbitrators",
+			evidence: &payload.InactiveArbitrators{Sponsor: []byte{6}},
+			signers:  []string{},
+		},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.signers, getIllegalSigners(test.evidence),
+			test.name)
+	}
+}
+
+func TestAddPushedEvidence(t *testing.T) {
+	s := &Server{}
+	assert.True(t, s.addPushedEvidence(common.Uint256{1}))
+	assert.False(t, s.addPushedEvidence(common.Uint256{1}))
+	assert.True(t, s.addPushedEvidence(common.Uint256{2}))
+
+	// the hashes are forgotten once the max count is reached
+	for i := 2; i < maxPushedEvidences; i++ {
+		assert.True(t, s.addPushedEvidence(common.Uint256{byte(i), byte(i >> 8), 1}))
+	}
+	assert.Len(t, s.pushedEvidences, maxPushedEvidences)
+	assert.False(t, s.addPushedEvidence(common.Uint256{1}))
+	assert.True(t, s.addPushedEvidence(common.Uint256{3}))
+	assert.Len(t, s.pushedEvidences, 1)
+	assert.True(t, s.addPushedEvidence(common.Uint256{1}))
+}
@@ -16,10 +16,13 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/elastos/Elastos.ELA/common"
 	"github.com/elastos/Elastos.ELA/common/config"
 	"github.com/elastos/Elastos.ELA/common/log"
 	"github.com/elastos/Elastos.ELA/core/types"
+	crstate "github.com/elastos/Elastos.ELA/cr/state"
 	"github.com/elastos/Elastos.ELA/events"
+	"github.com/elastos/Elastos.ELA/mempool"
 	"github.com/elastos/Elastos.ELA/servers"
 	"github.com/elastos/Elastos.ELA/servers/errors"
 
@@ -42,18 +45,51 @@ var (
 
 type Handler func(servers.Params) map[string]interface{}
 
+// SessionHandler handles a request related to the session it comes from.
+type SessionHandler func(*session, servers.Params) map[string]interface{}
+
 type Server struct {
 	sync.RWMutex
 	*http.Server
 	net.Listener
 	websocket.Upgrader
 
-	connCount int64
-	sessions  *sessions
-	handlers  map[string]Handler
+	connCount       int64
+	sessions        *sessions
+	handlers        map[string]Handler
+	sessionHandlers map[string]SessionHandler
+
+	// proposalMtx protects proposalStatus, which records status of CR
+	// proposals to find out status changes.
+	proposalMtx    sync.Mutex
+	proposalStatus map[common.Uint256]crstate.ProposalStatus
+
+	// getProposals returns all proposals of CR committee.
+	getProposals func() crstate.ProposalsMap
+
+	// pendingMtx protects pendingBlock, which is the latest processed block
+	// waiting for proposalHandler to push status changes of proposals, and
+	// proposalSignal wakes proposalHandler up.
+	pendingMtx     sync.Mutex
+	pendingBlock   *types.Block
+	proposalSignal chan struct{}
+
+	// evidenceMtx protects pushedEvidences, which records hashes of pushed
+	// illegal evidences.
+	evidenceMtx     sync.Mutex
+	pushedEvidences map[common.Uint256]struct{}
 }
 
 func Start() {
+	instance = &Server{
+		Upgrader:       websocket.Upgrader{},
+		sessions:       &sessions{},
+		proposalSignal: make(chan struct{}, 1),
+		getProposals: func() crstate.ProposalsMap {
+			return servers.Chain.GetCRCommittee().GetAllProposals()
+		},
+	}
+
 	events.Subscribe(func(e *events.Event) {
 		switch e.Type {
 		case events.ETBlockConnected:
@@ -61,13 +97,22 @@ func Start() {
 
 		case events.ETTransactionAccepted:
 			SendTx2Client(e.Data)
+
+		case events.ETConfirmAccepted:
+			SendConfirm2Client(e.Data)
+
+		case events.ETBlockProcessed:
+			SendProposalState2Client(e.Data)
+
+		case events.ETDPOSProposalArrived, events.ETDPOSProposalFinished,
+			events.ETDPOSVoteArrived, events.ETDPOSViewStarted,
+			events.ETDPOSConsensusStarted, events.ETDPOSConsensusFinished:
+			SendDPOSEvent2Client(e.Data)
+
+		case events.ETDPOSIllegalEvidence, events.ETIllegalBlockEvidence:
+			SendIllegalEvidence2Client(e.Data)
 		}
 	})
-
-	instance = &Server{
-		Upgrader: websocket.Upgrader{},
-		sessions: &sessions{},
-	}
 	instance.Start()
 }
 
@@ -90,11 +135,12 @@ func (s *Server) Start() {
 	}
 	var done = make(chan bool)
 	go s.sessionHandler(done)
+	go s.proposalHandler(done)
 
 	s.Server = &http.Server{Handler: http.HandlerFunc(s.Handler)}
 	err := s.Serve(s.Listener)
 
-	done <- true
+	close(done)
 	if err != nil {
 		log.Fatal("ListenAndServe: ", err.Error())
 	}
@@ -113,6 +159,30 @@ func (s *Server) initMethods() {
 		"heartbeat":          s.heartBeat,
 		"getsessioncount":    s.getSessionCount,
 	}
+	s.sessionHandlers = map[string]SessionHandler{
+		"subscribe":   s.subscribe,
+		"unsubscribe": s.unsubscribe,
+	}
+}
+
+func (s *Server) subscribe(ss *session, cmd servers.Params) map[string]interface{} {
+	topic, ok := cmd.String("topic")
+	if !ok {
+		return servers.ResponsePack(errors.InvalidParams, "need a param called topic")
+	}
+	sub, err := newSubscription(topic, cmd)
+	if err != nil {
+		return servers.ResponsePack(errors.InvalidParams, err.Error())
+	}
+	return servers.ResponsePack(errors.Success, ss.subscribe(topic, sub))
+}
+
+func (s *Server) unsubscribe(ss *session, cmd servers.Params) map[string]interface{} {
+	topic, ok := cmd.String("topic")
+	if !ok {
+		return servers.ResponsePack(errors.InvalidParams, "need a param called topic")
+	}
+	return servers.ResponsePack(errors.Success, ss.unsubscribe(topic))
 }
 
 func (s *Server) heartBeat(cmd servers.Params) map[string]interface{} {
@@ -151,7 +221,38 @@ func (s *Server) sessionHandler(done chan bool) {
 	}
 }
 
+// proposalHandler pushes status changes of proposals after blocks processed,
+// it runs in one goroutine to find out status changes in the order of blocks.
+func (s *Server) proposalHandler(done chan bool) {
+	for {
+		select {
+		case <-s.proposalSignal:
+			s.pendingMtx.Lock()
+			block := s.pendingBlock
+			s.pendingBlock = nil
+			s.pendingMtx.Unlock()
+			if block != nil {
+				s.pushProposalStates(block)
+			}
+
+		case <-done:
+			return
+		}
+	}
+}
+
 func (s *Server) Handler(w http.ResponseWriter, r *http.Request) {
+	var credential *servers.Credential
+	if servers.ACL != nil {
+		var accessErr *servers.AccessError
+		credential, accessErr = servers.ACL.AuthenticateRequest(r)
+		if accessErr != nil {
+			log.Warn(accessErr.Message)
+			http.Error(w, accessErr.Message, accessErr.HTTPStatus())
+			return
+		}
+	}
+
 	conn, err := s.Upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Error("websocket Upgrader: ", err)
@@ -163,6 +264,7 @@ func (s *Server) Handler(w http.ResponseWriter, r *http.Request) {
 		id:         atomic.AddInt64(&s.connCount, 1),
 		conn:       conn,
 		lastActive: time.Now(),
+		credential: credential,
 	}
 	s.sessions.Store(ss.id, ss)
 
@@ -226,6 +328,20 @@ func (s *Server) handle(ss *session, bysMsg []byte, r *http.Request) bool {
 		s.response(ss, resp)
 		return false
 	}
+	if ss.credential != nil {
+		if err := ss.credential.Allow(action); err != nil {
+			resp := servers.ResponsePack(err.Code, err.Message)
+			resp["Action"] = action
+			s.response(ss, resp)
+			return false
+		}
+	}
+	if handler, ok := s.sessionHandlers[action]; ok {
+		resp := handler(ss, req)
+		resp["Action"] = action
+		s.response(ss, resp)
+		return true
+	}
 	handler, ok := s.handlers[action]
 	if !ok {
 		resp := servers.ResponsePack(errors.InvalidMethod, "")
@@ -262,6 +378,7 @@ func SendTx2Client(v interface{}) {
 			instance.PushResult("sendnewtransaction", v)
 		}()
 	}
+	go instance.pushTransaction(v)
 }
 
 func SendBlock2WSclient(v interface{}) {
@@ -280,8 +397,32 @@ func SendBlock2WSclient(v interface{}) {
 			instance.PushResult("sendblocktransactions", v)
 		}()
 	}
+	go instance.pushBlock(v)
+}
+
+func SendConfirm2Client(v interface{}) {
+	go instance.pushConfirm(v)
+}
+
+func SendProposalState2Client(v interface{}) {
+	block, ok := v.(*types.Block)
+	if !ok {
+		return
+	}
+
+	// Only the latest block is kept if proposalHandler falls behind, status
+	// of proposals read later already includes changes of skipped blocks, and
+	// the changes are pushed with the height of the latest block.
+	instance.pendingMtx.Lock()
+	instance.pendingBlock = block
+	instance.pendingMtx.Unlock()
+	select {
+	case instance.proposalSignal <- struct{}{}:
+	default:
+	}
 }
 
+// PushResult pushes result to sessions which have not subscribed any topics.
 func (s *Server) PushResult(action string, v interface{}) {
 	var result interface{}
 	switch action {
@@ -307,21 +448,196 @@ func (s *Server) PushResult(action string, v interface{}) {
 		log.Error("httpwebsocket/server.go in pushresult function: unknown action")
 	}
 
-	resp := servers.ResponsePack(errors.Success, result)
-	resp["Action"] = action
-
-	data, err := json.Marshal(resp)
+	data, err := packPushData(action, result)
 	if err != nil {
 		log.Error("Websocket PushResult:", err)
 		return
 	}
 
-	// Broadcast message to all connected clients.
+	// Broadcast message to all connected clients not subscribed topics, and
+	// permitted to receive the action by their credentials.
 	s.sessions.Foreach(func(v *session) {
+		if v.subscribed() {
+			return
+		}
+		if v.credential != nil && !v.credential.Permits(action) {
+			return
+		}
 		v.Send(data)
 	})
 }
 
+func (s *Server) pushBlock(v interface{}) {
+	block, ok := v.(*types.Block)
+	if !ok {
+		return
+	}
+
+	var rawBlock, blockTxs []byte
+	s.sessions.Foreach(func(ss *session) {
+		sub := ss.subscription(TopicBlock)
+		if sub == nil {
+			return
+		}
+
+		var err error
+		if sub.verbose {
+			if rawBlock == nil {
+				rawBlock, err = packPushData("sendrawblock",
+					servers.GetBlockInfo(block, true))
+				if err != nil {
+					log.Error("Websocket pushBlock:", err)
+					return
+				}
+			}
+			ss.Send(rawBlock)
+		} else {
+			if blockTxs == nil {
+				blockTxs, err = packPushData("sendblocktransactions",
+					servers.GetBlockTransactions(block))
+				if err != nil {
+					log.Error("Websocket pushBlock:", err)
+					return
+				}
+			}
+			ss.Send(blockTxs)
+		}
+	})
+}
+
+func (s *Server) pushTransaction(v interface{}) {
+	tx, ok := v.(*types.Transaction)
+	if !ok {
+		return
+	}
+
+	var references map[*types.Input]types.Output
+	getReferences := func() map[*types.Input]types.Output {
+		if references == nil {
+			references, _ = servers.Chain.UTXOCache.GetTxReference(tx)
+		}
+		return references
+	}
+
+	var data []byte
+	s.sessions.Foreach(func(ss *session) {
+		sub := ss.subscription(TopicTransaction)
+		if sub == nil || !sub.matchTransaction(tx, getReferences) {
+			return
+		}
+		if data == nil {
+			var err error
+			data, err = packPushData("sendnewtransaction",
+				servers.GetTransactionContextInfo(nil, tx))
+			if err != nil {
+				log.Error("Websocket pushTransaction:", err)
+				return
+			}
+		}
+		ss.Send(data)
+	})
+}
+
+func (s *Server) pushConfirm(v interface{}) {
+	info, ok := v.(*mempool.ConfirmInfo)
+	if !ok {
+		return
+	}
+
+	type confirmInfo struct {
+		servers.ConfirmInfo
+		Height uint32 `json:"height"`
+	}
+	var data []byte
+	s.sessions.Foreach(func(ss *session) {
+		if ss.subscription(TopicConfirm) == nil {
+			return
+		}
+		if data == nil {
+			var err error
+			data, err = packPushData("sendconfirm", &confirmInfo{
+				ConfirmInfo: servers.GetConfirmInfo(info.Confirm),
+				Height:      info.Height,
+			})
+			if err != nil {
+				log.Error("Websocket pushConfirm:", err)
+				return
+			}
+		}
+		ss.Send(data)
+	})
+}
+
+func (s *Server) pushProposalStates(block *types.Block) {
+	s.proposalMtx.Lock()
+	defer s.proposalMtx.Unlock()
+
+	var subscribers []*session
+	s.sessions.Foreach(func(ss *session) {
+		if ss.subscription(TopicProposal) != nil {
+			subscribers = append(subscribers, ss)
+		}
+	})
+	// Forget status of proposals if nobody cares, and start over when a
+	// session subscribed again.
+	if len(subscribers) == 0 {
+		s.proposalStatus = nil
+		return
+	}
+
+	proposals := s.getProposals()
+	if s.proposalStatus == nil {
+		s.proposalStatus = make(map[common.Uint256]crstate.ProposalStatus,
+			len(proposals))
+		for k, v := range proposals {
+			s.proposalStatus[k] = v.Status
+		}
+		return
+	}
+
+	type proposalStatusInfo struct {
+		ProposalHash   string `json:"proposalhash"`
+		Status         string `json:"status"`
+		PreviousStatus string `json:"previousstatus"`
+		Height         uint32 `json:"height"`
+	}
+	for hash, proposal := range proposals {
+		previous, ok := s.proposalStatus[hash]
+		if ok && previous == proposal.Status {
+			continue
+		}
+		s.proposalStatus[hash] = proposal.Status
+
+		info := &proposalStatusInfo{
+			ProposalHash: servers.ToReversedString(hash),
+			Status:       proposal.Status.String(),
+			Height:       block.Height,
+		}
+		if ok {
+			info.PreviousStatus = previous.String()
+		}
+		data, err := packPushData("sendproposalstate", info)
+		if err != nil {
+			log.Error("Websocket pushProposalStates:", err)
+			continue
+		}
+		go func(hash common.Uint256, data []byte) {
+			for _, ss := range subscribers {
+				sub := ss.subscription(TopicProposal)
+				if sub != nil && sub.matchProposal(hash) {
+					ss.Send(data)
+				}
+			}
+		}(hash, data)
+	}
+}
+
+func packPushData(action string, result interface{}) ([]byte, error) {
+	resp := servers.ResponsePack(errors.Success, result)
+	resp["Action"] = action
+	return json.Marshal(resp)
+}
+
 func (s *Server) initTlsListen() (net.Listener, error) {
 
 	CertPath := config.Parameters.RestCertPath
@@ -0,0 +1,109 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package httpwebsocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/servers"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendProposalState2Client(t *testing.T) {
+	defer func(s *Server) { instance = s }(instance)
+	instance = &Server{
+		sessions:       &sessions{},
+		proposalSignal: make(chan struct{}, 1),
+	}
+
+	// blocks are queued without waiting for the handler, and only the latest
+	// one is kept
+	for height := uint32(1); height <= 3; height++ {
+		SendProposalState2Client(&types.Block{
+			Header: types.Header{Height: height},
+		})
+	}
+	SendProposalState2Client("not a block")
+	assert.Len(t, instance.proposalSignal, 1)
+	assert.Equal(t, uint32(3), instance.pendingBlock.Height)
+
+	// the handler takes the pending block, nothing is pushed without
+	// subscribers
+	done := make(chan bool)
+	exited := make(chan struct{})
+	go func() {
+		instance.proposalHandler(done)
+		close(exited)
+	}()
+	assert.Eventually(t, func() bool {
+		instance.pendingMtx.Lock()
+		defer instance.pendingMtx.Unlock()
+		return instance.pendingBlock == nil
+	}, time.Second, time.Millisecond)
+	close(done)
+	<-exited
+	assert.Nil(t, instance.proposalStatus)
+}
+
+func TestPushResult_Credential(t *testing.T) {
+	defer func(acl *servers.AccessControl) { servers.ACL = acl }(servers.ACL)
+	acl, err := servers.NewAccessControl(&config.RpcConfiguration{
+		Credentials: []config.RpcCredential{
+			{APIKey: "all", Methods: []string{"*"}},
+			{APIKey: "reader", Methods: []string{"get*", "subscribe"}},
+			{APIKey: "blocks", Methods: []string{"sendblocktransactions"}},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	servers.ACL = acl
+	s, url, stop := newTestServer(t)
+	defer stop()
+
+	clients := map[string]*websocket.Conn{
+		"all":    dialTestServer(t, url+"/?apikey=all"),
+		"reader": dialTestServer(t, url+"/?apikey=reader"),
+		"blocks": dialTestServer(t, url+"/?apikey=blocks"),
+	}
+	defer func() {
+		for _, conn := range clients {
+			conn.Close()
+		}
+	}()
+
+	// the default pushes are sent only if the credential permits the actions
+	s.PushResult("sendblocktransactions",
+		&types.Block{Header: types.Header{Height: 10}})
+	s.PushResult("sendnewtransaction", &types.Transaction{
+		TxType:  types.TransferAsset,
+		Payload: &payload.TransferAsset{},
+	})
+	marker, _ := packPushData("marker", nil)
+	s.sessions.Foreach(func(ss *session) { ss.Send(marker) })
+	assert.Equal(t, []string{"sendblocktransactions", "sendnewtransaction"},
+		readTestActions(t, clients["all"]))
+	assert.Empty(t, readTestActions(t, clients["reader"]))
+	assert.Equal(t, []string{"sendblocktransactions"},
+		readTestActions(t, clients["blocks"]))
+
+	// the session of a restricted credential receives what it subscribed
+	conn := clients["reader"]
+	assert.NoError(t, conn.WriteJSON(map[string]interface{}{
+		"action": "subscribe", "topic": TopicBlock, "verbose": false}))
+	assert.Equal(t, float64(0), readTestPush(t, conn)["Error"])
+	s.pushBlock(&types.Block{Header: types.Header{Height: 11}})
+	s.sessions.Foreach(func(ss *session) { ss.Send(marker) })
+	assert.Equal(t, []string{"sendblocktransactions"}, readTestActions(t, conn))
+	assert.Empty(t, readTestActions(t, clients["all"]))
+	assert.Empty(t, readTestActions(t, clients["blocks"]))
+}
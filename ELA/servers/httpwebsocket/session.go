@@ -10,6 +10,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/elastos/Elastos.ELA/servers"
+
 	"github.com/gorilla/websocket"
 )
 
@@ -18,6 +20,13 @@ type session struct {
 	id         int64
 	conn       *websocket.Conn
 	lastActive time.Time
+
+	// credential is the credential the session authenticated by, it is nil
+	// if no credential is configured.
+	credential *servers.Credential
+
+	subMtx        sync.RWMutex
+	subscriptions map[string]*subscription
 }
 
 func (s *session) Send(data []byte) error {
@@ -0,0 +1,220 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package httpwebsocket
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/servers"
+)
+
+// Topics a session can subscribe.
+const (
+	// TopicBlock pushes connected blocks.
+	TopicBlock = "block"
+
+	// TopicConfirm pushes DPoS confirms of blocks.
+	TopicConfirm = "confirm"
+
+	// TopicTransaction pushes transactions accepted into transaction pool.
+	TopicTransaction = "transaction"
+
+	// TopicProposal pushes status changes of CR proposals.
+	TopicProposal = "proposal"
+
+	// TopicDPOSProposal pushes DPoS proposals arrived and finished.
+	TopicDPOSProposal = "dposproposal"
+
+	// TopicDPOSVote pushes DPoS votes arrived.
+	TopicDPOSVote = "dposvote"
+
+	// TopicDPOSView pushes DPoS view changes.
+	TopicDPOSView = "dposview"
+
+	// TopicDPOSConsensus pushes DPoS consensus started and finished.
+	TopicDPOSConsensus = "dposconsensus"
+
+	// TopicInactiveArbitrators pushes inactive arbitrators evidences.
+	TopicInactiveArbitrators = "inactivearbitrators"
+
+	// TopicIllegalEvidence pushes evidences of illegal proposals, votes and
+	// blocks.
+	TopicIllegalEvidence = "illegalevidence"
+)
+
+// subscription holds the filters of a subscribed topic, empty filters match
+// everything.
+type subscription struct {
+	// verbose indicates whether to push block with full transactions or
+	// transaction hashes only.
+	verbose bool
+
+	addresses map[common.Uint168]struct{}
+	txTypes   map[types.TxType]struct{}
+	proposals map[common.Uint256]struct{}
+}
+
+// matchTransaction returns if the transaction matches the filters, references
+// is called only if address filter is set.
+func (s *subscription) matchTransaction(tx *types.Transaction,
+	references func() map[*types.Input]types.Output) bool {
+	if len(s.txTypes) > 0 {
+		if _, ok := s.txTypes[tx.TxType]; !ok {
+			return false
+		}
+	}
+	if len(s.addresses) == 0 {
+		return true
+	}
+
+	for _, output := range tx.Outputs {
+		if _, ok := s.addresses[output.ProgramHash]; ok {
+			return true
+		}
+	}
+	for _, output := range references() {
+		if _, ok := s.addresses[output.ProgramHash]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *subscription) matchProposal(hash common.Uint256) bool {
+	if len(s.proposals) == 0 {
+		return true
+	}
+	_, ok := s.proposals[hash]
+	return ok
+}
+
+// newSubscription creates a subscription of the given topic with filters in
+// request parameters.
+func newSubscription(topic string, params servers.Params) (
+	*subscription, error) {
+	sub := &subscription{
+		verbose:   true,
+		addresses: make(map[common.Uint168]struct{}),
+		txTypes:   make(map[types.TxType]struct{}),
+		proposals: make(map[common.Uint256]struct{}),
+	}
+
+	switch topic {
+	case TopicBlock:
+		if verbose, ok := params.Bool("verbose"); ok {
+			sub.verbose = verbose
+		}
+
+	case TopicConfirm, TopicDPOSProposal, TopicDPOSVote, TopicDPOSView,
+		TopicDPOSConsensus, TopicInactiveArbitrators, TopicIllegalEvidence:
+
+	case TopicTransaction:
+		if _, ok := params["addresses"]; ok {
+			addresses, ok := params.ArrayString("addresses")
+			if !ok {
+				return nil, errors.New("addresses should be an array of string")
+			}
+			for _, address := range addresses {
+				programHash, err := common.Uint168FromAddress(address)
+				if err != nil {
+					return nil, errors.New("invalid address " + address)
+				}
+				sub.addresses[*programHash] = struct{}{}
+			}
+		}
+		if v, ok := params["txtypes"]; ok {
+			txTypes, ok := v.([]interface{})
+			if !ok {
+				return nil, errors.New("txtypes should be an array of integer")
+			}
+			for _, t := range txTypes {
+				txType, ok := t.(float64)
+				if !ok || txType < 0 || txType > 0xff {
+					return nil, errors.New("invalid txtype")
+				}
+				sub.txTypes[types.TxType(txType)] = struct{}{}
+			}
+		}
+
+	case TopicProposal:
+		if _, ok := params["proposalhashes"]; ok {
+			hashes, ok := params.ArrayString("proposalhashes")
+			if !ok {
+				return nil, errors.New("proposalhashes should be an array of string")
+			}
+			for _, h := range hashes {
+				hashBytes, err := servers.FromReversedString(h)
+				if err != nil {
+					return nil, errors.New("invalid proposal hash " + h)
+				}
+				hash, err := common.Uint256FromBytes(hashBytes)
+				if err != nil {
+					return nil, errors.New("invalid proposal hash " + h)
+				}
+				sub.proposals[*hash] = struct{}{}
+			}
+		}
+
+	default:
+		return nil, errors.New("unknown topic " + topic)
+	}
+
+	return sub, nil
+}
+
+// subscribe adds or replaces a subscription of the session, and returns
+// topics subscribed by the session.
+func (s *session) subscribe(topic string, sub *subscription) []string {
+	s.subMtx.Lock()
+	defer s.subMtx.Unlock()
+
+	if s.subscriptions == nil {
+		s.subscriptions = make(map[string]*subscription)
+	}
+	s.subscriptions[topic] = sub
+	return s.topics()
+}
+
+// unsubscribe removes a subscription of the session, and returns topics
+// subscribed by the session.
+func (s *session) unsubscribe(topic string) []string {
+	s.subMtx.Lock()
+	defer s.subMtx.Unlock()
+
+	if s.subscriptions == nil {
+		s.subscriptions = make(map[string]*subscription)
+	}
+	delete(s.subscriptions, topic)
+	return s.topics()
+}
+
+// subscribed returns if the session has ever subscribed topics, session not
+// subscribed receives everything pushed.
+func (s *session) subscribed() bool {
+	s.subMtx.RLock()
+	defer s.subMtx.RUnlock()
+	return s.subscriptions != nil
+}
+
+// subscription returns the subscription of the given topic, or nil if the
+// session has not subscribed the topic.
+func (s *session) subscription(topic string) *subscription {
+	s.subMtx.RLock()
+	defer s.subMtx.RUnlock()
+	return s.subscriptions[topic]
+}
+
+func (s *session) topics() []string {
+	topics := make([]string, 0, len(s.subscriptions))
+	for k := range s.subscriptions {
+		topics = append(topics, k)
+	}
+	sort.Strings(topics)
+	return topics
+}
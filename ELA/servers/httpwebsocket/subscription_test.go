@@ -0,0 +1,414 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package httpwebsocket
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elastos/Elastos.ELA/blockchain"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/core/contract"
+	"github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/outputpayload"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	crstate "github.com/elastos/Elastos.ELA/cr/state"
+	"github.com/elastos/Elastos.ELA/servers"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// testTxStore is a transaction store of UTXO cache.
+type testTxStore map[common.Uint256]*types.Transaction
+
+func (s testTxStore) GetTransaction(txID common.Uint256) (
+	*types.Transaction, uint32, error) {
+	tx, ok := s[txID]
+	if !ok {
+		return nil, 0, errors.New("transaction not found")
+	}
+	return tx, 1, nil
+}
+
+// newTestServer starts a websocket server, the returned function stops it.
+func newTestServer(t *testing.T) (*Server, string, func()) {
+	s := &Server{
+		sessions:       &sessions{},
+		proposalSignal: make(chan struct{}, 1),
+	}
+	s.initMethods()
+	server := httptest.NewServer(http.HandlerFunc(s.Handler))
+	return s, "ws" + strings.TrimPrefix(server.URL, "http"), server.Close
+}
+
+// dialTestServer connects to the server and sends the requests, the
+// responses of the requests are checked to be successful.
+func dialTestServer(t *testing.T, url string,
+	requests ...map[string]interface{}) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range requests {
+		if err := conn.WriteJSON(req); err != nil {
+			t.Fatal(err)
+		}
+		resp := readTestPush(t, conn)
+		if resp["Error"] != float64(0) {
+			t.Fatalf("request %v failed: %v", req, resp)
+		}
+	}
+	return conn
+}
+
+func readTestPush(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var resp map[string]interface{}
+	if err := conn.ReadJSON(&resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// readTestActions reads the actions of pushes until the marker pushed after
+// the events.
+func readTestActions(t *testing.T, conn *websocket.Conn) []string {
+	var actions []string
+	for {
+		push := readTestPush(t, conn)
+		action, _ := push["Action"].(string)
+		if action == "marker" {
+			return actions
+		}
+		actions = append(actions, action)
+	}
+}
+
+func newTestOutput(programHash common.Uint168) *types.Output {
+	return &types.Output{
+		AssetID:     config.ELAAssetID,
+		Value:       1e8,
+		ProgramHash: programHash,
+		Type:        types.OTNone,
+		Payload:     &outputpayload.DefaultOutput{},
+	}
+}
+
+func TestSubscriptionFilters(t *testing.T) {
+	defer func(chain *blockchain.BlockChain) { servers.Chain = chain }(
+		servers.Chain)
+	s, url, stop := newTestServer(t)
+	defer stop()
+
+	addressA := common.Uint168{byte(contract.PrefixStandard), 0xa}
+	addressB := common.Uint168{byte(contract.PrefixStandard), 0xb}
+	addressC := common.Uint168{byte(contract.PrefixStandard), 0xc}
+	newTx := func(txType types.TxType, to common.Uint168,
+		from ...*types.Transaction) *types.Transaction {
+		tx := &types.Transaction{
+			TxType:  txType,
+			Payload: &payload.TransferAsset{},
+			Outputs: []*types.Output{newTestOutput(to)},
+		}
+		for _, prev := range from {
+			tx.Inputs = append(tx.Inputs, &types.Input{
+				Previous: types.OutPoint{TxID: prev.Hash()},
+			})
+		}
+		return tx
+	}
+	prevA := newTx(types.TransferAsset, addressA)
+	prevB := newTx(types.TransferAsset, addressB)
+	prevC := newTx(types.TransferAsset, addressC)
+	servers.Chain = &blockchain.BlockChain{
+		Nodes: make([]*blockchain.BlockNode, 11),
+		UTXOCache: blockchain.NewUTXOCache(testTxStore{
+			prevA.Hash(): prevA,
+			prevB.Hash(): prevB,
+			prevC.Hash(): prevC,
+		}, &config.DefaultParams),
+	}
+	addressAString, err := addressA.ToAddress()
+	assert.NoError(t, err)
+
+	subscribe := func(topic string, filters map[string]interface{}) map[string]interface{} {
+		req := map[string]interface{}{"action": "subscribe", "topic": topic}
+		for k, v := range filters {
+			req[k] = v
+		}
+		return req
+	}
+	clients := map[string]*websocket.Conn{
+		"default": dialTestServer(t, url),
+		// the verbose block needs a chain with parameters, so only blocks
+		// of transaction hashes are pushed here
+		"blockTxs": dialTestServer(t, url, subscribe(TopicBlock,
+			map[string]interface{}{"verbose": false})),
+		"txAll": dialTestServer(t, url, subscribe(TopicTransaction, nil)),
+		"txType": dialTestServer(t, url, subscribe(TopicTransaction,
+			map[string]interface{}{"txtypes": []interface{}{
+				float64(types.TransferCrossChainAsset)}})),
+		"txAddress": dialTestServer(t, url, subscribe(TopicTransaction,
+			map[string]interface{}{"addresses": []interface{}{addressAString}})),
+		"confirm": dialTestServer(t, url, subscribe(TopicConfirm, nil)),
+		// the last subscription replaces the previous one of the topic
+		"txReplaced": dialTestServer(t, url,
+			subscribe(TopicTransaction, nil),
+			subscribe(TopicTransaction, map[string]interface{}{
+				"txtypes": []interface{}{float64(types.CoinBase)}})),
+		// the session subscribed any topics does not receive default pushes
+		"unsubscribed": dialTestServer(t, url,
+			subscribe(TopicBlock, nil),
+			map[string]interface{}{"action": "unsubscribe", "topic": TopicBlock}),
+	}
+	defer func() {
+		for _, conn := range clients {
+			conn.Close()
+		}
+	}()
+
+	block := &types.Block{Header: types.Header{Height: 10}}
+	for _, test := range []struct {
+		name  string
+		push  func()
+		conns map[string][]string
+	}{
+		{
+			name: "block",
+			push: func() { s.pushBlock(block) },
+			conns: map[string][]string{
+				"blockTxs": {"sendblocktransactions"},
+			},
+		},
+		{
+			name: "default block",
+			push: func() { s.PushResult("sendblocktransactions", block) },
+			conns: map[string][]string{
+				"default": {"sendblocktransactions"},
+			},
+		},
+		{
+			name: "transaction paying to address",
+			push: func() { s.pushTransaction(newTx(types.TransferAsset, addressA, prevB)) },
+			conns: map[string][]string{
+				"txAll":     {"sendnewtransaction"},
+				"txAddress": {"sendnewtransaction"},
+			},
+		},
+		{
+			name: "transaction spending from address and of type",
+			push: func() {
+				s.pushTransaction(newTx(types.TransferCrossChainAsset, addressC, prevA))
+			},
+			conns: map[string][]string{
+				"txAll":     {"sendnewtransaction"},
+				"txType":    {"sendnewtransaction"},
+				"txAddress": {"sendnewtransaction"},
+			},
+		},
+		{
+			name: "transaction matches no filter",
+			push: func() { s.pushTransaction(newTx(types.TransferAsset, addressC, prevB, prevC)) },
+			conns: map[string][]string{
+				"txAll": {"sendnewtransaction"},
+			},
+		},
+		{
+			name: "replaced filter",
+			push: func() { s.pushTransaction(newTx(types.CoinBase, addressB)) },
+			conns: map[string][]string{
+				"txAll":      {"sendnewtransaction"},
+				"txReplaced": {"sendnewtransaction"},
+			},
+		},
+		{
+			name: "default transaction",
+			push: func() {
+				s.PushResult("sendnewtransaction", newTx(types.TransferAsset, addressA))
+			},
+			conns: map[string][]string{
+				"default": {"sendnewtransaction"},
+			},
+		},
+		{
+			name:  "not a block or transaction",
+			push:  func() { s.pushBlock("block"); s.pushTransaction("tx") },
+			conns: map[string][]string{},
+		},
+	} {
+		test.push()
+		marker, _ := packPushData("marker", nil)
+		s.sessions.Foreach(func(ss *session) { ss.Send(marker) })
+		for name, conn := range clients {
+			assert.Equal(t, test.conns[name], readTestActions(t, conn),
+				"%s: %s", test.name, name)
+		}
+	}
+}
+
+func TestSubscriptionFilters_Proposal(t *testing.T) {
+	s, url, stop := newTestServer(t)
+	defer stop()
+
+	hashA := common.Uint256{0xa}
+	hashB := common.Uint256{0xb}
+	proposals := crstate.ProposalsMap{
+		hashA: &crstate.ProposalState{Status: crstate.Registered},
+		hashB: &crstate.ProposalState{Status: crstate.Registered},
+	}
+	s.getProposals = func() crstate.ProposalsMap {
+		result := make(crstate.ProposalsMap, len(proposals))
+		for k, v := range proposals {
+			p := *v
+			result[k] = &p
+		}
+		return result
+	}
+	block := &types.Block{Header: types.Header{Height: 100}}
+
+	// the status is not recorded without subscribers
+	s.pushProposalStates(block)
+	assert.Nil(t, s.proposalStatus)
+
+	all := dialTestServer(t, url, map[string]interface{}{
+		"action": "subscribe", "topic": TopicProposal})
+	defer all.Close()
+	filtered := dialTestServer(t, url, map[string]interface{}{
+		"action": "subscribe", "topic": TopicProposal,
+		"proposalhashes": []interface{}{servers.ToReversedString(hashA)}})
+	defer filtered.Close()
+	blocks := dialTestServer(t, url, map[string]interface{}{
+		"action": "subscribe", "topic": TopicBlock})
+	defer blocks.Close()
+
+	// the first block records the status only
+	s.pushProposalStates(block)
+	assert.Len(t, s.proposalStatus, 2)
+
+	hashC := common.Uint256{0xc}
+	proposals[hashA].Status = crstate.CRAgreed
+	proposals[hashC] = &crstate.ProposalState{Status: crstate.Registered}
+	block.Height++
+	s.pushProposalStates(block)
+
+	type statusPush struct {
+		Action string
+		Result struct {
+			ProposalHash   string
+			Status         string
+			PreviousStatus string
+			Height         uint32
+		}
+	}
+	read := func(conn *websocket.Conn, count int) map[string]statusPush {
+		pushes := make(map[string]statusPush)
+		for i := 0; i < count; i++ {
+			conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			var push statusPush
+			if !assert.NoError(t, conn.ReadJSON(&push)) {
+				break
+			}
+			assert.Equal(t, "sendproposalstate", push.Action)
+			pushes[push.Result.ProposalHash] = push
+		}
+		return pushes
+	}
+	pushes := read(all, 2)
+	if assert.Len(t, pushes, 2) {
+		agreed := pushes[servers.ToReversedString(hashA)]
+		assert.Equal(t, "CRAgreed", agreed.Result.Status)
+		assert.Equal(t, "Registered", agreed.Result.PreviousStatus)
+		assert.Equal(t, uint32(101), agreed.Result.Height)
+		registered := pushes[servers.ToReversedString(hashC)]
+		assert.Equal(t, "Registered", registered.Result.Status)
+		assert.Equal(t, "", registered.Result.PreviousStatus)
+	}
+	pushes = read(filtered, 1)
+	assert.Contains(t, pushes, servers.ToReversedString(hashA))
+
+	// the unchanged proposals are not pushed again
+	proposals[hashB].Status = crstate.VoterCanceled
+	block.Height++
+	s.pushProposalStates(block)
+	pushes = read(all, 1)
+	assert.Contains(t, pushes, servers.ToReversedString(hashB))
+	proposals[hashA].Status = crstate.VoterAgreed
+	block.Height++
+	s.pushProposalStates(block)
+	pushes = read(filtered, 1)
+	if assert.Contains(t, pushes, servers.ToReversedString(hashA)) {
+		assert.Equal(t, "VoterAgreed",
+			pushes[servers.ToReversedString(hashA)].Result.Status)
+	}
+	pushes = read(all, 1)
+	assert.Contains(t, pushes, servers.ToReversedString(hashA))
+
+	// the filtered session received the proposal of the filter only
+	filtered.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	_, _, err := filtered.ReadMessage()
+	assert.Error(t, err)
+
+	// the session of other topics receives nothing
+	marker, _ := packPushData("marker", nil)
+	s.sessions.Foreach(func(ss *session) { ss.Send(marker) })
+	assert.Empty(t, readTestActions(t, blocks))
+}
+
+func TestNewSubscription(t *testing.T) {
+	params := func(s string) servers.Params {
+		var p servers.Params
+		if err := json.Unmarshal([]byte(s), &p); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	for _, test := range []struct {
+		topic  string
+		params string
+		err    string
+	}{
+		{TopicBlock, `{"verbose":false}`, ""},
+		{TopicConfirm, `{}`, ""},
+		{TopicIllegalEvidence, `{}`, ""},
+		{TopicTransaction, `{"addresses":["EQ4QhsYRwuBbNBXc8BPW972xA9ANByKt6U"],"txtypes":[2,8]}`, ""},
+		{TopicTransaction, `{"addresses":"EQ4QhsYRwuBbNBXc8BPW972xA9ANByKt6U"}`,
+			"addresses should be an array of string"},
+		{TopicTransaction, `{"addresses":["invalid"]}`, "invalid address invalid"},
+		{TopicTransaction, `{"txtypes":2}`, "txtypes should be an array of integer"},
+		{TopicTransaction, `{"txtypes":[256]}`, "invalid txtype"},
+		{TopicTransaction, `{"txtypes":["2"]}`, "invalid txtype"},
+		{TopicProposal, `{"proposalhashes":["` + strings.Repeat("0a", 32) + `"]}`, ""},
+		{TopicProposal, `{"proposalhashes":["0a"]}`, "invalid proposal hash 0a"},
+		{TopicProposal, `{"proposalhashes":["xyz"]}`, "invalid proposal hash xyz"},
+		{"unknown", `{}`, "unknown topic unknown"},
+	} {
+		sub, err := newSubscription(test.topic, params(test.params))
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.params)
+			continue
+		}
+		if assert.NoError(t, err, test.params) {
+			assert.NotNil(t, sub)
+		}
+	}
+
+	sub, err := newSubscription(TopicBlock, params(`{"verbose":false}`))
+	if assert.NoError(t, err) {
+		assert.False(t, sub.verbose)
+	}
+	sub, err = newSubscription(TopicBlock, params(`{}`))
+	if assert.NoError(t, err) {
+		assert.True(t, sub.verbose)
+	}
+}
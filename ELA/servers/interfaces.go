@@ -9,13 +9,17 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/elastos/Elastos.ELA/account"
 	aux "github.com/elastos/Elastos.ELA/auxpow"
 	"github.com/elastos/Elastos.ELA/blockchain"
+	"github.com/elastos/Elastos.ELA/blockchain/indexers"
 	"github.com/elastos/Elastos.ELA/common"
 	"github.com/elastos/Elastos.ELA/common/config"
 	"github.com/elastos/Elastos.ELA/common/log"
@@ -29,6 +33,7 @@ import (
 	"github.com/elastos/Elastos.ELA/dpos/state"
 	"github.com/elastos/Elastos.ELA/elanet"
 	"github.com/elastos/Elastos.ELA/elanet/pact"
+	elaerr "github.com/elastos/Elastos.ELA/errors"
 	"github.com/elastos/Elastos.ELA/mempool"
 	"github.com/elastos/Elastos.ELA/p2p/msg"
 	"github.com/elastos/Elastos.ELA/pow"
@@ -50,9 +55,26 @@ var (
 	Arbiter     *dpos.Arbitrator
 	Arbiters    state.Arbitrators
 	Wallet      *wallet.Wallet
+	ACL         *AccessControl
 	emptyHash   = common.Uint168{}
 )
 
+// DefaultFeeRate is the basic fee rate in sela per KB, returned by fee
+// estimation when there is not enough data.
+const DefaultFeeRate = 10000
+
+// DefaultAddressTransactionsLimit is the count of transactions returned by
+// getaddresstransactions when limit is not given.
+const DefaultAddressTransactionsLimit = 100
+
+// MaxAddressTransactionsLimit is the max count of transactions returned by
+// getaddresstransactions in one call.
+const MaxAddressTransactionsLimit = 1000
+
+// MaxBanTime is the max seconds a host can be banned by setban, which keeps
+// the ban end time far away from overflow.
+const MaxBanTime = 10 * 365 * 24 * 60 * 60
+
 func ToReversedString(hash common.Uint256) string {
 	return common.BytesToHexString(common.BytesReverse(hash[:]))
 }
@@ -227,6 +249,158 @@ func GetNodeState(param Params) map[string]interface{} {
 	})
 }
 
+func GetPeerInfo(param Params) map[string]interface{} {
+	peers := Server.ConnectedPeers()
+	result := make([]*RpcPeerInfo, 0, len(peers))
+	for _, peer := range peers {
+		snap := peer.ToPeer().StatsSnapshot()
+		result = append(result, &RpcPeerInfo{
+			ID:             snap.ID,
+			Addr:           snap.Addr,
+			Services:       pact.ServiceFlag(snap.Services).String(),
+			RelayTx:        snap.RelayTx != 0,
+			LastSend:       snap.LastSend.Unix(),
+			LastRecv:       snap.LastRecv.Unix(),
+			BytesSent:      snap.BytesSent,
+			BytesRecv:      snap.BytesRecv,
+			ConnTime:       snap.ConnTime.Unix(),
+			TimeOffset:     snap.TimeOffset,
+			Version:        snap.Version,
+			Inbound:        snap.Inbound,
+			StartingHeight: snap.StartingHeight,
+			Height:         snap.LastBlock,
+			BanScore:       peer.BanScore(),
+			PingTime:       snap.LastPingMicros,
+		})
+	}
+	return ResponsePack(Success, result)
+}
+
+func AddNode(param Params) map[string]interface{} {
+	if rtn := checkRPCServiceLevel(config.ConfigurationPermitted); rtn != nil {
+		return rtn
+	}
+
+	node, ok := param.String("node")
+	if !ok {
+		return ResponsePack(InvalidParams, "need a string parameter named node")
+	}
+	command, ok := param.String("command")
+	if !ok {
+		return ResponsePack(InvalidParams, "need a string parameter named command")
+	}
+
+	var err error
+	switch command {
+	case "add":
+		err = Server.Connect(node, true)
+	case "remove":
+		err = Server.RemoveByAddr(node)
+	case "onetry":
+		err = Server.Connect(node, false)
+	default:
+		return ResponsePack(InvalidParams,
+			"command should be one of add, remove and onetry")
+	}
+	if err != nil {
+		return ResponsePack(InternalError, err.Error())
+	}
+	return ResponsePack(Success, nil)
+}
+
+func DisconnectNode(param Params) map[string]interface{} {
+	if rtn := checkRPCServiceLevel(config.ConfigurationPermitted); rtn != nil {
+		return rtn
+	}
+
+	var err error
+	if address, ok := param.String("address"); ok && address != "" {
+		err = Server.DisconnectByAddr(address)
+	} else if id, ok := param.Int("nodeid"); ok && id >= 0 {
+		err = Server.DisconnectByID(uint64(id))
+	} else {
+		return ResponsePack(InvalidParams, "need a parameter address or nodeid")
+	}
+	if err != nil {
+		return ResponsePack(InternalError, err.Error())
+	}
+	return ResponsePack(Success, nil)
+}
+
+func SetBan(param Params) map[string]interface{} {
+	if rtn := checkRPCServiceLevel(config.ConfigurationPermitted); rtn != nil {
+		return rtn
+	}
+
+	host, ok := param.String("host")
+	if !ok {
+		return ResponsePack(InvalidParams, "need a string parameter named host")
+	}
+	command, ok := param.String("command")
+	if !ok {
+		return ResponsePack(InvalidParams, "need a string parameter named command")
+	}
+
+	switch command {
+	case "add":
+		var banEnd time.Time
+		if _, ok := param["bantime"]; ok {
+			banTime, ok := param.Int("bantime")
+			if !ok || banTime < 0 {
+				return ResponsePack(InvalidParams, "bantime should be a non-negative integer")
+			}
+			if absolute, _ := param.Bool("absolute"); absolute {
+				if banTime > time.Now().Unix()+MaxBanTime {
+					return ResponsePack(InvalidParams, "bantime should not be "+
+						"later than 10 years from now")
+				}
+				banEnd = time.Unix(banTime, 0)
+			} else if banTime > MaxBanTime {
+				return ResponsePack(InvalidParams, "bantime should not be "+
+					"longer than 10 years")
+			} else if banTime > 0 {
+				banEnd = time.Now().Add(time.Duration(banTime) * time.Second)
+			}
+		}
+		if err := Server.BanHost(host, banEnd); err != nil {
+			return ResponsePack(InvalidParams, err.Error())
+		}
+
+	case "remove":
+		if err := Server.UnbanHost(host); err != nil {
+			return ResponsePack(InvalidParams, err.Error())
+		}
+
+	default:
+		return ResponsePack(InvalidParams, "command should be add or remove")
+	}
+	return ResponsePack(Success, nil)
+}
+
+func ListBanned(param Params) map[string]interface{} {
+	banned := Server.BannedHosts()
+	result := make([]*BannedHostInfo, 0, len(banned))
+	for host, banEnd := range banned {
+		result = append(result, &BannedHostInfo{
+			Host:        host,
+			BannedUntil: banEnd.Unix(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Host < result[j].Host
+	})
+	return ResponsePack(Success, result)
+}
+
+func ClearBanned(param Params) map[string]interface{} {
+	if rtn := checkRPCServiceLevel(config.ConfigurationPermitted); rtn != nil {
+		return rtn
+	}
+
+	Server.ClearBanned()
+	return ResponsePack(Success, nil)
+}
+
 func SetLogLevel(param Params) map[string]interface{} {
 	if rtn := checkRPCServiceLevel(config.ConfigurationPermitted); rtn != nil {
 		return rtn
@@ -371,19 +545,19 @@ func GetArbiterPeersInfo(params Params) map[string]interface{} {
 	return ResponsePack(Success, result)
 }
 
-func GetArbitersInfo(params Params) map[string]interface{} {
-	type arbitersInfo struct {
-		Arbiters               []string `json:"arbiters"`
-		Candidates             []string `json:"candidates"`
-		NextArbiters           []string `json:"nextarbiters"`
-		NextCandidates         []string `json:"nextcandidates"`
-		OnDutyArbiter          string   `json:"ondutyarbiter"`
-		CurrentTurnStartHeight int      `json:"currentturnstartheight"`
-		NextTurnStartHeight    int      `json:"nextturnstartheight"`
-	}
+type RpcArbitersInfo struct {
+	Arbiters               []string `json:"arbiters"`
+	Candidates             []string `json:"candidates"`
+	NextArbiters           []string `json:"nextarbiters"`
+	NextCandidates         []string `json:"nextcandidates"`
+	OnDutyArbiter          string   `json:"ondutyarbiter"`
+	CurrentTurnStartHeight int      `json:"currentturnstartheight"`
+	NextTurnStartHeight    int      `json:"nextturnstartheight"`
+}
 
+func GetArbitersInfo(params Params) map[string]interface{} {
 	dutyIndex := Arbiters.GetDutyIndex()
-	result := &arbitersInfo{
+	result := &RpcArbitersInfo{
 		Arbiters:       make([]string, 0),
 		Candidates:     make([]string, 0),
 		NextArbiters:   make([]string, 0),
@@ -719,6 +893,51 @@ func SendRawTransaction(param Params) map[string]interface{} {
 	return ResponsePack(Success, ToReversedString(txn.Hash()))
 }
 
+// MempoolAcceptInfo is the result of testmempoolaccept.
+type MempoolAcceptInfo struct {
+	TxID     string                `json:"txid"`
+	Allowed  bool                  `json:"allowed"`
+	Size     int                   `json:"size"`
+	Fee      string                `json:"fee"`
+	FeePerKB string                `json:"feeperkb"`
+	Error    *elaerr.JsonFormatter `json:"error,omitempty"`
+}
+
+func TestMempoolAccept(param Params) map[string]interface{} {
+	if rtn := checkRPCServiceLevel(config.TransactionPermitted); rtn != nil {
+		return rtn
+	}
+
+	str, ok := param.String("data")
+	if !ok {
+		return ResponsePack(InvalidParams, "need a string parameter named data")
+	}
+
+	bys, err := common.HexStringToBytes(str)
+	if err != nil {
+		return ResponsePack(InvalidParams, "hex string to bytes error")
+	}
+	var txn Transaction
+	if err := txn.Deserialize(bytes.NewReader(bys)); err != nil {
+		return ResponsePack(InvalidTransaction, err.Error())
+	}
+
+	size := txn.GetSize()
+	fee, errCode := TxMemPool.TestAcceptTransaction(&txn)
+	info := MempoolAcceptInfo{
+		TxID:     ToReversedString(txn.Hash()),
+		Allowed:  errCode == nil,
+		Size:     size,
+		Fee:      fee.String(),
+		FeePerKB: (fee * 1000 / common.Fixed64(size)).String(),
+	}
+	if errCode != nil {
+		info.Error = elaerr.ToJsonFormatter(errCode)
+	}
+
+	return ResponsePack(Success, info)
+}
+
 func GetBlockHeight(param Params) map[string]interface{} {
 	return ResponsePack(Success, Chain.GetHeight())
 }
@@ -909,6 +1128,71 @@ func GetReceivedByAddress(param Params) map[string]interface{} {
 	return ResponsePack(Success, balance.String())
 }
 
+// utxoSetInfoCache caches the utxo set info of the best block, so the utxo
+// index is walked once a block at most.
+var utxoSetInfoCache struct {
+	sync.Mutex
+	info *indexers.UTXOSetInfo
+}
+
+// getUTXOSetInfo returns the utxo set info of the best block, the utxo index
+// is walked only if the cached info is not of the best block.
+func getUTXOSetInfo(bestBlock common.Uint256) (*indexers.UTXOSetInfo, error) {
+	utxoSetInfoCache.Lock()
+	defer utxoSetInfoCache.Unlock()
+
+	info := utxoSetInfoCache.info
+	if info != nil && info.BestBlock.IsEqual(bestBlock) {
+		return info, nil
+	}
+	info, err := Store.GetFFLDB().GetUTXOSetInfo()
+	if err != nil {
+		return nil, err
+	}
+	utxoSetInfoCache.info = info
+	return info, nil
+}
+
+// GetTxOutSetInfo walks the utxo index and returns statistics of the utxo
+// set.  The utxo index does not record asset IDs, it indexes ELA outputs only
+// because outputs of other assets are not allowed, so ELA is the only asset
+// can be queried.
+func GetTxOutSetInfo(param Params) map[string]interface{} {
+	if rtn := checkRPCServiceLevel(config.WalletPermitted); rtn != nil {
+		return rtn
+	}
+
+	assetID := config.ELAAssetID
+	if id, ok := param.String("assetid"); ok {
+		hash, err := common.Uint256FromReversedHexString(id)
+		if err != nil {
+			return ResponsePack(InvalidParams, "invalid assetid")
+		}
+		assetID = *hash
+	}
+	if !assetID.IsEqual(config.ELAAssetID) {
+		return ResponsePack(InvalidParams, "only ELA asset is indexed in "+
+			"the utxo index")
+	}
+
+	info, err := getUTXOSetInfo(Chain.GetCurrentBlockHash())
+	if err != nil {
+		return ResponsePack(InternalError, "get utxo set info failed, "+err.Error())
+	}
+
+	return ResponsePack(Success, TxOutSetInfo{
+		Height:    info.Height,
+		BestBlock: ToReversedString(info.BestBlock),
+		TxOuts:    info.UTXOs,
+		Addresses: info.Addresses,
+		Total: map[string]string{
+			ToReversedString(assetID): info.TotalAmount.String(),
+		},
+		Hash:              ToReversedString(info.Hash),
+		CirculationAmount: Chain.GetCRCommittee().GetCirculationAmount().String(),
+	})
+}
+
 func GetUTXOsByAmount(param Params) map[string]interface{} {
 	if rtn := checkRPCServiceLevel(config.WalletPermitted); rtn != nil {
 		return rtn
@@ -1387,6 +1671,103 @@ func GetUnspendOutput(param Params) map[string]interface{} {
 	return ResponsePack(Success, UTXOoutputs)
 }
 
+func GetAddressTransactions(param Params) map[string]interface{} {
+	if rtn := checkRPCServiceLevel(config.WalletPermitted); rtn != nil {
+		return rtn
+	}
+
+	address, ok := param.String("address")
+	if !ok {
+		return ResponsePack(InvalidParams, "need a string parameter named address")
+	}
+	programHash, err := common.Uint168FromAddress(address)
+	if err != nil {
+		return ResponsePack(InvalidParams, "invalid address, "+err.Error())
+	}
+	var start uint32
+	if _, ok := param["start"]; ok {
+		if start, ok = param.Uint("start"); !ok {
+			return ResponsePack(InvalidParams,
+				"start should be a non-negative integer")
+		}
+	}
+	limit := uint32(DefaultAddressTransactionsLimit)
+	if _, ok := param["limit"]; ok {
+		if limit, ok = param.Uint("limit"); !ok || limit == 0 ||
+			limit > MaxAddressTransactionsLimit {
+			return ResponsePack(InvalidParams, fmt.Sprintf(
+				"limit should be in range 1-%d", MaxAddressTransactionsLimit))
+		}
+	}
+
+	txs, total, err := Store.GetFFLDB().GetAddressTransactions(programHash,
+		start, limit)
+	if err != nil {
+		return ResponsePack(InternalError, err.Error())
+	}
+
+	result := AddressTransactionsInfo{
+		Transactions: make([]AddressTransactionInfo, 0, len(txs)),
+		TotalCount:   total,
+	}
+	for _, tx := range txs {
+		result.Transactions = append(result.Transactions, AddressTransactionInfo{
+			TxID:   ToReversedString(tx.TxID),
+			Height: tx.Height,
+			Delta:  tx.Delta.String(),
+		})
+	}
+	return ResponsePack(Success, result)
+}
+
+func GetSpendingInfo(param Params) map[string]interface{} {
+	if rtn := checkRPCServiceLevel(config.WalletPermitted); rtn != nil {
+		return rtn
+	}
+
+	txIDStr, ok := param.String("txid")
+	if !ok {
+		return ResponsePack(InvalidParams, "need a string parameter named txid")
+	}
+	txIDBytes, err := FromReversedString(txIDStr)
+	if err != nil {
+		return ResponsePack(InvalidParams, "invalid txid, "+err.Error())
+	}
+	txID, err := common.Uint256FromBytes(txIDBytes)
+	if err != nil {
+		return ResponsePack(InvalidParams, "invalid txid, "+err.Error())
+	}
+	vout, ok := param.Uint("vout")
+	if !ok {
+		return ResponsePack(InvalidParams, "need an integer parameter named vout")
+	}
+
+	txn, _, err := Store.GetTransaction(*txID)
+	if err != nil {
+		return ResponsePack(UnknownTransaction, "")
+	}
+	if vout >= uint32(len(txn.Outputs)) {
+		return ResponsePack(InvalidParams, "vout out of range")
+	}
+
+	info, err := Store.GetFFLDB().GetSpendingInfo(&OutPoint{
+		TxID:  *txID,
+		Index: uint16(vout),
+	})
+	if err != nil {
+		return ResponsePack(InternalError, err.Error())
+	}
+	if info == nil {
+		return ResponsePack(Success, SpendingInfo{Spent: false})
+	}
+	return ResponsePack(Success, SpendingInfo{
+		Spent:  true,
+		TxID:   ToReversedString(info.TxID),
+		VIn:    &info.Index,
+		Height: info.Height,
+	})
+}
+
 //Transaction
 func GetTransactionByHash(param Params) map[string]interface{} {
 	str, ok := param.String("hash")
@@ -1473,6 +1854,7 @@ type RpcProducersInfo struct {
 	ProducerInfoSlice []RpcProducerInfo `json:"producers"`
 	TotalVotes        string            `json:"totalvotes"`
 	TotalCounts       uint64            `json:"totalcounts"`
+	Height            uint32            `json:"height,omitempty"`
 }
 
 //single cr candidate info
@@ -1522,6 +1904,7 @@ type RpcCrMemberInfo struct {
 type RpcCrMembersInfo struct {
 	CRMemberInfoSlice []RpcCrMemberInfo `json:"crmembersinfo"`
 	TotalCounts       uint64            `json:"totalcounts"`
+	Height            uint32            `json:"height,omitempty"`
 }
 
 type RpcProposalBaseState struct {
@@ -1567,6 +1950,7 @@ type RpcProposalState struct {
 
 type RpcCRProposalStateInfo struct {
 	ProposalState RpcProposalState `json:"proposalstate"`
+	Height        uint32           `json:"height,omitempty"`
 }
 
 func ListProducers(param Params) map[string]interface{} {
@@ -1579,24 +1963,111 @@ func ListProducers(param Params) map[string]interface{} {
 	if ok {
 		s = strings.ToLower(s)
 	}
+	st, height, err := getStateByHeight(param)
+	if err != nil {
+		return ResponsePack(InvalidParams, err.Error())
+	}
+	producerInfoSlice, totalVotes := getProducerInfos(st, s)
+
+	count := int64(len(producerInfoSlice))
+	if limit < 0 {
+		limit = count
+	}
+	var rsProducerInfoSlice []RpcProducerInfo
+	if start < count {
+		end := start
+		if start+limit <= count {
+			end = start + limit
+		} else {
+			end = count
+		}
+		rsProducerInfoSlice = append(rsProducerInfoSlice, producerInfoSlice[start:end]...)
+	}
+
+	result := &RpcProducersInfo{
+		ProducerInfoSlice: rsProducerInfoSlice,
+		TotalVotes:        totalVotes.String(),
+		TotalCounts:       uint64(count),
+		Height:            height,
+	}
+
+	return ResponsePack(Success, result)
+}
+
+// getStateByHeight returns the DPoS state at the height given by the optional
+// height parameter, and the height of the state. The current state and zero
+// height are returned if height is not given. A historical state is only
+// available at heights checkpoints are saved, an error with the nearest
+// saved height is returned for other heights.
+func getStateByHeight(param Params) (*state.State, uint32, error) {
+	height, ok := param.Uint("height")
+	if !ok {
+		return Chain.GetState(), 0, nil
+	}
+	if err := checkHistoryHeight(height); err != nil {
+		return nil, 0, err
+	}
+	if height == Chain.GetHeight() {
+		return Chain.GetState(), height, nil
+	}
+	st, err := Chain.GetState().GetHistoryState(height)
+	return st, height, err
+}
+
+// getCommitteeByHeight returns the CR committee at the height given by the
+// optional height parameter, and the height of the committee. The current
+// committee and zero height are returned if height is not given. A historical
+// committee is only available at heights checkpoints are saved, an error with
+// the nearest saved height is returned for other heights.
+func getCommitteeByHeight(param Params,
+	withProposals bool) (*crstate.Committee, uint32, error) {
+	height, ok := param.Uint("height")
+	if !ok {
+		return Chain.GetCRCommittee(), 0, nil
+	}
+	if err := checkHistoryHeight(height); err != nil {
+		return nil, 0, err
+	}
+	if height == Chain.GetHeight() {
+		return Chain.GetCRCommittee(), height, nil
+	}
+	cm, err := Chain.GetCRCommittee().GetHistoryCommittee(height,
+		withProposals)
+	return cm, height, err
+}
+
+// checkHistoryHeight checks the height of historical states is not higher
+// than the best height.
+func checkHistoryHeight(height uint32) error {
+	if bestHeight := Chain.GetHeight(); height > bestHeight {
+		return fmt.Errorf("height %d is higher than the best height %d",
+			height, bestHeight)
+	}
+	return nil
+}
+
+// getProducerInfos returns producers of the given state sorted by votes, and
+// the total votes of them.
+func getProducerInfos(st *state.State, s string) ([]RpcProducerInfo,
+	common.Fixed64) {
 	var producers []*state.Producer
 	switch s {
 	case "all":
-		producers = Chain.GetState().GetAllProducers()
+		producers = st.GetAllProducers()
 	case "pending":
-		producers = Chain.GetState().GetPendingProducers()
+		producers = st.GetPendingProducers()
 	case "active":
-		producers = Chain.GetState().GetActiveProducers()
+		producers = st.GetActiveProducers()
 	case "inactive":
-		producers = Chain.GetState().GetInactiveProducers()
+		producers = st.GetInactiveProducers()
 	case "canceled":
-		producers = Chain.GetState().GetCanceledProducers()
+		producers = st.GetCanceledProducers()
 	case "illegal":
-		producers = Chain.GetState().GetIllegalProducers()
+		producers = st.GetIllegalProducers()
 	case "returned":
-		producers = Chain.GetState().GetReturnedDepositProducers()
+		producers = st.GetReturnedDepositProducers()
 	default:
-		producers = Chain.GetState().GetProducers()
+		producers = st.GetProducers()
 	}
 
 	sort.Slice(producers, func(i, j int) bool {
@@ -1611,46 +2082,27 @@ func ListProducers(param Params) map[string]interface{} {
 	var totalVotes common.Fixed64
 	for i, p := range producers {
 		totalVotes += p.Votes()
-		producerInfo := RpcProducerInfo{
-			OwnerPublicKey: hex.EncodeToString(p.Info().OwnerPublicKey),
-			NodePublicKey:  hex.EncodeToString(p.Info().NodePublicKey),
-			Nickname:       p.Info().NickName,
-			Url:            p.Info().Url,
-			Location:       p.Info().Location,
-			Active:         p.State() == state.Active,
-			Votes:          p.Votes().String(),
-			State:          p.State().String(),
-			RegisterHeight: p.RegisterHeight(),
-			CancelHeight:   p.CancelHeight(),
-			InactiveHeight: p.InactiveSince(),
-			IllegalHeight:  p.IllegalHeight(),
-			Index:          uint64(i),
-		}
-		producerInfoSlice = append(producerInfoSlice, producerInfo)
-	}
-
-	count := int64(len(producers))
-	if limit < 0 {
-		limit = count
-	}
-	var rsProducerInfoSlice []RpcProducerInfo
-	if start < count {
-		end := start
-		if start+limit <= count {
-			end = start + limit
-		} else {
-			end = count
-		}
-		rsProducerInfoSlice = append(rsProducerInfoSlice, producerInfoSlice[start:end]...)
+		producerInfoSlice = append(producerInfoSlice, getProducerInfo(p, i))
 	}
+	return producerInfoSlice, totalVotes
+}
 
-	result := &RpcProducersInfo{
-		ProducerInfoSlice: rsProducerInfoSlice,
-		TotalVotes:        totalVotes.String(),
-		TotalCounts:       uint64(count),
+func getProducerInfo(p *state.Producer, index int) RpcProducerInfo {
+	return RpcProducerInfo{
+		OwnerPublicKey: hex.EncodeToString(p.Info().OwnerPublicKey),
+		NodePublicKey:  hex.EncodeToString(p.Info().NodePublicKey),
+		Nickname:       p.Info().NickName,
+		Url:            p.Info().Url,
+		Location:       p.Info().Location,
+		Active:         p.State() == state.Active,
+		Votes:          p.Votes().String(),
+		State:          p.State().String(),
+		RegisterHeight: p.RegisterHeight(),
+		CancelHeight:   p.CancelHeight(),
+		InactiveHeight: p.InactiveSince(),
+		IllegalHeight:  p.IllegalHeight(),
+		Index:          uint64(index),
 	}
-
-	return ResponsePack(Success, result)
 }
 
 func GetSecretaryGeneral(param Params) map[string]interface{} {
@@ -1671,6 +2123,35 @@ func ListCRCandidates(param Params) map[string]interface{} {
 	if ok {
 		s = strings.ToLower(s)
 	}
+	candidateInfoSlice, totalVotes := getCRCandidateInfos(s)
+
+	count := int64(len(candidateInfoSlice))
+	if limit < 0 {
+		limit = count
+	}
+	var rSCandidateInfoSlice []RpcCrCandidateInfo
+	if start < count {
+		end := start
+		if start+limit <= count {
+			end = start + limit
+		} else {
+			end = count
+		}
+		rSCandidateInfoSlice = append(rSCandidateInfoSlice, candidateInfoSlice[start:end]...)
+	}
+
+	result := &RpcCrCandidatesInfo{
+		CRCandidateInfoSlice: rSCandidateInfoSlice,
+		TotalVotes:           totalVotes.String(),
+		TotalCounts:          uint64(count),
+	}
+
+	return ResponsePack(Success, result)
+}
+
+// getCRCandidateInfos returns CR candidates of the given state sorted by
+// votes, and the total votes of them.
+func getCRCandidateInfos(s string) ([]RpcCrCandidateInfo, common.Fixed64) {
 	var candidates []*crstate.Candidate
 	crCommittee := Chain.GetCRCommittee()
 	switch s {
@@ -1701,54 +2182,54 @@ func ListCRCandidates(param Params) map[string]interface{} {
 	var totalVotes common.Fixed64
 	for i, c := range candidates {
 		totalVotes += c.Votes()
-		cidAddress, _ := c.Info().CID.ToAddress()
-		var didAddress string
-		if !c.Info().DID.IsEqual(emptyHash) {
-			didAddress, _ = c.Info().DID.ToAddress()
-		}
-		candidateInfo := RpcCrCandidateInfo{
-			Code:           hex.EncodeToString(c.Info().Code),
-			CID:            cidAddress,
-			DID:            didAddress,
-			NickName:       c.Info().NickName,
-			Url:            c.Info().Url,
-			Location:       c.Info().Location,
-			State:          c.State().String(),
-			Votes:          c.Votes().String(),
-			RegisterHeight: c.RegisterHeight(),
-			CancelHeight:   c.CancelHeight(),
-			Index:          uint64(i),
-		}
-		candidateInfoSlice = append(candidateInfoSlice, candidateInfo)
-	}
-
-	count := int64(len(candidates))
-	if limit < 0 {
-		limit = count
+		candidateInfoSlice = append(candidateInfoSlice, getCRCandidateInfo(c, i))
 	}
-	var rSCandidateInfoSlice []RpcCrCandidateInfo
-	if start < count {
-		end := start
-		if start+limit <= count {
-			end = start + limit
-		} else {
-			end = count
-		}
-		rSCandidateInfoSlice = append(rSCandidateInfoSlice, candidateInfoSlice[start:end]...)
+	return candidateInfoSlice, totalVotes
+}
+
+func getCRCandidateInfo(c *crstate.Candidate, index int) RpcCrCandidateInfo {
+	cidAddress, _ := c.Info().CID.ToAddress()
+	var didAddress string
+	if !c.Info().DID.IsEqual(emptyHash) {
+		didAddress, _ = c.Info().DID.ToAddress()
+	}
+	return RpcCrCandidateInfo{
+		Code:           hex.EncodeToString(c.Info().Code),
+		CID:            cidAddress,
+		DID:            didAddress,
+		NickName:       c.Info().NickName,
+		Url:            c.Info().Url,
+		Location:       c.Info().Location,
+		State:          c.State().String(),
+		Votes:          c.Votes().String(),
+		RegisterHeight: c.RegisterHeight(),
+		CancelHeight:   c.CancelHeight(),
+		Index:          uint64(index),
 	}
+}
 
-	result := &RpcCrCandidatesInfo{
-		CRCandidateInfoSlice: rSCandidateInfoSlice,
-		TotalVotes:           totalVotes.String(),
-		TotalCounts:          uint64(count),
+//list current crs according to (state)
+func ListCurrentCRs(param Params) map[string]interface{} {
+	cm, height, err := getCommitteeByHeight(param, false)
+	if err != nil {
+		return ResponsePack(InvalidParams, err.Error())
+	}
+	rsCRMemberInfoSlice := getCRMemberInfos(cm)
+
+	count := int64(len(rsCRMemberInfoSlice))
+
+	result := &RpcCrMembersInfo{
+		CRMemberInfoSlice: rsCRMemberInfoSlice,
+		TotalCounts:       uint64(count),
+		Height:            height,
 	}
 
 	return ResponsePack(Success, result)
 }
 
-//list current crs according to (state)
-func ListCurrentCRs(param Params) map[string]interface{} {
-	cm := Chain.GetCRCommittee()
+// getCRMemberInfos returns members of the given CR committee sorted by code
+// hash, or nil if not in election period.
+func getCRMemberInfos(cm *crstate.Committee) []RpcCrMemberInfo {
 	var crMembers []*crstate.CRMember
 	if cm.IsInElectionPeriod() {
 		crMembers = cm.GetAllMembers()
@@ -1782,15 +2263,7 @@ func ListCurrentCRs(param Params) map[string]interface{} {
 		}
 		rsCRMemberInfoSlice = append(rsCRMemberInfoSlice, memberInfo)
 	}
-
-	count := int64(len(crMembers))
-
-	result := &RpcCrMembersInfo{
-		CRMemberInfoSlice: rsCRMemberInfoSlice,
-		TotalCounts:       uint64(count),
-	}
-
-	return ResponsePack(Success, result)
+	return rsCRMemberInfoSlice
 }
 
 func ListCRProposalBaseState(param Params) map[string]interface{} {
@@ -1803,6 +2276,37 @@ func ListCRProposalBaseState(param Params) map[string]interface{} {
 	if ok {
 		s = strings.ToLower(s)
 	}
+	RpcProposalBaseStates, err := getProposalBaseStates(s)
+	if err != nil {
+		return ResponsePack(InvalidParams, err.Error())
+	}
+
+	count := int64(len(RpcProposalBaseStates))
+	if limit < 0 {
+		limit = count
+	}
+	var rSRpcProposalBaseStates []RpcProposalBaseState
+	if start < count {
+		end := start
+		if start+limit <= count {
+			end = start + limit
+		} else {
+			end = count
+		}
+		rSRpcProposalBaseStates = append(rSRpcProposalBaseStates, RpcProposalBaseStates[start:end]...)
+	}
+
+	result := &RpcCRProposalBaseStateInfo{
+		ProposalBaseStates: rSRpcProposalBaseStates,
+		TotalCounts:        uint64(count),
+	}
+
+	return ResponsePack(Success, result)
+}
+
+// getProposalBaseStates returns proposals of the given state sorted by
+// proposal hash.
+func getProposalBaseStates(s string) ([]RpcProposalBaseState, error) {
 	var proposalMap crstate.ProposalsMap
 	crCommittee := Chain.GetCRCommittee()
 	switch s {
@@ -1825,7 +2329,7 @@ func ListCRProposalBaseState(param Params) map[string]interface{} {
 	case "terminated":
 		proposalMap = crCommittee.GetProposals(crstate.Terminated)
 	default:
-		return ResponsePack(InvalidParams, "invalidate state")
+		return nil, errors.New("invalidate state")
 	}
 
 	var crVotes map[string]string
@@ -1861,33 +2365,15 @@ func ListCRProposalBaseState(param Params) map[string]interface{} {
 	for k := range RpcProposalBaseStates {
 		RpcProposalBaseStates[k].Index = uint64(k)
 	}
-
-	count := int64(len(RpcProposalBaseStates))
-	if limit < 0 {
-		limit = count
-	}
-	var rSRpcProposalBaseStates []RpcProposalBaseState
-	if start < count {
-		end := start
-		if start+limit <= count {
-			end = start + limit
-		} else {
-			end = count
-		}
-		rSRpcProposalBaseStates = append(rSRpcProposalBaseStates, RpcProposalBaseStates[start:end]...)
-	}
-
-	result := &RpcCRProposalBaseStateInfo{
-		ProposalBaseStates: rSRpcProposalBaseStates,
-		TotalCounts:        uint64(count),
-	}
-
-	return ResponsePack(Success, result)
+	return RpcProposalBaseStates, nil
 }
 
 func GetCRProposalState(param Params) map[string]interface{} {
 	var proposalState *crstate.ProposalState
-	crCommittee := Chain.GetCRCommittee()
+	crCommittee, height, err := getCommitteeByHeight(param, true)
+	if err != nil {
+		return ResponsePack(InvalidParams, err.Error())
+	}
 	ProposalHashHexStr, ok := param.String("proposalhash")
 	if ok {
 		proposalHashBytes, err := FromReversedString(ProposalHashHexStr)
@@ -1941,7 +2427,6 @@ func GetCRProposalState(param Params) map[string]interface{} {
 		})
 	}
 
-	var err error
 	rpcProposal.Recipient, err = proposalState.Proposal.Recipient.ToAddress()
 	if err != nil {
 		return ResponsePack(InternalError, "invalidate Recipient")
@@ -1964,7 +2449,10 @@ func GetCRProposalState(param Params) map[string]interface{} {
 		ProposalOwner:      hex.EncodeToString(proposalState.ProposalOwner),
 		AvailableAmount:    crCommittee.AvailableWithdrawalAmount(proposalHash).String(),
 	}
-	result := &RpcCRProposalStateInfo{ProposalState: RpcProposalState}
+	result := &RpcCRProposalStateInfo{
+		ProposalState: RpcProposalState,
+		Height:        height,
+	}
 	return ResponsePack(Success, result)
 }
 
@@ -1987,6 +2475,12 @@ func ProducerStatus(param Params) map[string]interface{} {
 	return ResponsePack(Success, producer.State().String())
 }
 
+type RpcVoteStatus struct {
+	Total   string `json:"total"`
+	Voting  string `json:"voting"`
+	Pending bool   `json:"pending"`
+}
+
 func VoteStatus(param Params) map[string]interface{} {
 	address, ok := param.String("address")
 	if !ok {
@@ -2035,12 +2529,7 @@ func VoteStatus(param Params) map[string]interface{} {
 		}
 	}
 
-	type voteInfo struct {
-		Total   string `json:"total"`
-		Voting  string `json:"voting"`
-		Pending bool   `json:"pending"`
-	}
-	return ResponsePack(Success, &voteInfo{
+	return ResponsePack(Success, &RpcVoteStatus{
 		Total:   total.String(),
 		Voting:  voting.String(),
 		Pending: pending,
@@ -2123,33 +2612,47 @@ func GetCRDepositCoin(param Params) map[string]interface{} {
 	})
 }
 
+type RpcFeeEstimate struct {
+	FeeRate    int64   `json:"feerate"`
+	Blocks     uint32  `json:"blocks"`
+	Confidence float64 `json:"confidence"`
+}
+
 func EstimateSmartFee(param Params) map[string]interface{} {
 	if rtn := checkRPCServiceLevel(config.TransactionPermitted); rtn != nil {
 		return rtn
 	}
 
-	confirm, ok := param.Int("confirmations")
-	if !ok {
+	confirm, ok := param.Uint("confirmations")
+	if !ok || confirm == 0 {
 		return ResponsePack(InvalidParams, "need a param called confirmations")
 	}
-	if confirm > 25 {
-		return ResponsePack(InvalidParams, "support only 25 confirmations at most")
+	if confirm > mempool.EstimateFeeMaxConfirms {
+		return ResponsePack(InvalidParams, fmt.Sprintf("support only %d "+
+			"confirmations at most", mempool.EstimateFeeMaxConfirms))
 	}
-	var FeeRate = 10000 //basic fee rate 10000 sela per KB
-	var count = 0
-
-	// TODO just return fixed transaction fee for now, we didn't have that much
-	// transactions in a block yet.
 
-	return ResponsePack(Success, GetFeeRate(count, int(confirm))*FeeRate)
-}
+	// Fall back to the basic fee rate if we have not seen enough transactions
+	// confirmed yet.
+	estimate, err := TxMemPool.EstimateFee(confirm)
+	if err != nil {
+		estimate = &mempool.FeeEstimate{
+			FeeRate:    DefaultFeeRate,
+			Blocks:     confirm,
+			Confidence: 0,
+		}
+	}
 
-func GetFeeRate(count int, confirm int) int {
-	gap := count - confirm
-	if gap < 0 {
-		gap = -1
+	verbose, _ := param.Bool("verbose")
+	if !verbose {
+		return ResponsePack(Success, int64(estimate.FeeRate))
 	}
-	return gap + 2
+
+	return ResponsePack(Success, &RpcFeeEstimate{
+		FeeRate:    int64(estimate.FeeRate),
+		Blocks:     estimate.Blocks,
+		Confidence: estimate.Confidence,
+	})
 }
 
 func DecodeRawTransaction(param Params) map[string]interface{} {
@@ -2400,7 +2903,13 @@ func ResponsePack(errCode ServerErrCode, result interface{}) map[string]interfac
 	return map[string]interface{}{"Result": result, "Error": errCode}
 }
 
+// checkRPCServiceLevel checks the method of the level is permitted by the
+// RPCServiceLevel. It's skipped when the access control is configured, every
+// request is then authorized by the methods allowed for its credential.
 func checkRPCServiceLevel(level config.RPCServiceLevel) map[string]interface{} {
+	if ACL != nil {
+		return nil
+	}
 	if level < config.RPCServiceLevelFromString(ChainParams.RPCServiceLevel) {
 		return ResponsePack(InvalidMethod,
 			"requesting method if out of service level")
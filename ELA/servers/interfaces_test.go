@@ -0,0 +1,214 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package servers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elastos/Elastos.ELA/blockchain"
+	"github.com/elastos/Elastos.ELA/blockchain/indexers"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/elanet"
+	. "github.com/elastos/Elastos.ELA/servers/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// banServer records the hosts banned through it.
+type banServer struct {
+	elanet.Server
+	bans map[string]time.Time
+}
+
+func (s *banServer) BanHost(host string, banEnd time.Time) error {
+	s.bans[host] = banEnd
+	return nil
+}
+
+func TestSetBan(t *testing.T) {
+	defer func(params *config.Params, acl *AccessControl, server elanet.Server) {
+		ChainParams, ACL, Server = params, acl, server
+	}(ChainParams, ACL, Server)
+	ChainParams = &config.Params{
+		RPCServiceLevel: config.ConfigurationPermitted.String()}
+	ACL = nil
+	server := &banServer{bans: make(map[string]time.Time)}
+	Server = server
+
+	now := time.Now()
+	resp := SetBan(Params{"host": "1.2.3.4", "command": "add",
+		"bantime": float64(3600)})
+	assert.Equal(t, Success, resp["Error"])
+	assert.WithinDuration(t, now.Add(time.Hour), server.bans["1.2.3.4"],
+		time.Minute)
+
+	resp = SetBan(Params{"host": "1.2.3.5", "command": "add",
+		"bantime": float64(MaxBanTime)})
+	assert.Equal(t, Success, resp["Error"])
+	assert.True(t, server.bans["1.2.3.5"].After(now))
+
+	resp = SetBan(Params{"host": "1.2.3.6", "command": "add",
+		"bantime": float64(now.Unix() + 60), "absolute": true})
+	assert.Equal(t, Success, resp["Error"])
+	assert.Equal(t, now.Unix()+60, server.bans["1.2.3.6"].Unix())
+
+	// the ban time overflowing time.Duration is rejected
+	for _, params := range []Params{
+		{"host": "1.2.3.7", "command": "add", "bantime": "9223372036854775807"},
+		{"host": "1.2.3.7", "command": "add", "bantime": float64(MaxBanTime + 1)},
+		{"host": "1.2.3.7", "command": "add", "bantime": "9223372036854775807",
+			"absolute": true},
+		{"host": "1.2.3.7", "command": "add", "bantime": float64(-1)},
+	} {
+		resp = SetBan(params)
+		assert.Equal(t, InvalidParams, resp["Error"], params)
+	}
+	assert.NotContains(t, server.bans, "1.2.3.7")
+}
+
+// utxoSetStore answers the utxo set info of the best block and counts the
+// walks of the utxo index.
+type utxoSetStore struct {
+	blockchain.IFFLDBChainStore
+	bestBlock common.Uint256
+	walks     int
+}
+
+// ffldbChainStore returns the given store as the FFLDB store.
+type ffldbChainStore struct {
+	blockchain.IChainStore
+	ffldb blockchain.IFFLDBChainStore
+}
+
+func (s *ffldbChainStore) GetFFLDB() blockchain.IFFLDBChainStore {
+	return s.ffldb
+}
+
+func (s *utxoSetStore) GetUTXOSetInfo() (*indexers.UTXOSetInfo, error) {
+	s.walks++
+	return &indexers.UTXOSetInfo{BestBlock: s.bestBlock, UTXOs: 1}, nil
+}
+
+func TestGetUTXOSetInfo(t *testing.T) {
+	defer func(store blockchain.IChainStore) { Store = store }(Store)
+	defer func() { utxoSetInfoCache.info = nil }()
+	store := &utxoSetStore{bestBlock: common.Uint256{1}}
+	Store = &ffldbChainStore{ffldb: store}
+
+	// the utxo index is walked once a block
+	for i := 0; i < 2; i++ {
+		info, err := getUTXOSetInfo(common.Uint256{1})
+		assert.NoError(t, err)
+		assert.Equal(t, common.Uint256{1}, info.BestBlock)
+		assert.Equal(t, 1, store.walks)
+	}
+	store.bestBlock = common.Uint256{2}
+	info, err := getUTXOSetInfo(common.Uint256{2})
+	assert.NoError(t, err)
+	assert.Equal(t, common.Uint256{2}, info.BestBlock)
+	assert.Equal(t, 2, store.walks)
+}
+
+func TestGetTxOutSetInfo(t *testing.T) {
+	defer func(params *config.Params, acl *AccessControl) {
+		ChainParams, ACL = params, acl
+	}(ChainParams, ACL)
+	ACL = nil
+
+	ChainParams = &config.Params{RPCServiceLevel: config.QueryOnly.String()}
+	resp := GetTxOutSetInfo(Params{})
+	assert.Equal(t, InvalidMethod, resp["Error"])
+
+	// assets other than ELA are not indexed
+	ChainParams.RPCServiceLevel = config.WalletPermitted.String()
+	resp = GetTxOutSetInfo(Params{"assetid": ToReversedString(common.Uint256{1})})
+	assert.Equal(t, InvalidParams, resp["Error"])
+	resp = GetTxOutSetInfo(Params{"assetid": "invalid"})
+	assert.Equal(t, InvalidParams, resp["Error"])
+}
+
+// addressTxStore records the range of address transactions requested.
+type addressTxStore struct {
+	blockchain.IFFLDBChainStore
+	skip, limit uint32
+}
+
+func (s *addressTxStore) GetAddressTransactions(programHash *common.Uint168,
+	skip, limit uint32) ([]*indexers.AddressTx, uint32, error) {
+	s.skip, s.limit = skip, limit
+	return []*indexers.AddressTx{{TxID: common.Uint256{1}, Height: 10,
+		Delta: -1}}, 1, nil
+}
+
+func TestGetAddressTransactions(t *testing.T) {
+	defer func(params *config.Params, acl *AccessControl,
+		store blockchain.IChainStore) {
+		ChainParams, ACL, Store = params, acl, store
+	}(ChainParams, ACL, Store)
+	ACL = nil
+	store := &addressTxStore{}
+	Store = &ffldbChainStore{ffldb: store}
+	address := "EgHPRhodCsDKuDBPApCK3KLayiBomrJrbH"
+
+	ChainParams = &config.Params{RPCServiceLevel: config.QueryOnly.String()}
+	resp := GetAddressTransactions(Params{"address": address})
+	assert.Equal(t, InvalidMethod, resp["Error"])
+
+	ChainParams.RPCServiceLevel = config.WalletPermitted.String()
+	for _, test := range []struct {
+		params      Params
+		skip, limit uint32
+	}{
+		{Params{}, 0, DefaultAddressTransactionsLimit},
+		{Params{"start": float64(20), "limit": float64(10)}, 20, 10},
+		{Params{"start": "5", "limit": "1000"}, 5, 1000},
+	} {
+		test.params["address"] = address
+		resp = GetAddressTransactions(test.params)
+		if !assert.Equal(t, Success, resp["Error"], test.params) {
+			continue
+		}
+		assert.Equal(t, test.skip, store.skip)
+		assert.Equal(t, test.limit, store.limit)
+		result := resp["Result"].(AddressTransactionsInfo)
+		assert.Equal(t, uint32(1), result.TotalCount)
+		assert.Equal(t, "-0.00000001", result.Transactions[0].Delta)
+	}
+
+	for _, params := range []Params{
+		{},
+		{"address": "invalid"},
+		{"address": address, "start": float64(-1)},
+		{"address": address, "start": "abc"},
+		{"address": address, "limit": float64(0)},
+		{"address": address, "limit": float64(1001)},
+		{"address": address, "limit": "abc"},
+		{"address": address, "limit": nil},
+	} {
+		resp = GetAddressTransactions(params)
+		assert.Equal(t, InvalidParams, resp["Error"], params)
+	}
+}
+
+func TestGetSpendingInfo(t *testing.T) {
+	defer func(params *config.Params, acl *AccessControl) {
+		ChainParams, ACL = params, acl
+	}(ChainParams, ACL)
+	ACL = nil
+	params := Params{"txid": ToReversedString(common.Uint256{1}), "vout": float64(0)}
+
+	ChainParams = &config.Params{RPCServiceLevel: config.QueryOnly.String()}
+	resp := GetSpendingInfo(params)
+	assert.Equal(t, InvalidMethod, resp["Error"])
+
+	ChainParams.RPCServiceLevel = config.WalletPermitted.String()
+	resp = GetSpendingInfo(Params{"txid": "invalid", "vout": float64(0)})
+	assert.Equal(t, InvalidParams, resp["Error"])
+	resp = GetSpendingInfo(Params{"txid": params["txid"]})
+	assert.Equal(t, InvalidParams, resp["Error"])
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package servers
+
+import (
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastos/Elastos.ELA/common"
+	. "github.com/elastos/Elastos.ELA/servers/errors"
+)
+
+const (
+	// DefaultPageLimit is the count of items returned in a page when limit
+	// is not given.
+	DefaultPageLimit = 50
+
+	// MaxPageLimit is the max count of items returned in a page.
+	MaxPageLimit = 500
+)
+
+// Page is a page of a list, NextCursor is empty if it is the last page.
+type Page struct {
+	Items      interface{} `json:"items"`
+	NextCursor string      `json:"nextcursor,omitempty"`
+	TotalCount uint64      `json:"totalcount"`
+}
+
+// pageCursor locates an item in a list sorted by votes in descending order
+// and then by id in ascending order. A cursor points to the last item of the
+// previous page, so the next page still starts at the right place when items
+// are added or removed between two requests.
+type pageCursor struct {
+	votes common.Fixed64
+	id    string
+}
+
+func (c pageCursor) less(o pageCursor) bool {
+	if c.votes != o.votes {
+		return c.votes > o.votes
+	}
+	return c.id < o.id
+}
+
+func (c pageCursor) String() string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(strconv.FormatInt(int64(c.votes), 10) + ":" + c.id))
+}
+
+func parsePageCursor(s string) (*pageCursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	parts := strings.SplitN(string(buf), ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid cursor")
+	}
+	votes, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &pageCursor{votes: common.Fixed64(votes), id: parts[1]}, nil
+}
+
+// pageParams returns the cursor and limit given in parameters, cursor is nil
+// for the first page.
+func pageParams(param Params) (*pageCursor, int, error) {
+	var cursor *pageCursor
+	if s, ok := param.String("cursor"); ok && s != "" {
+		var err error
+		if cursor, err = parsePageCursor(s); err != nil {
+			return nil, 0, err
+		}
+	}
+	limit := DefaultPageLimit
+	if _, ok := param["limit"]; ok {
+		l, ok := param.Int("limit")
+		if !ok || l <= 0 || l > MaxPageLimit {
+			return nil, 0, errors.New("limit should be in range 1-" +
+				strconv.Itoa(MaxPageLimit))
+		}
+		limit = int(l)
+	}
+	return cursor, limit, nil
+}
+
+// paginate sorts a list of count items by the cursor of each item, and
+// returns the range of the page after the given cursor along with the cursor
+// of the next page.
+func paginate(count int, swap func(i, j int), key func(i int) pageCursor,
+	cursor *pageCursor, limit int) (start, end int, next string) {
+	sort.Sort(&cursorSorter{count: count, swap: swap, key: key})
+
+	if cursor != nil {
+		start = sort.Search(count, func(i int) bool {
+			return cursor.less(key(i))
+		})
+	}
+	end = start + limit
+	if end >= count {
+		end = count
+	} else {
+		next = key(end - 1).String()
+	}
+	return start, end, next
+}
+
+type cursorSorter struct {
+	count int
+	swap  func(i, j int)
+	key   func(i int) pageCursor
+}
+
+func (s *cursorSorter) Len() int           { return s.count }
+func (s *cursorSorter) Swap(i, j int)      { s.swap(i, j) }
+func (s *cursorSorter) Less(i, j int) bool { return s.key(i).less(s.key(j)) }
+
+func votesOf(votes string) common.Fixed64 {
+	v, err := common.StringToFixed64(votes)
+	if err != nil {
+		return 0
+	}
+	return *v
+}
+
+// ListProducersPage returns a page of producers of the given state, sorted by
+// votes and then by node public key.
+func ListProducersPage(param Params) map[string]interface{} {
+	cursor, limit, err := pageParams(param)
+	if err != nil {
+		return ResponsePack(InvalidParams, err.Error())
+	}
+	s, _ := param.String("state")
+	producers, _ := getProducerInfos(Chain.GetState(), strings.ToLower(s))
+
+	start, end, next := paginate(len(producers), func(i, j int) {
+		producers[i], producers[j] = producers[j], producers[i]
+	}, func(i int) pageCursor {
+		return pageCursor{
+			votes: votesOf(producers[i].Votes),
+			id:    producers[i].NodePublicKey,
+		}
+	}, cursor, limit)
+	for i := range producers {
+		producers[i].Index = uint64(i)
+	}
+
+	return ResponsePack(Success, &Page{
+		Items:      append(make([]RpcProducerInfo, 0), producers[start:end]...),
+		NextCursor: next,
+		TotalCount: uint64(len(producers)),
+	})
+}
+
+// GetProducerInfo returns the producer of the given owner or node public key.
+func GetProducerInfo(param Params) map[string]interface{} {
+	publicKey, ok := param.String("publickey")
+	if !ok {
+		return ResponsePack(InvalidParams, "public key not found")
+	}
+	publicKeyBytes, err := common.HexStringToBytes(publicKey)
+	if err != nil {
+		return ResponsePack(InvalidParams, "invalid public key")
+	}
+	producer := Chain.GetState().GetProducer(publicKeyBytes)
+	if producer == nil {
+		return ResponsePack(InvalidParams, "unknown producer public key")
+	}
+	return ResponsePack(Success, getProducerInfo(producer, 0))
+}
+
+// ListCRCandidatesPage returns a page of CR candidates of the given state,
+// sorted by votes and then by CID.
+func ListCRCandidatesPage(param Params) map[string]interface{} {
+	cursor, limit, err := pageParams(param)
+	if err != nil {
+		return ResponsePack(InvalidParams, err.Error())
+	}
+	s, _ := param.String("state")
+	candidates, _ := getCRCandidateInfos(strings.ToLower(s))
+
+	start, end, next := paginate(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	}, func(i int) pageCursor {
+		return pageCursor{
+			votes: votesOf(candidates[i].Votes),
+			id:    candidates[i].CID,
+		}
+	}, cursor, limit)
+	for i := range candidates {
+		candidates[i].Index = uint64(i)
+	}
+
+	return ResponsePack(Success, &Page{
+		Items:      append(make([]RpcCrCandidateInfo, 0), candidates[start:end]...),
+		NextCursor: next,
+		TotalCount: uint64(len(candidates)),
+	})
+}
+
+// GetCRCandidateInfo returns the CR candidate of the given CID.
+func GetCRCandidateInfo(param Params) map[string]interface{} {
+	cid, ok := param.String("cid")
+	if !ok {
+		return ResponsePack(InvalidParams, "cid not found")
+	}
+	programHash, err := common.Uint168FromAddress(cid)
+	if err != nil {
+		return ResponsePack(InvalidParams, "invalid cid")
+	}
+	candidate := Chain.GetCRCommittee().GetCandidate(*programHash)
+	if candidate == nil {
+		return ResponsePack(InvalidParams, "unknown cid")
+	}
+	return ResponsePack(Success, getCRCandidateInfo(candidate, 0))
+}
+
+// ListCRMembersPage returns a page of current CR members sorted by CID.
+func ListCRMembersPage(param Params) map[string]interface{} {
+	cursor, limit, err := pageParams(param)
+	if err != nil {
+		return ResponsePack(InvalidParams, err.Error())
+	}
+	members := getCRMemberInfos(Chain.GetCRCommittee())
+
+	start, end, next := paginate(len(members), func(i, j int) {
+		members[i], members[j] = members[j], members[i]
+	}, func(i int) pageCursor {
+		return pageCursor{id: members[i].CID}
+	}, cursor, limit)
+	for i := range members {
+		members[i].Index = uint64(i)
+	}
+
+	return ResponsePack(Success, &Page{
+		Items:      append(make([]RpcCrMemberInfo, 0), members[start:end]...),
+		NextCursor: next,
+		TotalCount: uint64(len(members)),
+	})
+}
+
+// ListCRProposalsPage returns a page of CR proposals of the given state
+// sorted by proposal hash, proposals of all states are returned if state is
+// not given.
+func ListCRProposalsPage(param Params) map[string]interface{} {
+	cursor, limit, err := pageParams(param)
+	if err != nil {
+		return ResponsePack(InvalidParams, err.Error())
+	}
+	s, ok := param.String("state")
+	if !ok || s == "" {
+		s = "all"
+	}
+	proposals, err := getProposalBaseStates(strings.ToLower(s))
+	if err != nil {
+		return ResponsePack(InvalidParams, err.Error())
+	}
+
+	start, end, next := paginate(len(proposals), func(i, j int) {
+		proposals[i], proposals[j] = proposals[j], proposals[i]
+	}, func(i int) pageCursor {
+		return pageCursor{id: proposals[i].ProposalHash}
+	}, cursor, limit)
+
+	return ResponsePack(Success, &Page{
+		Items:      append(make([]RpcProposalBaseState, 0), proposals[start:end]...),
+		NextCursor: next,
+		TotalCount: uint64(len(proposals)),
+	})
+}
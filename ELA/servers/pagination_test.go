@@ -0,0 +1,115 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package servers
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageCursor(t *testing.T) {
+	cursor := pageCursor{votes: 12345, id: "a:b"}
+	parsed, err := parsePageCursor(cursor.String())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, *parsed)
+
+	cursor = pageCursor{votes: -1, id: ""}
+	parsed, err = parsePageCursor(cursor.String())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, *parsed)
+
+	for _, s := range []string{
+		"!!!",
+		base64.RawURLEncoding.EncodeToString([]byte("12345")),
+		base64.RawURLEncoding.EncodeToString([]byte("votes:id")),
+	} {
+		_, err := parsePageCursor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestPageParams(t *testing.T) {
+	cursor, limit, err := pageParams(Params{})
+	assert.NoError(t, err)
+	assert.Nil(t, cursor)
+	assert.Equal(t, DefaultPageLimit, limit)
+
+	// limit from JSON-RPC is a number and from REST is a string
+	_, limit, err = pageParams(Params{"limit": float64(10)})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, limit)
+	_, limit, err = pageParams(Params{"limit": "500"})
+	assert.NoError(t, err)
+	assert.Equal(t, MaxPageLimit, limit)
+
+	for _, l := range []interface{}{float64(0), float64(-1), "501", "ten", true} {
+		_, _, err = pageParams(Params{"limit": l})
+		assert.Error(t, err, l)
+	}
+
+	expected := pageCursor{votes: 100, id: "id"}
+	cursor, _, err = pageParams(Params{"cursor": expected.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, *cursor)
+
+	_, _, err = pageParams(Params{"cursor": "invalid cursor"})
+	assert.Error(t, err)
+}
+
+func TestPaginate(t *testing.T) {
+	type item struct {
+		id    string
+		votes common.Fixed64
+	}
+	items := []item{
+		{"c", 10}, {"a", 20}, {"e", 5}, {"b", 10}, {"d", 10},
+	}
+	swap := func(i, j int) { items[i], items[j] = items[j], items[i] }
+	key := func(i int) pageCursor {
+		return pageCursor{votes: items[i].votes, id: items[i].id}
+	}
+	ids := func(start, end int) []string {
+		var ids []string
+		for _, item := range items[start:end] {
+			ids = append(ids, item.id)
+		}
+		return ids
+	}
+
+	// sorted by votes in descending order and then by id
+	start, end, next := paginate(len(items), swap, key, nil, 2)
+	assert.Equal(t, []string{"a", "b"}, ids(start, end))
+	assert.NotEmpty(t, next)
+
+	cursor, err := parsePageCursor(next)
+	assert.NoError(t, err)
+	start, end, next = paginate(len(items), swap, key, cursor, 2)
+	assert.Equal(t, []string{"c", "d"}, ids(start, end))
+	assert.NotEmpty(t, next)
+
+	// the page continues from the cursor after an item is removed
+	items = append(items[:0], items[1:]...)
+	cursor, err = parsePageCursor(next)
+	assert.NoError(t, err)
+	start, end, next = paginate(len(items), swap, key, cursor, 2)
+	assert.Equal(t, []string{"e"}, ids(start, end))
+	assert.Empty(t, next)
+
+	// the page of exactly the rest items is the last page
+	start, end, next = paginate(len(items), swap, key, nil, len(items))
+	assert.Equal(t, []string{"b", "c", "d", "e"}, ids(start, end))
+	assert.Empty(t, next)
+
+	// cursor after the last item returns an empty page
+	cursor = &pageCursor{votes: 0, id: ""}
+	start, end, next = paginate(len(items), swap, key, cursor, 2)
+	assert.Equal(t, start, end)
+	assert.Empty(t, next)
+}
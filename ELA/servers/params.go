@@ -6,6 +6,7 @@
 package servers
 
 import (
+	"encoding/json"
 	"strconv"
 
 	"github.com/elastos/Elastos.ELA/common/log"
@@ -131,3 +132,24 @@ func (p Params) ArrayString(key string) ([]string, bool) {
 		return nil, false
 	}
 }
+
+// JSON returns the JSON text of a parameter, which can be given as a JSON
+// object or array, or a string of the JSON text.
+func (p Params) JSON(key string) (string, bool) {
+	value, ok := p[key]
+	if !ok || value == nil {
+		return "", false
+	}
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(data), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,748 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package servers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/elastos/Elastos.ELA/account"
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/config"
+	"github.com/elastos/Elastos.ELA/core/contract"
+	pg "github.com/elastos/Elastos.ELA/core/contract/program"
+	. "github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/outputpayload"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	crstate "github.com/elastos/Elastos.ELA/cr/state"
+	"github.com/elastos/Elastos.ELA/crypto"
+	. "github.com/elastos/Elastos.ELA/servers/errors"
+
+	"github.com/tidwall/gjson"
+)
+
+// standardProgramSize is the size of the program of a standard signature,
+// which is not in the unsigned transaction but counted in the fee.
+const standardProgramSize = 1 + crypto.PublicKeyScriptLength +
+	1 + crypto.SignatureScriptLength
+
+// defaultFeeConfirmations is the count of blocks within which the special
+// transaction is expected to be confirmed by the estimated fee rate.
+const defaultFeeConfirmations = 6
+
+// specialTxTypes is the transaction types that can be created by
+// createrawspecialtransaction, by the lower case names of them.
+var specialTxTypes = map[string]TxType{
+	"registerproducer":    RegisterProducer,
+	"updateproducer":      UpdateProducer,
+	"cancelproducer":      CancelProducer,
+	"activateproducer":    ActivateProducer,
+	"returndepositcoin":   ReturnDepositCoin,
+	"registercr":          RegisterCR,
+	"updatecr":            UpdateCR,
+	"unregistercr":        UnregisterCR,
+	"returncrdepositcoin": ReturnCRDepositCoin,
+	"crcproposal":         CRCProposal,
+	"crcproposalreview":   CRCProposalReview,
+	"crcproposaltracking": CRCProposalTracking,
+	"crcproposalwithdraw": CRCProposalWithdraw,
+}
+
+// RawSpecialTransactionInfo is the result of createrawspecialtransaction.
+type RawSpecialTransactionInfo struct {
+	Hex              string                `json:"hex"`
+	Fee              string                `json:"fee"`
+	Digest           string                `json:"digest,omitempty"`
+	PayloadSignature *PayloadSignatureInfo `json:"payloadsignature,omitempty"`
+}
+
+// PayloadSignatureInfo is a signature of the payload to be given, Digest is
+// the SHA-256 digest of Data signed by the signature.
+type PayloadSignatureInfo struct {
+	Field  string `json:"field"`
+	Data   string `json:"data"`
+	Digest string `json:"digest"`
+}
+
+// CreateRawSpecialTransaction creates an unsigned special transaction by the
+// payload information in the same format as returned by getrawtransaction.
+// Inputs are selected from the from address and the change is returned to
+// it, the fee is estimated if not given. The digest to sign is returned once
+// all signatures in the payload are given, otherwise the data to be signed
+// for the next signature of the payload is returned.
+func CreateRawSpecialTransaction(param Params) map[string]interface{} {
+	if rtn := checkRPCServiceLevel(config.WalletPermitted); rtn != nil {
+		return rtn
+	}
+
+	typeName, ok := param.String("type")
+	if !ok {
+		return ResponsePack(InvalidParams, "need a parameter named type")
+	}
+	txType, ok := specialTxTypes[strings.ToLower(typeName)]
+	if !ok {
+		return ResponsePack(InvalidParams, "unsupported transaction type "+
+			typeName)
+	}
+	payloadParam, ok := param.JSON("payload")
+	if !ok {
+		return ResponsePack(InvalidParams, "need a parameter named payload")
+	}
+	txPayload, payloadVersion, err := parsePayloadInfo(txType, payloadParam)
+	if err != nil {
+		return ResponsePack(InvalidParams, "invalid payload, "+err.Error())
+	}
+
+	var outputs []*Output
+	if outputsParam, ok := param.JSON("outputs"); ok {
+		if outputs, err = parseOutputs(outputsParam); err != nil {
+			return ResponsePack(InvalidParams, err.Error())
+		}
+	}
+	deposit, err := depositOutput(txType, txPayload, outputs, param)
+	if err != nil {
+		return ResponsePack(InvalidParams, err.Error())
+	}
+	if deposit != nil {
+		outputs = append(outputs, deposit)
+	}
+
+	locktime, _ := param.Uint("locktime")
+	txn := &Transaction{
+		Version:        TxVersion09,
+		TxType:         txType,
+		PayloadVersion: payloadVersion,
+		Payload:        txPayload,
+		Attributes:     []*Attribute{},
+		Inputs:         []*Input{},
+		Outputs:        outputs,
+		Programs:       []*pg.Program{},
+		LockTime:       locktime,
+	}
+
+	var fee common.Fixed64
+	// Activate producer transaction costs no fee and has no input or output.
+	if txType != ActivateProducer {
+		if fee, err = fundTransaction(txn, param); err != nil {
+			return ResponsePack(InvalidParams, err.Error())
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := txn.Serialize(buf); err != nil {
+		return ResponsePack(InternalError, "txn serialize failed")
+	}
+	result := &RawSpecialTransactionInfo{
+		Hex: common.BytesToHexString(buf.Bytes()),
+		Fee: fee.String(),
+	}
+
+	field, data, err := pendingPayloadSignature(txPayload, payloadVersion)
+	if err != nil {
+		return ResponsePack(InvalidParams, err.Error())
+	}
+	if field != "" {
+		digest := sha256.Sum256(data)
+		result.PayloadSignature = &PayloadSignatureInfo{
+			Field:  field,
+			Data:   common.BytesToHexString(data),
+			Digest: common.BytesToHexString(digest[:]),
+		}
+		return ResponsePack(Success, result)
+	}
+
+	buf = new(bytes.Buffer)
+	if err := txn.SerializeUnsigned(buf); err != nil {
+		return ResponsePack(InternalError, "txn serialize failed")
+	}
+	digest := sha256.Sum256(buf.Bytes())
+	result.Digest = common.BytesToHexString(digest[:])
+	return ResponsePack(Success, result)
+}
+
+// parsePayloadInfo parses the payload of the transaction type from the JSON
+// of the payload information, and returns the payload version of it.
+func parsePayloadInfo(txType TxType, data string) (Payload, byte, error) {
+	switch txType {
+	case RegisterProducer, UpdateProducer:
+		var info ProducerInfo
+		if err := json.Unmarshal([]byte(data), &info); err != nil {
+			return nil, 0, err
+		}
+		p := &payload.ProducerInfo{
+			NickName:   info.NickName,
+			Url:        info.Url,
+			Location:   info.Location,
+			NetAddress: info.NetAddress,
+		}
+		var err error
+		if p.OwnerPublicKey, err = parsePublicKey(info.OwnerPublicKey); err != nil {
+			return nil, 0, errors.New("invalid ownerpublickey")
+		}
+		if p.NodePublicKey, err = parsePublicKey(info.NodePublicKey); err != nil {
+			return nil, 0, errors.New("invalid nodepublickey")
+		}
+		if p.Signature, err = common.HexStringToBytes(info.Signature); err != nil {
+			return nil, 0, errors.New("invalid signature")
+		}
+		return p, payload.ProducerInfoVersion, nil
+
+	case CancelProducer:
+		var info CancelProducerInfo
+		if err := json.Unmarshal([]byte(data), &info); err != nil {
+			return nil, 0, err
+		}
+		p := &payload.ProcessProducer{}
+		var err error
+		if p.OwnerPublicKey, err = parsePublicKey(info.OwnerPublicKey); err != nil {
+			return nil, 0, errors.New("invalid ownerpublickey")
+		}
+		if p.Signature, err = common.HexStringToBytes(info.Signature); err != nil {
+			return nil, 0, errors.New("invalid signature")
+		}
+		return p, payload.ProcessProducerVersion, nil
+
+	case ActivateProducer:
+		var info ActivateProducerInfo
+		if err := json.Unmarshal([]byte(data), &info); err != nil {
+			return nil, 0, err
+		}
+		p := &payload.ActivateProducer{}
+		var err error
+		if p.NodePublicKey, err = parsePublicKey(info.NodePublicKey); err != nil {
+			return nil, 0, errors.New("invalid nodepublickey")
+		}
+		if p.Signature, err = common.HexStringToBytes(info.Signature); err != nil {
+			return nil, 0, errors.New("invalid signature")
+		}
+		return p, payload.ActivateProducerVersion, nil
+
+	case ReturnDepositCoin, ReturnCRDepositCoin:
+		return &payload.ReturnDepositCoin{}, 0, nil
+
+	case RegisterCR, UpdateCR:
+		var info CRInfo
+		if err := json.Unmarshal([]byte(data), &info); err != nil {
+			return nil, 0, err
+		}
+		p := &payload.CRInfo{
+			NickName: info.NickName,
+			Url:      info.Url,
+			Location: info.Location,
+		}
+		var err error
+		if p.Code, err = common.HexStringToBytes(info.Code); err != nil ||
+			len(p.Code) == 0 {
+			return nil, 0, errors.New("invalid code")
+		}
+		cid, err := common.Uint168FromAddress(info.CID)
+		if err != nil {
+			return nil, 0, errors.New("invalid cid")
+		}
+		p.CID = *cid
+		version := payload.CRInfoVersion
+		if info.DID != "" {
+			did, err := common.Uint168FromAddress(info.DID)
+			if err != nil {
+				return nil, 0, errors.New("invalid did")
+			}
+			p.DID = *did
+			version = payload.CRInfoDIDVersion
+		}
+		if p.Signature, err = common.HexStringToBytes(info.Signature); err != nil {
+			return nil, 0, errors.New("invalid signature")
+		}
+		return p, version, nil
+
+	case UnregisterCR:
+		var info UnregisterCRInfo
+		if err := json.Unmarshal([]byte(data), &info); err != nil {
+			return nil, 0, err
+		}
+		cid, err := common.Uint168FromAddress(info.CID)
+		if err != nil {
+			return nil, 0, errors.New("invalid cid")
+		}
+		p := &payload.UnregisterCR{CID: *cid}
+		if p.Signature, err = common.HexStringToBytes(info.Signature); err != nil {
+			return nil, 0, errors.New("invalid signature")
+		}
+		return p, payload.UnregisterCRVersion, nil
+
+	case CRCProposal:
+		var info CRCProposalInfo
+		if err := json.Unmarshal([]byte(data), &info); err != nil {
+			return nil, 0, err
+		}
+		return parseCRCProposalInfo(&info)
+
+	case CRCProposalReview:
+		var info CRCProposalReviewInfo
+		if err := json.Unmarshal([]byte(data), &info); err != nil {
+			return nil, 0, err
+		}
+		p := &payload.CRCProposalReview{}
+		proposalHash, err := common.Uint256FromReversedHexString(info.ProposalHash)
+		if err != nil {
+			return nil, 0, errors.New("invalid proposalhash")
+		}
+		p.ProposalHash = *proposalHash
+		if p.VoteResult, err = payload.ParseVoteResult(info.VoteResult); err != nil {
+			return nil, 0, err
+		}
+		if info.OpinionHash != "" {
+			opinionHash, err := common.Uint256FromHexString(info.OpinionHash)
+			if err != nil {
+				return nil, 0, errors.New("invalid opinionhash")
+			}
+			p.OpinionHash = *opinionHash
+		}
+		did, err := common.Uint168FromAddress(info.DID)
+		if err != nil {
+			return nil, 0, errors.New("invalid did")
+		}
+		p.DID = *did
+		if p.Signature, err = common.HexStringToBytes(info.Sign); err != nil {
+			return nil, 0, errors.New("invalid sign")
+		}
+		return p, payload.CRCProposalReviewVersion, nil
+
+	case CRCProposalTracking:
+		var info CRCProposalTrackingInfo
+		if err := json.Unmarshal([]byte(data), &info); err != nil {
+			return nil, 0, err
+		}
+		return parseCRCProposalTrackingInfo(&info)
+
+	case CRCProposalWithdraw:
+		var info CRCProposalWithdrawInfo
+		if err := json.Unmarshal([]byte(data), &info); err != nil {
+			return nil, 0, err
+		}
+		p := &payload.CRCProposalWithdraw{}
+		proposalHash, err := common.Uint256FromReversedHexString(info.ProposalHash)
+		if err != nil {
+			return nil, 0, errors.New("invalid proposalhash")
+		}
+		p.ProposalHash = *proposalHash
+		if p.OwnerPublicKey, err = parsePublicKey(info.OwnerPublicKey); err != nil {
+			return nil, 0, errors.New("invalid ownerpublickey")
+		}
+		if p.Signature, err = common.HexStringToBytes(info.Signature); err != nil {
+			return nil, 0, errors.New("invalid signature")
+		}
+		return p, payload.CRCProposalWithdrawVersion, nil
+	}
+	return nil, 0, errors.New("unsupported transaction type")
+}
+
+func parseCRCProposalInfo(info *CRCProposalInfo) (Payload, byte, error) {
+	p := &payload.CRCProposal{CategoryData: info.CategoryData}
+	var err error
+	if p.ProposalType, err = payload.ParseCRCProposalType(info.ProposalType); err != nil {
+		return nil, 0, err
+	}
+	if p.OwnerPublicKey, err = parsePublicKey(info.OwnerPublicKey); err != nil {
+		return nil, 0, errors.New("invalid ownerpublickey")
+	}
+	draftHash, err := common.Uint256FromReversedHexString(info.DraftHash)
+	if err != nil {
+		return nil, 0, errors.New("invalid drafthash")
+	}
+	p.DraftHash = *draftHash
+	for _, b := range info.Budgets {
+		budgetType, err := payload.ParseInstallmentType(b.Type)
+		if err != nil {
+			return nil, 0, err
+		}
+		amount, err := common.StringToFixed64(b.Amount)
+		if err != nil {
+			return nil, 0, errors.New("invalid budget amount")
+		}
+		p.Budgets = append(p.Budgets, payload.Budget{
+			Type:   budgetType,
+			Stage:  b.Stage,
+			Amount: *amount,
+		})
+	}
+	recipient, err := common.Uint168FromAddress(info.Recipient)
+	if err != nil {
+		return nil, 0, errors.New("invalid recipient")
+	}
+	p.Recipient = *recipient
+	if p.Signature, err = common.HexStringToBytes(info.Signature); err != nil {
+		return nil, 0, errors.New("invalid signature")
+	}
+	if info.CRCouncilMemberDID != "" {
+		did, err := common.Uint168FromAddress(info.CRCouncilMemberDID)
+		if err != nil {
+			return nil, 0, errors.New("invalid crcouncilmemberdid")
+		}
+		p.CRCouncilMemberDID = *did
+	}
+	if p.CRCouncilMemberSignature, err = common.HexStringToBytes(
+		info.CRCouncilMemberSignature); err != nil {
+		return nil, 0, errors.New("invalid crcouncilmembersignature")
+	}
+	return p, payload.CRCProposalVersion, nil
+}
+
+func parseCRCProposalTrackingInfo(
+	info *CRCProposalTrackingInfo) (Payload, byte, error) {
+	p := &payload.CRCProposalTracking{Stage: info.Stage}
+	var err error
+	if p.ProposalTrackingType, err = payload.ParseCRCProposalTrackingType(
+		info.ProposalTrackingType); err != nil {
+		return nil, 0, err
+	}
+	proposalHash, err := common.Uint256FromReversedHexString(info.ProposalHash)
+	if err != nil {
+		return nil, 0, errors.New("invalid proposalhash")
+	}
+	p.ProposalHash = *proposalHash
+	if info.MessageHash != "" {
+		messageHash, err := common.Uint256FromHexString(info.MessageHash)
+		if err != nil {
+			return nil, 0, errors.New("invalid messagehash")
+		}
+		p.MessageHash = *messageHash
+	}
+	if p.OwnerPublicKey, err = parsePublicKey(info.OwnerPublicKey); err != nil {
+		return nil, 0, errors.New("invalid ownerpublickey")
+	}
+	if info.NewOwnerPublicKey != "" {
+		if p.NewOwnerPublicKey, err = parsePublicKey(
+			info.NewOwnerPublicKey); err != nil {
+			return nil, 0, errors.New("invalid newownerpublickey")
+		}
+	}
+	if p.OwnerSignature, err = common.HexStringToBytes(
+		info.OwnerSignature); err != nil {
+		return nil, 0, errors.New("invalid ownersignature")
+	}
+	if p.NewOwnerSignature, err = common.HexStringToBytes(
+		info.NewOwnerSignature); err != nil {
+		return nil, 0, errors.New("invalid newownersignature")
+	}
+	if info.SecretaryGeneralOpinionHash != "" {
+		opinionHash, err := common.Uint256FromHexString(
+			info.SecretaryGeneralOpinionHash)
+		if err != nil {
+			return nil, 0, errors.New("invalid secretarygeneralopinionhash")
+		}
+		p.SecretaryGeneralOpinionHash = *opinionHash
+	}
+	if p.SecretaryGeneralSignature, err = common.HexStringToBytes(
+		info.SecretaryGeneralSignature); err != nil {
+		return nil, 0, errors.New("invalid secretarygeneralsignature")
+	}
+	return p, payload.CRCProposalTrackingVersion, nil
+}
+
+// pendingPayloadSignature returns the name of the first signature field not
+// given in the payload and the data should be signed for it, in the order
+// the signatures are checked by the chain. An empty field is returned if all
+// signatures are given.
+func pendingPayloadSignature(p Payload, version byte) (string, []byte, error) {
+	buf := new(bytes.Buffer)
+	switch object := p.(type) {
+	case *payload.ProducerInfo:
+		if len(object.Signature) == 0 {
+			err := object.SerializeUnsigned(buf, version)
+			return "signature", buf.Bytes(), err
+		}
+	case *payload.ProcessProducer:
+		if len(object.Signature) == 0 {
+			err := object.SerializeUnsigned(buf, version)
+			return "signature", buf.Bytes(), err
+		}
+	case *payload.ActivateProducer:
+		if len(object.Signature) == 0 {
+			err := object.SerializeUnsigned(buf, version)
+			return "signature", buf.Bytes(), err
+		}
+	case *payload.CRInfo:
+		if len(object.Signature) == 0 {
+			err := object.SerializeUnsigned(buf, version)
+			return "signature", buf.Bytes(), err
+		}
+	case *payload.UnregisterCR:
+		if len(object.Signature) == 0 {
+			err := object.SerializeUnsigned(buf, version)
+			return "signature", buf.Bytes(), err
+		}
+	case *payload.CRCProposal:
+		if err := object.SerializeUnsigned(buf, version); err != nil {
+			return "", nil, err
+		}
+		if len(object.Signature) == 0 {
+			return "signature", buf.Bytes(), nil
+		}
+		if len(object.CRCouncilMemberSignature) == 0 {
+			if err := common.WriteVarBytes(buf, object.Signature); err != nil {
+				return "", nil, err
+			}
+			if err := object.CRCouncilMemberDID.Serialize(buf); err != nil {
+				return "", nil, err
+			}
+			return "crcouncilmembersignature", buf.Bytes(), nil
+		}
+	case *payload.CRCProposalReview:
+		if len(object.Signature) == 0 {
+			err := object.SerializeUnsigned(buf, version)
+			return "sign", buf.Bytes(), err
+		}
+	case *payload.CRCProposalTracking:
+		if err := object.SerializeUnsigned(buf, version); err != nil {
+			return "", nil, err
+		}
+		if len(object.OwnerSignature) == 0 {
+			return "ownersignature", buf.Bytes(), nil
+		}
+		if err := common.WriteVarBytes(buf, object.OwnerSignature); err != nil {
+			return "", nil, err
+		}
+		if len(object.NewOwnerPublicKey) != 0 &&
+			len(object.NewOwnerSignature) == 0 {
+			return "newownersignature", buf.Bytes(), nil
+		}
+		if err := common.WriteVarBytes(buf, object.NewOwnerSignature); err != nil {
+			return "", nil, err
+		}
+		if len(object.SecretaryGeneralSignature) == 0 {
+			buf.WriteByte(byte(object.ProposalTrackingType))
+			err := object.SecretaryGeneralOpinionHash.Serialize(buf)
+			return "secretarygeneralsignature", buf.Bytes(), err
+		}
+	case *payload.CRCProposalWithdraw:
+		if len(object.Signature) == 0 {
+			err := object.SerializeUnsigned(buf, version)
+			return "signature", buf.Bytes(), err
+		}
+	}
+	return "", nil, nil
+}
+
+// depositOutput returns the deposit output of a register producer or CR
+// transaction if there is no deposit output given, the amount is taken from
+// the deposit parameter or the minimum deposit amount.
+func depositOutput(txType TxType, p Payload, outputs []*Output,
+	param Params) (*Output, error) {
+	var depositContract *contract.Contract
+	switch txType {
+	case RegisterProducer:
+		publicKey, err := crypto.DecodePoint(
+			p.(*payload.ProducerInfo).OwnerPublicKey)
+		if err != nil {
+			return nil, errors.New("invalid ownerpublickey")
+		}
+		if depositContract, err = contract.CreateDepositContractByPubKey(
+			publicKey); err != nil {
+			return nil, err
+		}
+	case RegisterCR:
+		var err error
+		if depositContract, err = contract.CreateDepositContractByCode(
+			p.(*payload.CRInfo).Code); err != nil {
+			return nil, errors.New("invalid code")
+		}
+	default:
+		return nil, nil
+	}
+
+	depositHash := depositContract.ToProgramHash()
+	for _, output := range outputs {
+		if output.ProgramHash.IsEqual(*depositHash) {
+			return nil, nil
+		}
+	}
+	amount := common.Fixed64(crstate.MinDepositAmount)
+	if depositParam, ok := param.String("deposit"); ok {
+		value, err := common.StringToFixed64(depositParam)
+		if err != nil {
+			return nil, errors.New("invalid deposit")
+		}
+		amount = *value
+	}
+	return &Output{
+		AssetID:     *account.SystemAssetID,
+		Value:       amount,
+		OutputLock:  0,
+		ProgramHash: *depositHash,
+		Type:        OTNone,
+		Payload:     &outputpayload.DefaultOutput{},
+	}, nil
+}
+
+// parseOutputs parses outputs in the same format as createrawtransaction.
+func parseOutputs(outputsParam string) ([]*Output, error) {
+	var outputs []*Output
+	var err error
+	gjson.Parse(outputsParam).ForEach(func(key, value gjson.Result) bool {
+		var amount *common.Fixed64
+		amount, err = common.StringToFixed64(value.Get("amount").String())
+		if err != nil {
+			err = errors.New("invalid amount in outputs param")
+			return false
+		}
+		var programHash *common.Uint168
+		programHash, err = common.Uint168FromAddress(
+			value.Get("address").String())
+		if err != nil {
+			err = errors.New("invalid address in outputs param")
+			return false
+		}
+		outputs = append(outputs, &Output{
+			AssetID:     *account.SystemAssetID,
+			Value:       *amount,
+			OutputLock:  0,
+			ProgramHash: *programHash,
+			Type:        OTNone,
+			Payload:     &outputpayload.DefaultOutput{},
+		})
+		return true
+	})
+	return outputs, err
+}
+
+// fundTransaction adds inputs from the from address to pay the outputs and
+// the fee of the transaction, and a change output if needed. The fee is
+// taken from the fee parameter or estimated by the size of the transaction
+// signed by a standard signature.
+func fundTransaction(txn *Transaction, param Params) (common.Fixed64, error) {
+	from, ok := param.String("from")
+	if !ok {
+		return 0, errors.New("need a parameter named from")
+	}
+	fromHash, err := common.Uint168FromAddress(from)
+	if err != nil {
+		return 0, errors.New("invalid from address")
+	}
+	changeHash := fromHash
+	if changeAddress, ok := param.String("changeaddress"); ok {
+		if changeHash, err = common.Uint168FromAddress(changeAddress); err != nil {
+			return 0, errors.New("invalid changeaddress")
+		}
+	}
+
+	var fee common.Fixed64
+	feeParam, fixedFee := param.String("fee")
+	if fixedFee {
+		value, err := common.StringToFixed64(feeParam)
+		if err != nil || *value < 0 {
+			return 0, errors.New("invalid fee")
+		}
+		fee = *value
+	}
+	feeRate, ok := param.Int("feerate")
+	if !ok {
+		feeRate = DefaultFeeRate
+		if estimate, err := TxMemPool.EstimateFee(
+			defaultFeeConfirmations); err == nil {
+			feeRate = int64(estimate.FeeRate)
+		}
+	}
+
+	utxos, err := spendableUTXOs(fromHash)
+	if err != nil {
+		return 0, err
+	}
+	outputs := txn.Outputs
+	var outputAmount common.Fixed64
+	for _, output := range outputs {
+		outputAmount += output.Value
+	}
+	for {
+		var inputAmount common.Fixed64
+		txn.Inputs = []*Input{}
+		for _, utxo := range utxos {
+			if inputAmount >= outputAmount+fee {
+				break
+			}
+			txn.Inputs = append(txn.Inputs, &Input{
+				Previous: OutPoint{TxID: utxo.TxID, Index: utxo.Index},
+				Sequence: math.MaxUint32,
+			})
+			inputAmount += utxo.Value
+		}
+		if inputAmount < outputAmount+fee {
+			return 0, errors.New("not enough utxo")
+		}
+		txn.Outputs = outputs
+		if change := inputAmount - outputAmount - fee; change > 0 {
+			txn.Outputs = append(outputs, &Output{
+				AssetID:     *account.SystemAssetID,
+				Value:       change,
+				OutputLock:  0,
+				ProgramHash: *changeHash,
+				Type:        OTNone,
+				Payload:     &outputpayload.DefaultOutput{},
+			})
+		}
+		if fixedFee {
+			return fee, nil
+		}
+
+		size := int64(txn.GetSize() + standardProgramSize)
+		required := common.Fixed64(size * feeRate / 1000)
+		if required < ChainParams.MinTransactionFee {
+			required = ChainParams.MinTransactionFee
+		}
+		if required <= fee {
+			return fee, nil
+		}
+		fee = required
+	}
+}
+
+// spendableUTXOs returns UTXOs of the program hash can be spent now, which
+// are not spent by transactions in the transaction pool, not locked and not
+// immature coinbase outputs. Vote outputs are excluded to keep the votes.
+func spendableUTXOs(programHash *common.Uint168) ([]*UTXO, error) {
+	utxos, err := Store.GetFFLDB().GetUTXO(programHash)
+	if err != nil {
+		return nil, errors.New("list unspent failed, " + err.Error())
+	}
+	bestHeight := Chain.GetHeight()
+	usedUTXOs := TxMemPool.GetUsedUTXOs()
+	var result []*UTXO
+	for _, utxo := range utxos {
+		outPoint := OutPoint{TxID: utxo.TxID, Index: utxo.Index}
+		if _, ok := usedUTXOs[outPoint.ReferKey()]; ok || utxo.Value == 0 {
+			continue
+		}
+		tx, height, err := Store.GetTransaction(utxo.TxID)
+		if err != nil {
+			return nil, errors.New("unknown transaction " +
+				utxo.TxID.String() + " from persisted utxo")
+		}
+		output := tx.Outputs[utxo.Index]
+		if output.Type == OTVote || output.OutputLock != 0 {
+			continue
+		}
+		if tx.TxType == CoinBase &&
+			bestHeight-height < ChainParams.CoinbaseMaturity {
+			continue
+		}
+		result = append(result, utxo)
+	}
+	return result, nil
+}
+
+func parsePublicKey(s string) ([]byte, error) {
+	publicKey, err := common.HexStringToBytes(s)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := crypto.DecodePoint(publicKey); err != nil {
+		return nil, err
+	}
+	return publicKey, nil
+}
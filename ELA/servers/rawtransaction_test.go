@@ -0,0 +1,243 @@
+// Copyright (c) 2017-2020 The Elastos Foundation
+// Use of this source code is governed by an MIT
+// license that can be found in the LICENSE file.
+//
+
+package servers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/elastos/Elastos.ELA/common"
+	"github.com/elastos/Elastos.ELA/common/config"
+	. "github.com/elastos/Elastos.ELA/core/types"
+	"github.com/elastos/Elastos.ELA/core/types/payload"
+	"github.com/elastos/Elastos.ELA/crypto"
+	. "github.com/elastos/Elastos.ELA/servers/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testKey is a key pair to sign payloads in tests.
+type testKey struct {
+	privateKey []byte
+	publicKey  *crypto.PublicKey
+	code       []byte
+}
+
+func newTestKey(t *testing.T) *testKey {
+	privateKey, publicKey, err := crypto.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := publicKey.EncodePoint(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testKey{privateKey: privateKey, publicKey: publicKey, code: code}
+}
+
+// sign signs the payload signature data returned by the RPC after checking
+// the digest of it.
+func (k *testKey) sign(t *testing.T, info *PayloadSignatureInfo) []byte {
+	data, err := common.HexStringToBytes(info.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(data)
+	assert.Equal(t, common.BytesToHexString(digest[:]), info.Digest)
+	signature, err := crypto.Sign(k.privateKey, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signature
+}
+
+func TestCreateRawSpecialTransaction(t *testing.T) {
+	defer func(params *config.Params, acl *AccessControl) {
+		ChainParams, ACL = params, acl
+	}(ChainParams, ACL)
+	ChainParams = &config.Params{RPCServiceLevel: config.WalletPermitted.String()}
+	ACL = nil
+
+	key := newTestKey(t)
+	info := map[string]interface{}{
+		"nodepublickey": common.BytesToHexString(key.code),
+	}
+	create := func() *RawSpecialTransactionInfo {
+		resp := CreateRawSpecialTransaction(Params{
+			"type":    "ActivateProducer",
+			"payload": info,
+		})
+		if !assert.Equal(t, Success, resp["Error"], resp["Result"]) {
+			t.FailNow()
+		}
+		return resp["Result"].(*RawSpecialTransactionInfo)
+	}
+
+	// the payload signature is asked first
+	result := create()
+	assert.Empty(t, result.Digest)
+	if !assert.NotNil(t, result.PayloadSignature) {
+		return
+	}
+	assert.Equal(t, "signature", result.PayloadSignature.Field)
+	signature := key.sign(t, result.PayloadSignature)
+
+	// the signature is checked the same way as the activate producer
+	// transaction is checked by blockchain
+	info["signature"] = common.BytesToHexString(signature)
+	result = create()
+	assert.Nil(t, result.PayloadSignature)
+	data, err := common.HexStringToBytes(result.Hex)
+	assert.NoError(t, err)
+	var txn Transaction
+	if !assert.NoError(t, txn.Deserialize(bytes.NewReader(data))) {
+		return
+	}
+	assert.Equal(t, ActivateProducer, txn.TxType)
+	assert.Equal(t, payload.ActivateProducerVersion, txn.PayloadVersion)
+	activate := txn.Payload.(*payload.ActivateProducer)
+	buf := new(bytes.Buffer)
+	assert.NoError(t, activate.SerializeUnsigned(buf, txn.PayloadVersion))
+	assert.NoError(t, crypto.Verify(*key.publicKey, buf.Bytes(),
+		activate.Signature))
+
+	// the digest of the transaction is given once the payload is signed
+	buf = new(bytes.Buffer)
+	assert.NoError(t, txn.SerializeUnsigned(buf))
+	digest := sha256.Sum256(buf.Bytes())
+	assert.Equal(t, common.BytesToHexString(digest[:]), result.Digest)
+
+	resp := CreateRawSpecialTransaction(Params{
+		"type":    "activateproducer",
+		"payload": map[string]interface{}{"nodepublickey": "0102"},
+	})
+	assert.Equal(t, InvalidParams, resp["Error"])
+	resp = CreateRawSpecialTransaction(Params{
+		"type":    "transferasset",
+		"payload": info,
+	})
+	assert.Equal(t, InvalidParams, resp["Error"])
+}
+
+func TestPendingPayloadSignature_CRCProposal(t *testing.T) {
+	owner := newTestKey(t)
+	member := newTestKey(t)
+	recipient := common.Uint168{0x21}
+	did := common.Uint168{0x67}
+	recipientAddress, _ := recipient.ToAddress()
+	didAddress, _ := did.ToAddress()
+	draftHash := common.Uint256{1, 2, 3}
+
+	info := &CRCProposalInfo{
+		ProposalType:   payload.Normal.Name(),
+		CategoryData:   "category",
+		OwnerPublicKey: common.BytesToHexString(owner.code),
+		DraftHash:      ToReversedString(draftHash),
+		Budgets: []BudgetBaseInfo{
+			{Type: payload.Imprest.Name(), Stage: 0, Amount: "1"},
+			{Type: "finalpayment", Stage: 1, Amount: "2"},
+		},
+		Recipient:          recipientAddress,
+		CRCouncilMemberDID: didAddress,
+	}
+	sign := func(key *testKey, field string) []byte {
+		p, version, err := parseCRCProposalInfo(info)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.Equal(t, payload.CRCProposalVersion, version)
+		name, data, err := pendingPayloadSignature(p, version)
+		assert.NoError(t, err)
+		assert.Equal(t, field, name)
+		digest := sha256.Sum256(data)
+		return key.sign(t, &PayloadSignatureInfo{
+			Field:  name,
+			Data:   common.BytesToHexString(data),
+			Digest: common.BytesToHexString(digest[:]),
+		})
+	}
+
+	info.Signature = common.BytesToHexString(sign(owner, "signature"))
+	info.CRCouncilMemberSignature = common.BytesToHexString(
+		sign(member, "crcouncilmembersignature"))
+	p, version, err := parseCRCProposalInfo(info)
+	assert.NoError(t, err)
+	name, _, err := pendingPayloadSignature(p, version)
+	assert.NoError(t, err)
+	assert.Empty(t, name)
+
+	// the signatures are checked the same way as the proposal transaction is
+	// checked by blockchain
+	proposal := p.(*payload.CRCProposal)
+	assert.Equal(t, draftHash, proposal.DraftHash)
+	assert.Equal(t, payload.FinalPayment, proposal.Budgets[1].Type)
+	assert.Equal(t, common.Fixed64(2e8), proposal.Budgets[1].Amount)
+	buf := new(bytes.Buffer)
+	assert.NoError(t, proposal.SerializeUnsigned(buf, payload.CRCProposalVersion))
+	assert.NoError(t, crypto.Verify(*owner.publicKey, buf.Bytes(),
+		proposal.Signature))
+	assert.NoError(t, common.WriteVarBytes(buf, proposal.Signature))
+	assert.NoError(t, proposal.CRCouncilMemberDID.Serialize(buf))
+	assert.NoError(t, crypto.Verify(*member.publicKey, buf.Bytes(),
+		proposal.CRCouncilMemberSignature))
+
+	info.ProposalType = "unknown"
+	_, _, err = parseCRCProposalInfo(info)
+	assert.EqualError(t, err, "invalid proposal type unknown")
+}
+
+func TestPendingPayloadSignature_CRCProposalTracking(t *testing.T) {
+	owner := newTestKey(t)
+	newOwner := newTestKey(t)
+	secretary := newTestKey(t)
+	info := &CRCProposalTrackingInfo{
+		ProposalTrackingType: payload.ChangeOwner.Name(),
+		ProposalHash:         ToReversedString(common.Uint256{4, 5, 6}),
+		MessageHash:          common.Uint256{7}.String(),
+		OwnerPublicKey:       common.BytesToHexString(owner.code),
+		NewOwnerPublicKey:    common.BytesToHexString(newOwner.code),
+	}
+	sign := func(key *testKey, field string) string {
+		p, version, err := parseCRCProposalTrackingInfo(info)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.Equal(t, payload.CRCProposalTrackingVersion, version)
+		name, data, err := pendingPayloadSignature(p, version)
+		assert.NoError(t, err)
+		assert.Equal(t, field, name)
+		signature, err := crypto.Sign(key.privateKey, data)
+		assert.NoError(t, err)
+		return common.BytesToHexString(signature)
+	}
+	info.OwnerSignature = sign(owner, "ownersignature")
+	info.NewOwnerSignature = sign(newOwner, "newownersignature")
+	info.SecretaryGeneralSignature = sign(secretary, "secretarygeneralsignature")
+
+	// the signatures are checked the same way as the proposal tracking
+	// transaction is checked by blockchain
+	p, _, err := parseCRCProposalTrackingInfo(info)
+	assert.NoError(t, err)
+	tracking := p.(*payload.CRCProposalTracking)
+	buf := new(bytes.Buffer)
+	assert.NoError(t, tracking.SerializeUnsigned(buf,
+		payload.CRCProposalTrackingVersion))
+	assert.NoError(t, crypto.Verify(*owner.publicKey, buf.Bytes(),
+		tracking.OwnerSignature))
+	assert.NoError(t, common.WriteVarBytes(buf, tracking.OwnerSignature))
+	assert.NoError(t, crypto.Verify(*newOwner.publicKey, buf.Bytes(),
+		tracking.NewOwnerSignature))
+	assert.NoError(t, common.WriteVarBytes(buf, tracking.NewOwnerSignature))
+	buf.WriteByte(byte(tracking.ProposalTrackingType))
+	assert.NoError(t, tracking.SecretaryGeneralOpinionHash.Serialize(buf))
+	assert.NoError(t, crypto.Verify(*secretary.publicKey, buf.Bytes(),
+		tracking.SecretaryGeneralSignature))
+
+	name, _, err := pendingPayloadSignature(p, payload.CRCProposalTrackingVersion)
+	assert.NoError(t, err)
+	assert.Empty(t, name)
+}
@@ -13,6 +13,7 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/elastos/Elastos.ELA/common"
 
@@ -50,6 +51,18 @@ func GetConfirmedPassword() ([]byte, error) {
 	return first, nil
 }
 
+// GetMnemonic gets mnemonic from user input without echo, so it's not left in
+// the shell history or the process list. The words are separated by single
+// spaces.
+func GetMnemonic() (string, error) {
+	fmt.Printf("Mnemonic:")
+	mnemonic, err := gopass.GetPasswd()
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(strings.Fields(string(mnemonic)), " "), nil
+}
+
 func FileExisted(filename string) bool {
 	_, err := os.Stat(filename)
 	return err == nil || os.IsExist(err)
@@ -35,7 +35,7 @@ func TestWallet_New(t *testing.T) {
 
 	version, err := wallet.LoadStoredData("Version")
 	assert.NoError(t, err)
-	assert.Equal(t, "1.0.0", string(version))
+	assert.Equal(t, "2.0.0", string(version))
 }
 
 func TestWallet_ImportAddress(t *testing.T) {